@@ -0,0 +1,40 @@
+package client
+
+import (
+	"net/http"
+
+	webapi_blocklist "github.com/iotaledger/goshimmer/plugins/webapi/blocklist"
+)
+
+const (
+	routeBlocklist       = "blocklist"
+	routeBlocklistAdd    = "blocklist/add"
+	routeBlocklistRemove = "blocklist/remove"
+)
+
+// BlockedIssuers returns the issuers that are blocked by the node.
+func (api *GoShimmerAPI) BlockedIssuers() (*webapi_blocklist.BlockedIssuersResponse, error) {
+	res := &webapi_blocklist.BlockedIssuersResponse{}
+	if err := api.do(http.MethodGet, routeBlocklist, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// BlockIssuer adds the issuer with the given base58 encoded public key to the blocklist of the node.
+func (api *GoShimmerAPI) BlockIssuer(issuerPublicKey string) (*webapi_blocklist.Response, error) {
+	res := &webapi_blocklist.Response{}
+	if err := api.do(http.MethodPost, routeBlocklistAdd, &webapi_blocklist.Request{IssuerPublicKey: issuerPublicKey}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UnblockIssuer removes the issuer with the given base58 encoded public key from the blocklist of the node.
+func (api *GoShimmerAPI) UnblockIssuer(issuerPublicKey string) (*webapi_blocklist.Response, error) {
+	res := &webapi_blocklist.Response{}
+	if err := api.do(http.MethodPost, routeBlocklistRemove, &webapi_blocklist.Request{IssuerPublicKey: issuerPublicKey}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
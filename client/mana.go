@@ -0,0 +1,50 @@
+package client
+
+import (
+	"net/http"
+
+	webapi_mana "github.com/iotaledger/goshimmer/plugins/webapi/mana"
+)
+
+const (
+	routeGetMana       = "mana"
+	routeGetAllMana    = "mana/all"
+	routeGetPercentile = "mana/percentile"
+	queryNodeID        = "?nodeID="
+)
+
+// GetOwnMana returns the access and consensus mana of the node this api client is communicating with.
+func (api *GoShimmerAPI) GetOwnMana() (*webapi_mana.GetManaResponse, error) {
+	res := &webapi_mana.GetManaResponse{}
+	if err := api.do(http.MethodGet, routeGetMana, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetManaFullNodeID returns the access and consensus mana of the node with the given base58 encoded full nodeID.
+func (api *GoShimmerAPI) GetManaFullNodeID(fullNodeID string) (*webapi_mana.GetManaResponse, error) {
+	res := &webapi_mana.GetManaResponse{}
+	if err := api.do(http.MethodGet, routeGetMana+queryNodeID+fullNodeID, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetAllMana returns the access and consensus mana of all known nodes.
+func (api *GoShimmerAPI) GetAllMana() (*webapi_mana.GetAllManaResponse, error) {
+	res := &webapi_mana.GetAllManaResponse{}
+	if err := api.do(http.MethodGet, routeGetAllMana, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetManaPercentile returns the mana percentiles of the node with the given base58 encoded full nodeID.
+func (api *GoShimmerAPI) GetManaPercentile(fullNodeID string) (*webapi_mana.GetPercentileResponse, error) {
+	res := &webapi_mana.GetPercentileResponse{}
+	if err := api.do(http.MethodGet, routeGetPercentile+queryNodeID+fullNodeID, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
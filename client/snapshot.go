@@ -0,0 +1,20 @@
+package client
+
+import (
+	"net/http"
+
+	webapi_snapshot "github.com/iotaledger/goshimmer/plugins/webapi/snapshot"
+)
+
+const (
+	routeSnapshotExport = "snapshot/export"
+)
+
+// ExportSnapshot makes the node export a snapshot of its confirmed ledger state to its configured export file.
+func (api *GoShimmerAPI) ExportSnapshot() (*webapi_snapshot.ExportResponse, error) {
+	res := &webapi_snapshot.ExportResponse{}
+	if err := api.do(http.MethodPost, routeSnapshotExport, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	webapi_value "github.com/iotaledger/goshimmer/plugins/webapi/value"
+)
+
+// Subscribe opens a websocket to the node that pushes the changes of the inclusion state (booked, liked, confirmed,
+// rejected and branch-changed) of the transactions that are referenced by the given request. Further interests can be
+// registered at any time via Subscription.Subscribe.
+func (api *GoShimmerAPI) Subscribe(request webapi_value.SubscriptionRequest) (*Subscription, error) {
+	header := http.Header{}
+	if api.basicAuth.IsEnabled() {
+		req := &http.Request{Header: header}
+		req.SetBasicAuth(api.basicAuth.Credentials())
+	}
+
+	url := fmt.Sprintf("%s/%s", api.baseURL, routeSubscribe)
+	url = strings.Replace(url, "http", "ws", 1)
+	conn, res, err := websocket.DefaultDialer.Dial(url, header)
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusUnauthorized {
+			return nil, fmt.Errorf("%w: %s", ErrUnauthorized, err)
+		}
+		return nil, err
+	}
+
+	subscription := &Subscription{
+		conn:          conn,
+		notifications: make(chan webapi_value.InclusionStateNotification),
+		closed:        make(chan struct{}),
+	}
+	go subscription.readNotifications()
+
+	if err := subscription.Subscribe(request); err != nil {
+		_ = subscription.Close()
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// Subscription is an open websocket that receives the changes of the inclusion state of subscribed transactions.
+type Subscription struct {
+	conn          *websocket.Conn
+	notifications chan webapi_value.InclusionStateNotification
+	closed        chan struct{}
+	closeOnce     sync.Once
+	writeMutex    sync.Mutex
+	err           error
+}
+
+// Subscribe registers the interest in further transactions, outputs or addresses.
+func (s *Subscription) Subscribe(request webapi_value.SubscriptionRequest) error {
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+
+	return s.conn.WriteJSON(request)
+}
+
+// Notifications returns the channel that receives the notifications. The channel is closed when the subscription is
+// closed or the connection is lost (see Err).
+func (s *Subscription) Notifications() <-chan webapi_value.InclusionStateNotification {
+	return s.notifications
+}
+
+// Err returns the error that caused the notification channel to be closed. It must only be called after the channel
+// was closed.
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+// Close closes the underlying websocket.
+func (s *Subscription) Close() error {
+	s.closeOnce.Do(func() { close(s.closed) })
+
+	return s.conn.Close()
+}
+
+func (s *Subscription) readNotifications() {
+	defer close(s.notifications)
+
+	for {
+		var notification webapi_value.InclusionStateNotification
+		if err := s.conn.ReadJSON(&notification); err != nil {
+			s.err = err
+			return
+		}
+		select {
+		case s.notifications <- notification:
+		case <-s.closed:
+			return
+		}
+	}
+}
@@ -9,8 +9,9 @@ import (
 )
 
 const (
-	routePastCone = "tools/message/pastcone"
-	routeMissing  = "tools/message/missing"
+	routePastCone  = "tools/message/pastcone"
+	routeMissing   = "tools/message/missing"
+	routeOrphanage = "tools/message/orphanage"
 
 	routeValueTips  = "tools/value/tips"
 	routeValueDebug = "tools/value/objects"
@@ -47,6 +48,15 @@ func (api *GoShimmerAPI) Missing() (*webapi_tools_message.MissingResponse, error
 	return res, nil
 }
 
+// Orphanage returns the statistics about the messages that did not get any approver in time.
+func (api *GoShimmerAPI) Orphanage() (*webapi_tools_message.OrphanageResponse, error) {
+	res := &webapi_tools_message.OrphanageResponse{}
+	if err := api.do(http.MethodGet, routeOrphanage, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // ------------------- Value layer -----------------------------
 
 // ValueObjects returns the list of value objects.
@@ -3,6 +3,7 @@ package client
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	webapi_value "github.com/iotaledger/goshimmer/plugins/webapi/value"
 )
@@ -11,8 +12,16 @@ const (
 	routeAttachments    = "value/attachments"
 	routeGetTxnByID     = "value/transactionByID"
 	routeSendTxn        = "value/sendTransaction"
+	routeCheckTxn       = "value/checkTransaction"
 	routeSendTxnByJSON  = "value/sendTransactionByJson"
 	routeUnspentOutputs = "value/unspentOutputs"
+	routeAlias          = "value/alias"
+	routeAsset          = "value/asset"
+	routeStateRoot      = "value/stateRoot"
+	routeOutputProof    = "value/outputProof"
+	routeAddressHistory = "value/addressHistory"
+	routeBalanceAt      = "value/balanceAt"
+	routeSubscribe      = "value/subscribe"
 )
 
 // GetAttachments gets the attachments of a transaction ID
@@ -27,6 +36,92 @@ func (api *GoShimmerAPI) GetAttachments(base58EncodedTxnID string) (*webapi_valu
 	return res, nil
 }
 
+// GetAlias gets the current output of the alias with the given base58 encoded alias address
+func (api *GoShimmerAPI) GetAlias(base58EncodedAliasAddress string) (*webapi_value.GetAliasResponse, error) {
+	res := &webapi_value.GetAliasResponse{}
+	if err := api.do(http.MethodGet, func() string {
+		return fmt.Sprintf("%s?address=%s", routeAlias, base58EncodedAliasAddress)
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetAsset gets the on-tangle definition of the asset with the given base58 encoded color
+func (api *GoShimmerAPI) GetAsset(base58EncodedColor string) (*webapi_value.GetAssetResponse, error) {
+	res := &webapi_value.GetAssetResponse{}
+	if err := api.do(http.MethodGet, func() string {
+		return fmt.Sprintf("%s?color=%s", routeAsset, base58EncodedColor)
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetStateRoot gets the merkle root that commits to the confirmed unspent outputs of the ledger
+func (api *GoShimmerAPI) GetStateRoot() (*webapi_value.GetStateRootResponse, error) {
+	res := &webapi_value.GetStateRootResponse{}
+	if err := api.do(http.MethodGet, routeStateRoot, nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetOutputProof gets the proof of the inclusion (or the non-inclusion) of the output with the given base58 encoded ID
+// in the confirmed unspent outputs of the ledger
+func (api *GoShimmerAPI) GetOutputProof(base58EncodedOutputID string) (*webapi_value.GetOutputProofResponse, error) {
+	res := &webapi_value.GetOutputProofResponse{}
+	if err := api.do(http.MethodGet, func() string {
+		return fmt.Sprintf("%s?outputID=%s", routeOutputProof, base58EncodedOutputID)
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetAddressHistory gets the chronologically ordered history of the given base58 encoded address, starting at the
+// given offset and containing at most limit entries
+func (api *GoShimmerAPI) GetAddressHistory(base58EncodedAddress string, offset, limit int) (*webapi_value.GetAddressHistoryResponse, error) {
+	res := &webapi_value.GetAddressHistoryResponse{}
+	if err := api.do(http.MethodGet, func() string {
+		return fmt.Sprintf("%s?address=%s&offset=%d&limit=%d", routeAddressHistory, base58EncodedAddress, offset, limit)
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetBalanceAt gets the outputs and balances of the given base58 encoded address in the confirmed ledger state at the
+// given time
+func (api *GoShimmerAPI) GetBalanceAt(base58EncodedAddress string, timestamp time.Time) (*webapi_value.GetBalanceAtResponse, error) {
+	res := &webapi_value.GetBalanceAtResponse{}
+	if err := api.do(http.MethodGet, func() string {
+		return fmt.Sprintf("%s?address=%s&timestamp=%d", routeBalanceAt, base58EncodedAddress, timestamp.Unix())
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetBalanceAtCheckpoint gets the outputs and balances of the given base58 encoded address in the confirmed ledger
+// state that is defined by the given base58 encoded confirmed transaction ID
+func (api *GoShimmerAPI) GetBalanceAtCheckpoint(base58EncodedAddress string, base58EncodedTxnID string) (*webapi_value.GetBalanceAtResponse, error) {
+	res := &webapi_value.GetBalanceAtResponse{}
+	if err := api.do(http.MethodGet, func() string {
+		return fmt.Sprintf("%s?address=%s&checkpoint=%s", routeBalanceAt, base58EncodedAddress, base58EncodedTxnID)
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // GetTransactionByID gets the transaction of a transaction ID
 func (api *GoShimmerAPI) GetTransactionByID(base58EncodedTxnID string) (*webapi_value.GetTransactionByIDResponse, error) {
 	res := &webapi_value.GetTransactionByIDResponse{}
@@ -61,6 +156,17 @@ func (api *GoShimmerAPI) SendTransaction(txnBytes []byte) (string, error) {
 	return res.TransactionID, nil
 }
 
+// CheckTransaction performs the validation checks of the ledger on the transaction(bytes) without issuing it.
+func (api *GoShimmerAPI) CheckTransaction(txnBytes []byte) (*webapi_value.CheckTransactionResponse, error) {
+	res := &webapi_value.CheckTransactionResponse{}
+	if err := api.do(http.MethodPost, routeCheckTxn,
+		&webapi_value.CheckTransactionRequest{TransactionBytes: txnBytes}, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // SendTransactionByJSON sends the transaction(JSON) to the Value Tangle and returns transaction ID.
 //func (api *GoShimmerAPI) SendTransactionByJSON(txn webapi_value.SendTransactionByJSONRequest) (string, error) {
 //	res := &webapi_value.SendTransactionByJSONResponse{}
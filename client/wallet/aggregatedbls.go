@@ -0,0 +1,314 @@
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/crypto/bls"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"go.dedis.ch/kyber/v3/pairing/bn256"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/xerrors"
+)
+
+// region AggregatedBLSTransfer ////////////////////////////////////////////////////////////////////////////////////////
+
+// AggregatedBLSTransfer is a transfer of the funds of several BLSAddresses (i.e. of the participants of a coin-join)
+// that is authorized by a single aggregated signature. Every participant signs the essence with the key of its
+// BLSAddress before the signatures are aggregated into an AggregatedBLSUnlockBlock. It can be passed between the
+// participants in its marshaled form.
+type AggregatedBLSTransfer struct {
+	essence    *ledgerstate.TransactionEssence
+	publicKeys []bls.PublicKey
+	signatures map[uint8]bls.Signature
+}
+
+// AggregatedBLSTransferFromBytes unmarshals an AggregatedBLSTransfer from a sequence of bytes.
+func AggregatedBLSTransferFromBytes(transferBytes []byte) (transfer *AggregatedBLSTransfer, err error) {
+	marshalUtil := marshalutil.New(transferBytes)
+
+	transfer = &AggregatedBLSTransfer{
+		signatures: make(map[uint8]bls.Signature),
+	}
+	if transfer.essence, err = ledgerstate.TransactionEssenceFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse TransactionEssence: %w", err)
+		return
+	}
+
+	publicKeyCount, err := marshalUtil.ReadUint8()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse public key count: %w", err)
+		return
+	}
+	publicKeys := make([]bls.PublicKey, publicKeyCount)
+	for i := range publicKeys {
+		if publicKeys[i], err = ledgerstate.BLSPublicKeyFromMarshalUtil(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse public key: %w", err)
+			return
+		}
+	}
+	if transfer.publicKeys, err = sortBLSPublicKeys(publicKeys); err != nil {
+		return
+	}
+
+	signatureCount, err := marshalUtil.ReadUint8()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse signature count: %w", err)
+		return
+	}
+	for i := uint8(0); i < signatureCount; i++ {
+		keyIndex, keyIndexErr := marshalUtil.ReadUint8()
+		if keyIndexErr != nil {
+			err = xerrors.Errorf("failed to parse key index of signature: %w", keyIndexErr)
+			return
+		}
+		if int(keyIndex) >= len(transfer.publicKeys) {
+			err = errors.New("signature references a public key that is not part of the transfer")
+			return
+		}
+		signature, signatureErr := bls.SignatureFromMarshalUtil(marshalUtil)
+		if signatureErr != nil {
+			err = xerrors.Errorf("failed to parse signature: %w", signatureErr)
+			return
+		}
+		if err = transfer.addSignature(bls.NewSignatureWithPublicKey(transfer.publicKeys[keyIndex], signature)); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Essence returns the TransactionEssence that is signed by the participants.
+func (a *AggregatedBLSTransfer) Essence() *ledgerstate.TransactionEssence {
+	return a.essence
+}
+
+// PublicKeys returns the (sorted) public keys of the BLSAddresses whose funds are transferred.
+func (a *AggregatedBLSTransfer) PublicKeys() []bls.PublicKey {
+	return a.publicKeys
+}
+
+// Complete returns true if all participants signed the transfer.
+func (a *AggregatedBLSTransfer) Complete() bool {
+	return len(a.signatures) == len(a.publicKeys)
+}
+
+// Transaction returns the Transaction that spends the funds of all BLSAddresses with a single AggregatedBLSUnlockBlock
+// (it requires the transfer to be complete).
+func (a *AggregatedBLSTransfer) Transaction() (tx *ledgerstate.Transaction, err error) {
+	if !a.Complete() {
+		err = errors.New("the transfer has not been signed by all participants, yet")
+		return
+	}
+
+	signatures := make([]bls.SignatureWithPublicKey, len(a.publicKeys))
+	for i, publicKey := range a.publicKeys {
+		signatures[i] = bls.NewSignatureWithPublicKey(publicKey, a.signatures[uint8(i)])
+	}
+	aggregatedUnlockBlock, err := ledgerstate.NewAggregatedBLSUnlockBlock(signatures...)
+	if err != nil {
+		return
+	}
+
+	unlockBlocks := make(ledgerstate.UnlockBlocks, len(a.essence.Inputs()))
+	unlockBlocks[0] = aggregatedUnlockBlock
+	for i := 1; i < len(unlockBlocks); i++ {
+		unlockBlocks[i] = ledgerstate.NewReferenceUnlockBlock(0)
+	}
+
+	return ledgerstate.NewTransaction(a.essence, unlockBlocks), nil
+}
+
+// Bytes returns a marshaled version of the transfer (including the signatures that were collected so far).
+func (a *AggregatedBLSTransfer) Bytes() []byte {
+	marshalUtil := marshalutil.New().
+		WriteBytes(a.essence.Bytes()).
+		WriteUint8(uint8(len(a.publicKeys)))
+	for _, publicKey := range a.publicKeys {
+		marshalUtil.WriteBytes(publicKey.Bytes())
+	}
+
+	marshalUtil.WriteUint8(uint8(len(a.signatures)))
+	for i := range a.publicKeys {
+		if signature, signatureExists := a.signatures[uint8(i)]; signatureExists {
+			marshalUtil.WriteUint8(uint8(i)).WriteBytes(signature.Bytes())
+		}
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// addSignature adds the signature of a participant after verifying it against the essence.
+func (a *AggregatedBLSTransfer) addSignature(signature bls.SignatureWithPublicKey) (err error) {
+	keyIndex := -1
+	for i, publicKey := range a.publicKeys {
+		if bytes.Equal(publicKey.Bytes(), signature.PublicKey.Bytes()) {
+			keyIndex = i
+			break
+		}
+	}
+	if keyIndex == -1 {
+		return errors.New("the public key is not part of the transfer")
+	}
+	if _, signatureExists := a.signatures[uint8(keyIndex)]; signatureExists {
+		return errors.New("the transfer already carries a signature of the given public key")
+	}
+	if !signature.IsValid(a.essence.Bytes()) {
+		return errors.New("the signature does not sign the essence of the transfer")
+	}
+	a.signatures[uint8(keyIndex)] = signature.Signature
+
+	return
+}
+
+// sortBLSPublicKeys is an internal utility function that sorts the given public keys and that makes sure that they can
+// be aggregated (at least two distinct keys).
+func sortBLSPublicKeys(publicKeys []bls.PublicKey) (sortedPublicKeys []bls.PublicKey, err error) {
+	if len(publicKeys) < 2 || len(publicKeys) > 255 {
+		err = errors.New("an aggregated transfer needs between 2 and 255 participants")
+		return
+	}
+
+	sortedPublicKeys = make([]bls.PublicKey, len(publicKeys))
+	copy(sortedPublicKeys, publicKeys)
+	sort.Slice(sortedPublicKeys, func(i, j int) bool {
+		return bytes.Compare(sortedPublicKeys[i].Bytes(), sortedPublicKeys[j].Bytes()) < 0
+	})
+	for i := 1; i < len(sortedPublicKeys); i++ {
+		if bytes.Equal(sortedPublicKeys[i-1].Bytes(), sortedPublicKeys[i].Bytes()) {
+			err = errors.New("the participants of an aggregated transfer need to have distinct public keys")
+			return
+		}
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Wallet API ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// BLSPrivateKey returns the BLS private key that is derived from the key of the wallet address with the given index.
+func (wallet *Wallet) BLSPrivateKey(addressIndex uint64) bls.PrivateKey {
+	derivedKey := blake2b.Sum512(append([]byte("BLS"), wallet.Seed().KeyPair(addressIndex).PrivateKey.Bytes()...))
+
+	return bls.PrivateKey{
+		Scalar: bn256.NewSuite().G2().Scalar().SetBytes(derivedKey[:]),
+	}
+}
+
+// BLSAddress returns the BLSAddress of the BLS key that is derived from the wallet address with the given index. Its
+// funds can be spent by aggregated transfers.
+func (wallet *Wallet) BLSAddress(addressIndex uint64) address.Address {
+	blsAddress := ledgerstate.NewBLSAddress(wallet.BLSPrivateKey(addressIndex).PublicKey().Bytes())
+
+	return address.Address{AddressBytes: blsAddress.Array()}
+}
+
+// PrepareAggregatedBLSTransfer creates a transfer that consumes all unspent outputs of the BLSAddresses of the given
+// public keys. Since the consumed funds belong to different participants, a remainder address has to be provided in
+// the options if the destinations do not consume all funds. The transfer needs to be signed by all participants before
+// it can be sent.
+func (wallet *Wallet) PrepareAggregatedBLSTransfer(publicKeys []bls.PublicKey, options ...SendFundsOption) (transfer *AggregatedBLSTransfer, err error) {
+	sendFundsOptions, err := buildSendFundsOptions(options...)
+	if err != nil {
+		return
+	}
+	if err = wallet.checkDestinationAmounts(sendFundsOptions); err != nil {
+		return
+	}
+	sortedPublicKeys, err := sortBLSPublicKeys(publicKeys)
+	if err != nil {
+		return
+	}
+
+	blsAddresses := make([]address.Address, len(sortedPublicKeys))
+	for i, publicKey := range sortedPublicKeys {
+		blsAddresses[i] = address.Address{AddressBytes: ledgerstate.NewBLSAddress(publicKey.Bytes()).Array()}
+	}
+	unspentOutputs, err := wallet.connector.UnspentOutputs(blsAddresses...)
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	outputsToConsume := make(OutputsByAddressAndOutputID)
+	for _, blsAddress := range blsAddresses {
+		for outputID, output := range unspentOutputs[blsAddress] {
+			if output.InclusionState.Rejected || output.Locked(now) {
+				continue
+			}
+
+			if _, addressExists := outputsToConsume[blsAddress]; !addressExists {
+				outputsToConsume[blsAddress] = make(map[ledgerstate.OutputID]*Output)
+			}
+			outputsToConsume[blsAddress][outputID] = output
+		}
+		if _, addressExists := outputsToConsume[blsAddress]; !addressExists {
+			err = xerrors.Errorf("there are no funds on the BLSAddress %s", blsAddress.Address().Base58())
+			return
+		}
+	}
+
+	inputs, consumedFunds := wallet.buildInputs(outputsToConsume)
+	requiredFunds := make(map[ledgerstate.Color]uint64)
+	for _, coloredBalances := range sendFundsOptions.Destinations {
+		for color, amount := range coloredBalances {
+			if color == ledgerstate.ColorMint {
+				color = ledgerstate.ColorIOTA
+			}
+			requiredFunds[color] += amount
+		}
+	}
+	for color, amount := range requiredFunds {
+		if consumedFunds[color] < amount {
+			err = errors.New("not enough funds on the BLSAddresses to create transaction")
+			return
+		}
+	}
+	remainder := remainderAmount(outputsToConsume, sendFundsOptions)
+	if remainder != 0 && sendFundsOptions.RemainderAddress == address.AddressEmpty {
+		err = errors.New("a remainder address is required if the destinations do not consume all funds")
+		return
+	}
+	if wallet.isDustRemainder(remainder) {
+		err = xerrors.Errorf("the remainder of %d tokens would create a dust output", remainder)
+		return
+	}
+	outputs := wallet.buildOutputs(sendFundsOptions, consumedFunds)
+
+	return &AggregatedBLSTransfer{
+		essence:    ledgerstate.NewTransactionEssence(0, now, identity.ID{}, identity.ID{}, inputs, outputs),
+		publicKeys: sortedPublicKeys,
+		signatures: make(map[uint8]bls.Signature),
+	}, nil
+}
+
+// SignAggregatedBLSTransfer adds the signature of the BLS key that is derived from the wallet address with the given
+// index to the transfer.
+func (wallet *Wallet) SignAggregatedBLSTransfer(transfer *AggregatedBLSTransfer, addressIndex uint64) (err error) {
+	signature, err := wallet.BLSPrivateKey(addressIndex).Sign(transfer.Essence().Bytes())
+	if err != nil {
+		return
+	}
+
+	return transfer.addSignature(signature)
+}
+
+// SendAggregatedBLSTransfer sends a transfer that was signed by all participants to the network.
+func (wallet *Wallet) SendAggregatedBLSTransfer(transfer *AggregatedBLSTransfer) (tx *ledgerstate.Transaction, err error) {
+	if tx, err = transfer.Transaction(); err != nil {
+		return
+	}
+
+	err = wallet.connector.SendTransaction(tx)
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
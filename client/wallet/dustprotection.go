@@ -0,0 +1,95 @@
+package wallet
+
+import (
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"golang.org/x/xerrors"
+)
+
+// checkDestinationAmounts returns an error if any of the destinations would receive less tokens than the minimum
+// output amount of the dust protection.
+func (wallet *Wallet) checkDestinationAmounts(sendFundsOptions *sendFundsOptions) (err error) {
+	for addr, coloredBalances := range sendFundsOptions.Destinations {
+		amount := uint64(0)
+		for _, balance := range coloredBalances {
+			amount += balance
+		}
+
+		if amount < wallet.dustProtectionParams.MinimumOutputAmount {
+			return xerrors.Errorf("the destination %s would receive less than the minimum output amount of %d tokens", addr.Address().Base58(), wallet.dustProtectionParams.MinimumOutputAmount)
+		}
+	}
+
+	return
+}
+
+// avoidDustRemainder adds further unspent outputs of the wallet to the outputs to consume if the remainder of the
+// transfer would otherwise create a dust output.
+func (wallet *Wallet) avoidDustRemainder(outputsToConsume OutputsByAddressAndOutputID, sendFundsOptions *sendFundsOptions) (err error) {
+	remainder := remainderAmount(outputsToConsume, sendFundsOptions)
+	for addr, unspentOutputsOnAddress := range wallet.unspentOutputManager.UnspentOutputs() {
+		if !wallet.isDustRemainder(remainder) {
+			break
+		}
+
+		// we consume all outputs of an address at once if we are not using a reusable address
+		if _, addressConsumed := outputsToConsume[addr]; addressConsumed && !wallet.reusableAddress {
+			continue
+		}
+		if _, addressExists := outputsToConsume[addr]; !addressExists {
+			outputsToConsume[addr] = make(map[ledgerstate.OutputID]*Output)
+		}
+
+		for outputID, output := range unspentOutputsOnAddress {
+			if _, outputConsumed := outputsToConsume[addr][outputID]; outputConsumed {
+				continue
+			}
+
+			outputsToConsume[addr][outputID] = output
+			output.Balances.ForEach(func(_ ledgerstate.Color, balance uint64) bool {
+				remainder += balance
+				return true
+			})
+
+			if wallet.reusableAddress && !wallet.isDustRemainder(remainder) {
+				break
+			}
+		}
+	}
+
+	if wallet.isDustRemainder(remainder) {
+		return xerrors.Errorf("the remainder of %d tokens would create a dust output", remainder)
+	}
+
+	// the remainder address must not be spent from if we are not using a reusable address
+	if _, remainderAddressInConsumedOutputs := outputsToConsume[sendFundsOptions.RemainderAddress]; remainderAddressInConsumedOutputs && !wallet.reusableAddress {
+		sendFundsOptions.RemainderAddress = wallet.NewReceiveAddress()
+	}
+
+	return
+}
+
+// isDustRemainder returns true if a remainder output with the given amount of tokens would be a dust output.
+func (wallet *Wallet) isDustRemainder(remainder uint64) bool {
+	return remainder != 0 && (remainder < wallet.dustProtectionParams.MinimumOutputAmount || wallet.dustProtectionParams.IsDust(remainder))
+}
+
+// remainderAmount returns the amount of tokens that are left over after the destinations of the transfer were funded
+// from the given outputs.
+func remainderAmount(outputsToConsume OutputsByAddressAndOutputID, sendFundsOptions *sendFundsOptions) (remainder uint64) {
+	for _, outputs := range outputsToConsume {
+		for _, output := range outputs {
+			output.Balances.ForEach(func(_ ledgerstate.Color, balance uint64) bool {
+				remainder += balance
+				return true
+			})
+		}
+	}
+
+	for _, coloredBalances := range sendFundsOptions.Destinations {
+		for _, amount := range coloredBalances {
+			remainder -= amount
+		}
+	}
+
+	return
+}
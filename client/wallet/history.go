@@ -0,0 +1,18 @@
+package wallet
+
+import (
+	"time"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// HistoryEntry defines the effect of a single transaction on the balances of one of the wallets addresses.
+type HistoryEntry struct {
+	Address       address.Address
+	TransactionID ledgerstate.TransactionID
+	Timestamp     time.Time
+	Direction     ledgerstate.AddressHistoryDirection
+	Balances      *ledgerstate.ColoredBalances
+	Rejected      bool
+}
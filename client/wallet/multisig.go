@@ -0,0 +1,227 @@
+package wallet
+
+import (
+	"errors"
+	"time"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"golang.org/x/xerrors"
+)
+
+// region MultiSignatureTransfer ///////////////////////////////////////////////////////////////////////////////////////
+
+// MultiSignatureTransfer is a transfer of funds from a ThresholdAddress that needs to be co-signed by the owners of its
+// keys before it can be sent to the network. It can be passed between the co-signers in its marshaled form.
+type MultiSignatureTransfer struct {
+	essence     *ledgerstate.TransactionEssence
+	unlockBlock *ledgerstate.MultiSignatureUnlockBlock
+}
+
+// MultiSignatureTransferFromBytes unmarshals a MultiSignatureTransfer from a sequence of bytes.
+func MultiSignatureTransferFromBytes(bytes []byte) (transfer *MultiSignatureTransfer, err error) {
+	marshalUtil := marshalutil.New(bytes)
+
+	transfer = &MultiSignatureTransfer{}
+	if transfer.essence, err = ledgerstate.TransactionEssenceFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse TransactionEssence: %w", err)
+		return
+	}
+
+	threshold, err := marshalUtil.ReadUint8()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse threshold: %w", err)
+		return
+	}
+	publicKeyCount, err := marshalUtil.ReadUint8()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse public key count: %w", err)
+		return
+	}
+	publicKeys := make([]ed25519.PublicKey, publicKeyCount)
+	for i := range publicKeys {
+		if publicKeys[i], err = ed25519.ParsePublicKey(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse public key: %w", err)
+			return
+		}
+	}
+	transfer.unlockBlock = ledgerstate.NewMultiSignatureUnlockBlock(threshold, publicKeys...)
+
+	signatureCount, err := marshalUtil.ReadUint8()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse signature count: %w", err)
+		return
+	}
+	for i := uint8(0); i < signatureCount; i++ {
+		publicKey, publicKeyErr := ed25519.ParsePublicKey(marshalUtil)
+		if publicKeyErr != nil {
+			err = xerrors.Errorf("failed to parse public key of signature: %w", publicKeyErr)
+			return
+		}
+		signature, signatureErr := ed25519.ParseSignature(marshalUtil)
+		if signatureErr != nil {
+			err = xerrors.Errorf("failed to parse signature: %w", signatureErr)
+			return
+		}
+		if err = transfer.addSignature(publicKey, signature); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Essence returns the TransactionEssence that is signed by the co-signers.
+func (m *MultiSignatureTransfer) Essence() *ledgerstate.TransactionEssence {
+	return m.essence
+}
+
+// Complete returns true if the transfer carries enough signatures to be sent to the network.
+func (m *MultiSignatureTransfer) Complete() bool {
+	return m.unlockBlock.Complete()
+}
+
+// Transaction returns the Transaction that spends the funds of the ThresholdAddress (it requires the transfer to be
+// complete).
+func (m *MultiSignatureTransfer) Transaction() (tx *ledgerstate.Transaction, err error) {
+	if !m.Complete() {
+		err = errors.New("the transfer has not been signed by enough co-signers, yet")
+		return
+	}
+
+	unlockBlocks := make(ledgerstate.UnlockBlocks, len(m.essence.Inputs()))
+	unlockBlocks[0] = m.unlockBlock
+	for i := 1; i < len(unlockBlocks); i++ {
+		unlockBlocks[i] = ledgerstate.NewReferenceUnlockBlock(0)
+	}
+
+	return ledgerstate.NewTransaction(m.essence, unlockBlocks), nil
+}
+
+// Bytes returns a marshaled version of the transfer (including the signatures that were collected so far).
+func (m *MultiSignatureTransfer) Bytes() []byte {
+	marshalUtil := marshalutil.New().
+		WriteBytes(m.essence.Bytes()).
+		WriteUint8(m.unlockBlock.Threshold()).
+		WriteUint8(uint8(len(m.unlockBlock.PublicKeys())))
+	for _, publicKey := range m.unlockBlock.PublicKeys() {
+		marshalUtil.WriteBytes(publicKey.Bytes())
+	}
+
+	signatures := m.unlockBlock.Signatures()
+	marshalUtil.WriteUint8(uint8(len(signatures)))
+	for _, publicKey := range m.unlockBlock.PublicKeys() {
+		if signature, signatureExists := signatures[publicKey]; signatureExists {
+			marshalUtil.WriteBytes(publicKey.Bytes()).WriteBytes(signature.Bytes())
+		}
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// addSignature adds the signature of a co-signer after verifying it against the essence.
+func (m *MultiSignatureTransfer) addSignature(publicKey ed25519.PublicKey, signature ed25519.Signature) (err error) {
+	if !publicKey.VerifySignature(m.essence.Bytes(), signature) {
+		return errors.New("the signature does not sign the essence of the transfer")
+	}
+
+	return m.unlockBlock.AddSignature(publicKey, signature)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Wallet API ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// PrepareMultiSignatureTransfer creates a transfer of funds from the ThresholdAddress defined by the given threshold
+// and public keys. It consumes all unspent outputs of the ThresholdAddress and sends the remainder back to it unless a
+// different remainder address is provided in the options. The transfer needs to be co-signed before it can be sent.
+func (wallet *Wallet) PrepareMultiSignatureTransfer(threshold uint8, publicKeys []ed25519.PublicKey, options ...SendFundsOption) (transfer *MultiSignatureTransfer, err error) {
+	sendFundsOptions, err := buildSendFundsOptions(options...)
+	if err != nil {
+		return
+	}
+	if err = wallet.checkDestinationAmounts(sendFundsOptions); err != nil {
+		return
+	}
+
+	thresholdAddress, err := ledgerstate.NewThresholdAddress(threshold, publicKeys...)
+	if err != nil {
+		return
+	}
+	walletAddress := address.Address{AddressBytes: thresholdAddress.Array()}
+	if sendFundsOptions.RemainderAddress == address.AddressEmpty {
+		sendFundsOptions.RemainderAddress = walletAddress
+	}
+
+	unspentOutputs, err := wallet.connector.UnspentOutputs(walletAddress)
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	outputsToConsume := make(OutputsByAddressAndOutputID)
+	for outputID, output := range unspentOutputs[walletAddress] {
+		if output.InclusionState.Rejected || output.Locked(now) {
+			continue
+		}
+
+		if _, addressExists := outputsToConsume[walletAddress]; !addressExists {
+			outputsToConsume[walletAddress] = make(map[ledgerstate.OutputID]*Output)
+		}
+		outputsToConsume[walletAddress][outputID] = output
+	}
+	if len(outputsToConsume) == 0 {
+		err = errors.New("there are no funds on the threshold address")
+		return
+	}
+
+	inputs, consumedFunds := wallet.buildInputs(outputsToConsume)
+	requiredFunds := make(map[ledgerstate.Color]uint64)
+	for _, coloredBalances := range sendFundsOptions.Destinations {
+		for color, amount := range coloredBalances {
+			if color == ledgerstate.ColorMint {
+				color = ledgerstate.ColorIOTA
+			}
+			requiredFunds[color] += amount
+		}
+	}
+	for color, amount := range requiredFunds {
+		if consumedFunds[color] < amount {
+			err = errors.New("not enough funds on the threshold address to create transaction")
+			return
+		}
+	}
+	if remainder := remainderAmount(outputsToConsume, sendFundsOptions); wallet.isDustRemainder(remainder) {
+		err = xerrors.Errorf("the remainder of %d tokens would create a dust output", remainder)
+		return
+	}
+	outputs := wallet.buildOutputs(sendFundsOptions, consumedFunds)
+
+	return &MultiSignatureTransfer{
+		essence:     ledgerstate.NewTransactionEssence(0, now, identity.ID{}, identity.ID{}, inputs, outputs),
+		unlockBlock: ledgerstate.NewMultiSignatureUnlockBlock(threshold, publicKeys...),
+	}, nil
+}
+
+// CoSignMultiSignatureTransfer adds the signature of the key of the wallet address with the given index to the
+// transfer.
+func (wallet *Wallet) CoSignMultiSignatureTransfer(transfer *MultiSignatureTransfer, addressIndex uint64) (err error) {
+	keyPair := wallet.Seed().KeyPair(addressIndex)
+
+	return transfer.addSignature(keyPair.PublicKey, keyPair.PrivateKey.Sign(transfer.Essence().Bytes()))
+}
+
+// SendMultiSignatureTransfer sends a completely co-signed transfer to the network.
+func (wallet *Wallet) SendMultiSignatureTransfer(transfer *MultiSignatureTransfer) (tx *ledgerstate.Transaction, err error) {
+	if tx, err = transfer.Transaction(); err != nil {
+		return
+	}
+
+	err = wallet.connector.SendTransaction(tx)
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -3,6 +3,7 @@ package wallet
 import (
 	"github.com/iotaledger/goshimmer/client"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/hive.go/bitmask"
 )
 
@@ -39,3 +40,11 @@ func GenericConnector(connector Connector) Option {
 		wallet.connector = connector
 	}
 }
+
+// DustProtection configures the rules of the network that limit the creation of outputs with tiny balances, so the
+// wallet does not issue transactions that violate them.
+func DustProtection(params ledgerstate.DustProtectionParams) Option {
+	return func(wallet *Wallet) {
+		wallet.dustProtectionParams = params
+	}
+}
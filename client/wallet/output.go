@@ -1,6 +1,8 @@
 package wallet
 
 import (
+	"time"
+
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/hive.go/stringify"
@@ -14,6 +16,16 @@ type Output struct {
 	OutputID       ledgerstate.OutputID
 	Balances       *ledgerstate.ColoredBalances
 	InclusionState InclusionState
+
+	// SpendableFrom and SpendableUntil define the period in which the Output can be unlocked by its Address (the zero
+	// value means that the period is not bounded in that direction).
+	SpendableFrom  time.Time
+	SpendableUntil time.Time
+}
+
+// Locked returns true if the Output can not be unlocked by its Address at the given time.
+func (o *Output) Locked(now time.Time) bool {
+	return (!o.SpendableFrom.IsZero() && now.Before(o.SpendableFrom)) || (!o.SpendableUntil.IsZero() && !now.Before(o.SpendableUntil))
 }
 
 // String returns a human-readable representation of the Output.
@@ -23,6 +35,8 @@ func (o *Output) String() string {
 		stringify.StructField("OutputID", o.OutputID),
 		stringify.StructField("Balances", o.Balances),
 		stringify.StructField("InclusionState", o.InclusionState),
+		stringify.StructField("SpendableFrom", o.SpendableFrom),
+		stringify.StructField("SpendableUntil", o.SpendableUntil),
 	)
 }
 
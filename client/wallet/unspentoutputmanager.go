@@ -1,6 +1,8 @@
 package wallet
 
 import (
+	"time"
+
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 )
@@ -62,7 +64,8 @@ func (unspentOutputManager *UnspentOutputManager) Refresh(includeSpentAddresses
 	return
 }
 
-// UnspentOutputs returns the outputs that have not been spent, yet.
+// UnspentOutputs returns the outputs that have not been spent, yet. Outputs that are currently locked (i.e. by a
+// timelock or because they have fallen back to another address) are skipped.
 func (unspentOutputManager *UnspentOutputManager) UnspentOutputs(addresses ...address.Address) (unspentOutputs map[address.Address]map[ledgerstate.OutputID]*Output) {
 	// prepare result
 	unspentOutputs = make(map[address.Address]map[ledgerstate.OutputID]*Output)
@@ -73,6 +76,7 @@ func (unspentOutputManager *UnspentOutputManager) UnspentOutputs(addresses ...ad
 	}
 
 	// iterate through addresses and scan for unspent outputs
+	now := time.Now()
 	for _, addr := range addresses {
 		// skip the address if we have no outputs for it stored
 		unspentOutputsOnAddress, addressExistsInStoredOutputs := unspentOutputManager.unspentOutputs[addr]
@@ -82,8 +86,8 @@ func (unspentOutputManager *UnspentOutputManager) UnspentOutputs(addresses ...ad
 
 		// iterate through outputs
 		for transactionID, output := range unspentOutputsOnAddress {
-			// skip spent outputs
-			if output.InclusionState.Spent {
+			// skip spent outputs and outputs that can not be unlocked right now
+			if output.InclusionState.Spent || output.Locked(now) {
 				continue
 			}
 
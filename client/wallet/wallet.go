@@ -3,6 +3,7 @@ package wallet
 import (
 	"errors"
 	"reflect"
+	"sort"
 	"time"
 	"unsafe"
 
@@ -24,6 +25,9 @@ type Wallet struct {
 
 	// if this option is enabled the wallet will use a single reusable address instead of changing addresses.
 	reusableAddress bool
+
+	// the rules of the network that limit the creation of outputs with tiny balances.
+	dustProtectionParams ledgerstate.DustProtectionParams
 }
 
 // New is the factory method of the wallet. It either creates a new wallet or restores the wallet backup that is handed
@@ -31,7 +35,8 @@ type Wallet struct {
 func New(options ...Option) (wallet *Wallet) {
 	// create wallet
 	wallet = &Wallet{
-		assetRegistry: NewAssetRegistry(),
+		assetRegistry:        NewAssetRegistry(),
+		dustProtectionParams: ledgerstate.DefaultDustProtectionParams,
 	}
 
 	// configure wallet
@@ -76,12 +81,18 @@ func (wallet *Wallet) SendFunds(options ...SendFundsOption) (tx *ledgerstate.Tra
 	if err != nil {
 		return
 	}
+	if err = wallet.checkDestinationAmounts(sendFundsOptions); err != nil {
+		return
+	}
 
 	// determine which outputs to use for our transfer
 	consumedOutputs, err := wallet.determineOutputsToConsume(sendFundsOptions)
 	if err != nil {
 		return
 	}
+	if err = wallet.avoidDustRemainder(consumedOutputs, sendFundsOptions); err != nil {
+		return
+	}
 
 	// build transaction
 	inputs, consumedFunds := wallet.buildInputs(consumedOutputs)
@@ -157,6 +168,17 @@ func (wallet *Wallet) CreateAsset(asset Asset) (assetColor ledgerstate.Color, er
 	return
 }
 
+// LookupAsset retrieves the on-tangle definition of the asset with the given color and registers it in the
+// AssetRegistry, so the wallet displays the same name and symbol as every other wallet.
+func (wallet *Wallet) LookupAsset(color ledgerstate.Color) (asset Asset, err error) {
+	if asset, err = wallet.connector.(*WebConnector).Asset(color); err != nil {
+		return
+	}
+	wallet.assetRegistry.RegisterAsset(color, asset)
+
+	return
+}
+
 // AssetRegistry return the internal AssetRegistry instance of the wallet.
 func (wallet *Wallet) AssetRegistry() *AssetRegistry {
 	return wallet.assetRegistry
@@ -198,6 +220,31 @@ func (wallet *Wallet) RequestFaucetFunds(waitForConfirmation ...bool) (err error
 		return
 	}
 
+	// nodes that support subscriptions push the confirmation of the faucet transaction, so we do not need to poll
+	if webConnector, isWebConnector := wallet.connector.(*WebConnector); isWebConnector {
+		if subscription, subscribeErr := webConnector.SubscribeAddresses(wallet.ReceiveAddress()); subscribeErr == nil {
+			defer subscription.Close()
+
+			if err = wallet.connector.RequestFaucetFunds(wallet.ReceiveAddress()); err != nil {
+				return
+			}
+
+			for notification := range subscription.Notifications() {
+				if notification.Error != "" {
+					return errors.New(notification.Error)
+				}
+				if notification.Type == "confirmed" {
+					return wallet.Refresh()
+				}
+			}
+			if subscription.Err() != nil {
+				return subscription.Err()
+			}
+
+			return errors.New("subscription closed before the faucet funds were confirmed")
+		}
+	}
+
 	err = wallet.connector.RequestFaucetFunds(wallet.ReceiveAddress())
 	if err != nil {
 		return
@@ -230,6 +277,26 @@ func (wallet *Wallet) Refresh(rescanSpentAddresses ...bool) (err error) {
 	return
 }
 
+// History returns the chronologically ordered history of the transactions that moved funds from or to the addresses
+// of this wallet.
+func (wallet *Wallet) History() (history []*HistoryEntry, err error) {
+	history = make([]*HistoryEntry, 0)
+	for _, addr := range wallet.addressManager.Addresses() {
+		addressHistory, addressHistoryErr := wallet.connector.(*WebConnector).AddressHistory(addr)
+		if addressHistoryErr != nil {
+			err = addressHistoryErr
+
+			return
+		}
+		history = append(history, addressHistory...)
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Timestamp.Before(history[j].Timestamp)
+	})
+
+	return
+}
+
 // Balance returns the confirmed and pending balance of the funds managed by this wallet.
 func (wallet *Wallet) Balance() (confirmedBalance map[ledgerstate.Color]uint64, pendingBalance map[ledgerstate.Color]uint64, err error) {
 	err = wallet.unspentOutputManager.Refresh()
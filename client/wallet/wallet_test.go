@@ -9,7 +9,10 @@ import (
 	walletseed "github.com/iotaledger/goshimmer/client/wallet/packages/seed"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/hive.go/bitmask"
+	"github.com/iotaledger/hive.go/crypto/bls"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWallet_SendFunds(t *testing.T) {
@@ -120,6 +123,144 @@ func TestWallet_SendFunds(t *testing.T) {
 	}
 }
 
+func TestWallet_SendFundsDustRemainder(t *testing.T) {
+	senderSeed := walletseed.NewSeed()
+	receiverAddress := walletseed.NewSeed().Address(0)
+	newOutput := func(transactionID ledgerstate.TransactionID, amount uint64) *Output {
+		return &Output{
+			Address:        senderSeed.Address(0),
+			OutputID:       ledgerstate.NewOutputID(transactionID, 0),
+			Balances:       ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: amount}),
+			InclusionState: InclusionState{Liked: true, Confirmed: true},
+		}
+	}
+	newWallet := func(outputs []*Output, options ...Option) *Wallet {
+		return New(append([]Option{
+			Import(senderSeed, 1, []bitmask.BitMask{}, NewAssetRegistry()),
+			GenericConnector(newMockConnector(outputs...)),
+			ReusableAddress(true),
+		}, options...)...)
+	}
+
+	dustProtectionParams := ledgerstate.DefaultDustProtectionParams
+	dustProtectionParams.DustThreshold = 100
+
+	// the wallet consumes an additional output instead of creating a dust remainder
+	tx, err := newWallet([]*Output{newOutput(ledgerstate.TransactionID{1}, 1000), newOutput(ledgerstate.TransactionID{2}, 500)}, DustProtection(dustProtectionParams)).SendFunds(Destination(receiverAddress, 950))
+	require.NoError(t, err)
+	assert.Len(t, tx.Essence().Inputs(), 2)
+	for _, output := range tx.Essence().Outputs() {
+		assert.False(t, dustProtectionParams.IsDust(ledgerstate.OutputAmount(output)), output.String())
+	}
+
+	// the wallet refuses to create a dust remainder if it has no further funds
+	_, err = newWallet([]*Output{newOutput(ledgerstate.TransactionID{1}, 1000)}, DustProtection(dustProtectionParams)).SendFunds(Destination(receiverAddress, 950))
+	assert.EqualError(t, err, "the remainder of 50 tokens would create a dust output")
+
+	// the wallet creates small remainders if the limitation of dust outputs is disabled (default)
+	tx, err = newWallet([]*Output{newOutput(ledgerstate.TransactionID{1}, 1000)}).SendFunds(Destination(receiverAddress, 950))
+	require.NoError(t, err)
+	assert.Len(t, tx.Essence().Outputs(), 2)
+}
+
+func TestWallet_MultiSignatureTransfer(t *testing.T) {
+	seeds := []*walletseed.Seed{walletseed.NewSeed(), walletseed.NewSeed(), walletseed.NewSeed()}
+	publicKeys := make([]ed25519.PublicKey, len(seeds))
+	wallets := make([]*Wallet, len(seeds))
+	thresholdAddress, err := ledgerstate.NewThresholdAddress(2, seeds[0].KeyPair(0).PublicKey, seeds[1].KeyPair(0).PublicKey, seeds[2].KeyPair(0).PublicKey)
+	require.NoError(t, err)
+	mockedConnector := newMockConnector(&Output{
+		Address:  address.Address{AddressBytes: thresholdAddress.Array()},
+		OutputID: ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 1),
+		Balances: ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{
+			ledgerstate.ColorIOTA: 1337,
+		}),
+		InclusionState: InclusionState{
+			Liked:     true,
+			Confirmed: true,
+		},
+	})
+	for i, seed := range seeds {
+		publicKeys[i] = seed.KeyPair(0).PublicKey
+		wallets[i] = New(Import(seed, 1, []bitmask.BitMask{}, NewAssetRegistry()), GenericConnector(mockedConnector))
+	}
+
+	// the transfer can not exceed the funds of the threshold address
+	_, err = wallets[0].PrepareMultiSignatureTransfer(2, publicKeys, Destination(walletseed.NewSeed().Address(0), 1338))
+	assert.Error(t, err)
+
+	transfer, err := wallets[0].PrepareMultiSignatureTransfer(2, publicKeys, Destination(walletseed.NewSeed().Address(0), 1000))
+	require.NoError(t, err)
+	require.NoError(t, wallets[0].CoSignMultiSignatureTransfer(transfer, 0))
+	assert.False(t, transfer.Complete())
+	_, err = wallets[0].SendMultiSignatureTransfer(transfer)
+	assert.Error(t, err)
+
+	// the second co-signer receives the marshaled transfer
+	restoredTransfer, err := MultiSignatureTransferFromBytes(transfer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, transfer.Bytes(), restoredTransfer.Bytes())
+	require.NoError(t, wallets[2].CoSignMultiSignatureTransfer(restoredTransfer, 0))
+	assert.True(t, restoredTransfer.Complete())
+
+	tx, err := wallets[2].SendMultiSignatureTransfer(restoredTransfer)
+	require.NoError(t, err)
+	assert.True(t, tx.UnlockBlocks()[0].(*ledgerstate.MultiSignatureUnlockBlock).AddressSignatureValid(thresholdAddress, tx.Essence().Bytes()))
+}
+
+func TestWallet_AggregatedBLSTransfer(t *testing.T) {
+	mockedConnector := newMockConnector()
+	wallets := []*Wallet{
+		New(Import(walletseed.NewSeed(), 1, []bitmask.BitMask{}, NewAssetRegistry()), GenericConnector(mockedConnector)),
+		New(Import(walletseed.NewSeed(), 1, []bitmask.BitMask{}, NewAssetRegistry()), GenericConnector(mockedConnector)),
+	}
+	publicKeys := make([]bls.PublicKey, len(wallets))
+	for i, wallet := range wallets {
+		publicKeys[i] = wallet.BLSPrivateKey(0).PublicKey()
+		mockedConnector.outputs[wallet.BLSAddress(0)] = make(map[ledgerstate.OutputID]*Output)
+		mockedConnector.outputs[wallet.BLSAddress(0)][ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, uint16(i))] = &Output{
+			Address:  wallet.BLSAddress(0),
+			OutputID: ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, uint16(i)),
+			Balances: ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{
+				ledgerstate.ColorIOTA: 1000,
+			}),
+			InclusionState: InclusionState{
+				Liked:     true,
+				Confirmed: true,
+			},
+		}
+	}
+	// the derived BLS keys are deterministic
+	assert.Equal(t, wallets[0].BLSPrivateKey(0).Bytes(), wallets[0].BLSPrivateKey(0).Bytes())
+	assert.NotEqual(t, wallets[0].BLSPrivateKey(0).Bytes(), wallets[0].BLSPrivateKey(1).Bytes())
+
+	// the funds of the participants can only be partially spent with an explicit remainder address
+	_, err := wallets[0].PrepareAggregatedBLSTransfer(publicKeys, Destination(walletseed.NewSeed().Address(0), 1500))
+	assert.Error(t, err)
+
+	transfer, err := wallets[0].PrepareAggregatedBLSTransfer(publicKeys, Destination(walletseed.NewSeed().Address(0), 1200), Destination(walletseed.NewSeed().Address(0), 800))
+	require.NoError(t, err)
+	require.NoError(t, wallets[0].SignAggregatedBLSTransfer(transfer, 0))
+	assert.Error(t, wallets[0].SignAggregatedBLSTransfer(transfer, 1))
+	assert.False(t, transfer.Complete())
+	_, err = wallets[0].SendAggregatedBLSTransfer(transfer)
+	assert.Error(t, err)
+
+	// the second participant receives the marshaled transfer
+	restoredTransfer, err := AggregatedBLSTransferFromBytes(transfer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, transfer.Bytes(), restoredTransfer.Bytes())
+	require.NoError(t, wallets[1].SignAggregatedBLSTransfer(restoredTransfer, 0))
+	assert.True(t, restoredTransfer.Complete())
+
+	tx, err := wallets[1].SendAggregatedBLSTransfer(restoredTransfer)
+	require.NoError(t, err)
+	assert.Len(t, tx.UnlockBlocks(), 2)
+	for _, wallet := range wallets {
+		assert.True(t, tx.UnlockBlocks()[0].(*ledgerstate.AggregatedBLSUnlockBlock).AddressSignatureValid(wallet.BLSAddress(0).Address(), tx.Essence().Bytes()))
+	}
+}
+
 type mockConnector struct {
 	outputs map[address.Address]map[ledgerstate.OutputID]*Output
 }
@@ -1,11 +1,17 @@
 package wallet
 
 import (
+	"time"
+
 	"github.com/iotaledger/goshimmer/client"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	webapi_value "github.com/iotaledger/goshimmer/plugins/webapi/value"
 )
 
+// addressHistoryPageSize defines the amount of history entries that are requested at once.
+const addressHistoryPageSize = 1000
+
 // WebConnector implements a connector that uses the web API to connect to a node to implement the required functions
 // for the wallet.
 type WebConnector struct {
@@ -40,6 +46,24 @@ func (webConnector *WebConnector) RequestFaucetFunds(addr address.Address) (err
 	return
 }
 
+// Asset retrieves the on-tangle definition of the asset with the given color.
+func (webConnector *WebConnector) Asset(color ledgerstate.Color) (asset Asset, err error) {
+	response, err := webConnector.client.GetAsset(color.Base58())
+	if err != nil {
+		return
+	}
+
+	asset = Asset{
+		Color:     color,
+		Name:      response.AssetOutput.Name,
+		Symbol:    response.AssetOutput.Symbol,
+		Precision: int(response.AssetOutput.Precision),
+	}
+	asset.Address, err = ledgerstate.AddressFromBase58EncodedString(response.AssetOutput.ControllingAddress)
+
+	return
+}
+
 // UnspentOutputs returns the outputs of transactions on the given addresses that have not been spent yet.
 func (webConnector WebConnector) UnspentOutputs(addresses ...address.Address) (unspentOutputs map[address.Address]map[ledgerstate.OutputID]*Output, err error) {
 	// build reverse lookup table + arguments for client call
@@ -96,6 +120,12 @@ func (webConnector WebConnector) UnspentOutputs(addresses ...address.Address) (u
 					Spent:       false,
 				},
 			}
+			if output.SpendableFrom != 0 {
+				walletOutput.SpendableFrom = time.Unix(0, output.SpendableFrom)
+			}
+			if output.SpendableUntil != 0 {
+				walletOutput.SpendableUntil = time.Unix(0, output.SpendableUntil)
+			}
 
 			// store output in result
 			if _, addressExists := unspentOutputs[addr]; !addressExists {
@@ -108,6 +138,51 @@ func (webConnector WebConnector) UnspentOutputs(addresses ...address.Address) (u
 	return
 }
 
+// AddressHistory retrieves the chronologically ordered history of the transactions that moved funds from or to the
+// given address.
+func (webConnector WebConnector) AddressHistory(addr address.Address) (history []*HistoryEntry, err error) {
+	history = make([]*HistoryEntry, 0)
+	for {
+		response, requestErr := webConnector.client.GetAddressHistory(addr.Address().Base58(), len(history), addressHistoryPageSize)
+		if requestErr != nil {
+			err = requestErr
+
+			return
+		}
+
+		for _, entry := range response.Entries {
+			transactionID, parseErr := ledgerstate.TransactionIDFromBase58(entry.TransactionID)
+			if parseErr != nil {
+				err = parseErr
+
+				return
+			}
+
+			balancesByColor := make(map[ledgerstate.Color]uint64)
+			for _, bal := range entry.Balances {
+				balancesByColor[colorFromString(bal.Color)] += uint64(bal.Value)
+			}
+
+			historyEntry := &HistoryEntry{
+				Address:       addr,
+				TransactionID: transactionID,
+				Timestamp:     time.Unix(entry.Timestamp, 0),
+				Direction:     ledgerstate.IncomingDirection,
+				Balances:      ledgerstate.NewColoredBalances(balancesByColor),
+				Rejected:      entry.Rejected,
+			}
+			if entry.Direction == "outgoing" {
+				historyEntry.Direction = ledgerstate.OutgoingDirection
+			}
+			history = append(history, historyEntry)
+		}
+
+		if len(response.Entries) == 0 || len(history) >= response.Total {
+			return
+		}
+	}
+}
+
 // SendTransaction sends a new transaction to the network.
 func (webConnector WebConnector) SendTransaction(tx *ledgerstate.Transaction) (err error) {
 	_, err = webConnector.client.SendTransaction(tx.Bytes())
@@ -115,6 +190,17 @@ func (webConnector WebConnector) SendTransaction(tx *ledgerstate.Transaction) (e
 	return
 }
 
+// SubscribeAddresses opens a subscription that pushes the changes of the inclusion state of the transactions that
+// move funds from or to the given addresses.
+func (webConnector WebConnector) SubscribeAddresses(addresses ...address.Address) (subscription *client.Subscription, err error) {
+	request := webapi_value.SubscriptionRequest{Addresses: make([]string, len(addresses))}
+	for i, addr := range addresses {
+		request.Addresses[i] = addr.Address().Base58()
+	}
+
+	return webConnector.client.Subscribe(request)
+}
+
 // colorFromString is an internal utility method that parses the given string into a Color.
 func colorFromString(colorStr string) (color ledgerstate.Color) {
 	if colorStr == "IOTA" {
@@ -0,0 +1,464 @@
+package ledgerstate
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+	"golang.org/x/xerrors"
+)
+
+// region AddressHistoryDirection //////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// IncomingDirection represents the direction of the Outputs that were created on an Address by a Transaction.
+	IncomingDirection AddressHistoryDirection = iota
+
+	// OutgoingDirection represents the direction of the Outputs that were consumed from an Address by a Transaction.
+	OutgoingDirection
+)
+
+// AddressHistoryDirection represents the direction in which tokens are moved from the perspective of an Address.
+type AddressHistoryDirection uint8
+
+// AddressHistoryDirectionFromMarshalUtil unmarshals an AddressHistoryDirection using a MarshalUtil (for easier
+// unmarshaling).
+func AddressHistoryDirectionFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (direction AddressHistoryDirection, err error) {
+	directionByte, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse AddressHistoryDirection (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if direction = AddressHistoryDirection(directionByte); direction > OutgoingDirection {
+		err = xerrors.Errorf("invalid AddressHistoryDirection (%d): %w", directionByte, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// Bytes returns a marshaled version of the AddressHistoryDirection.
+func (a AddressHistoryDirection) Bytes() []byte {
+	return []byte{byte(a)}
+}
+
+// String returns a human readable version of the AddressHistoryDirection.
+func (a AddressHistoryDirection) String() string {
+	switch a {
+	case IncomingDirection:
+		return "IncomingDirection"
+	case OutgoingDirection:
+		return "OutgoingDirection"
+	default:
+		return "AddressHistoryDirection(" + strconv.Itoa(int(a)) + ")"
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AddressHistoryEntry //////////////////////////////////////////////////////////////////////////////////////////
+
+// AddressHistoryEntryPartitionKeys defines the "layout" of the key. This enables prefix iterations in the objectstorage.
+var AddressHistoryEntryPartitionKeys = objectstorage.PartitionKey([]int{AddressLength, TransactionIDLength, 1}...)
+
+// AddressHistoryEntry represents the effect of a Transaction on the balances of an Address. Every Transaction creates
+// up to two entries per Address - one for the Outputs that it consumed from the Address and one for the Outputs that it
+// created on the Address. Entries of Transactions that end up in a rejected Branch are kept but marked as rejected.
+type AddressHistoryEntry struct {
+	address       Address
+	transactionID TransactionID
+	direction     AddressHistoryDirection
+	timestamp     time.Time
+	balances      *ColoredBalances
+	rejected      bool
+	rejectedMutex sync.RWMutex
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewAddressHistoryEntry creates a new AddressHistoryEntry from the given details.
+func NewAddressHistoryEntry(address Address, transactionID TransactionID, direction AddressHistoryDirection, timestamp time.Time, balances *ColoredBalances, rejected bool) *AddressHistoryEntry {
+	return &AddressHistoryEntry{
+		address:       address,
+		transactionID: transactionID,
+		direction:     direction,
+		timestamp:     timestamp,
+		balances:      balances,
+		rejected:      rejected,
+	}
+}
+
+// AddressHistoryEntryFromBytes unmarshals an AddressHistoryEntry from a sequence of bytes.
+func AddressHistoryEntryFromBytes(bytes []byte) (addressHistoryEntry *AddressHistoryEntry, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if addressHistoryEntry, err = AddressHistoryEntryFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse AddressHistoryEntry from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AddressHistoryEntryFromMarshalUtil unmarshals an AddressHistoryEntry using a MarshalUtil (for easier unmarshaling).
+func AddressHistoryEntryFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (addressHistoryEntry *AddressHistoryEntry, err error) {
+	addressHistoryEntry = &AddressHistoryEntry{}
+	if addressHistoryEntry.address, err = AddressFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Address from MarshalUtil: %w", err)
+		return
+	}
+	if addressHistoryEntry.transactionID, err = TransactionIDFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse TransactionID from MarshalUtil: %w", err)
+		return
+	}
+	if addressHistoryEntry.direction, err = AddressHistoryDirectionFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse AddressHistoryDirection from MarshalUtil: %w", err)
+		return
+	}
+	if addressHistoryEntry.timestamp, err = marshalUtil.ReadTime(); err != nil {
+		err = xerrors.Errorf("failed to parse timestamp (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if addressHistoryEntry.balances, err = ColoredBalancesFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse ColoredBalances from MarshalUtil: %w", err)
+		return
+	}
+	if addressHistoryEntry.rejected, err = marshalUtil.ReadBool(); err != nil {
+		err = xerrors.Errorf("failed to parse rejected flag (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// AddressHistoryEntryFromObjectStorage is a factory method that creates a new AddressHistoryEntry instance from a
+// storage key of the object storage. It is used by the object storage, to create new instances of this entity.
+func AddressHistoryEntryFromObjectStorage(key []byte, data []byte) (result objectstorage.StorableObject, err error) {
+	if result, _, err = AddressHistoryEntryFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = xerrors.Errorf("failed to parse AddressHistoryEntry from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// Address returns the Address that is affected by the AddressHistoryEntry.
+func (a *AddressHistoryEntry) Address() Address {
+	return a.address
+}
+
+// TransactionID returns the TransactionID of the Transaction that created the AddressHistoryEntry.
+func (a *AddressHistoryEntry) TransactionID() TransactionID {
+	return a.transactionID
+}
+
+// Direction returns the direction in which the tokens were moved from the perspective of the Address.
+func (a *AddressHistoryEntry) Direction() AddressHistoryDirection {
+	return a.direction
+}
+
+// Timestamp returns the timestamp of the Transaction that created the AddressHistoryEntry.
+func (a *AddressHistoryEntry) Timestamp() time.Time {
+	return a.timestamp
+}
+
+// Balances returns the sum of the balances that were moved from or to the Address.
+func (a *AddressHistoryEntry) Balances() *ColoredBalances {
+	return a.balances
+}
+
+// Rejected returns true if the Transaction of the AddressHistoryEntry was rejected.
+func (a *AddressHistoryEntry) Rejected() bool {
+	a.rejectedMutex.RLock()
+	defer a.rejectedMutex.RUnlock()
+
+	return a.rejected
+}
+
+// SetRejected updates the rejected flag of the AddressHistoryEntry and returns true if the value was changed.
+func (a *AddressHistoryEntry) SetRejected(rejected bool) (modified bool) {
+	a.rejectedMutex.Lock()
+	defer a.rejectedMutex.Unlock()
+
+	if a.rejected == rejected {
+		return
+	}
+
+	a.rejected = rejected
+	a.SetModified()
+	modified = true
+
+	return
+}
+
+// Bytes marshals the AddressHistoryEntry into a sequence of bytes.
+func (a *AddressHistoryEntry) Bytes() []byte {
+	return byteutils.ConcatBytes(a.ObjectStorageKey(), a.ObjectStorageValue())
+}
+
+// String returns a human readable version of the AddressHistoryEntry.
+func (a *AddressHistoryEntry) String() string {
+	return stringify.Struct("AddressHistoryEntry",
+		stringify.StructField("address", a.address),
+		stringify.StructField("transactionID", a.transactionID),
+		stringify.StructField("direction", a.direction),
+		stringify.StructField("timestamp", a.timestamp),
+		stringify.StructField("balances", a.balances),
+		stringify.StructField("rejected", a.Rejected()),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (a *AddressHistoryEntry) Update(other objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (a *AddressHistoryEntry) ObjectStorageKey() []byte {
+	return byteutils.ConcatBytes(a.address.Bytes(), a.transactionID.Bytes(), a.direction.Bytes())
+}
+
+// ObjectStorageValue marshals the AddressHistoryEntry into a sequence of bytes that are used as the value part in the
+// object storage.
+func (a *AddressHistoryEntry) ObjectStorageValue() []byte {
+	return marshalutil.New().
+		WriteTime(a.timestamp).
+		Write(a.balances).
+		WriteBool(a.Rejected()).
+		Bytes()
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ objectstorage.StorableObject = &AddressHistoryEntry{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedAddressHistoryEntry ////////////////////////////////////////////////////////////////////////////////////
+
+// CachedAddressHistoryEntry is a wrapper for the generic CachedObject returned by the object storage that overrides the
+// accessor methods with a type-casted one.
+type CachedAddressHistoryEntry struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks the CachedObject to still be in use by the program.
+func (c *CachedAddressHistoryEntry) Retain() *CachedAddressHistoryEntry {
+	return &CachedAddressHistoryEntry{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedAddressHistoryEntry) Unwrap() *AddressHistoryEntry {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*AddressHistoryEntry)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (c *CachedAddressHistoryEntry) Consume(consumer func(addressHistoryEntry *AddressHistoryEntry), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*AddressHistoryEntry))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedAddressHistoryEntry.
+func (c *CachedAddressHistoryEntry) String() string {
+	return stringify.Struct("CachedAddressHistoryEntry",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedAddressHistoryEntries //////////////////////////////////////////////////////////////////////////////////
+
+// CachedAddressHistoryEntries represents a collection of CachedAddressHistoryEntry objects.
+type CachedAddressHistoryEntries []*CachedAddressHistoryEntry
+
+// Unwrap is the type-casted equivalent of Get. It returns a slice of unwrapped objects with the object being nil if it
+// does not exist.
+func (c CachedAddressHistoryEntries) Unwrap() (unwrappedEntries []*AddressHistoryEntry) {
+	unwrappedEntries = make([]*AddressHistoryEntry, len(c))
+	for i, cachedAddressHistoryEntry := range c {
+		untypedObject := cachedAddressHistoryEntry.Get()
+		if untypedObject == nil {
+			continue
+		}
+
+		typedObject := untypedObject.(*AddressHistoryEntry)
+		if typedObject == nil || typedObject.IsDeleted() {
+			continue
+		}
+
+		unwrappedEntries[i] = typedObject
+	}
+
+	return
+}
+
+// Consume iterates over the CachedObjects, unwraps them and passes a type-casted version to the consumer (if the object
+// is not empty - it exists). It automatically releases the object when the consumer finishes. It returns true, if at
+// least one object was consumed.
+func (c CachedAddressHistoryEntries) Consume(consumer func(addressHistoryEntry *AddressHistoryEntry), forceRelease ...bool) (consumed bool) {
+	for _, cachedAddressHistoryEntry := range c {
+		consumed = cachedAddressHistoryEntry.Consume(consumer, forceRelease...) || consumed
+	}
+
+	return
+}
+
+// Release is a utility function that allows us to release all CachedObjects in the collection.
+func (c CachedAddressHistoryEntries) Release(force ...bool) {
+	for _, cachedAddressHistoryEntry := range c {
+		cachedAddressHistoryEntry.Release(force...)
+	}
+}
+
+// String returns a human readable version of the CachedAddressHistoryEntries.
+func (c CachedAddressHistoryEntries) String() string {
+	structBuilder := stringify.StructBuilder("CachedAddressHistoryEntries")
+	for i, cachedAddressHistoryEntry := range c {
+		structBuilder.AddField(stringify.StructField(strconv.Itoa(i), cachedAddressHistoryEntry))
+	}
+
+	return structBuilder.String()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region UTXODAG API //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// AddressHistory retrieves the AddressHistoryEntries of the given Address.
+func (u *UTXODAG) AddressHistory(address Address) (cachedAddressHistoryEntries CachedAddressHistoryEntries) {
+	u.addressHistoryStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		cachedAddressHistoryEntries = append(cachedAddressHistoryEntries, &CachedAddressHistoryEntry{cachedObject})
+		return true
+	}, address.Bytes())
+
+	return
+}
+
+// storeAddressHistory is an internal utility function that stores the AddressHistoryEntries of a newly booked
+// Transaction. The balances of the consumed and the created Outputs are summed up per Address.
+func (u *UTXODAG) storeAddressHistory(transaction *Transaction, consumedOutputs Outputs, targetBranch BranchID) {
+	rejected := false
+	u.branchDAG.Branch(targetBranch).Consume(func(branch Branch) {
+		rejected = branch.InclusionState() == Rejected
+	})
+
+	createdOutputs := make(Outputs, 0, len(transaction.Essence().Outputs()))
+	for _, outputID := range u.createdOutputIDsOfTransaction(transaction.ID()) {
+		// the stored Outputs carry the final Colors of minted tokens
+		u.Output(outputID).Consume(func(output Output) {
+			createdOutputs = append(createdOutputs, output)
+		})
+	}
+
+	for direction, outputs := range map[AddressHistoryDirection]Outputs{OutgoingDirection: consumedOutputs, IncomingDirection: createdOutputs} {
+		for _, delta := range balancesByAddress(outputs) {
+			cachedEntry, stored := u.addressHistoryStorage.StoreIfAbsent(NewAddressHistoryEntry(delta.address, transaction.ID(), direction, transaction.Essence().Timestamp(), NewColoredBalances(delta.balances), rejected))
+			if stored {
+				cachedEntry.Release()
+			}
+		}
+	}
+}
+
+// onBranchRejected is an internal event handler that marks the AddressHistoryEntries of the Transactions in the future
+// cone of a rejected ConflictBranch as rejected. Rejections of AggregatedBranches do not need to be handled separately
+// as their Transactions are always part of the future cone of a ConflictBranch.
+func (u *UTXODAG) onBranchRejected(event *BranchDAGEvent) {
+	defer event.Release()
+
+	branch := event.Branch.Unwrap()
+	if branch == nil || branch.Type() != ConflictBranchType {
+		return
+	}
+
+	conflictingTransactionID := TransactionID(branch.ID())
+	u.markAddressHistoryRejected(conflictingTransactionID)
+	u.walkFutureCone(u.createdOutputIDsOfTransaction(conflictingTransactionID), func(transactionID TransactionID) (nextOutputsToVisit []OutputID) {
+		u.markAddressHistoryRejected(transactionID)
+
+		return u.createdOutputIDsOfTransaction(transactionID)
+	})
+}
+
+// markAddressHistoryRejected is an internal utility function that marks the AddressHistoryEntries of the given
+// Transaction as rejected.
+func (u *UTXODAG) markAddressHistoryRejected(transactionID TransactionID) {
+	addressesByDirection := map[AddressHistoryDirection][]OutputID{
+		OutgoingDirection: u.consumedOutputIDsOfTransaction(transactionID),
+		IncomingDirection: u.createdOutputIDsOfTransaction(transactionID),
+	}
+
+	for direction, outputIDs := range addressesByDirection {
+		for _, outputID := range outputIDs {
+			u.Output(outputID).Consume(func(output Output) {
+				(&CachedAddressHistoryEntry{CachedObject: u.addressHistoryStorage.Load(byteutils.ConcatBytes(output.Address().Bytes(), transactionID.Bytes(), direction.Bytes()))}).Consume(func(addressHistoryEntry *AddressHistoryEntry) {
+					addressHistoryEntry.SetRejected(true)
+				})
+			})
+		}
+	}
+}
+
+// addressBalances is an internal utility type that holds the summed up balances of an Address.
+type addressBalances struct {
+	address  Address
+	balances map[Color]uint64
+}
+
+// balancesByAddress is an internal utility function that sums up the balances of the given Outputs per Address.
+func balancesByAddress(outputs Outputs) (result map[[AddressLength]byte]*addressBalances) {
+	result = make(map[[AddressLength]byte]*addressBalances)
+	for _, output := range outputs {
+		if output == nil {
+			continue
+		}
+
+		addressKey := output.Address().Array()
+		if _, exists := result[addressKey]; !exists {
+			result[addressKey] = &addressBalances{address: output.Address(), balances: make(map[Color]uint64)}
+		}
+		output.Balances().ForEach(func(color Color, balance uint64) bool {
+			result[addressKey].balances[color] += balance
+			return true
+		})
+	}
+
+	return
+}
+
+// SortAddressHistoryEntries sorts the given AddressHistoryEntries chronologically. Entries with the same timestamp are
+// ordered by their TransactionID and direction to produce a deterministic order.
+func SortAddressHistoryEntries(entries []*AddressHistoryEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].Timestamp().Equal(entries[j].Timestamp()) {
+			return entries[i].Timestamp().Before(entries[j].Timestamp())
+		}
+		if entries[i].TransactionID() != entries[j].TransactionID() {
+			return bytes.Compare(entries[i].TransactionID().Bytes(), entries[j].TransactionID().Bytes()) < 0
+		}
+
+		return entries[i].Direction() < entries[j].Direction()
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
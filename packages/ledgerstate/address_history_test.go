@@ -0,0 +1,120 @@
+package ledgerstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressHistoryEntry_Bytes(t *testing.T) {
+	wallets := createWallets(1)
+	entry := NewAddressHistoryEntry(wallets[0].address, GenesisTransactionID, OutgoingDirection, time.Now(), NewColoredBalances(map[Color]uint64{ColorIOTA: 1337}), true)
+
+	restoredEntry, _, err := AddressHistoryEntryFromBytes(entry.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, entry.Bytes(), restoredEntry.Bytes())
+	assert.True(t, restoredEntry.Rejected())
+	assert.Equal(t, OutgoingDirection, restoredEntry.Direction())
+	assert.True(t, entry.Timestamp().Equal(restoredEntry.Timestamp()))
+}
+
+func TestUTXODAG_AddressHistory(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+	defer utxoDAG.Shutdown()
+
+	wallets := createWallets(3)
+	utxoDAG.LoadSnapshot(map[TransactionID]map[Address]*ColoredBalances{
+		GenesisTransactionID: {
+			wallets[0].address: NewColoredBalances(map[Color]uint64{ColorIOTA: 100}),
+		},
+	})
+	var genesisOutput Output
+	utxoDAG.Output(NewOutputID(GenesisTransactionID, 0)).Consume(func(output Output) {
+		genesisOutput = output
+	})
+	signedBy := func(w wallet) func(txEssence *TransactionEssence, _ Output) UnlockBlock {
+		return func(txEssence *TransactionEssence, _ Output) UnlockBlock {
+			return NewSignatureUnlockBlock(w.sign(txEssence))
+		}
+	}
+
+	// send 60 tokens to the second wallet and keep a remainder of 40
+	tx1 := aliasTransaction([]Output{genesisOutput}, []Output{
+		NewSigLockedSingleOutput(60, wallets[1].address),
+		NewSigLockedSingleOutput(40, wallets[0].address),
+	}, signedBy(wallets[0]))
+	_, err := utxoDAG.BookTransaction(tx1)
+	require.NoError(t, err)
+
+	history := addressHistory(utxoDAG, wallets[0].address)
+	require.Len(t, history, 2)
+	for _, entry := range history {
+		assert.Equal(t, tx1.ID(), entry.TransactionID())
+		assert.True(t, entry.Timestamp().Equal(tx1.Essence().Timestamp()))
+		assert.False(t, entry.Rejected())
+	}
+	assert.Equal(t, map[Color]uint64{ColorIOTA: 100}, historyEntry(t, history, OutgoingDirection).Balances().Map())
+	assert.Equal(t, map[Color]uint64{ColorIOTA: 40}, historyEntry(t, history, IncomingDirection).Balances().Map())
+
+	history = addressHistory(utxoDAG, wallets[1].address)
+	require.Len(t, history, 1)
+	assert.Equal(t, map[Color]uint64{ColorIOTA: 60}, historyEntry(t, history, IncomingDirection).Balances().Map())
+
+	// spend the received tokens (future cone of tx1)
+	var receivedOutput Output
+	for _, outputID := range utxoDAG.createdOutputIDsOfTransaction(tx1.ID()) {
+		utxoDAG.Output(outputID).Consume(func(output Output) {
+			if output.Address().Array() == wallets[1].address.Array() {
+				receivedOutput = output
+			}
+		})
+	}
+	tx2 := aliasTransaction([]Output{receivedOutput}, []Output{NewSigLockedSingleOutput(60, wallets[2].address)}, signedBy(wallets[1]))
+	_, err = utxoDAG.BookTransaction(tx2)
+	require.NoError(t, err)
+
+	// double spend the genesis and confirm the double spend
+	doubleSpend := aliasTransaction([]Output{genesisOutput}, []Output{NewSigLockedSingleOutput(100, wallets[2].address)}, signedBy(wallets[0]))
+	doubleSpendBranch, err := utxoDAG.BookTransaction(doubleSpend)
+	require.NoError(t, err)
+	_, err = branchDAG.SetBranchLiked(doubleSpendBranch, true)
+	require.NoError(t, err)
+	_, err = branchDAG.SetBranchFinalized(doubleSpendBranch, true)
+	require.NoError(t, err)
+
+	for _, entry := range addressHistory(utxoDAG, wallets[0].address) {
+		assert.Equal(t, entry.TransactionID() == tx1.ID(), entry.Rejected(), entry.String())
+	}
+	for _, entry := range addressHistory(utxoDAG, wallets[1].address) {
+		assert.True(t, entry.Rejected(), entry.String())
+	}
+	history = addressHistory(utxoDAG, wallets[2].address)
+	require.Len(t, history, 2)
+	for _, entry := range history {
+		assert.Equal(t, entry.TransactionID() == tx2.ID(), entry.Rejected(), entry.String())
+	}
+}
+
+func addressHistory(utxoDAG *UTXODAG, address Address) (entries []*AddressHistoryEntry) {
+	cachedEntries := utxoDAG.AddressHistory(address)
+	defer cachedEntries.Release()
+
+	entries = cachedEntries.Unwrap()
+	SortAddressHistoryEntries(entries)
+
+	return
+}
+
+func historyEntry(t *testing.T, entries []*AddressHistoryEntry, direction AddressHistoryDirection) *AddressHistoryEntry {
+	for _, entry := range entries {
+		if entry.Direction() == direction {
+			return entry
+		}
+	}
+	require.FailNow(t, "missing AddressHistoryEntry", "direction: %s", direction)
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"math/rand"
 	"testing"
 
+	"github.com/iotaledger/hive.go/crypto/bls"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -64,3 +65,147 @@ func TestBLSAddress(t *testing.T) {
 	assert.Equal(t, address.Type(), addressFromBase58.Type())
 	assert.Equal(t, address.Digest(), addressFromBase58.Digest())
 }
+
+func TestAliasAddress(t *testing.T) {
+	address := NewAliasAddress(NewOutputID(GenesisTransactionID, 0).Bytes())
+
+	// alias address from bytes
+	address1, _, err := AliasAddressFromBytes(address.Bytes())
+	require.NoError(t, err)
+	assert.True(t, address.Equals(address1))
+
+	// alias address from bytes using AddressFromBytes
+	address2, _, err := AddressFromBytes(address.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, AliasAddressType, address2.Type())
+	assert.Equal(t, address.Digest(), address2.Digest())
+
+	// alias address from base58 string
+	address3, err := AddressFromBase58EncodedString(address.Base58())
+	require.NoError(t, err)
+	assert.Equal(t, address.Bytes(), address3.Bytes())
+
+	// alias addresses can not be unlocked by signatures
+	keyPair := ed25519.GenerateKeyPair()
+	signature := NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign([]byte("data")))
+	assert.False(t, signature.AddressSignatureValid(address, []byte("data")))
+}
+
+func TestThresholdAddress(t *testing.T) {
+	keyPairs := []ed25519.KeyPair{ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair()}
+	address, err := NewThresholdAddress(2, keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey)
+	require.NoError(t, err)
+
+	// the order of the public keys does not matter
+	reorderedAddress, err := NewThresholdAddress(2, keyPairs[2].PublicKey, keyPairs[0].PublicKey, keyPairs[1].PublicKey)
+	require.NoError(t, err)
+	assert.Equal(t, address.Bytes(), reorderedAddress.Bytes())
+
+	// but the threshold does
+	otherThresholdAddress, err := NewThresholdAddress(1, keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey)
+	require.NoError(t, err)
+	assert.NotEqual(t, address.Bytes(), otherThresholdAddress.Bytes())
+
+	// invalid parameters
+	_, err = NewThresholdAddress(4, keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey)
+	assert.Error(t, err)
+	_, err = NewThresholdAddress(1, keyPairs[0].PublicKey, keyPairs[0].PublicKey)
+	assert.Error(t, err)
+
+	// threshold address from bytes using AddressFromBytes
+	address1, _, err := AddressFromBytes(address.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, ThresholdAddressType, address1.Type())
+	assert.Equal(t, address.Digest(), address1.Digest())
+
+	// threshold address from base58 string
+	address2, err := AddressFromBase58EncodedString(address.Base58())
+	require.NoError(t, err)
+	assert.Equal(t, address.Bytes(), address2.Bytes())
+
+	// threshold addresses can not be unlocked by single signatures
+	signature := NewED25519Signature(keyPairs[0].PublicKey, keyPairs[0].PrivateKey.Sign([]byte("data")))
+	assert.False(t, signature.AddressSignatureValid(address, []byte("data")))
+}
+
+func TestMultiSignatureUnlockBlock(t *testing.T) {
+	keyPairs := []ed25519.KeyPair{ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair()}
+	address, err := NewThresholdAddress(2, keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey)
+	require.NoError(t, err)
+	data := []byte("data")
+
+	unlockBlock := NewMultiSignatureUnlockBlock(2, keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey)
+	unlockBlockAddress, err := unlockBlock.Address()
+	require.NoError(t, err)
+	assert.Equal(t, address.Bytes(), unlockBlockAddress.Bytes())
+
+	// a single signature does not reach the threshold
+	require.NoError(t, unlockBlock.AddSignature(keyPairs[2].PublicKey, keyPairs[2].PrivateKey.Sign(data)))
+	assert.False(t, unlockBlock.Complete())
+	assert.False(t, unlockBlock.AddressSignatureValid(address, data))
+
+	// duplicate signatures and foreign keys are rejected
+	assert.Error(t, unlockBlock.AddSignature(keyPairs[2].PublicKey, keyPairs[2].PrivateKey.Sign(data)))
+	foreignKeyPair := ed25519.GenerateKeyPair()
+	assert.Error(t, unlockBlock.AddSignature(foreignKeyPair.PublicKey, foreignKeyPair.PrivateKey.Sign(data)))
+
+	require.NoError(t, unlockBlock.AddSignature(keyPairs[0].PublicKey, keyPairs[0].PrivateKey.Sign(data)))
+	assert.True(t, unlockBlock.Complete())
+	assert.True(t, unlockBlock.AddressSignatureValid(address, data))
+	assert.False(t, unlockBlock.AddressSignatureValid(address, []byte("other data")))
+	assert.Error(t, unlockBlock.AddSignature(keyPairs[1].PublicKey, keyPairs[1].PrivateKey.Sign(data)))
+
+	// marshaling
+	restoredUnlockBlock, _, err := UnlockBlockFromBytes(unlockBlock.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, unlockBlock.Bytes(), restoredUnlockBlock.Bytes())
+	assert.True(t, restoredUnlockBlock.(*MultiSignatureUnlockBlock).AddressSignatureValid(address, data))
+
+	// incomplete UnlockBlocks can not be parsed
+	incompleteUnlockBlock := NewMultiSignatureUnlockBlock(2, keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey)
+	require.NoError(t, incompleteUnlockBlock.AddSignature(keyPairs[1].PublicKey, keyPairs[1].PrivateKey.Sign(data)))
+	_, _, err = UnlockBlockFromBytes(incompleteUnlockBlock.Bytes())
+	assert.Error(t, err)
+}
+
+func TestAggregatedBLSUnlockBlock(t *testing.T) {
+	privateKeys := []bls.PrivateKey{bls.PrivateKeyFromRandomness(), bls.PrivateKeyFromRandomness(), bls.PrivateKeyFromRandomness()}
+	data := []byte("data")
+	signatures := make([]bls.SignatureWithPublicKey, len(privateKeys))
+	for i, privateKey := range privateKeys {
+		signature, err := privateKey.Sign(data)
+		require.NoError(t, err)
+		signatures[i] = signature
+	}
+
+	unlockBlock, err := NewAggregatedBLSUnlockBlock(signatures...)
+	require.NoError(t, err)
+	for _, privateKey := range privateKeys {
+		assert.True(t, unlockBlock.AddressSignatureValid(NewBLSAddress(privateKey.PublicKey().Bytes()), data))
+	}
+	assert.False(t, unlockBlock.AddressSignatureValid(NewBLSAddress(bls.PrivateKeyFromRandomness().PublicKey().Bytes()), data))
+	assert.False(t, unlockBlock.AddressSignatureValid(NewBLSAddress(privateKeys[0].PublicKey().Bytes()), []byte("other data")))
+
+	// the order of the signatures does not matter
+	reorderedUnlockBlock, err := NewAggregatedBLSUnlockBlock(signatures[2], signatures[0], signatures[1])
+	require.NoError(t, err)
+	assert.Equal(t, unlockBlock.Bytes(), reorderedUnlockBlock.Bytes())
+
+	// marshaling
+	restoredUnlockBlock, _, err := UnlockBlockFromBytes(unlockBlock.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, unlockBlock.Bytes(), restoredUnlockBlock.Bytes())
+	assert.True(t, restoredUnlockBlock.(*AggregatedBLSUnlockBlock).SignatureValid(data))
+
+	// a signature that is missing from the aggregate invalidates it
+	incompleteUnlockBlock, err := NewAggregatedBLSUnlockBlock(signatures[0], signatures[1])
+	require.NoError(t, err)
+	incompleteUnlockBlock.publicKeys = unlockBlock.publicKeys
+	assert.False(t, incompleteUnlockBlock.SignatureValid(data))
+
+	// duplicate keys and single signatures are rejected
+	_, err = NewAggregatedBLSUnlockBlock(signatures[0], signatures[0])
+	assert.Error(t, err)
+	_, err = NewAggregatedBLSUnlockBlock(signatures[0])
+	assert.Error(t, err)
+}
@@ -0,0 +1,857 @@
+package ledgerstate
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+	"github.com/iotaledger/hive.go/types"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/xerrors"
+)
+
+// region SupplyPolicy /////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// FixedSupply represents a SupplyPolicy that neither allows to mint additional tokens nor to burn existing ones.
+	FixedSupply SupplyPolicy = 0
+
+	// MintableSupply represents a SupplyPolicy flag that allows the controller of an asset to mint additional tokens.
+	MintableSupply SupplyPolicy = 1 << 0
+
+	// BurnableSupply represents a SupplyPolicy flag that allows the holders of an asset to burn their tokens.
+	BurnableSupply SupplyPolicy = 1 << 1
+
+	// supplyPolicyMask contains all of the known SupplyPolicy flags.
+	supplyPolicyMask = MintableSupply | BurnableSupply
+)
+
+// SupplyPolicy defines how the supply of an asset can be changed after its creation.
+type SupplyPolicy uint8
+
+// SupplyPolicyFromMarshalUtil unmarshals a SupplyPolicy using a MarshalUtil (for easier unmarshaling).
+func SupplyPolicyFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (supplyPolicy SupplyPolicy, err error) {
+	supplyPolicyByte, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse SupplyPolicy (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if supplyPolicy = SupplyPolicy(supplyPolicyByte); supplyPolicy&^supplyPolicyMask != 0 {
+		err = xerrors.Errorf("invalid SupplyPolicy (%X): %w", supplyPolicyByte, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// Mintable returns true if the controller of the asset is allowed to mint additional tokens.
+func (s SupplyPolicy) Mintable() bool {
+	return s&MintableSupply != 0
+}
+
+// Burnable returns true if the holders of the asset are allowed to burn their tokens.
+func (s SupplyPolicy) Burnable() bool {
+	return s&BurnableSupply != 0
+}
+
+// Bytes returns a marshaled version of the SupplyPolicy.
+func (s SupplyPolicy) Bytes() []byte {
+	return []byte{byte(s)}
+}
+
+// String returns a human readable representation of the SupplyPolicy.
+func (s SupplyPolicy) String() string {
+	if s == FixedSupply {
+		return "FixedSupply"
+	}
+
+	flags := make([]string, 0)
+	if s.Mintable() {
+		flags = append(flags, "MintableSupply")
+	}
+	if s.Burnable() {
+		flags = append(flags, "BurnableSupply")
+	}
+
+	return strings.Join(flags, "|")
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AssetOutput //////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// MaxAssetNameLength defines the maximum length of the name of an asset.
+	MaxAssetNameLength = 64
+
+	// MaxAssetSymbolLength defines the maximum length of the symbol of an asset.
+	MaxAssetSymbolLength = 16
+)
+
+// AssetOutput is an Output that defines the metadata of an asset (the tokens of a Color). The Color of the asset is
+// derived from the OutputID of the origin of the chain, so the tokens that are minted by the origin carry the Color of
+// the asset. The metadata can never be changed while the control over the asset can be handed over to another Address.
+// The controller can mint additional tokens if the SupplyPolicy allows it. An AssetOutput can not be destroyed and has
+// to be continued by exactly one successor whenever it is spent.
+type AssetOutput struct {
+	id                 OutputID
+	idMutex            sync.RWMutex
+	assetColor         Color
+	balances           *ColoredBalances
+	controllingAddress Address
+	name               string
+	symbol             string
+	precision          uint8
+	supplyPolicy       SupplyPolicy
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewAssetOutput creates the origin of a new asset with the given metadata. The tokens that are minted by the origin
+// (using ColorMint) receive the Color of the asset.
+func NewAssetOutput(balances *ColoredBalances, controllingAddress Address, name string, symbol string, precision uint8, supplyPolicy SupplyPolicy) *AssetOutput {
+	return &AssetOutput{
+		assetColor:         ColorMint,
+		balances:           balances,
+		controllingAddress: controllingAddress,
+		name:               name,
+		symbol:             symbol,
+		precision:          precision,
+		supplyPolicy:       supplyPolicy,
+	}
+}
+
+// AssetOutputFromBytes unmarshals an AssetOutput from a sequence of bytes.
+func AssetOutputFromBytes(bytes []byte) (output *AssetOutput, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if output, err = AssetOutputFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse AssetOutput from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AssetOutputFromMarshalUtil unmarshals an AssetOutput using a MarshalUtil (for easier unmarshaling).
+func AssetOutputFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (output *AssetOutput, err error) {
+	outputType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse OutputType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if OutputType(outputType) != AssetOutputType {
+		err = xerrors.Errorf("invalid OutputType (%X): %w", outputType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	output = &AssetOutput{}
+	if output.assetColor, err = ColorFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse asset Color: %w", err)
+		return
+	}
+	if output.assetColor == ColorIOTA {
+		err = xerrors.Errorf("IOTA can not be defined as an asset: %w", cerrors.ErrParseBytesFailed)
+		return
+	}
+	if output.balances, err = ColoredBalancesFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse ColoredBalances: %w", err)
+		return
+	}
+	if output.controllingAddress, err = AddressFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse controlling Address (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if output.name, err = readAssetString(marshalUtil, MaxAssetNameLength); err != nil {
+		err = xerrors.Errorf("failed to parse name: %w", err)
+		return
+	}
+	if output.symbol, err = readAssetString(marshalUtil, MaxAssetSymbolLength); err != nil {
+		err = xerrors.Errorf("failed to parse symbol: %w", err)
+		return
+	}
+	if output.precision, err = marshalUtil.ReadUint8(); err != nil {
+		err = xerrors.Errorf("failed to parse precision (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if output.supplyPolicy, err = SupplyPolicyFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse SupplyPolicy: %w", err)
+		return
+	}
+
+	return
+}
+
+// ID returns the identifier of the Output that is used to address the Output in the UTXODAG.
+func (a *AssetOutput) ID() OutputID {
+	a.idMutex.RLock()
+	defer a.idMutex.RUnlock()
+
+	return a.id
+}
+
+// SetID allows to set the identifier of the Output. We offer a setter for the property since Outputs that are
+// created to become part of a transaction usually do not have an identifier, yet as their identifier depends on
+// the TransactionID that is only determinable after the Transaction has been fully constructed. The ID is therefore
+// only accessed when the Output is supposed to be persisted by the node.
+func (a *AssetOutput) SetID(outputID OutputID) Output {
+	a.idMutex.Lock()
+	defer a.idMutex.Unlock()
+
+	a.id = outputID
+
+	return a
+}
+
+// Type returns the type of the Output which allows us to generically handle Outputs of different types.
+func (a *AssetOutput) Type() OutputType {
+	return AssetOutputType
+}
+
+// Balances returns the funds that are associated with the Output.
+func (a *AssetOutput) Balances() *ColoredBalances {
+	return a.balances
+}
+
+// SetBalances sets the funds of an AssetOutput that is being constructed.
+func (a *AssetOutput) SetBalances(balances *ColoredBalances) {
+	a.balances = balances
+}
+
+// Address returns the Address that controls the asset.
+func (a *AssetOutput) Address() Address {
+	return a.controllingAddress
+}
+
+// SetControllingAddress hands over the control of the asset to another Address (in an AssetOutput that is being
+// constructed).
+func (a *AssetOutput) SetControllingAddress(controllingAddress Address) {
+	a.controllingAddress = controllingAddress
+}
+
+// AssetColor returns the Color of the asset. The Color of the origin is derived from its OutputID.
+func (a *AssetOutput) AssetColor() Color {
+	if a.IsOrigin() {
+		return Color(blake2b.Sum256(a.ID().Bytes()))
+	}
+
+	return a.assetColor
+}
+
+// IsOrigin returns true if the AssetOutput is the first Output of the chain of the asset.
+func (a *AssetOutput) IsOrigin() bool {
+	return a.assetColor == ColorMint
+}
+
+// Name returns the name of the asset.
+func (a *AssetOutput) Name() string {
+	return a.name
+}
+
+// Symbol returns the symbol (ticker) of the asset.
+func (a *AssetOutput) Symbol() string {
+	return a.symbol
+}
+
+// Precision returns the amount of decimal places that are used to display the tokens of the asset.
+func (a *AssetOutput) Precision() uint8 {
+	return a.precision
+}
+
+// SupplyPolicy returns the SupplyPolicy of the asset.
+func (a *AssetOutput) SupplyPolicy() SupplyPolicy {
+	return a.supplyPolicy
+}
+
+// NewAssetOutputNext creates the successor of the AssetOutput that can be modified before it is added to a
+// Transaction.
+func (a *AssetOutput) NewAssetOutputNext() *AssetOutput {
+	return &AssetOutput{
+		assetColor:         a.AssetColor(),
+		balances:           a.balances.Clone(),
+		controllingAddress: a.controllingAddress.Clone(),
+		name:               a.name,
+		symbol:             a.symbol,
+		precision:          a.precision,
+		supplyPolicy:       a.supplyPolicy,
+	}
+}
+
+// UnlockValid determines if the given Transaction and the corresponding UnlockBlock are allowed to spend the Output.
+// The AssetOutput needs to be unlocked by its controller and has to be continued by a successor with the same
+// metadata.
+func (a *AssetOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBlock, inputs Outputs) (unlockValid bool, err error) {
+	next, err := a.successor(tx)
+	if err != nil {
+		err = xerrors.Errorf("failed to determine successor of asset with %s: %w", a.AssetColor(), err)
+		return
+	}
+	if next == nil {
+		err = xerrors.Errorf("asset with %s can not be destroyed: %w", a.AssetColor(), ErrInvalidAssetTransition)
+		return
+	}
+	if err = a.validateTransition(next); err != nil {
+		return
+	}
+
+	return addressUnlockValid(a.controllingAddress, tx, unlockBlock, inputs)
+}
+
+// Input returns an Input that references the Output.
+func (a *AssetOutput) Input() Input {
+	if a.ID() == EmptyOutputID {
+		panic("Outputs that haven't been assigned an ID, yet cannot be converted to an Input")
+	}
+
+	return NewUTXOInput(a.ID())
+}
+
+// Clone creates a copy of the Output.
+func (a *AssetOutput) Clone() Output {
+	return &AssetOutput{
+		id:                 a.ID(),
+		assetColor:         a.assetColor,
+		balances:           a.balances.Clone(),
+		controllingAddress: a.controllingAddress.Clone(),
+		name:               a.name,
+		symbol:             a.symbol,
+		precision:          a.precision,
+		supplyPolicy:       a.supplyPolicy,
+	}
+}
+
+// UpdateMintingColor replaces the ColorMint in the balances of the Output with the hash of the OutputID and fixes the
+// Color of the asset of an origin. It returns a copy of the original Output with the modified balances.
+func (a *AssetOutput) UpdateMintingColor() (updatedOutput *AssetOutput) {
+	mintedColor := Color(blake2b.Sum256(a.ID().Bytes()))
+	coloredBalances := a.Balances().Map()
+	if mintedCoins, mintedCoinsExist := coloredBalances[ColorMint]; mintedCoinsExist {
+		delete(coloredBalances, ColorMint)
+		coloredBalances[mintedColor] = mintedCoins
+	}
+	updatedOutput = a.Clone().(*AssetOutput)
+	updatedOutput.balances = NewColoredBalances(coloredBalances)
+	if a.IsOrigin() {
+		updatedOutput.assetColor = mintedColor
+	}
+
+	return
+}
+
+// Bytes returns a marshaled version of the Output.
+func (a *AssetOutput) Bytes() []byte {
+	return a.ObjectStorageValue()
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (a *AssetOutput) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (a *AssetOutput) ObjectStorageKey() []byte {
+	return a.id.Bytes()
+}
+
+// ObjectStorageValue marshals the Output into a sequence of bytes. The ID is not serialized here as it is only used as
+// a key in the ObjectStorage.
+func (a *AssetOutput) ObjectStorageValue() []byte {
+	return marshalutil.New().
+		WriteByte(byte(AssetOutputType)).
+		WriteBytes(a.assetColor.Bytes()).
+		WriteBytes(a.balances.Bytes()).
+		WriteBytes(a.controllingAddress.Bytes()).
+		WriteUint8(uint8(len(a.name))).
+		WriteBytes([]byte(a.name)).
+		WriteUint8(uint8(len(a.symbol))).
+		WriteBytes([]byte(a.symbol)).
+		WriteUint8(a.precision).
+		WriteBytes(a.supplyPolicy.Bytes()).
+		Bytes()
+}
+
+// Compare offers a comparator for Outputs which returns -1 if the other Output is bigger, 1 if it is smaller and 0 if
+// they are the same.
+func (a *AssetOutput) Compare(other Output) int {
+	return bytes.Compare(a.Bytes(), other.Bytes())
+}
+
+// String returns a human readable version of the Output.
+func (a *AssetOutput) String() string {
+	return stringify.Struct("AssetOutput",
+		stringify.StructField("id", a.ID()),
+		stringify.StructField("assetColor", a.AssetColor()),
+		stringify.StructField("balances", a.balances),
+		stringify.StructField("controllingAddress", a.controllingAddress),
+		stringify.StructField("name", a.name),
+		stringify.StructField("symbol", a.symbol),
+		stringify.StructField("precision", a.precision),
+		stringify.StructField("supplyPolicy", a.supplyPolicy),
+	)
+}
+
+// successor returns the AssetOutput of the given Transaction that continues the chain of the asset (or nil if the
+// Transaction does not continue the asset).
+func (a *AssetOutput) successor(tx *Transaction) (next *AssetOutput, err error) {
+	assetColor := a.AssetColor()
+	for _, output := range tx.Essence().Outputs() {
+		assetOutput, isAssetOutput := output.(*AssetOutput)
+		if !isAssetOutput || assetOutput.IsOrigin() || assetOutput.assetColor != assetColor {
+			continue
+		}
+
+		if next != nil {
+			err = xerrors.Errorf("transaction creates more than one successor of the asset: %w", ErrInvalidAssetTransition)
+			return
+		}
+		next = assetOutput
+	}
+
+	return
+}
+
+// validateTransition checks if the given successor keeps the metadata of the AssetOutput.
+func (a *AssetOutput) validateTransition(next *AssetOutput) (err error) {
+	switch {
+	case next.name != a.name:
+		err = xerrors.Errorf("name of an asset can not be changed: %w", ErrInvalidAssetTransition)
+	case next.symbol != a.symbol:
+		err = xerrors.Errorf("symbol of an asset can not be changed: %w", ErrInvalidAssetTransition)
+	case next.precision != a.precision:
+		err = xerrors.Errorf("precision of an asset can not be changed: %w", ErrInvalidAssetTransition)
+	case next.supplyPolicy != a.supplyPolicy:
+		err = xerrors.Errorf("supply policy of an asset can not be changed: %w", ErrInvalidAssetTransition)
+	}
+
+	return
+}
+
+// code contract (make sure the type implements all required methods)
+var _ Output = &AssetOutput{}
+
+// readAssetString is an internal utility function that reads a length prefixed string of an AssetOutput.
+func readAssetString(marshalUtil *marshalutil.MarshalUtil, maxLength int) (value string, err error) {
+	length, err := marshalUtil.ReadUint8()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse length (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if int(length) > maxLength {
+		err = xerrors.Errorf("length (%d) exceeds maximum length (%d): %w", length, maxLength, cerrors.ErrParseBytesFailed)
+		return
+	}
+	valueBytes, err := marshalUtil.ReadBytes(int(length))
+	if err != nil {
+		err = xerrors.Errorf("failed to parse value (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	value = string(valueBytes)
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AssetOutputMapping ///////////////////////////////////////////////////////////////////////////////////////////
+
+// AssetOutputMapping represents a mapping between the Color of an asset and the AssetOutputs that define it. It allows
+// to look up the metadata of an asset by its Color.
+type AssetOutputMapping struct {
+	color    Color
+	outputID OutputID
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewAssetOutputMapping returns a new AssetOutputMapping.
+func NewAssetOutputMapping(color Color, outputID OutputID) *AssetOutputMapping {
+	return &AssetOutputMapping{
+		color:    color,
+		outputID: outputID,
+	}
+}
+
+// AssetOutputMappingFromBytes unmarshals an AssetOutputMapping from a sequence of bytes.
+func AssetOutputMappingFromBytes(bytes []byte) (assetOutputMapping *AssetOutputMapping, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if assetOutputMapping, err = AssetOutputMappingFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse AssetOutputMapping from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AssetOutputMappingFromMarshalUtil unmarshals an AssetOutputMapping using a MarshalUtil (for easier unmarshaling).
+func AssetOutputMappingFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (assetOutputMapping *AssetOutputMapping, err error) {
+	assetOutputMapping = &AssetOutputMapping{}
+	if assetOutputMapping.color, err = ColorFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Color from MarshalUtil: %w", err)
+		return
+	}
+	if assetOutputMapping.outputID, err = OutputIDFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse OutputID from MarshalUtil: %w", err)
+		return
+	}
+
+	return
+}
+
+// AssetOutputMappingFromObjectStorage is a factory method that creates a new AssetOutputMapping instance from a
+// storage key of the object storage. It is used by the object storage, to create new instances of this entity.
+func AssetOutputMappingFromObjectStorage(key []byte, _ []byte) (result objectstorage.StorableObject, err error) {
+	if result, _, err = AssetOutputMappingFromBytes(key); err != nil {
+		err = xerrors.Errorf("failed to parse AssetOutputMapping from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// Color returns the Color of the asset.
+func (a *AssetOutputMapping) Color() Color {
+	return a.color
+}
+
+// OutputID returns the OutputID of the AssetOutput.
+func (a *AssetOutputMapping) OutputID() OutputID {
+	return a.outputID
+}
+
+// Bytes marshals the AssetOutputMapping into a sequence of bytes.
+func (a *AssetOutputMapping) Bytes() []byte {
+	return a.ObjectStorageKey()
+}
+
+// String returns a human readable version of the AssetOutputMapping.
+func (a *AssetOutputMapping) String() string {
+	return stringify.Struct("AssetOutputMapping",
+		stringify.StructField("color", a.color),
+		stringify.StructField("outputID", a.outputID),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (a *AssetOutputMapping) Update(other objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (a *AssetOutputMapping) ObjectStorageKey() []byte {
+	return byteutils.ConcatBytes(a.color.Bytes(), a.outputID.Bytes())
+}
+
+// ObjectStorageValue marshals the AssetOutputMapping into a sequence of bytes that are used as the value part in the
+// object storage.
+func (a *AssetOutputMapping) ObjectStorageValue() (value []byte) {
+	return
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ objectstorage.StorableObject = &AssetOutputMapping{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedAssetOutputMapping /////////////////////////////////////////////////////////////////////////////////////
+
+// CachedAssetOutputMapping is a wrapper for the generic CachedObject returned by the object storage that overrides the
+// accessor methods with a type-casted one.
+type CachedAssetOutputMapping struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks the CachedObject to still be in use by the program.
+func (c *CachedAssetOutputMapping) Retain() *CachedAssetOutputMapping {
+	return &CachedAssetOutputMapping{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedAssetOutputMapping) Unwrap() *AssetOutputMapping {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*AssetOutputMapping)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (c *CachedAssetOutputMapping) Consume(consumer func(assetOutputMapping *AssetOutputMapping), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*AssetOutputMapping))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedAssetOutputMapping.
+func (c *CachedAssetOutputMapping) String() string {
+	return stringify.Struct("CachedAssetOutputMapping",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedAssetOutputMappings ////////////////////////////////////////////////////////////////////////////////////
+
+// CachedAssetOutputMappings represents a collection of CachedAssetOutputMapping objects.
+type CachedAssetOutputMappings []*CachedAssetOutputMapping
+
+// Unwrap is the type-casted equivalent of Get. It returns a slice of unwrapped objects with the object being nil if it
+// does not exist.
+func (c CachedAssetOutputMappings) Unwrap() (unwrappedMappings []*AssetOutputMapping) {
+	unwrappedMappings = make([]*AssetOutputMapping, len(c))
+	for i, cachedAssetOutputMapping := range c {
+		unwrappedMappings[i] = cachedAssetOutputMapping.Unwrap()
+	}
+
+	return
+}
+
+// Consume iterates over the CachedObjects, unwraps them and passes a type-casted version to the consumer (if the object
+// is not empty - it exists). It automatically releases the object when the consumer finishes. It returns true, if at
+// least one object was consumed.
+func (c CachedAssetOutputMappings) Consume(consumer func(assetOutputMapping *AssetOutputMapping), forceRelease ...bool) (consumed bool) {
+	for _, cachedAssetOutputMapping := range c {
+		consumed = cachedAssetOutputMapping.Consume(consumer, forceRelease...) || consumed
+	}
+
+	return
+}
+
+// Release is a utility function that allows us to release all CachedObjects in the collection.
+func (c CachedAssetOutputMappings) Release(force ...bool) {
+	for _, cachedAssetOutputMapping := range c {
+		cachedAssetOutputMapping.Release(force...)
+	}
+}
+
+// String returns a human readable version of the CachedAssetOutputMappings.
+func (c CachedAssetOutputMappings) String() string {
+	structBuilder := stringify.StructBuilder("CachedAssetOutputMappings")
+	for i, cachedAssetOutputMapping := range c {
+		structBuilder.AddField(stringify.StructField(strconv.Itoa(i), cachedAssetOutputMapping))
+	}
+
+	return structBuilder.String()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region UTXODAG API //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// AssetOutputMapping retrieves the mappings of the AssetOutputs that define the asset with the given Color.
+func (u *UTXODAG) AssetOutputMapping(color Color) (cachedAssetOutputMappings CachedAssetOutputMappings) {
+	u.assetOutputMappingStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		cachedAssetOutputMappings = append(cachedAssetOutputMappings, &CachedAssetOutputMapping{cachedObject})
+		return true
+	}, color.Bytes())
+
+	return
+}
+
+// AssetOutput retrieves the current (unspent) AssetOutput of the asset with the given Color. Since the metadata of an
+// asset can never change, any of the AssetOutputs of the chain is returned if there is no unspent one in the local
+// perception of the ledger (i.e. if the asset is currently being transitioned by conflicting Transactions).
+func (u *UTXODAG) AssetOutput(color Color) (cachedOutput *CachedOutput, err error) {
+	var currentOutputID OutputID
+	found := false
+	unspent := false
+	u.AssetOutputMapping(color).Consume(func(assetOutputMapping *AssetOutputMapping) {
+		if unspent {
+			return
+		}
+
+		u.OutputMetadata(assetOutputMapping.OutputID()).Consume(func(outputMetadata *OutputMetadata) {
+			if outputMetadata.BranchID() == InvalidBranchID {
+				return
+			}
+
+			currentOutputID = assetOutputMapping.OutputID()
+			found = true
+			unspent = outputMetadata.ConsumerCount() == 0
+		})
+	})
+
+	if !found {
+		err = xerrors.Errorf("failed to find AssetOutput of %s: %w", color, ErrAssetOutputNotFound)
+		return
+	}
+
+	return u.Output(currentOutputID), nil
+}
+
+// storeAssetOutputMapping is an internal utility function that stores the mapping between the Color of the asset and
+// the given AssetOutput.
+func (u *UTXODAG) storeAssetOutputMapping(assetOutput *AssetOutput) {
+	if cachedAssetOutputMapping, stored := u.assetOutputMappingStorage.StoreIfAbsent(NewAssetOutputMapping(assetOutput.AssetColor(), assetOutput.ID())); stored {
+		cachedAssetOutputMapping.Release()
+	}
+}
+
+// assetDefinition is an internal utility function that returns the SupplyPolicy of the asset with the given Color. It
+// first checks the given Outputs and falls back to the stored AssetOutputs of the asset.
+func (u *UTXODAG) assetDefinition(color Color, outputs Outputs) (supplyPolicy SupplyPolicy, defined bool) {
+	for _, output := range outputs {
+		if assetOutput, isAssetOutput := output.(*AssetOutput); isAssetOutput && !assetOutput.IsOrigin() && assetOutput.AssetColor() == color {
+			return assetOutput.SupplyPolicy(), true
+		}
+	}
+
+	u.AssetOutputMapping(color).Consume(func(assetOutputMapping *AssetOutputMapping) {
+		if defined {
+			return
+		}
+
+		u.Output(assetOutputMapping.OutputID()).Consume(func(output Output) {
+			if assetOutput, isAssetOutput := output.(*AssetOutput); isAssetOutput {
+				supplyPolicy = assetOutput.SupplyPolicy()
+				defined = true
+			}
+		})
+	})
+
+	return
+}
+
+// assetChainsValid is an internal utility function that checks if the AssetOutputs of a Transaction form valid chains:
+// every asset is consumed at most once, every consumed asset is continued by a successor (an asset can not be
+// destroyed, so its definition and the accounting of its re-minted supply never end) and there is no successor without
+// the consumed predecessor. That there is exactly one successor is checked when the AssetOutput is unlocked.
+func (u *UTXODAG) assetChainsValid(inputs Outputs, outputs Outputs) (err error) {
+	consumedAssets := make(map[Color]int)
+	for i, input := range inputs {
+		if assetInput, isAssetOutput := input.(*AssetOutput); isAssetOutput {
+			assetColor := assetInput.AssetColor()
+			if _, exists := consumedAssets[assetColor]; exists {
+				return newInputValidationError(ErrInvalidAssetTransition, i)
+			}
+			consumedAssets[assetColor] = i
+		}
+	}
+
+	continuedAssets := continuedAssetColors(outputs)
+	for i, output := range outputs {
+		if assetOutput, isAssetOutput := output.(*AssetOutput); isAssetOutput && !assetOutput.IsOrigin() {
+			if _, exists := consumedAssets[assetOutput.AssetColor()]; !exists {
+				return newOutputValidationError(ErrInvalidAssetTransition, i)
+			}
+		}
+	}
+
+	for assetColor, inputIndex := range consumedAssets {
+		if _, continued := continuedAssets[assetColor]; !continued {
+			return newInputValidationError(ErrInvalidAssetTransition, inputIndex)
+		}
+	}
+
+	return nil
+}
+
+// assetBurnsValid is an internal utility function that checks if the tokens of assets that are destroyed by a
+// Transaction (converted back to IOTA) are allowed to be burned by the SupplyPolicy of their asset. Colors that are not
+// defined by an AssetOutput can always be burned.
+func (u *UTXODAG) assetBurnsValid(inputs Outputs, outputs Outputs) (err error) {
+	consumedCoins := make(map[Color]uint64)
+	for _, input := range inputs {
+		input.Balances().ForEach(func(color Color, balance uint64) bool {
+			if color != ColorIOTA {
+				consumedCoins[color] += balance
+			}
+			return true
+		})
+	}
+	spentCoins := make(map[Color]uint64)
+	for _, output := range outputs {
+		output.Balances().ForEach(func(color Color, balance uint64) bool {
+			spentCoins[color] += balance
+			return true
+		})
+	}
+
+	for color, consumedBalance := range consumedCoins {
+		if spentBalance := spentCoins[color]; spentBalance < consumedBalance {
+			if supplyPolicy, defined := u.assetDefinition(color, inputs); defined && !supplyPolicy.Burnable() {
+				return newAssetBurnNotAllowedError(color, consumedBalance, spentBalance)
+			}
+		}
+	}
+
+	return nil
+}
+
+// mintableAssetColors is an internal utility function that returns the Colors of the assets whose controller allows
+// the given Transaction to mint additional tokens (by consuming an AssetOutput with a mintable SupplyPolicy and
+// continuing it with a successor that accounts for the re-minted supply).
+func mintableAssetColors(inputs Outputs, outputs Outputs) (mintableColors map[Color]types.Empty) {
+	continuedAssets := continuedAssetColors(outputs)
+
+	mintableColors = make(map[Color]types.Empty)
+	for _, input := range inputs {
+		if assetInput, isAssetOutput := input.(*AssetOutput); isAssetOutput && assetInput.SupplyPolicy().Mintable() {
+			if _, continued := continuedAssets[assetInput.AssetColor()]; continued {
+				mintableColors[assetInput.AssetColor()] = types.Void
+			}
+		}
+	}
+
+	return
+}
+
+// continuedAssetColors is an internal utility function that returns the Colors of the assets that are continued by a
+// successor AssetOutput in the given Outputs.
+func continuedAssetColors(outputs Outputs) (continuedColors map[Color]types.Empty) {
+	continuedColors = make(map[Color]types.Empty)
+	for _, output := range outputs {
+		if assetOutput, isAssetOutput := output.(*AssetOutput); isAssetOutput && !assetOutput.IsOrigin() {
+			continuedColors[assetOutput.AssetColor()] = types.Void
+		}
+	}
+
+	return
+}
+
+// remintedSupply is an internal utility function that returns the amount of tokens that were minted by the given
+// Transaction for the asset that is continued by the given AssetOutput.
+func (u *UTXODAG) remintedSupply(assetOutput *AssetOutput) (mintedBalance uint64) {
+	assetColor := assetOutput.AssetColor()
+	u.Transaction(assetOutput.ID().TransactionID()).Consume(func(transaction *Transaction) {
+		spentBalance := uint64(0)
+		for _, output := range transaction.Essence().Outputs() {
+			balance, _ := output.Balances().Get(assetColor)
+			spentBalance += balance
+		}
+
+		consumedBalance := uint64(0)
+		cachedConsumedOutputs := u.consumedOutputs(transaction)
+		defer cachedConsumedOutputs.Release()
+		for _, input := range cachedConsumedOutputs.Unwrap() {
+			if input != nil {
+				balance, _ := input.Balances().Get(assetColor)
+				consumedBalance += balance
+			}
+		}
+
+		if spentBalance > consumedBalance {
+			mintedBalance = spentBalance - consumedBalance
+		}
+	})
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
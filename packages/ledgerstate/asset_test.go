@@ -0,0 +1,196 @@
+package ledgerstate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/xerrors"
+)
+
+func TestAssetOutput_Marshaling(t *testing.T) {
+	controllingAddress := NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	origin := NewAssetOutput(NewColoredBalances(map[Color]uint64{ColorMint: 100}), controllingAddress, "Shimmer Token", "SMR", 6, MintableSupply|BurnableSupply)
+	origin.SetID(NewOutputID(GenesisTransactionID, 1))
+	assert.True(t, origin.IsOrigin())
+	assert.Equal(t, Color(blake2b.Sum256(origin.ID().Bytes())), origin.AssetColor())
+
+	restoredOrigin, _, err := OutputFromBytes(origin.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, origin.Bytes(), restoredOrigin.Bytes())
+	assert.True(t, restoredOrigin.(*AssetOutput).IsOrigin())
+
+	// the minted tokens of the origin carry the Color of the asset
+	bookedOrigin := origin.UpdateMintingColor()
+	assert.False(t, bookedOrigin.IsOrigin())
+	assert.Equal(t, origin.AssetColor(), bookedOrigin.AssetColor())
+	mintedBalance, minted := bookedOrigin.Balances().Get(origin.AssetColor())
+	assert.True(t, minted)
+	assert.Equal(t, uint64(100), mintedBalance)
+
+	next := bookedOrigin.NewAssetOutputNext()
+	restoredNext, _, err := AssetOutputFromBytes(next.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, next.Bytes(), restoredNext.Bytes())
+	assert.Equal(t, origin.AssetColor(), restoredNext.AssetColor())
+	assert.Equal(t, "Shimmer Token", restoredNext.Name())
+	assert.Equal(t, "SMR", restoredNext.Symbol())
+	assert.Equal(t, uint8(6), restoredNext.Precision())
+	assert.True(t, restoredNext.SupplyPolicy().Mintable())
+	assert.True(t, restoredNext.SupplyPolicy().Burnable())
+	assert.Equal(t, controllingAddress.Bytes(), restoredNext.Address().Bytes())
+
+	// the length of the name is limited
+	invalidOutput := NewAssetOutput(NewColoredBalances(map[Color]uint64{ColorMint: 100}), controllingAddress, strings.Repeat("a", MaxAssetNameLength+1), "SMR", 6, FixedSupply)
+	_, _, err = AssetOutputFromBytes(invalidOutput.Bytes())
+	assert.Error(t, err)
+
+	// unknown SupplyPolicy flags are rejected
+	invalidOutput = NewAssetOutput(NewColoredBalances(map[Color]uint64{ColorMint: 100}), controllingAddress, "Shimmer Token", "SMR", 6, SupplyPolicy(1<<7))
+	_, _, err = AssetOutputFromBytes(invalidOutput.Bytes())
+	assert.Error(t, err)
+}
+
+func TestAssetOutputChain(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(3)
+	controller, holder, other := wallets[0], wallets[1], wallets[2]
+	signedBy := func(w wallet) func(*TransactionEssence, Output) UnlockBlock {
+		return func(txEssence *TransactionEssence, _ Output) UnlockBlock {
+			return NewSignatureUnlockBlock(w.sign(txEssence))
+		}
+	}
+	bookTransaction := func(tx *Transaction) {
+		_, err := utxoDAG.CheckTransaction(tx)
+		require.NoError(t, err)
+		_, err = utxoDAG.BookTransaction(tx)
+		require.NoError(t, err)
+		utxoDAG.TransactionMetadata(tx.ID()).Consume(func(transactionMetadata *TransactionMetadata) {
+			transactionMetadata.SetFinalized(true)
+		})
+	}
+	bookedOutput := func(tx *Transaction, outputType OutputType) (output Output) {
+		for _, txOutput := range tx.Essence().Outputs() {
+			if txOutput.Type() == outputType {
+				require.True(t, utxoDAG.Output(txOutput.ID()).Consume(func(storedOutput Output) {
+					output = storedOutput
+				}))
+			}
+		}
+		return
+	}
+	genesisOutputs := make([]Output, 4)
+	for i := range genesisOutputs {
+		genesisOutputs[i] = NewSigLockedSingleOutput(100, controller.address)
+		genesisOutputs[i].SetID(NewOutputID(GenesisTransactionID, uint16(i)))
+		genesisOutputMetadata := NewOutputMetadata(genesisOutputs[i].ID())
+		genesisOutputMetadata.SetBranchID(MasterBranchID)
+		genesisOutputMetadata.SetSolid(true)
+		genesisOutputMetadata.SetFinalized(true)
+		require.NoError(t, utxoDAG.LoadSnapshotOutput(genesisOutputs[i], genesisOutputMetadata))
+	}
+
+	// create an asset with a fixed supply
+	fixedOriginTx := aliasTransaction([]Output{genesisOutputs[0]}, []Output{
+		NewAssetOutput(NewColoredBalances(map[Color]uint64{ColorMint: 100}), controller.address, "Fixed", "FIX", 0, FixedSupply),
+	}, signedBy(controller))
+	bookTransaction(fixedOriginTx)
+	fixedOrigin := bookedOutput(fixedOriginTx, AssetOutputType).(*AssetOutput)
+	fixedColor := fixedOrigin.AssetColor()
+	assertAssetOutput(t, utxoDAG, fixedColor, fixedOrigin.ID())
+	_, err := utxoDAG.AssetOutput(Color{1})
+	assert.True(t, xerrors.Is(err, ErrAssetOutputNotFound))
+
+	// the tokens of an asset are distributed by continuing the AssetOutput
+	transferNext := fixedOrigin.NewAssetOutputNext()
+	transferNext.SetBalances(NewColoredBalances(map[Color]uint64{fixedColor: 10}))
+	transferOutputs := []Output{transferNext, NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{fixedColor: 90}), holder.address)}
+	_, err = utxoDAG.CheckTransaction(aliasTransaction([]Output{fixedOrigin}, transferOutputs, signedBy(other)))
+	assert.True(t, xerrors.Is(err, ErrTransactionInvalid))
+	transferTx := aliasTransaction([]Output{fixedOrigin}, transferOutputs, signedBy(controller))
+
+	// an AssetOutput can not be destroyed
+	_, err = utxoDAG.CheckTransaction(aliasTransaction([]Output{fixedOrigin}, []Output{
+		NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{fixedColor: 100}), holder.address),
+	}, signedBy(controller)))
+	assert.True(t, xerrors.Is(err, ErrInvalidAssetTransition))
+
+	// the metadata of an asset can not be changed
+	renamedNext := fixedOrigin.NewAssetOutputNext()
+	renamedNext.name = "Renamed"
+	_, err = utxoDAG.CheckTransaction(aliasTransaction([]Output{fixedOrigin}, []Output{renamedNext}, signedBy(controller)))
+	assert.True(t, xerrors.Is(err, ErrTransactionInvalid))
+
+	// a successor can not be created without consuming its predecessor
+	fakeNext := fixedOrigin.NewAssetOutputNext()
+	fakeNext.SetBalances(NewColoredBalances(map[Color]uint64{ColorIOTA: 100}))
+	_, err = utxoDAG.CheckTransaction(aliasTransaction([]Output{genesisOutputs[1]}, []Output{fakeNext}, signedBy(controller)))
+	assert.True(t, xerrors.Is(err, ErrInvalidAssetTransition))
+
+	// a fixed supply can not be re-minted
+	remintNext := fixedOrigin.NewAssetOutputNext()
+	_, err = utxoDAG.CheckTransaction(aliasTransaction([]Output{fixedOrigin, genesisOutputs[1]}, []Output{
+		remintNext,
+		NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{fixedColor: 100}), holder.address),
+	}, signedBy(controller)))
+	assert.True(t, xerrors.Is(err, ErrBalanceMismatch))
+
+	bookTransaction(transferTx)
+	assertAssetOutput(t, utxoDAG, fixedColor, bookedOutput(transferTx, AssetOutputType).ID())
+
+	// the tokens of a fixed supply can not be burned
+	holderOutput := bookedOutput(transferTx, SigLockedColoredOutputType)
+	_, err = utxoDAG.CheckTransaction(aliasTransaction([]Output{holderOutput}, []Output{NewSigLockedSingleOutput(90, holder.address)}, signedBy(holder)))
+	assert.True(t, xerrors.Is(err, ErrAssetBurnNotAllowed))
+
+	// create an asset whose supply can be changed
+	mintableOriginTx := aliasTransaction([]Output{genesisOutputs[2]}, []Output{
+		NewAssetOutput(NewColoredBalances(map[Color]uint64{ColorMint: 100}), controller.address, "Mintable", "MNT", 2, MintableSupply|BurnableSupply),
+	}, signedBy(controller))
+	bookTransaction(mintableOriginTx)
+	mintableOrigin := bookedOutput(mintableOriginTx, AssetOutputType).(*AssetOutput)
+	mintableColor := mintableOrigin.AssetColor()
+
+	// the tokens of a burnable asset can be burned
+	burnNext := mintableOrigin.NewAssetOutputNext()
+	burnNext.SetBalances(NewColoredBalances(map[Color]uint64{ColorIOTA: 50, mintableColor: 50}))
+	_, err = utxoDAG.CheckTransaction(aliasTransaction([]Output{mintableOrigin}, []Output{burnNext}, signedBy(controller)))
+	require.NoError(t, err)
+
+	// re-minting requires a successor that keeps track of the re-minted supply
+	_, err = utxoDAG.CheckTransaction(aliasTransaction([]Output{mintableOrigin, genesisOutputs[3]}, []Output{
+		NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{mintableColor: 200}), holder.address),
+	}, signedBy(controller)))
+	assert.True(t, xerrors.Is(err, ErrBalanceMismatch))
+	assert.Error(t, utxoDAG.assetChainsValid(Outputs{mintableOrigin, genesisOutputs[3]}, Outputs{
+		NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{mintableColor: 200}), holder.address),
+	}))
+
+	// the controller of a mintable asset can mint additional tokens from IOTA
+	remintNext = mintableOrigin.NewAssetOutputNext()
+	remintTx := aliasTransaction([]Output{mintableOrigin, genesisOutputs[3]}, []Output{
+		remintNext,
+		NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{mintableColor: 100}), holder.address),
+	}, signedBy(controller))
+	bookTransaction(remintTx)
+	assertAssetOutput(t, utxoDAG, mintableColor, bookedOutput(remintTx, AssetOutputType).ID())
+
+	// re-minted tokens are accounted for by the supply audit
+	supplyAudit := utxoDAG.AuditSupply()
+	assert.Equal(t, uint64(200), supplyAudit.Supply[mintableColor])
+	assert.Equal(t, uint64(200), supplyAudit.MintedSupply[mintableColor])
+	assert.False(t, supplyAudit.Divergent())
+}
+
+func assertAssetOutput(t *testing.T, utxoDAG *UTXODAG, color Color, expectedOutputID OutputID) {
+	cachedOutput, err := utxoDAG.AssetOutput(color)
+	require.NoError(t, err)
+	assert.True(t, cachedOutput.Consume(func(output Output) {
+		assert.Equal(t, expectedOutputID, output.ID())
+	}))
+}
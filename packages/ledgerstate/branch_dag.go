@@ -167,6 +167,7 @@ func (b *BranchDAG) MergeToMaster(branchID BranchID) (movedBranches map[BranchID
 
 	// remove merged Branch
 	conflictBranch.Delete()
+	b.childBranchStorage.Delete(byteutils.ConcatBytes(MasterBranchID.Bytes(), branchID.Bytes()))
 	movedBranches[conflictBranch.ID()] = MasterBranchID
 
 	// load ChildBranch references
@@ -253,6 +254,82 @@ func (b *BranchDAG) MergeToMaster(branchID BranchID) (movedBranches map[BranchID
 	return
 }
 
+// RemovableBranches returns the Branches that get removed together with the given rejected ConflictBranch (the
+// ConflictBranch itself and its AggregatedBranches) mapped to the InvalidBranch. It does not modify the BranchDAG, so
+// the caller can move all remaining references to these Branches before removing them with RemoveRejectedBranch.
+func (b *BranchDAG) RemovableBranches(branchID BranchID) (movedBranches map[BranchID]BranchID, err error) {
+	movedBranches = make(map[BranchID]BranchID)
+
+	// load Branch
+	cachedBranch := b.Branch(branchID)
+	defer cachedBranch.Release()
+
+	// unwrap ConflictBranch
+	conflictBranch, err := cachedBranch.UnwrapConflictBranch()
+	if err != nil {
+		err = xerrors.Errorf("tried to remove non-ConflictBranch with %s: %w", branchID, err)
+		return
+	} else if conflictBranch == nil {
+		err = xerrors.Errorf("failed to load Branch with %s: %w", branchID, cerrors.ErrFatal)
+		return
+	}
+
+	// abort if the Branch is not Rejected or if it is one of the static Branches
+	if conflictBranch.InclusionState() != Rejected || branchID == InvalidBranchID || branchID == LazyBookedConflictsBranchID {
+		err = xerrors.Errorf("tried to remove non-rejected Branch with %s: %w", branchID, cerrors.ErrFatal)
+		return
+	}
+	movedBranches[branchID] = InvalidBranchID
+
+	// collect AggregatedBranches
+	b.ChildBranches(branchID).Consume(func(childBranch *ChildBranch) {
+		if childBranch.ChildBranchType() == AggregatedBranchType {
+			movedBranches[childBranch.ChildBranchID()] = InvalidBranchID
+		}
+	})
+
+	return
+}
+
+// RemoveRejectedBranch removes a rejected ConflictBranch and its AggregatedBranches from the BranchDAG. The returned map
+// contains the removed Branches (see RemovableBranches). Since the Branches are deleted right away, the caller has to
+// move the Transactions, Outputs and Messages that reference them to the InvalidBranch before. ConflictBranches in the
+// future cone are rejected as well and need to be removed separately.
+func (b *BranchDAG) RemoveRejectedBranch(branchID BranchID) (movedBranches map[BranchID]BranchID, err error) {
+	if movedBranches, err = b.RemovableBranches(branchID); err != nil {
+		return
+	}
+
+	// remove rejected Branch
+	b.Branch(branchID).Consume(func(branch Branch) {
+		branch.Delete()
+		for parentBranchID := range branch.Parents() {
+			b.childBranchStorage.Delete(byteutils.ConcatBytes(parentBranchID.Bytes(), branchID.Bytes()))
+		}
+
+		// update ConflictMembers to not contain the removed Branch
+		for conflictID := range branch.(*ConflictBranch).Conflicts() {
+			b.unregisterConflictMember(conflictID, branchID)
+		}
+	})
+
+	// remove AggregatedBranches and the ChildBranch references
+	b.ChildBranches(branchID).Consume(func(childBranchReference *ChildBranch) {
+		if childBranchReference.ChildBranchType() == AggregatedBranchType {
+			b.Branch(childBranchReference.ChildBranchID()).Consume(func(childBranch Branch) {
+				for parentBranchID := range childBranch.Parents() {
+					b.childBranchStorage.Delete(byteutils.ConcatBytes(parentBranchID.Bytes(), childBranch.ID().Bytes()))
+				}
+				childBranch.Delete()
+			})
+		}
+
+		childBranchReference.Delete()
+	})
+
+	return
+}
+
 // Branch retrieves the Branch with the given BranchID from the object storage.
 func (b *BranchDAG) Branch(branchID BranchID) (cachedBranch *CachedBranch) {
 	return &CachedBranch{CachedObject: b.branchStorage.Load(branchID.Bytes())}
@@ -554,6 +631,26 @@ func (b *BranchDAG) createConflictBranchFromNormalizedParentBranchIDs(branchID B
 	return
 }
 
+// addConflicts is an internal utility function that registers an existing ConflictBranch as a member of the given
+// Conflicts (i.e. when one of its other Inputs gets double spent). It returns true if at least one Conflict was added.
+func (b *BranchDAG) addConflicts(branchID BranchID, conflictIDs ConflictIDs) (modified bool) {
+	b.Branch(branchID).Consume(func(branch Branch) {
+		conflictBranch, isConflictBranch := branch.(*ConflictBranch)
+		if !isConflictBranch {
+			return
+		}
+
+		for conflictID := range conflictIDs {
+			if conflictBranch.AddConflict(conflictID) {
+				b.registerConflictMember(conflictID, branchID)
+				modified = true
+			}
+		}
+	})
+
+	return
+}
+
 // aggregateNormalizedBranches is an internal utility function that retrieves the AggregatedBranch that corresponds to
 // the given normalized BranchIDs. It automatically creates the AggregatedBranch if it didn't exist, yet.
 func (b *BranchDAG) aggregateNormalizedBranches(normalizedBranchIDs BranchIDs) (cachedAggregatedBranch *CachedBranch, newBranchCreated bool, err error) {
@@ -625,6 +722,11 @@ func (b *BranchDAG) unregisterConflictMember(conflictID ConflictID, branchID Bra
 		if b.conflictMemberStorage.DeleteIfPresent(NewConflictMember(conflictID, branchID).ObjectStorageKey()) {
 			conflict.DecreaseMemberCount()
 		}
+
+		// remove Conflicts that were fully resolved
+		if conflict.MemberCount() == 0 {
+			conflict.Delete()
+		}
 	})
 }
 
@@ -0,0 +1,157 @@
+package ledgerstate
+
+import (
+	"golang.org/x/xerrors"
+)
+
+// region DustProtectionParams /////////////////////////////////////////////////////////////////////////////////////////
+
+// DefaultDustProtectionParams contains the recommended DustProtectionParams of a network. The limitation of dust Outputs
+// per Address is disabled by default.
+var DefaultDustProtectionParams = DustProtectionParams{
+	MinimumOutputAmount:      1,
+	DustThreshold:            0,
+	MaxDustOutputsPerAddress: 100,
+	DustAllowance:            1000000,
+}
+
+// DustProtectionParams contains the rules that prevent the ledger state from being bloated by a large amount of Outputs
+// with tiny balances. The zero value disables the dust protection.
+//
+// Only the MinimumOutputAmount is a rule of the ledger, as it depends on nothing but the Transaction. The limitation of
+// dust Outputs per Address depends on the local view of the node on the Address (which Outputs are unspent, pending or
+// conflicting) and is therefore only applied as a local policy to the Transactions issued by the node (see
+// UTXODAG.CheckDustAllowance).
+type DustProtectionParams struct {
+	// MinimumOutputAmount defines the minimum amount of tokens that every created Output has to hold.
+	MinimumOutputAmount uint64
+
+	// DustThreshold defines the amount of tokens below which an Output is considered to be a dust Output (0 disables
+	// the limitation of dust Outputs).
+	DustThreshold uint64
+
+	// MaxDustOutputsPerAddress defines the maximum amount of unspent dust Outputs that an Address without a dust
+	// allowance can hold.
+	MaxDustOutputsPerAddress int
+
+	// DustAllowance defines the amount of tokens that an Address has to hold in non-dust Outputs to be allowed to hold
+	// an unlimited amount of dust Outputs.
+	DustAllowance uint64
+}
+
+// IsDust returns true if an Output with the given amount of tokens is considered to be a dust Output.
+func (d DustProtectionParams) IsDust(amount uint64) bool {
+	return amount < d.DustThreshold
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region UTXODAG dust protection //////////////////////////////////////////////////////////////////////////////////////
+
+// DustProtection is an UTXODAGOption that enables the dust protection with the given parameters.
+func DustProtection(params DustProtectionParams) UTXODAGOption {
+	return func(utxoDAG *UTXODAG) {
+		utxoDAG.dustProtectionParams = params
+	}
+}
+
+// minimumOutputAmountsValid is an internal utility function that checks if the Outputs created by a Transaction hold
+// at least the MinimumOutputAmount of the DustProtectionParams of the UTXODAG.
+func (u *UTXODAG) minimumOutputAmountsValid(outputs Outputs) (err error) {
+	for i, output := range outputs {
+		if OutputAmount(output) < u.dustProtectionParams.MinimumOutputAmount {
+			return newOutputValidationError(ErrOutputBelowMinimumAmount, i)
+		}
+	}
+
+	return nil
+}
+
+// CheckDustAllowance checks if the dust Outputs created by the given Transaction exceed the limit of dust Outputs on
+// their Addresses. The result depends on the local view of the node on the Addresses, so it must not be used to decide
+// on the validity of a Transaction but only as a policy for the Transactions that are issued by the node itself.
+func (u *UTXODAG) CheckDustAllowance(transaction *Transaction) (err error) {
+	if u.dustProtectionParams.DustThreshold == 0 {
+		return nil
+	}
+
+	cachedConsumedOutputs := u.consumedOutputs(transaction)
+	defer cachedConsumedOutputs.Release()
+	consumedOutputs := cachedConsumedOutputs.Unwrap()
+	if err = u.allOutputsExist(consumedOutputs); err != nil {
+		return xerrors.Errorf("not all consumedOutputs of transaction are solid: %w", err)
+	}
+
+	outputs := transaction.Essence().Outputs()
+	newDustOutputs := make(map[[AddressLength]byte]int)
+	for _, output := range outputs {
+		if u.dustProtectionParams.IsDust(OutputAmount(output)) {
+			newDustOutputs[output.Address().Array()]++
+		}
+	}
+	if len(newDustOutputs) == 0 {
+		return nil
+	}
+
+	spentOutputs := Outputs(consumedOutputs).ByID()
+	for i, output := range outputs {
+		addressArray := output.Address().Array()
+		newDustOutputsOnAddress, dustOutputCreated := newDustOutputs[addressArray]
+		if !dustOutputCreated {
+			continue
+		}
+		delete(newDustOutputs, addressArray)
+
+		dustOutputs, allowance := u.dustOnAddress(output.Address(), spentOutputs)
+		for _, createdOutput := range outputs {
+			if amount := OutputAmount(createdOutput); createdOutput.Address().Array() == addressArray && !u.dustProtectionParams.IsDust(amount) {
+				allowance += amount
+			}
+		}
+
+		if dustOutputs += newDustOutputsOnAddress; dustOutputs > u.dustProtectionParams.MaxDustOutputsPerAddress && allowance < u.dustProtectionParams.DustAllowance {
+			return newDustAllowanceExceededError(output.Address(), dustOutputs, i)
+		}
+	}
+
+	return nil
+}
+
+// dustOnAddress is an internal utility function that returns the amount of unspent dust Outputs on the given Address
+// and the sum of the tokens held in its unspent non-dust Outputs (the dust allowance). Outputs that are about to be
+// spent are ignored.
+func (u *UTXODAG) dustOnAddress(address Address, spentOutputs OutputsByID) (dustOutputs int, allowance uint64) {
+	u.AddressOutputMapping(address).Consume(func(addressOutputMapping *AddressOutputMapping) {
+		if _, spent := spentOutputs[addressOutputMapping.OutputID()]; spent {
+			return
+		}
+
+		u.OutputMetadata(addressOutputMapping.OutputID()).Consume(func(outputMetadata *OutputMetadata) {
+			if outputMetadata.ConsumerCount() != 0 || outputMetadata.BranchID() == InvalidBranchID {
+				return
+			}
+
+			u.Output(addressOutputMapping.OutputID()).Consume(func(output Output) {
+				if amount := OutputAmount(output); u.dustProtectionParams.IsDust(amount) {
+					dustOutputs++
+				} else {
+					allowance += amount
+				}
+			})
+		})
+	})
+
+	return
+}
+
+// OutputAmount returns the total amount of tokens (of all Colors) that are held by the given Output.
+func OutputAmount(output Output) (amount uint64) {
+	output.Balances().ForEach(func(_ Color, balance uint64) bool {
+		amount += balance
+		return true
+	})
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,113 @@
+package ledgerstate
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+)
+
+func TestUTXODAG_DustProtection(t *testing.T) {
+	store := mapdb.NewMapDB()
+	branchDAG := NewBranchDAG(store)
+	require.NoError(t, branchDAG.Prune())
+	defer branchDAG.Shutdown()
+	utxoDAG := NewUTXODAG(store, branchDAG, DustProtection(DustProtectionParams{
+		MinimumOutputAmount:      10,
+		DustThreshold:            100,
+		MaxDustOutputsPerAddress: 2,
+		DustAllowance:            800,
+	}))
+	defer utxoDAG.Shutdown()
+
+	wallets := createWallets(2)
+	utxoDAG.LoadSnapshot(map[TransactionID]map[Address]*ColoredBalances{
+		GenesisTransactionID: {
+			wallets[0].address: NewColoredBalances(map[Color]uint64{ColorIOTA: 1000}),
+		},
+	})
+	var genesisOutput Output
+	utxoDAG.Output(NewOutputID(GenesisTransactionID, 0)).Consume(func(output Output) {
+		genesisOutput = output
+	})
+	signedBy := func(w wallet) func(txEssence *TransactionEssence, _ Output) UnlockBlock {
+		return func(txEssence *TransactionEssence, _ Output) UnlockBlock {
+			return NewSignatureUnlockBlock(w.sign(txEssence))
+		}
+	}
+	checkTransaction := func(outputs ...Output) (err error) {
+		_, err = utxoDAG.CheckTransaction(aliasTransaction([]Output{genesisOutput}, outputs, signedBy(wallets[0])))
+		return
+	}
+	checkDustAllowance := func(outputs ...Output) (err error) {
+		tx := aliasTransaction([]Output{genesisOutput}, outputs, signedBy(wallets[0]))
+
+		// the limitation of dust outputs is a local policy that does not affect the validity of the transaction
+		_, err = utxoDAG.CheckTransaction(tx)
+		require.NoError(t, err)
+
+		return utxoDAG.CheckDustAllowance(tx)
+	}
+
+	// outputs below the minimum amount are invalid
+	err := checkTransaction(NewSigLockedSingleOutput(5, wallets[1].address), NewSigLockedSingleOutput(995, wallets[0].address))
+	assert.True(t, xerrors.Is(err, ErrOutputBelowMinimumAmount), err)
+	assert.True(t, xerrors.Is(err, ErrTransactionInvalid), err)
+
+	// addresses without a dust allowance can hold a limited amount of dust outputs
+	assert.NoError(t, checkDustAllowance(
+		NewSigLockedSingleOutput(40, wallets[1].address),
+		NewSigLockedSingleOutput(50, wallets[1].address),
+		NewSigLockedSingleOutput(910, wallets[0].address),
+	))
+	err = checkDustAllowance(
+		NewSigLockedSingleOutput(40, wallets[1].address),
+		NewSigLockedSingleOutput(50, wallets[1].address),
+		NewSigLockedSingleOutput(60, wallets[1].address),
+		NewSigLockedSingleOutput(850, wallets[0].address),
+	)
+	require.True(t, xerrors.Is(err, ErrDustAllowanceExceeded), err)
+	var validationErr *TransactionValidationError
+	require.True(t, xerrors.As(err, &validationErr))
+	assert.Equal(t, wallets[1].address.Array(), validationErr.Address.Array())
+	assert.Equal(t, 3, validationErr.DustOutputs)
+
+	// addresses with a dust allowance can hold any amount of dust outputs
+	assert.NoError(t, checkDustAllowance(
+		NewSigLockedSingleOutput(40, wallets[0].address),
+		NewSigLockedSingleOutput(50, wallets[0].address),
+		NewSigLockedSingleOutput(60, wallets[0].address),
+		NewSigLockedSingleOutput(850, wallets[0].address),
+	))
+
+	// unspent dust outputs in the ledger state count towards the limit
+	tx := aliasTransaction([]Output{genesisOutput}, []Output{
+		NewSigLockedSingleOutput(40, wallets[1].address),
+		NewSigLockedSingleOutput(50, wallets[1].address),
+		NewSigLockedSingleOutput(910, wallets[0].address),
+	}, signedBy(wallets[0]))
+	_, err = utxoDAG.BookTransaction(tx)
+	require.NoError(t, err)
+	var remainder Output
+	for _, output := range tx.Essence().Outputs() {
+		utxoDAG.StoreAddressOutputMapping(output.Address(), output.ID())
+		if output.Address().Array() == wallets[0].address.Array() {
+			remainder = output
+		}
+	}
+	dustTx := aliasTransaction([]Output{remainder}, []Output{
+		NewSigLockedSingleOutput(60, wallets[1].address),
+		NewSigLockedSingleOutput(850, wallets[0].address),
+	}, signedBy(wallets[0]))
+	_, err = utxoDAG.CheckTransaction(dustTx)
+	assert.NoError(t, err)
+	err = utxoDAG.CheckDustAllowance(dustTx)
+	assert.True(t, xerrors.Is(err, ErrDustAllowanceExceeded), err)
+
+	// the limitation of dust outputs is disabled by default
+	defaultUTXODAG := NewUTXODAG(store, branchDAG, DustProtection(DefaultDustProtectionParams))
+	defer defaultUTXODAG.Shutdown()
+	assert.NoError(t, defaultUTXODAG.CheckDustAllowance(dustTx))
+}
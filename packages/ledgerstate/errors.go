@@ -2,6 +2,8 @@ package ledgerstate
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
 var (
@@ -13,4 +15,226 @@ var (
 
 	// ErrInvalidStateTransition is returned if there is an invalid state transition in the ledger state.
 	ErrInvalidStateTransition = errors.New("invalid state transition")
+
+	// ErrInvalidAliasTransition is returned if a Transaction violates the rules for the chain of an AliasOutput.
+	ErrInvalidAliasTransition = errors.New("invalid alias transition")
+
+	// ErrAliasOutputNotFound is returned if the current AliasOutput of an alias can not be found.
+	ErrAliasOutputNotFound = errors.New("alias output not found")
+
+	// ErrInvalidAssetTransition is returned if a Transaction violates the rules for the chain of an AssetOutput.
+	ErrInvalidAssetTransition = errors.New("invalid asset transition")
+
+	// ErrAssetOutputNotFound is returned if the AssetOutput of an asset can not be found.
+	ErrAssetOutputNotFound = errors.New("asset output not found")
+
+	// ErrInvalidThresholdAddress is returned if the parameters of a ThresholdAddress are invalid.
+	ErrInvalidThresholdAddress = errors.New("invalid threshold address")
+)
+
+// region TransactionValidationError ///////////////////////////////////////////////////////////////////////////////////
+
+var (
+	// ErrUnknownInput is the reason of a TransactionValidationError if an Input references an Output that is not known.
+	ErrUnknownInput = errors.New("unknown input")
+
+	// ErrBalanceMismatch is the reason of a TransactionValidationError if the consumed and spent balances of a color do
+	// not match.
+	ErrBalanceMismatch = errors.New("balance mismatch")
+
+	// ErrUncoloredBalanceMismatch is the reason of a TransactionValidationError if the tokens that are left over from the
+	// consumed Colors do not match the uncolored (IOTA and newly minted) tokens of the Outputs. The mismatch can not be
+	// attributed to a single Color or Output, as the left over tokens of all Colors are pooled.
+	ErrUncoloredBalanceMismatch = errors.New("uncolored balance mismatch")
+
+	// ErrBalanceOverflow is the reason of a TransactionValidationError if the sum of the balances overflows.
+	ErrBalanceOverflow = errors.New("balance overflow")
+
+	// ErrInvalidUnlockBlockIndex is the reason of a TransactionValidationError if a ReferenceUnlockBlock references an
+	// UnlockBlock that does not exist or that is a ReferenceUnlockBlock itself.
+	ErrInvalidUnlockBlockIndex = errors.New("invalid unlock block index")
+
+	// ErrUnlockBlockInvalid is the reason of a TransactionValidationError if an UnlockBlock does not authorize the
+	// spending of the Output that is referenced by its Input.
+	ErrUnlockBlockInvalid = errors.New("unlock block invalid")
+
+	// ErrInputsSpentInPastCone is the reason of a TransactionValidationError if an Input is spent in the past cone of
+	// another Input of the same Transaction.
+	ErrInputsSpentInPastCone = errors.New("inputs spent in past cone")
+
+	// ErrOutputBelowMinimumAmount is the reason of a TransactionValidationError if an Output holds less tokens than the
+	// minimum amount of the dust protection.
+	ErrOutputBelowMinimumAmount = errors.New("output below minimum amount")
+
+	// ErrDustAllowanceExceeded is the reason of a TransactionValidationError if an Output would increase the amount of
+	// dust Outputs on an Address without a dust allowance beyond the limit of the dust protection.
+	ErrDustAllowanceExceeded = errors.New("dust allowance exceeded")
+
+	// ErrAssetBurnNotAllowed is the reason of a TransactionValidationError if a Transaction burns tokens of an asset
+	// whose SupplyPolicy does not allow them to be burned.
+	ErrAssetBurnNotAllowed = errors.New("asset burn not allowed")
+
+	// ErrDuplicateAliasInput is the reason of a TransactionValidationError if a Transaction consumes more than one
+	// AliasOutput of the same alias.
+	ErrDuplicateAliasInput = errors.New("alias consumed more than once")
+
+	// ErrDuplicateAliasSuccessor is the reason of a TransactionValidationError if a Transaction creates more than one
+	// successor of the same alias.
+	ErrDuplicateAliasSuccessor = errors.New("more than one successor of the alias")
+
+	// ErrAliasSuccessorWithoutPredecessor is the reason of a TransactionValidationError if a Transaction creates the
+	// successor of an alias without consuming its current AliasOutput.
+	ErrAliasSuccessorWithoutPredecessor = errors.New("alias successor without consumed predecessor")
+
+	// ErrInvalidAliasStateIndex is the reason of a TransactionValidationError if the state index of the successor of an
+	// alias is not increased by exactly one (state transition) or not kept (governance transition).
+	ErrInvalidAliasStateIndex = errors.New("invalid alias state index")
 )
+
+// TransactionValidationError is the error that is returned if a Transaction fails one of the validation checks of the
+// UTXODAG. It carries the reason of the failure together with the index of the offending Input or Output and can be
+// compared to ErrTransactionInvalid or ErrTransactionNotSolid using xerrors.Is.
+type TransactionValidationError struct {
+	// Reason contains the sentinel error that describes the kind of the failure.
+	Reason error
+
+	// InputIndex contains the index of the offending Input (or -1 if the failure is not related to an Input).
+	InputIndex int
+
+	// OutputIndex contains the index of the offending Output (or -1 if the failure is not related to an Output).
+	OutputIndex int
+
+	// Color contains the Color of the mismatching balance (only set for ErrBalanceMismatch and ErrAssetBurnNotAllowed).
+	Color Color
+
+	// Consumed contains the consumed balance of the mismatching Color (only set for ErrBalanceMismatch and
+	// ErrAssetBurnNotAllowed) or the left over tokens of all Colors (only set for ErrUncoloredBalanceMismatch).
+	Consumed uint64
+
+	// Spent contains the spent balance of the mismatching Color (only set for ErrBalanceMismatch and
+	// ErrAssetBurnNotAllowed) or the uncolored tokens of the Outputs (only set for ErrUncoloredBalanceMismatch).
+	Spent uint64
+
+	// Address contains the Address that would hold too many dust Outputs (only set for ErrDustAllowanceExceeded).
+	Address Address
+
+	// DustOutputs contains the amount of dust Outputs that the Address would hold (only set for
+	// ErrDustAllowanceExceeded).
+	DustOutputs int
+}
+
+// newInputValidationError is an internal utility function that creates a TransactionValidationError for an Input.
+func newInputValidationError(reason error, inputIndex int) *TransactionValidationError {
+	return &TransactionValidationError{
+		Reason:      reason,
+		InputIndex:  inputIndex,
+		OutputIndex: -1,
+	}
+}
+
+// newOutputValidationError is an internal utility function that creates a TransactionValidationError for an Output.
+func newOutputValidationError(reason error, outputIndex int) *TransactionValidationError {
+	return &TransactionValidationError{
+		Reason:      reason,
+		InputIndex:  -1,
+		OutputIndex: outputIndex,
+	}
+}
+
+// newBalanceMismatchError is an internal utility function that creates a TransactionValidationError for a mismatching
+// balance of the given Color.
+func newBalanceMismatchError(color Color, consumed uint64, spent uint64, outputIndex int) *TransactionValidationError {
+	return &TransactionValidationError{
+		Reason:      ErrBalanceMismatch,
+		InputIndex:  -1,
+		OutputIndex: outputIndex,
+		Color:       color,
+		Consumed:    consumed,
+		Spent:       spent,
+	}
+}
+
+// newUncoloredBalanceMismatchError is an internal utility function that creates a TransactionValidationError for left
+// over tokens that do not match the uncolored tokens of the Outputs.
+func newUncoloredBalanceMismatchError(consumed uint64, spent uint64) *TransactionValidationError {
+	return &TransactionValidationError{
+		Reason:      ErrUncoloredBalanceMismatch,
+		InputIndex:  -1,
+		OutputIndex: -1,
+		Consumed:    consumed,
+		Spent:       spent,
+	}
+}
+
+// newAssetBurnNotAllowedError is an internal utility function that creates a TransactionValidationError for tokens of
+// the given Color that are burned although the SupplyPolicy of their asset does not allow it.
+func newAssetBurnNotAllowedError(color Color, consumed uint64, spent uint64) *TransactionValidationError {
+	return &TransactionValidationError{
+		Reason:      ErrAssetBurnNotAllowed,
+		InputIndex:  -1,
+		OutputIndex: -1,
+		Color:       color,
+		Consumed:    consumed,
+		Spent:       spent,
+	}
+}
+
+// newDustAllowanceExceededError is an internal utility function that creates a TransactionValidationError for an Output
+// that would exceed the limit of dust Outputs on the given Address.
+func newDustAllowanceExceededError(address Address, dustOutputs int, outputIndex int) *TransactionValidationError {
+	return &TransactionValidationError{
+		Reason:      ErrDustAllowanceExceeded,
+		InputIndex:  -1,
+		OutputIndex: outputIndex,
+		Address:     address,
+		DustOutputs: dustOutputs,
+	}
+}
+
+// Error returns a human readable description of the failure.
+func (t *TransactionValidationError) Error() string {
+	var builder strings.Builder
+	builder.WriteString(t.Reason.Error())
+	if t.Reason == ErrBalanceMismatch || t.Reason == ErrAssetBurnNotAllowed {
+		builder.WriteString(fmt.Sprintf(" of %s (consumed %d, spent %d)", t.Color, t.Consumed, t.Spent))
+	}
+	if t.Reason == ErrUncoloredBalanceMismatch {
+		builder.WriteString(fmt.Sprintf(" (consumed %d, spent %d)", t.Consumed, t.Spent))
+	}
+	if t.Reason == ErrDustAllowanceExceeded {
+		builder.WriteString(fmt.Sprintf(" of %s (%d dust outputs)", t.Address.Base58(), t.DustOutputs))
+	}
+	if t.InputIndex >= 0 {
+		builder.WriteString(fmt.Sprintf(" at input %d", t.InputIndex))
+	}
+	if t.OutputIndex >= 0 {
+		builder.WriteString(fmt.Sprintf(" at output %d", t.OutputIndex))
+	}
+
+	return builder.String()
+}
+
+// Unwrap returns the Reason of the failure.
+func (t *TransactionValidationError) Unwrap() error {
+	return t.Reason
+}
+
+// Is returns true if the target is the Reason of the failure or the generic error that describes the failure
+// (ErrTransactionNotSolid for unknown Inputs and ErrTransactionInvalid for everything else).
+func (t *TransactionValidationError) Is(target error) bool {
+	switch target {
+	case t.Reason:
+		return true
+	case ErrTransactionNotSolid:
+		return t.Reason == ErrUnknownInput
+	case ErrTransactionInvalid:
+		return t.Reason != ErrUnknownInput
+	default:
+		return false
+	}
+}
+
+// code contract (make sure the type implements all required methods)
+var _ error = &TransactionValidationError{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,85 @@
+package ledgerstate
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/cerrors"
+	"golang.org/x/xerrors"
+)
+
+// region UTXODAG historical state /////////////////////////////////////////////////////////////////////////////////////
+
+// OutputsOnAddressAt reconstructs the Outputs that were held by the given Address at the given time. An Output is part
+// of the result if it was created by a confirmed Transaction that was issued at or before the given time and if it was
+// not spent by a confirmed Transaction that was issued at or before the given time.
+func (u *UTXODAG) OutputsOnAddressAt(address Address, timestamp time.Time) (outputs Outputs) {
+	outputs = make(Outputs, 0)
+	u.AddressOutputMapping(address).Consume(func(addressOutputMapping *AddressOutputMapping) {
+		if !u.OutputUnspentAt(addressOutputMapping.OutputID(), timestamp) {
+			return
+		}
+
+		u.Output(addressOutputMapping.OutputID()).Consume(func(output Output) {
+			outputs = append(outputs, output)
+		})
+	})
+
+	return
+}
+
+// OutputUnspentAt returns true if the Output with the given ID existed in the confirmed ledger state at the given time
+// and was not spent by a confirmed Transaction, yet.
+func (u *UTXODAG) OutputUnspentAt(outputID OutputID, timestamp time.Time) (unspent bool) {
+	if !u.transactionConfirmedAt(outputID.TransactionID(), timestamp) {
+		return false
+	}
+
+	unspent = true
+	u.Consumers(outputID).Consume(func(consumer *Consumer) {
+		unspent = unspent && !u.transactionConfirmedAt(consumer.TransactionID(), timestamp)
+	})
+
+	return
+}
+
+// CheckpointTime returns the time of the confirmed ledger state that is defined by the Transaction with the given ID.
+// It returns an error if the Transaction is unknown or not confirmed.
+func (u *UTXODAG) CheckpointTime(transactionID TransactionID) (timestamp time.Time, err error) {
+	inclusionState, err := u.InclusionState(transactionID)
+	if err != nil {
+		err = xerrors.Errorf("failed to retrieve InclusionState of Transaction with %s: %w", transactionID, err)
+		return
+	}
+	if inclusionState != Confirmed {
+		err = xerrors.Errorf("Transaction with %s is not confirmed: %w", transactionID, cerrors.ErrFatal)
+		return
+	}
+
+	timestamp = u.transactionTimestamp(transactionID)
+
+	return
+}
+
+// transactionConfirmedAt is an internal utility function that returns true if the Transaction with the given ID is
+// confirmed and was issued at or before the given time.
+func (u *UTXODAG) transactionConfirmedAt(transactionID TransactionID, timestamp time.Time) bool {
+	inclusionState, err := u.InclusionState(transactionID)
+	if err != nil || inclusionState != Confirmed {
+		return false
+	}
+
+	return !u.transactionTimestamp(transactionID).After(timestamp)
+}
+
+// transactionTimestamp is an internal utility function that returns the timestamp of the Transaction with the given ID.
+// Transactions of snapshots are not stored in the UTXODAG and are considered to be part of the ledger state since the
+// beginning, so their timestamp is the zero time.
+func (u *UTXODAG) transactionTimestamp(transactionID TransactionID) (timestamp time.Time) {
+	u.Transaction(transactionID).Consume(func(transaction *Transaction) {
+		timestamp = transaction.Essence().Timestamp()
+	})
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,78 @@
+package ledgerstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUTXODAG_OutputsOnAddressAt(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+	defer utxoDAG.Shutdown()
+
+	wallets := createWallets(2)
+	utxoDAG.LoadSnapshot(map[TransactionID]map[Address]*ColoredBalances{
+		GenesisTransactionID: {
+			wallets[0].address: NewColoredBalances(map[Color]uint64{ColorIOTA: 100}),
+		},
+	})
+	var genesisOutput Output
+	utxoDAG.Output(NewOutputID(GenesisTransactionID, 0)).Consume(func(output Output) {
+		genesisOutput = output
+	})
+	confirmTransaction := func(transactionID TransactionID) {
+		utxoDAG.TransactionMetadata(transactionID).Consume(func(transactionMetadata *TransactionMetadata) {
+			transactionMetadata.SetFinalized(true)
+		})
+	}
+
+	beforeTx1 := time.Now()
+	tx1 := aliasTransaction([]Output{genesisOutput}, []Output{
+		NewSigLockedSingleOutput(60, wallets[1].address),
+		NewSigLockedSingleOutput(40, wallets[0].address),
+	}, func(txEssence *TransactionEssence, _ Output) UnlockBlock {
+		return NewSignatureUnlockBlock(wallets[0].sign(txEssence))
+	})
+	_, err := utxoDAG.BookTransaction(tx1)
+	require.NoError(t, err)
+	for _, output := range tx1.Essence().Outputs() {
+		utxoDAG.StoreAddressOutputMapping(output.Address(), output.ID())
+	}
+
+	// pending Transactions do not change the historical state
+	assert.Equal(t, map[Color]uint64{ColorIOTA: 100}, balancesAt(utxoDAG, wallets[0].address, time.Now()))
+	assert.Empty(t, balancesAt(utxoDAG, wallets[1].address, time.Now()))
+
+	confirmTransaction(tx1.ID())
+	checkpoint, err := utxoDAG.CheckpointTime(tx1.ID())
+	require.NoError(t, err)
+	assert.True(t, tx1.Essence().Timestamp().Equal(checkpoint))
+
+	assert.Equal(t, map[Color]uint64{ColorIOTA: 100}, balancesAt(utxoDAG, wallets[0].address, beforeTx1))
+	assert.Empty(t, balancesAt(utxoDAG, wallets[1].address, beforeTx1))
+	assert.Equal(t, map[Color]uint64{ColorIOTA: 40}, balancesAt(utxoDAG, wallets[0].address, checkpoint))
+	assert.Equal(t, map[Color]uint64{ColorIOTA: 60}, balancesAt(utxoDAG, wallets[1].address, checkpoint))
+
+	assert.True(t, utxoDAG.OutputUnspentAt(genesisOutput.ID(), beforeTx1))
+	assert.False(t, utxoDAG.OutputUnspentAt(genesisOutput.ID(), checkpoint))
+
+	_, err = utxoDAG.CheckpointTime(GenesisTransactionID)
+	require.NoError(t, err)
+	_, err = utxoDAG.CheckpointTime(TransactionID{1})
+	require.Error(t, err)
+}
+
+func balancesAt(utxoDAG *UTXODAG, address Address, timestamp time.Time) (balances map[Color]uint64) {
+	balances = make(map[Color]uint64)
+	for _, output := range utxoDAG.OutputsOnAddressAt(address, timestamp) {
+		output.Balances().ForEach(func(color Color, balance uint64) bool {
+			balances[color] += balance
+			return true
+		})
+	}
+
+	return
+}
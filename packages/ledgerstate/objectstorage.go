@@ -36,6 +36,15 @@ const (
 
 	// PrefixAddressOutputMappingStorage defines the storage prefix for the AddressOutputMapping object storage.
 	PrefixAddressOutputMappingStorage
+
+	// PrefixStateTreeStorage defines the storage prefix for the nodes of the StateTree.
+	PrefixStateTreeStorage
+
+	// PrefixAddressHistoryStorage defines the storage prefix for the AddressHistoryEntry object storage.
+	PrefixAddressHistoryStorage
+
+	// PrefixAssetOutputMappingStorage defines the storage prefix for the AssetOutputMapping object storage.
+	PrefixAssetOutputMappingStorage
 )
 
 // branchStorageOptions contains a list of default settings for the Branch object storage.
@@ -101,3 +110,17 @@ var addressOutputMappingStorageOptions = []objectstorage.Option{
 	objectstorage.PartitionKey(AddressLength, OutputIDLength),
 	objectstorage.LeakDetectionEnabled(false),
 }
+
+// addressHistoryStorageOptions contains a list of default settings for the AddressHistoryEntry object storage.
+var addressHistoryStorageOptions = []objectstorage.Option{
+	AddressHistoryEntryPartitionKeys,
+	objectstorage.CacheTime(10 * time.Second),
+	objectstorage.LeakDetectionEnabled(false),
+}
+
+// assetOutputMappingStorageOptions contains a list of default settings for the AssetOutputMapping object storage.
+var assetOutputMappingStorageOptions = []objectstorage.Option{
+	objectstorage.CacheTime(10 * time.Second),
+	objectstorage.PartitionKey(ColorLength, OutputIDLength),
+	objectstorage.LeakDetectionEnabled(false),
+}
@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/iotaledger/hive.go/bitmask"
 	"github.com/iotaledger/hive.go/byteutils"
 	"github.com/iotaledger/hive.go/cerrors"
 	"github.com/iotaledger/hive.go/marshalutil"
@@ -35,6 +36,12 @@ const (
 
 	// MaxOutputBalance defines the maximum balance on an Output (the supply).
 	MaxOutputBalance = 2779530283277761
+
+	// MaxAliasDataSize defines the maximum size of the state data and the immutable data of an AliasOutput.
+	MaxAliasDataSize = 4 * 1024
+
+	// MaxOutputPayloadSize defines the maximum size of the payload that can be attached to an ExtendedLockedOutput.
+	MaxOutputPayloadSize = 4 * 1024
 )
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -51,6 +58,18 @@ const (
 
 	// SigLockedColoredOutputType represents an Output that holds colored coins that gets unlocked by a signature.
 	SigLockedColoredOutputType
+
+	// AliasOutputType represents an Output that forms a chain of states of an alias that is identified by an
+	// AliasAddress.
+	AliasOutputType
+
+	// ExtendedLockedOutputType represents an Output that holds colored coins and that can optionally be time locked,
+	// fall back to another Address after a deadline and carry a payload.
+	ExtendedLockedOutputType
+
+	// AssetOutputType represents an Output that forms a chain of records which define the metadata of an asset (the
+	// tokens of a Color).
+	AssetOutputType
 )
 
 // String returns a human readable representation of the OutputType.
@@ -58,6 +77,9 @@ func (o OutputType) String() string {
 	return [...]string{
 		"SigLockedSingleOutputType",
 		"SigLockedColoredOutputType",
+		"AliasOutputType",
+		"ExtendedLockedOutputType",
+		"AssetOutputType",
 	}[o]
 }
 
@@ -187,8 +209,8 @@ type Output interface {
 	Address() Address
 
 	// UnlockValid determines if the given Transaction and the corresponding UnlockBlock are allowed to spend the
-	// Output.
-	UnlockValid(tx *Transaction, unlockBlock UnlockBlock) (bool, error)
+	// Output. The consumed Outputs of the Transaction are required to validate UnlockBlocks that reference other Inputs.
+	UnlockValid(tx *Transaction, unlockBlock UnlockBlock, inputs Outputs) (bool, error)
 
 	// Input returns an Input that references the Output.
 	Input() Input
@@ -241,6 +263,21 @@ func OutputFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (output Output,
 			err = xerrors.Errorf("failed to parse SigLockedColoredOutput: %w", err)
 			return
 		}
+	case AliasOutputType:
+		if output, err = AliasOutputFromMarshalUtil(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse AliasOutput: %w", err)
+			return
+		}
+	case ExtendedLockedOutputType:
+		if output, err = ExtendedLockedOutputFromMarshalUtil(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse ExtendedLockedOutput: %w", err)
+			return
+		}
+	case AssetOutputType:
+		if output, err = AssetOutputFromMarshalUtil(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse AssetOutput: %w", err)
+			return
+		}
 	default:
 		err = xerrors.Errorf("unsupported OutputType (%X): %w", outputType, cerrors.ErrParseBytesFailed)
 		return
@@ -580,16 +617,8 @@ func (s *SigLockedSingleOutput) Balances() *ColoredBalances {
 }
 
 // UnlockValid determines if the given Transaction and the corresponding UnlockBlock are allowed to spend the Output.
-func (s *SigLockedSingleOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBlock) (unlockValid bool, err error) {
-	signatureUnlockBlock, correctType := unlockBlock.(*SignatureUnlockBlock)
-	if !correctType {
-		err = xerrors.Errorf("UnlockBlock does not match expected OutputType: %w", cerrors.ErrParseBytesFailed)
-		return
-	}
-
-	unlockValid = signatureUnlockBlock.AddressSignatureValid(s.address, tx.Essence().Bytes())
-
-	return
+func (s *SigLockedSingleOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBlock, inputs Outputs) (unlockValid bool, err error) {
+	return addressUnlockValid(s.address, tx, unlockBlock, inputs)
 }
 
 // Address returns the Address that the Output is associated to.
@@ -751,16 +780,8 @@ func (s *SigLockedColoredOutput) Balances() *ColoredBalances {
 }
 
 // UnlockValid determines if the given Transaction and the corresponding UnlockBlock are allowed to spend the Output.
-func (s *SigLockedColoredOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBlock) (unlockValid bool, err error) {
-	signatureUnlockBlock, correctType := unlockBlock.(*SignatureUnlockBlock)
-	if !correctType {
-		err = xerrors.Errorf("UnlockBlock does not match expected OutputType: %w", cerrors.ErrParseBytesFailed)
-		return
-	}
-
-	unlockValid = signatureUnlockBlock.AddressSignatureValid(s.address, tx.Essence().Bytes())
-
-	return
+func (s *SigLockedColoredOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBlock, inputs Outputs) (unlockValid bool, err error) {
+	return addressUnlockValid(s.address, tx, unlockBlock, inputs)
 }
 
 // Address returns the Address that the Output is associated to.
@@ -846,6 +867,823 @@ var _ Output = &SigLockedColoredOutput{}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region AliasOutput //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// AliasOutput is an Output that represents the current state of an alias. Every AliasOutput of the same alias is
+// identified by the same AliasAddress, which is derived from the OutputID of the origin of the chain. The state of an
+// alias can be advanced by its state controller (state transition) while the controllers can only be changed by its
+// governance controller (governance transition). A Transaction can create at most one successor per alias.
+type AliasOutput struct {
+	id               OutputID
+	idMutex          sync.RWMutex
+	aliasAddress     *AliasAddress
+	balances         *ColoredBalances
+	stateAddress     Address
+	governingAddress Address
+	stateIndex       uint32
+	stateData        []byte
+	immutableData    []byte
+	governanceUpdate bool
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewAliasOutput creates the origin of a new alias with the given controllers and immutable data. The AliasAddress of
+// the alias is derived from the OutputID that gets assigned to the Output.
+func NewAliasOutput(balances *ColoredBalances, stateAddress Address, governingAddress Address, immutableData []byte) *AliasOutput {
+	return &AliasOutput{
+		balances:         balances,
+		stateAddress:     stateAddress,
+		governingAddress: governingAddress,
+		stateData:        []byte{},
+		immutableData:    immutableData,
+	}
+}
+
+// AliasOutputFromBytes unmarshals an AliasOutput from a sequence of bytes.
+func AliasOutputFromBytes(bytes []byte) (output *AliasOutput, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if output, err = AliasOutputFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse AliasOutput from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AliasOutputFromMarshalUtil unmarshals an AliasOutput using a MarshalUtil (for easier unmarshaling).
+func AliasOutputFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (output *AliasOutput, err error) {
+	outputType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse OutputType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if OutputType(outputType) != AliasOutputType {
+		err = xerrors.Errorf("invalid OutputType (%X): %w", outputType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	output = &AliasOutput{}
+	if output.aliasAddress, err = AliasAddressFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse AliasAddress: %w", err)
+		return
+	}
+	if output.aliasAddress.Equals(emptyAliasAddress) {
+		output.aliasAddress = nil
+	}
+	if output.balances, err = ColoredBalancesFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse ColoredBalances: %w", err)
+		return
+	}
+	if output.stateAddress, err = AddressFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse state Address (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if output.governingAddress, err = AddressFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse governing Address (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if output.stateIndex, err = marshalUtil.ReadUint32(); err != nil {
+		err = xerrors.Errorf("failed to parse state index (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if output.stateData, err = readAliasData(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse state data: %w", err)
+		return
+	}
+	if output.immutableData, err = readAliasData(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse immutable data: %w", err)
+		return
+	}
+	if output.governanceUpdate, err = marshalUtil.ReadBool(); err != nil {
+		err = xerrors.Errorf("failed to parse governance update flag (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	if output.stateAddress.Type() == AliasAddressType || output.governingAddress.Type() == AliasAddressType {
+		err = xerrors.Errorf("controllers of an AliasOutput must not be AliasAddresses: %w", cerrors.ErrParseBytesFailed)
+		return
+	}
+	if output.IsOrigin() && (output.stateIndex != 0 || output.governanceUpdate) {
+		err = xerrors.Errorf("origin of an alias must have state index 0 and must not be a governance update: %w", cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// ID returns the identifier of the Output that is used to address the Output in the UTXODAG.
+func (a *AliasOutput) ID() OutputID {
+	a.idMutex.RLock()
+	defer a.idMutex.RUnlock()
+
+	return a.id
+}
+
+// SetID allows to set the identifier of the Output. We offer a setter for the property since Outputs that are
+// created to become part of a transaction usually do not have an identifier, yet as their identifier depends on
+// the TransactionID that is only determinable after the Transaction has been fully constructed. The ID is therefore
+// only accessed when the Output is supposed to be persisted by the node.
+func (a *AliasOutput) SetID(outputID OutputID) Output {
+	a.idMutex.Lock()
+	defer a.idMutex.Unlock()
+
+	a.id = outputID
+
+	return a
+}
+
+// Type returns the type of the Output which allows us to generically handle Outputs of different types.
+func (a *AliasOutput) Type() OutputType {
+	return AliasOutputType
+}
+
+// Balances returns the funds that are associated with the Output.
+func (a *AliasOutput) Balances() *ColoredBalances {
+	return a.balances
+}
+
+// SetBalances sets the funds of an AliasOutput that is being constructed.
+func (a *AliasOutput) SetBalances(balances *ColoredBalances) {
+	a.balances = balances
+}
+
+// Address returns the AliasAddress that identifies the alias.
+func (a *AliasOutput) Address() Address {
+	return a.AliasAddress()
+}
+
+// AliasAddress returns the AliasAddress that identifies the alias. The AliasAddress of the origin is derived from its
+// OutputID.
+func (a *AliasOutput) AliasAddress() *AliasAddress {
+	if a.IsOrigin() {
+		return NewAliasAddress(a.ID().Bytes())
+	}
+
+	return a.aliasAddress
+}
+
+// IsOrigin returns true if the AliasOutput is the first Output of the chain of the alias.
+func (a *AliasOutput) IsOrigin() bool {
+	return a.aliasAddress == nil
+}
+
+// StateAddress returns the Address of the state controller of the alias.
+func (a *AliasOutput) StateAddress() Address {
+	return a.stateAddress
+}
+
+// SetStateAddress sets the state controller of an AliasOutput that is being constructed (requires a governance
+// transition).
+func (a *AliasOutput) SetStateAddress(stateAddress Address) {
+	a.stateAddress = stateAddress
+}
+
+// GoverningAddress returns the Address of the governance controller of the alias.
+func (a *AliasOutput) GoverningAddress() Address {
+	return a.governingAddress
+}
+
+// SetGoverningAddress sets the governance controller of an AliasOutput that is being constructed (requires a
+// governance transition).
+func (a *AliasOutput) SetGoverningAddress(governingAddress Address) {
+	a.governingAddress = governingAddress
+}
+
+// StateIndex returns the index of the state of the alias (it is increased by one with every state transition).
+func (a *AliasOutput) StateIndex() uint32 {
+	return a.stateIndex
+}
+
+// StateData returns the data that is associated to the current state of the alias.
+func (a *AliasOutput) StateData() []byte {
+	return a.stateData
+}
+
+// SetStateData sets the state data of an AliasOutput that is being constructed (requires a state transition).
+func (a *AliasOutput) SetStateData(stateData []byte) {
+	a.stateData = stateData
+}
+
+// ImmutableData returns the data that was defined by the origin of the alias and that can never be changed.
+func (a *AliasOutput) ImmutableData() []byte {
+	return a.immutableData
+}
+
+// IsGovernanceUpdate returns true if the AliasOutput was created by a governance transition.
+func (a *AliasOutput) IsGovernanceUpdate() bool {
+	return a.governanceUpdate
+}
+
+// NewAliasOutputNext creates the successor of the AliasOutput that can be modified before it is added to a
+// Transaction. A state transition increases the state index while a governance transition keeps it.
+func (a *AliasOutput) NewAliasOutputNext(governanceUpdate bool) *AliasOutput {
+	next := &AliasOutput{
+		aliasAddress:     a.AliasAddress(),
+		balances:         a.balances.Clone(),
+		stateAddress:     a.stateAddress.Clone(),
+		governingAddress: a.governingAddress.Clone(),
+		stateIndex:       a.stateIndex,
+		stateData:        a.stateData,
+		immutableData:    a.immutableData,
+		governanceUpdate: governanceUpdate,
+	}
+	if !governanceUpdate {
+		next.stateIndex++
+	}
+
+	return next
+}
+
+// UnlockValid determines if the given Transaction and the corresponding UnlockBlock are allowed to spend the Output.
+// State transitions need to be unlocked by the state controller while governance transitions and the destruction of
+// the alias need to be unlocked by the governance controller.
+func (a *AliasOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBlock, inputs Outputs) (unlockValid bool, err error) {
+	next, err := a.successor(tx)
+	if err != nil {
+		err = xerrors.Errorf("failed to determine successor of alias with %s: %w", a.AliasAddress().Base58(), err)
+		return
+	}
+
+	if next == nil || next.IsGovernanceUpdate() {
+		if next != nil {
+			if err = a.validateGovernanceTransition(next); err != nil {
+				return
+			}
+		}
+
+		return addressUnlockValid(a.governingAddress, tx, unlockBlock, inputs)
+	}
+
+	if err = a.validateStateTransition(next); err != nil {
+		return
+	}
+
+	return addressUnlockValid(a.stateAddress, tx, unlockBlock, inputs)
+}
+
+// Input returns an Input that references the Output.
+func (a *AliasOutput) Input() Input {
+	if a.ID() == EmptyOutputID {
+		panic("Outputs that haven't been assigned an ID, yet cannot be converted to an Input")
+	}
+
+	return NewUTXOInput(a.ID())
+}
+
+// Clone creates a copy of the Output.
+func (a *AliasOutput) Clone() Output {
+	clonedOutput := &AliasOutput{
+		id:               a.ID(),
+		balances:         a.balances.Clone(),
+		stateAddress:     a.stateAddress.Clone(),
+		governingAddress: a.governingAddress.Clone(),
+		stateIndex:       a.stateIndex,
+		stateData:        make([]byte, len(a.stateData)),
+		immutableData:    make([]byte, len(a.immutableData)),
+		governanceUpdate: a.governanceUpdate,
+	}
+	if a.aliasAddress != nil {
+		clonedOutput.aliasAddress = a.aliasAddress.Clone().(*AliasAddress)
+	}
+	copy(clonedOutput.stateData, a.stateData)
+	copy(clonedOutput.immutableData, a.immutableData)
+
+	return clonedOutput
+}
+
+// UpdateMintingColor replaces the ColorMint in the balances of the Output with the hash of the OutputID. It returns a
+// copy of the original Output with the modified balances.
+func (a *AliasOutput) UpdateMintingColor() (updatedOutput *AliasOutput) {
+	coloredBalances := a.Balances().Map()
+	if mintedCoins, mintedCoinsExist := coloredBalances[ColorMint]; mintedCoinsExist {
+		delete(coloredBalances, ColorMint)
+		coloredBalances[Color(blake2b.Sum256(a.ID().Bytes()))] = mintedCoins
+	}
+	updatedOutput = a.Clone().(*AliasOutput)
+	updatedOutput.balances = NewColoredBalances(coloredBalances)
+
+	return
+}
+
+// Bytes returns a marshaled version of the Output.
+func (a *AliasOutput) Bytes() []byte {
+	return a.ObjectStorageValue()
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (a *AliasOutput) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (a *AliasOutput) ObjectStorageKey() []byte {
+	return a.id.Bytes()
+}
+
+// ObjectStorageValue marshals the Output into a sequence of bytes. The ID is not serialized here as it is only used as
+// a key in the ObjectStorage.
+func (a *AliasOutput) ObjectStorageValue() []byte {
+	aliasAddress := emptyAliasAddress
+	if a.aliasAddress != nil {
+		aliasAddress = a.aliasAddress
+	}
+
+	return marshalutil.New().
+		WriteByte(byte(AliasOutputType)).
+		WriteBytes(aliasAddress.Bytes()).
+		WriteBytes(a.balances.Bytes()).
+		WriteBytes(a.stateAddress.Bytes()).
+		WriteBytes(a.governingAddress.Bytes()).
+		WriteUint32(a.stateIndex).
+		WriteUint16(uint16(len(a.stateData))).
+		WriteBytes(a.stateData).
+		WriteUint16(uint16(len(a.immutableData))).
+		WriteBytes(a.immutableData).
+		WriteBool(a.governanceUpdate).
+		Bytes()
+}
+
+// Compare offers a comparator for Outputs which returns -1 if the other Output is bigger, 1 if it is smaller and 0 if
+// they are the same.
+func (a *AliasOutput) Compare(other Output) int {
+	return bytes.Compare(a.Bytes(), other.Bytes())
+}
+
+// String returns a human readable version of the Output.
+func (a *AliasOutput) String() string {
+	return stringify.Struct("AliasOutput",
+		stringify.StructField("id", a.ID()),
+		stringify.StructField("aliasAddress", a.AliasAddress()),
+		stringify.StructField("balances", a.balances),
+		stringify.StructField("stateAddress", a.stateAddress),
+		stringify.StructField("governingAddress", a.governingAddress),
+		stringify.StructField("stateIndex", a.stateIndex),
+		stringify.StructField("stateData", a.stateData),
+		stringify.StructField("immutableData", a.immutableData),
+		stringify.StructField("governanceUpdate", a.governanceUpdate),
+	)
+}
+
+// successor returns the AliasOutput of the given Transaction that continues the chain of the alias (or nil if the
+// alias is destroyed by the Transaction).
+func (a *AliasOutput) successor(tx *Transaction) (next *AliasOutput, err error) {
+	aliasAddress := a.AliasAddress()
+	for _, output := range tx.Essence().Outputs() {
+		aliasOutput, isAliasOutput := output.(*AliasOutput)
+		if !isAliasOutput || aliasOutput.IsOrigin() || !aliasOutput.aliasAddress.Equals(aliasAddress) {
+			continue
+		}
+
+		if next != nil {
+			err = xerrors.Errorf("transaction creates more than one successor of the alias: %w", ErrInvalidAliasTransition)
+			return
+		}
+		next = aliasOutput
+	}
+
+	return
+}
+
+// validateStateTransition checks if the given successor is a valid state transition of the AliasOutput.
+func (a *AliasOutput) validateStateTransition(next *AliasOutput) (err error) {
+	switch {
+	case next.stateIndex != a.stateIndex+1:
+		err = xerrors.Errorf("state index of successor (%d) is not %d: %w", next.stateIndex, a.stateIndex+1, ErrInvalidAliasTransition)
+	case !bytes.Equal(next.stateAddress.Bytes(), a.stateAddress.Bytes()):
+		err = xerrors.Errorf("state address can not be changed by a state transition: %w", ErrInvalidAliasTransition)
+	case !bytes.Equal(next.governingAddress.Bytes(), a.governingAddress.Bytes()):
+		err = xerrors.Errorf("governing address can not be changed by a state transition: %w", ErrInvalidAliasTransition)
+	case !bytes.Equal(next.immutableData, a.immutableData):
+		err = xerrors.Errorf("immutable data can not be changed: %w", ErrInvalidAliasTransition)
+	}
+
+	return
+}
+
+// validateGovernanceTransition checks if the given successor is a valid governance transition of the AliasOutput.
+func (a *AliasOutput) validateGovernanceTransition(next *AliasOutput) (err error) {
+	switch {
+	case next.stateIndex != a.stateIndex:
+		err = xerrors.Errorf("state index can not be changed by a governance transition: %w", ErrInvalidAliasTransition)
+	case !bytes.Equal(next.stateData, a.stateData):
+		err = xerrors.Errorf("state data can not be changed by a governance transition: %w", ErrInvalidAliasTransition)
+	case !bytes.Equal(next.balances.Bytes(), a.balances.Bytes()):
+		err = xerrors.Errorf("balances can not be changed by a governance transition: %w", ErrInvalidAliasTransition)
+	case !bytes.Equal(next.immutableData, a.immutableData):
+		err = xerrors.Errorf("immutable data can not be changed: %w", ErrInvalidAliasTransition)
+	}
+
+	return
+}
+
+// stateTransitionedBy returns true if the given Transaction creates a state transition of the alias.
+func (a *AliasOutput) stateTransitionedBy(tx *Transaction) bool {
+	next, err := a.successor(tx)
+
+	return err == nil && next != nil && !next.IsGovernanceUpdate()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ Output = &AliasOutput{}
+
+// emptyAliasAddress is the AliasAddress that is used to mark the origin of an alias in its marshaled form.
+var emptyAliasAddress = &AliasAddress{digest: make([]byte, 32)}
+
+// readAliasData is an internal utility function that reads length prefixed data of an AliasOutput.
+func readAliasData(marshalUtil *marshalutil.MarshalUtil) (data []byte, err error) {
+	dataLength, err := marshalUtil.ReadUint16()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse data length (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if dataLength > MaxAliasDataSize {
+		err = xerrors.Errorf("data length (%d) exceeds MaxAliasDataSize (%d): %w", dataLength, MaxAliasDataSize, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if data, err = marshalUtil.ReadBytes(int(dataLength)); err != nil {
+		err = xerrors.Errorf("failed to parse data (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ExtendedLockedOutput /////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// flagExtendedLockedOutputFallbackPresent marks that the ExtendedLockedOutput defines a fallback Address.
+	flagExtendedLockedOutputFallbackPresent uint = iota
+
+	// flagExtendedLockedOutputTimelockPresent marks that the ExtendedLockedOutput defines a timelock.
+	flagExtendedLockedOutputTimelockPresent
+
+	// flagExtendedLockedOutputPayloadPresent marks that the ExtendedLockedOutput carries a payload.
+	flagExtendedLockedOutputPayloadPresent
+)
+
+// ExtendedLockedOutput is an Output that holds colored balances and that can be unlocked by providing a signature for
+// an Address. It can optionally be locked until a given time (timelock) and it can optionally define a fallback Address
+// that takes over the control of the funds once the fallback deadline has passed. It can additionally carry an
+// arbitrary payload of limited size. All time based conditions are evaluated against the timestamp of the spending
+// Transaction.
+type ExtendedLockedOutput struct {
+	id       OutputID
+	idMutex  sync.RWMutex
+	balances *ColoredBalances
+	address  Address
+
+	// optional part
+	fallbackAddress  Address
+	fallbackDeadline time.Time
+	timelock         time.Time
+	payload          []byte
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewExtendedLockedOutput is the constructor for an ExtendedLockedOutput. The optional parts of the Output can be set
+// by the corresponding builder methods.
+func NewExtendedLockedOutput(balances *ColoredBalances, address Address) *ExtendedLockedOutput {
+	return &ExtendedLockedOutput{
+		balances: balances,
+		address:  address,
+	}
+}
+
+// ExtendedLockedOutputFromBytes unmarshals an ExtendedLockedOutput from a sequence of bytes.
+func ExtendedLockedOutputFromBytes(bytes []byte) (output *ExtendedLockedOutput, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if output, err = ExtendedLockedOutputFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse ExtendedLockedOutput from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// ExtendedLockedOutputFromMarshalUtil unmarshals an ExtendedLockedOutput using a MarshalUtil (for easier unmarshaling).
+func ExtendedLockedOutputFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (output *ExtendedLockedOutput, err error) {
+	outputType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse OutputType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if OutputType(outputType) != ExtendedLockedOutputType {
+		err = xerrors.Errorf("invalid OutputType (%X): %w", outputType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	output = &ExtendedLockedOutput{}
+	if output.balances, err = ColoredBalancesFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse ColoredBalances: %w", err)
+		return
+	}
+	if output.address, err = AddressFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Address (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	flagsByte, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse flags (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	flags := bitmask.BitMask(flagsByte)
+	if flags.HasBit(flagExtendedLockedOutputFallbackPresent) {
+		if output.fallbackAddress, err = AddressFromMarshalUtil(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse fallback Address (%v): %w", err, cerrors.ErrParseBytesFailed)
+			return
+		}
+		if output.fallbackDeadline, err = marshalUtil.ReadTime(); err != nil {
+			err = xerrors.Errorf("failed to parse fallback deadline (%v): %w", err, cerrors.ErrParseBytesFailed)
+			return
+		}
+	}
+	if flags.HasBit(flagExtendedLockedOutputTimelockPresent) {
+		if output.timelock, err = marshalUtil.ReadTime(); err != nil {
+			err = xerrors.Errorf("failed to parse timelock (%v): %w", err, cerrors.ErrParseBytesFailed)
+			return
+		}
+	}
+	if flags.HasBit(flagExtendedLockedOutputPayloadPresent) {
+		payloadLength, payloadLengthErr := marshalUtil.ReadUint16()
+		if payloadLengthErr != nil {
+			err = xerrors.Errorf("failed to parse payload length (%v): %w", payloadLengthErr, cerrors.ErrParseBytesFailed)
+			return
+		}
+		if payloadLength > MaxOutputPayloadSize {
+			err = xerrors.Errorf("payload length (%d) exceeds MaxOutputPayloadSize (%d): %w", payloadLength, MaxOutputPayloadSize, cerrors.ErrParseBytesFailed)
+			return
+		}
+		if output.payload, err = marshalUtil.ReadBytes(int(payloadLength)); err != nil {
+			err = xerrors.Errorf("failed to parse payload (%v): %w", err, cerrors.ErrParseBytesFailed)
+			return
+		}
+	}
+
+	return
+}
+
+// WithFallbackOptions adds a fallback Address and a fallback deadline to the ExtendedLockedOutput. Once the deadline has
+// passed, the Output can only be unlocked by the fallback Address.
+func (o *ExtendedLockedOutput) WithFallbackOptions(fallbackAddress Address, fallbackDeadline time.Time) *ExtendedLockedOutput {
+	o.fallbackAddress = fallbackAddress
+	o.fallbackDeadline = fallbackDeadline
+
+	return o
+}
+
+// WithTimeLock adds a timelock to the ExtendedLockedOutput which prevents it from being unlocked before the given time.
+func (o *ExtendedLockedOutput) WithTimeLock(timelock time.Time) *ExtendedLockedOutput {
+	o.timelock = timelock
+
+	return o
+}
+
+// SetPayload sets the payload that is attached to the ExtendedLockedOutput.
+func (o *ExtendedLockedOutput) SetPayload(payload []byte) (err error) {
+	if len(payload) > MaxOutputPayloadSize {
+		return xerrors.Errorf("payload length (%d) exceeds MaxOutputPayloadSize (%d)", len(payload), MaxOutputPayloadSize)
+	}
+	o.payload = payload
+
+	return
+}
+
+// ID returns the identifier of the Output that is used to address the Output in the UTXODAG.
+func (o *ExtendedLockedOutput) ID() OutputID {
+	o.idMutex.RLock()
+	defer o.idMutex.RUnlock()
+
+	return o.id
+}
+
+// SetID allows to set the identifier of the Output. We offer a setter for the property since Outputs that are
+// created to become part of a transaction usually do not have an identifier, yet as their identifier depends on
+// the TransactionID that is only determinable after the Transaction has been fully constructed. The ID is therefore
+// only accessed when the Output is supposed to be persisted by the node.
+func (o *ExtendedLockedOutput) SetID(outputID OutputID) Output {
+	o.idMutex.Lock()
+	defer o.idMutex.Unlock()
+
+	o.id = outputID
+
+	return o
+}
+
+// Type returns the type of the Output which allows us to generically handle Outputs of different types.
+func (o *ExtendedLockedOutput) Type() OutputType {
+	return ExtendedLockedOutputType
+}
+
+// Balances returns the funds that are associated with the Output.
+func (o *ExtendedLockedOutput) Balances() *ColoredBalances {
+	return o.balances
+}
+
+// Address returns the Address that the Output is associated to (it ignores the fallback Address).
+func (o *ExtendedLockedOutput) Address() Address {
+	return o.address
+}
+
+// FallbackAddress returns the Address that controls the Output after the fallback deadline (or nil if none was set).
+func (o *ExtendedLockedOutput) FallbackAddress() Address {
+	return o.fallbackAddress
+}
+
+// FallbackDeadline returns the time after which the Output can only be unlocked by the fallback Address.
+func (o *ExtendedLockedOutput) FallbackDeadline() time.Time {
+	return o.fallbackDeadline
+}
+
+// TimeLock returns the time before which the Output can not be unlocked (the zero value if no timelock was set).
+func (o *ExtendedLockedOutput) TimeLock() time.Time {
+	return o.timelock
+}
+
+// Payload returns the data that is attached to the Output.
+func (o *ExtendedLockedOutput) Payload() []byte {
+	return o.payload
+}
+
+// TimeLockedNow returns true if the Output can not be unlocked at the given time because of its timelock.
+func (o *ExtendedLockedOutput) TimeLockedNow(now time.Time) bool {
+	return !o.timelock.IsZero() && now.Before(o.timelock)
+}
+
+// UnlockAddressNow returns the Address that is allowed to unlock the Output at the given time (ignoring the timelock).
+func (o *ExtendedLockedOutput) UnlockAddressNow(now time.Time) Address {
+	if o.fallbackAddress == nil || now.Before(o.fallbackDeadline) {
+		return o.address
+	}
+
+	return o.fallbackAddress
+}
+
+// UnlockValid determines if the given Transaction and the corresponding UnlockBlock are allowed to spend the Output.
+// The timelock and the fallback deadline are evaluated against the timestamp of the Transaction.
+func (o *ExtendedLockedOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBlock, inputs Outputs) (unlockValid bool, err error) {
+	if o.TimeLockedNow(tx.Essence().Timestamp()) {
+		return false, nil
+	}
+
+	return addressUnlockValid(o.UnlockAddressNow(tx.Essence().Timestamp()), tx, unlockBlock, inputs)
+}
+
+// Input returns an Input that references the Output.
+func (o *ExtendedLockedOutput) Input() Input {
+	if o.ID() == EmptyOutputID {
+		panic("Outputs that haven't been assigned an ID, yet cannot be converted to an Input")
+	}
+
+	return NewUTXOInput(o.ID())
+}
+
+// Clone creates a copy of the Output.
+func (o *ExtendedLockedOutput) Clone() Output {
+	clonedOutput := &ExtendedLockedOutput{
+		id:               o.ID(),
+		balances:         o.balances.Clone(),
+		address:          o.address.Clone(),
+		fallbackDeadline: o.fallbackDeadline,
+		timelock:         o.timelock,
+	}
+	if o.fallbackAddress != nil {
+		clonedOutput.fallbackAddress = o.fallbackAddress.Clone()
+	}
+	if o.payload != nil {
+		clonedOutput.payload = make([]byte, len(o.payload))
+		copy(clonedOutput.payload, o.payload)
+	}
+
+	return clonedOutput
+}
+
+// UpdateMintingColor replaces the ColorMint in the balances of the Output with the hash of the OutputID. It returns a
+// copy of the original Output with the modified balances.
+func (o *ExtendedLockedOutput) UpdateMintingColor() (updatedOutput *ExtendedLockedOutput) {
+	coloredBalances := o.Balances().Map()
+	if mintedCoins, mintedCoinsExist := coloredBalances[ColorMint]; mintedCoinsExist {
+		delete(coloredBalances, ColorMint)
+		coloredBalances[Color(blake2b.Sum256(o.ID().Bytes()))] = mintedCoins
+	}
+	updatedOutput = o.Clone().(*ExtendedLockedOutput)
+	updatedOutput.balances = NewColoredBalances(coloredBalances)
+
+	return
+}
+
+// Bytes returns a marshaled version of the Output.
+func (o *ExtendedLockedOutput) Bytes() []byte {
+	return o.ObjectStorageValue()
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (o *ExtendedLockedOutput) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (o *ExtendedLockedOutput) ObjectStorageKey() []byte {
+	return o.id.Bytes()
+}
+
+// ObjectStorageValue marshals the Output into a sequence of bytes. The ID is not serialized here as it is only used as
+// a key in the ObjectStorage.
+func (o *ExtendedLockedOutput) ObjectStorageValue() []byte {
+	flags := bitmask.BitMask(0).
+		ModifyBit(flagExtendedLockedOutputFallbackPresent, o.fallbackAddress != nil).
+		ModifyBit(flagExtendedLockedOutputTimelockPresent, !o.timelock.IsZero()).
+		ModifyBit(flagExtendedLockedOutputPayloadPresent, len(o.payload) != 0)
+
+	marshalUtil := marshalutil.New().
+		WriteByte(byte(ExtendedLockedOutputType)).
+		WriteBytes(o.balances.Bytes()).
+		WriteBytes(o.address.Bytes()).
+		WriteByte(byte(flags))
+	if flags.HasBit(flagExtendedLockedOutputFallbackPresent) {
+		marshalUtil.WriteBytes(o.fallbackAddress.Bytes()).WriteTime(o.fallbackDeadline)
+	}
+	if flags.HasBit(flagExtendedLockedOutputTimelockPresent) {
+		marshalUtil.WriteTime(o.timelock)
+	}
+	if flags.HasBit(flagExtendedLockedOutputPayloadPresent) {
+		marshalUtil.WriteUint16(uint16(len(o.payload))).WriteBytes(o.payload)
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// Compare offers a comparator for Outputs which returns -1 if the other Output is bigger, 1 if it is smaller and 0 if
+// they are the same.
+func (o *ExtendedLockedOutput) Compare(other Output) int {
+	return bytes.Compare(o.Bytes(), other.Bytes())
+}
+
+// String returns a human readable version of the Output.
+func (o *ExtendedLockedOutput) String() string {
+	return stringify.Struct("ExtendedLockedOutput",
+		stringify.StructField("id", o.ID()),
+		stringify.StructField("address", o.address),
+		stringify.StructField("balances", o.balances),
+		stringify.StructField("fallbackAddress", o.fallbackAddress),
+		stringify.StructField("fallbackDeadline", o.fallbackDeadline),
+		stringify.StructField("timelock", o.timelock),
+		stringify.StructField("payload", o.payload),
+	)
+}
+
+// code contract (make sure the type implements all required methods)
+var _ Output = &ExtendedLockedOutput{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility functions ////////////////////////////////////////////////////////////////////////////////////////////
+
+// addressUnlockValid is an internal utility function that checks if the given UnlockBlock unlocks the given Address.
+// Addresses that are secured by a signature scheme are unlocked by a SignatureUnlockBlock, ThresholdAddresses are
+// unlocked by a MultiSignatureUnlockBlock while AliasAddresses are unlocked by an AliasUnlockBlock that references the
+// state transition of the alias in the same Transaction.
+func addressUnlockValid(address Address, tx *Transaction, unlockBlock UnlockBlock, inputs Outputs) (unlockValid bool, err error) {
+	switch typedUnlockBlock := unlockBlock.(type) {
+	case *SignatureUnlockBlock:
+		unlockValid = typedUnlockBlock.AddressSignatureValid(address, tx.Essence().Bytes())
+	case *MultiSignatureUnlockBlock:
+		unlockValid = typedUnlockBlock.AddressSignatureValid(address, tx.Essence().Bytes())
+	case *AggregatedBLSUnlockBlock:
+		unlockValid = typedUnlockBlock.AddressSignatureValid(address, tx.Essence().Bytes())
+	case *AliasUnlockBlock:
+		aliasAddress, isAliasAddress := address.(*AliasAddress)
+		if !isAliasAddress {
+			err = xerrors.Errorf("AliasUnlockBlock can only unlock AliasAddresses: %w", cerrors.ErrParseBytesFailed)
+			return
+		}
+		if int(typedUnlockBlock.AliasInputIndex()) >= len(inputs) {
+			err = xerrors.Errorf("AliasUnlockBlock references non-existing Input (%d): %w", typedUnlockBlock.AliasInputIndex(), cerrors.ErrParseBytesFailed)
+			return
+		}
+		aliasInput, isAliasOutput := inputs[typedUnlockBlock.AliasInputIndex()].(*AliasOutput)
+		if !isAliasOutput || !aliasInput.AliasAddress().Equals(aliasAddress) {
+			err = xerrors.Errorf("AliasUnlockBlock does not reference the AliasOutput of %s: %w", aliasAddress.Base58(), ErrInvalidAliasTransition)
+			return
+		}
+
+		unlockValid = aliasInput.stateTransitionedBy(tx)
+	default:
+		err = xerrors.Errorf("UnlockBlock does not match expected OutputType: %w", cerrors.ErrParseBytesFailed)
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region CachedOutput /////////////////////////////////////////////////////////////////////////////////////////////////
 
 // CachedOutput is a wrapper for the generic CachedObject returned by the object storage that overrides the accessor
@@ -0,0 +1,107 @@
+package ledgerstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAliasOutput_Marshaling(t *testing.T) {
+	stateAddress := NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	governingAddress := NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	origin := NewAliasOutput(NewColoredBalances(map[Color]uint64{ColorIOTA: 100}), stateAddress, governingAddress, []byte("immutable"))
+	origin.SetID(NewOutputID(GenesisTransactionID, 1))
+	assert.True(t, origin.IsOrigin())
+	assert.True(t, origin.AliasAddress().Equals(NewAliasAddress(origin.ID().Bytes())))
+
+	// the origin does not carry its AliasAddress in the marshaled form
+	restoredOrigin, _, err := OutputFromBytes(origin.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, origin.Bytes(), restoredOrigin.Bytes())
+	assert.True(t, restoredOrigin.(*AliasOutput).IsOrigin())
+
+	next := origin.NewAliasOutputNext(false)
+	next.SetStateData([]byte("state"))
+	assert.False(t, next.IsOrigin())
+	assert.Equal(t, uint32(1), next.StateIndex())
+	assert.True(t, next.AliasAddress().Equals(origin.AliasAddress()))
+
+	restoredNext, _, err := AliasOutputFromBytes(next.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, next.Bytes(), restoredNext.Bytes())
+	assert.True(t, restoredNext.AliasAddress().Equals(origin.AliasAddress()))
+	assert.Equal(t, []byte("state"), restoredNext.StateData())
+	assert.Equal(t, []byte("immutable"), restoredNext.ImmutableData())
+	assert.Equal(t, stateAddress.Bytes(), restoredNext.StateAddress().Bytes())
+	assert.Equal(t, governingAddress.Bytes(), restoredNext.GoverningAddress().Bytes())
+
+	// controllers of an alias must not be aliases themselves
+	invalidOutput := NewAliasOutput(NewColoredBalances(map[Color]uint64{ColorIOTA: 100}), origin.AliasAddress(), governingAddress, nil)
+	_, _, err = AliasOutputFromBytes(invalidOutput.Bytes())
+	assert.Error(t, err)
+}
+
+func TestExtendedLockedOutput_Marshaling(t *testing.T) {
+	address := NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	fallbackAddress := NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	now := time.Now()
+
+	// an ExtendedLockedOutput without optional parts only carries the flags byte in addition to the mandatory fields
+	plainOutput := NewExtendedLockedOutput(NewColoredBalances(map[Color]uint64{ColorIOTA: 100}), address)
+	restoredPlainOutput, _, err := OutputFromBytes(plainOutput.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, plainOutput.Bytes(), restoredPlainOutput.Bytes())
+	assert.Nil(t, restoredPlainOutput.(*ExtendedLockedOutput).FallbackAddress())
+	assert.True(t, restoredPlainOutput.(*ExtendedLockedOutput).TimeLock().IsZero())
+
+	output := NewExtendedLockedOutput(NewColoredBalances(map[Color]uint64{ColorIOTA: 100}), address).
+		WithFallbackOptions(fallbackAddress, now.Add(time.Hour)).
+		WithTimeLock(now.Add(time.Minute))
+	require.NoError(t, output.SetPayload([]byte("payload")))
+	restoredOutput, _, err := ExtendedLockedOutputFromBytes(output.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, output.Bytes(), restoredOutput.Bytes())
+	assert.Equal(t, fallbackAddress.Bytes(), restoredOutput.FallbackAddress().Bytes())
+	assert.True(t, output.FallbackDeadline().Equal(restoredOutput.FallbackDeadline()))
+	assert.True(t, output.TimeLock().Equal(restoredOutput.TimeLock()))
+	assert.Equal(t, []byte("payload"), restoredOutput.Payload())
+
+	// payloads are bounded
+	assert.Error(t, output.SetPayload(make([]byte, MaxOutputPayloadSize+1)))
+}
+
+func TestExtendedLockedOutput_UnlockValid(t *testing.T) {
+	wallets := createWallets(2)
+	owner, sender := wallets[0], wallets[1]
+	now := time.Now()
+
+	output := NewExtendedLockedOutput(NewColoredBalances(map[Color]uint64{ColorIOTA: 100}), owner.address).
+		WithFallbackOptions(sender.address, now.Add(time.Hour)).
+		WithTimeLock(now.Add(time.Minute))
+	output.SetID(NewOutputID(GenesisTransactionID, 1))
+
+	unlockValid := func(timestamp time.Time, signer wallet) bool {
+		txEssence := NewTransactionEssence(0, timestamp, identity.ID{}, identity.ID{}, NewInputs(output.Input()), NewOutputs(NewSigLockedSingleOutput(100, signer.address)))
+		tx := NewTransaction(txEssence, signer.unlockBlocks(txEssence))
+		valid, err := output.UnlockValid(tx, tx.UnlockBlocks()[0], Outputs{output})
+		require.NoError(t, err)
+
+		return valid
+	}
+
+	// the timelock prevents spending by anybody
+	assert.False(t, unlockValid(now, owner))
+	assert.False(t, unlockValid(now, sender))
+
+	// after the timelock and before the fallback deadline only the owner can spend
+	assert.True(t, unlockValid(now.Add(30*time.Minute), owner))
+	assert.False(t, unlockValid(now.Add(30*time.Minute), sender))
+
+	// after the fallback deadline only the fallback address can spend
+	assert.False(t, unlockValid(now.Add(2*time.Hour), owner))
+	assert.True(t, unlockValid(now.Add(2*time.Hour), sender))
+}
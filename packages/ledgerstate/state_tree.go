@@ -0,0 +1,416 @@
+package ledgerstate
+
+import (
+	"encoding/binary"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+	"github.com/mr-tron/base58"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/xerrors"
+)
+
+// region MerkleRoot ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MerkleRootLength contains the amount of bytes that a marshaled version of the MerkleRoot contains.
+const MerkleRootLength = 32
+
+// MerkleRoot is the type that represents the root of the StateTree which commits to the confirmed unspent Outputs of
+// the ledger.
+type MerkleRoot [MerkleRootLength]byte
+
+// EmptyMerkleRoot represents the MerkleRoot of a StateTree that does not contain any Outputs.
+var EmptyMerkleRoot MerkleRoot
+
+// MerkleRootFromBytes unmarshals a MerkleRoot from a sequence of bytes.
+func MerkleRootFromBytes(bytes []byte) (merkleRoot MerkleRoot, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if merkleRoot, err = MerkleRootFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse MerkleRoot from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// MerkleRootFromBase58 creates a MerkleRoot from a base58 encoded string.
+func MerkleRootFromBase58(base58String string) (merkleRoot MerkleRoot, err error) {
+	bytes, err := base58.Decode(base58String)
+	if err != nil {
+		err = xerrors.Errorf("error while decoding base58 encoded MerkleRoot (%v): %w", err, cerrors.ErrBase58DecodeFailed)
+		return
+	}
+
+	if merkleRoot, _, err = MerkleRootFromBytes(bytes); err != nil {
+		err = xerrors.Errorf("failed to parse MerkleRoot from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// MerkleRootFromMarshalUtil unmarshals a MerkleRoot using a MarshalUtil (for easier unmarshaling).
+func MerkleRootFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (merkleRoot MerkleRoot, err error) {
+	merkleRootBytes, err := marshalUtil.ReadBytes(MerkleRootLength)
+	if err != nil {
+		err = xerrors.Errorf("failed to parse MerkleRoot (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	copy(merkleRoot[:], merkleRootBytes)
+
+	return
+}
+
+// Bytes returns a marshaled version of the MerkleRoot.
+func (m MerkleRoot) Bytes() []byte {
+	return m[:]
+}
+
+// Base58 returns a base58 encoded version of the MerkleRoot.
+func (m MerkleRoot) Base58() string {
+	return base58.Encode(m[:])
+}
+
+// String creates a human readable version of the MerkleRoot.
+func (m MerkleRoot) String() string {
+	return "MerkleRoot(" + m.Base58() + ")"
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region StateTree ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// stateTreeDepth contains the amount of levels of the StateTree (one for every bit of the hashed OutputID).
+const stateTreeDepth = 256
+
+// StateTree is a sparse Merkle tree over the confirmed unspent Outputs of the ledger. Its leaves are addressed by the
+// hash of the OutputID and contain the hash of the Output, which allows to prove the inclusion and the non-inclusion of
+// an Output with respect to a single MerkleRoot. Only the non-empty nodes of the tree are persisted.
+type StateTree struct {
+	store kvstore.KVStore
+	mutex sync.RWMutex
+}
+
+// NewStateTree creates a new StateTree that persists its nodes in the given KVStore.
+func NewStateTree(store kvstore.KVStore) *StateTree {
+	return &StateTree{
+		store: store,
+	}
+}
+
+// Root returns the current MerkleRoot of the StateTree.
+func (s *StateTree) Root() (root MerkleRoot) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.nodeHash(stateTreeDepth, [32]byte{})
+}
+
+// Add adds the given Output to the StateTree.
+func (s *StateTree) Add(output Output) {
+	s.update(stateTreeKey(output.ID()), OutputHash(output))
+}
+
+// Remove removes the Output with the given OutputID from the StateTree.
+func (s *StateTree) Remove(outputID OutputID) {
+	s.update(stateTreeKey(outputID), [32]byte{})
+}
+
+// Contains returns true if the Output with the given OutputID is part of the StateTree.
+func (s *StateTree) Contains(outputID OutputID) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.leafValue(stateTreeKey(outputID)) != [32]byte{}
+}
+
+// Proof returns an OutputProof that proves the inclusion (or the non-inclusion) of the Output with the given OutputID
+// with respect to the current MerkleRoot.
+func (s *StateTree) Proof(outputID OutputID) (proof *OutputProof) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	key := stateTreeKey(outputID)
+	proof = &OutputProof{
+		outputID:   outputID,
+		outputHash: s.leafValue(key),
+	}
+	for height := 0; height < stateTreeDepth; height++ {
+		proof.siblings[height] = s.nodeHash(height, stateTreeSiblingPath(key, height))
+	}
+
+	return
+}
+
+// update is an internal utility function that sets the value of the leaf with the given key and recomputes the hashes
+// of all the nodes on the path to the root (an empty value removes the leaf).
+func (s *StateTree) update(key [32]byte, value [32]byte) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	batch := s.store.Batched()
+	s.setNode(batch, 0, key, value)
+
+	currentHash := stateTreeLeafHash(key, value)
+	for height := 0; height < stateTreeDepth; height++ {
+		siblingHash := s.nodeHash(height, stateTreeSiblingPath(key, height))
+		if stateTreeBit(key, stateTreeDepth-1-height) == 0 {
+			currentHash = stateTreeNodeHash(currentHash, siblingHash)
+		} else {
+			currentHash = stateTreeNodeHash(siblingHash, currentHash)
+		}
+		s.setNode(batch, height+1, stateTreePath(key, height+1), currentHash)
+	}
+
+	if err := batch.Commit(); err != nil {
+		panic(xerrors.Errorf("failed to persist nodes of StateTree: %w", err))
+	}
+}
+
+// leafValue is an internal utility function that returns the value (the OutputHash) of the leaf with the given key.
+func (s *StateTree) leafValue(key [32]byte) (value [32]byte) {
+	storedValue, err := s.store.Get(stateTreeStorageKey(0, key))
+	if err != nil {
+		if xerrors.Is(err, kvstore.ErrKeyNotFound) {
+			return
+		}
+		panic(xerrors.Errorf("failed to load leaf of StateTree: %w", err))
+	}
+	copy(value[:], storedValue)
+
+	return
+}
+
+// nodeHash is an internal utility function that returns the hash of the node at the given height and path.
+func (s *StateTree) nodeHash(height int, path [32]byte) (hash [32]byte) {
+	if height == 0 {
+		return stateTreeLeafHash(path, s.leafValue(path))
+	}
+
+	storedHash, err := s.store.Get(stateTreeStorageKey(height, path))
+	if err != nil {
+		if xerrors.Is(err, kvstore.ErrKeyNotFound) {
+			return
+		}
+		panic(xerrors.Errorf("failed to load node of StateTree: %w", err))
+	}
+	copy(hash[:], storedHash)
+
+	return
+}
+
+// setNode is an internal utility function that persists the value of a node (empty nodes are deleted).
+func (s *StateTree) setNode(batch kvstore.BatchedMutations, height int, path [32]byte, value [32]byte) {
+	var err error
+	if value == [32]byte{} {
+		err = batch.Delete(stateTreeStorageKey(height, path))
+	} else {
+		err = batch.Set(stateTreeStorageKey(height, path), byteutils.ConcatBytes(value[:]))
+	}
+
+	if err != nil {
+		panic(xerrors.Errorf("failed to update node of StateTree: %w", err))
+	}
+}
+
+// OutputHash returns the hash of the given Output that is used as the value of its leaf in the StateTree.
+func OutputHash(output Output) [32]byte {
+	return blake2b.Sum256(byteutils.ConcatBytes(output.ID().Bytes(), output.Bytes()))
+}
+
+// stateTreeKey is an internal utility function that returns the key of the leaf of the given OutputID.
+func stateTreeKey(outputID OutputID) [32]byte {
+	return blake2b.Sum256(outputID.Bytes())
+}
+
+// stateTreeLeafHash is an internal utility function that returns the hash of a leaf (empty leafs have an empty hash).
+func stateTreeLeafHash(key [32]byte, value [32]byte) (hash [32]byte) {
+	if value == [32]byte{} {
+		return
+	}
+
+	return blake2b.Sum256(byteutils.ConcatBytes([]byte{0}, key[:], value[:]))
+}
+
+// stateTreeNodeHash is an internal utility function that returns the hash of an inner node (empty sub trees have an
+// empty hash).
+func stateTreeNodeHash(left [32]byte, right [32]byte) (hash [32]byte) {
+	if left == [32]byte{} && right == [32]byte{} {
+		return
+	}
+
+	return blake2b.Sum256(byteutils.ConcatBytes([]byte{1}, left[:], right[:]))
+}
+
+// stateTreeBit is an internal utility function that returns the bit at the given index (starting at the most
+// significant bit) of the key.
+func stateTreeBit(key [32]byte, index int) byte {
+	return (key[index/8] >> (7 - uint(index%8))) & 1
+}
+
+// stateTreePath is an internal utility function that returns the path of the node at the given height that lies on the
+// way from the leaf with the given key to the root (the lowest height bits of the key are cleared).
+func stateTreePath(key [32]byte, height int) (path [32]byte) {
+	path = key
+	for index := stateTreeDepth - height; index < stateTreeDepth; index++ {
+		path[index/8] &^= 1 << (7 - uint(index%8))
+	}
+
+	return
+}
+
+// stateTreeSiblingPath is an internal utility function that returns the path of the sibling of the node at the given
+// height that lies on the way from the leaf with the given key to the root.
+func stateTreeSiblingPath(key [32]byte, height int) (path [32]byte) {
+	path = stateTreePath(key, height)
+	index := stateTreeDepth - 1 - height
+	path[index/8] ^= 1 << (7 - uint(index%8))
+
+	return
+}
+
+// stateTreeStorageKey is an internal utility function that returns the key that is used to persist a node.
+func stateTreeStorageKey(height int, path [32]byte) []byte {
+	storageKey := make([]byte, 2, 2+len(path))
+	binary.BigEndian.PutUint16(storageKey, uint16(height))
+
+	return append(storageKey, path[:]...)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region OutputProof //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// OutputProof is a Merkle proof that proves the inclusion or the non-inclusion of an Output in the StateTree.
+type OutputProof struct {
+	outputID   OutputID
+	outputHash [32]byte
+	siblings   [stateTreeDepth][32]byte
+}
+
+// OutputProofFromBytes unmarshals an OutputProof from a sequence of bytes.
+func OutputProofFromBytes(bytes []byte) (proof *OutputProof, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if proof, err = OutputProofFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse OutputProof from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// OutputProofFromMarshalUtil unmarshals an OutputProof using a MarshalUtil (for easier unmarshaling).
+func OutputProofFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (proof *OutputProof, err error) {
+	proof = &OutputProof{}
+	if proof.outputID, err = OutputIDFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse OutputID: %w", err)
+		return
+	}
+	outputHashBytes, err := marshalUtil.ReadBytes(32)
+	if err != nil {
+		err = xerrors.Errorf("failed to parse output hash (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	copy(proof.outputHash[:], outputHashBytes)
+
+	bitmap, err := marshalUtil.ReadBytes(stateTreeDepth / 8)
+	if err != nil {
+		err = xerrors.Errorf("failed to parse sibling bitmap (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	for height := 0; height < stateTreeDepth; height++ {
+		if bitmap[height/8]&(1<<uint(height%8)) == 0 {
+			continue
+		}
+
+		siblingBytes, siblingErr := marshalUtil.ReadBytes(32)
+		if siblingErr != nil {
+			err = xerrors.Errorf("failed to parse sibling (%v): %w", siblingErr, cerrors.ErrParseBytesFailed)
+			return
+		}
+		copy(proof.siblings[height][:], siblingBytes)
+	}
+
+	return
+}
+
+// OutputID returns the identifier of the Output that is proven by the OutputProof.
+func (o *OutputProof) OutputID() OutputID {
+	return o.outputID
+}
+
+// OutputHash returns the hash of the Output (or an empty hash if the OutputProof proves the non-inclusion).
+func (o *OutputProof) OutputHash() [32]byte {
+	return o.outputHash
+}
+
+// Included returns true if the OutputProof proves the inclusion of the Output.
+func (o *OutputProof) Included() bool {
+	return o.outputHash != [32]byte{}
+}
+
+// Root returns the MerkleRoot that results from the OutputProof.
+func (o *OutputProof) Root() MerkleRoot {
+	key := stateTreeKey(o.outputID)
+	currentHash := stateTreeLeafHash(key, o.outputHash)
+	for height := 0; height < stateTreeDepth; height++ {
+		if stateTreeBit(key, stateTreeDepth-1-height) == 0 {
+			currentHash = stateTreeNodeHash(currentHash, o.siblings[height])
+		} else {
+			currentHash = stateTreeNodeHash(o.siblings[height], currentHash)
+		}
+	}
+
+	return currentHash
+}
+
+// Verify checks if the OutputProof is valid for the given MerkleRoot.
+func (o *OutputProof) Verify(root MerkleRoot) bool {
+	return o.Root() == root
+}
+
+// VerifyOutput checks if the OutputProof proves the inclusion of the given Output for the given MerkleRoot.
+func (o *OutputProof) VerifyOutput(root MerkleRoot, output Output) bool {
+	return o.outputID == output.ID() && o.Included() && o.outputHash == OutputHash(output) && o.Verify(root)
+}
+
+// Bytes returns a marshaled version of the OutputProof (empty siblings are omitted).
+func (o *OutputProof) Bytes() []byte {
+	bitmap := make([]byte, stateTreeDepth/8)
+	marshalUtil := marshalutil.New()
+	for height := 0; height < stateTreeDepth; height++ {
+		if o.siblings[height] != [32]byte{} {
+			bitmap[height/8] |= 1 << uint(height%8)
+			marshalUtil.WriteBytes(o.siblings[height][:])
+		}
+	}
+
+	return byteutils.ConcatBytes(o.outputID.Bytes(), o.outputHash[:], bitmap, marshalUtil.Bytes())
+}
+
+// String returns a human readable version of the OutputProof.
+func (o *OutputProof) String() string {
+	var siblings strings.Builder
+	for height := 0; height < stateTreeDepth; height++ {
+		if o.siblings[height] != [32]byte{} {
+			siblings.WriteString(strconv.Itoa(height) + ":" + base58.Encode(o.siblings[height][:]) + " ")
+		}
+	}
+
+	return stringify.Struct("OutputProof",
+		stringify.StructField("outputID", o.outputID),
+		stringify.StructField("included", o.Included()),
+		stringify.StructField("outputHash", base58.Encode(o.outputHash[:])),
+		stringify.StructField("siblings", strings.TrimSpace(siblings.String())),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
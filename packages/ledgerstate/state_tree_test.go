@@ -0,0 +1,118 @@
+package ledgerstate
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateTree(t *testing.T) {
+	stateTree := NewStateTree(mapdb.NewMapDB())
+	assert.Equal(t, EmptyMerkleRoot, stateTree.Root())
+
+	wallets := createWallets(1)
+	outputs := make([]Output, 3)
+	for i := range outputs {
+		outputs[i] = NewSigLockedSingleOutput(uint64(100*(i+1)), wallets[0].address)
+		outputs[i].SetID(NewOutputID(GenesisTransactionID, uint16(i+1)))
+	}
+
+	// adding outputs changes the root
+	stateTree.Add(outputs[0])
+	rootWithFirstOutput := stateTree.Root()
+	assert.NotEqual(t, EmptyMerkleRoot, rootWithFirstOutput)
+	stateTree.Add(outputs[1])
+	stateTree.Add(outputs[2])
+	root := stateTree.Root()
+	assert.NotEqual(t, rootWithFirstOutput, root)
+
+	// the root does not depend on the order of the updates
+	otherStateTree := NewStateTree(mapdb.NewMapDB())
+	otherStateTree.Add(outputs[2])
+	otherStateTree.Add(outputs[0])
+	otherStateTree.Add(outputs[1])
+	assert.Equal(t, root, otherStateTree.Root())
+
+	// inclusion proofs
+	for _, output := range outputs {
+		assert.True(t, stateTree.Contains(output.ID()))
+
+		proof := stateTree.Proof(output.ID())
+		assert.True(t, proof.Included())
+		assert.True(t, proof.VerifyOutput(root, output))
+		assert.False(t, proof.VerifyOutput(rootWithFirstOutput, output))
+
+		restoredProof, _, err := OutputProofFromBytes(proof.Bytes())
+		require.NoError(t, err)
+		assert.True(t, restoredProof.VerifyOutput(root, output))
+	}
+
+	// a proof can not be used for a different output
+	tamperedOutput := NewSigLockedSingleOutput(1337, wallets[0].address)
+	tamperedOutput.SetID(outputs[0].ID())
+	assert.False(t, stateTree.Proof(outputs[0].ID()).VerifyOutput(root, tamperedOutput))
+
+	// non-inclusion proofs
+	missingOutputID := NewOutputID(GenesisTransactionID, 4)
+	assert.False(t, stateTree.Contains(missingOutputID))
+	nonInclusionProof := stateTree.Proof(missingOutputID)
+	assert.False(t, nonInclusionProof.Included())
+	assert.True(t, nonInclusionProof.Verify(root))
+
+	// removing outputs restores the previous roots
+	stateTree.Remove(outputs[1].ID())
+	stateTree.Remove(outputs[2].ID())
+	assert.Equal(t, rootWithFirstOutput, stateTree.Root())
+	assert.False(t, stateTree.Proof(outputs[1].ID()).Included())
+	assert.True(t, stateTree.Proof(outputs[1].ID()).Verify(rootWithFirstOutput))
+
+	stateTree.Remove(outputs[0].ID())
+	assert.Equal(t, EmptyMerkleRoot, stateTree.Root())
+}
+
+func TestUTXODAG_CommitConfirmedTransaction(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(2)
+	snapshot := map[TransactionID]map[Address]*ColoredBalances{
+		GenesisTransactionID: {
+			wallets[0].address: NewColoredBalances(map[Color]uint64{ColorIOTA: 100}),
+		},
+	}
+	utxoDAG.LoadSnapshot(snapshot)
+	genesisOutputID := NewOutputID(GenesisTransactionID, 0)
+	assert.True(t, utxoDAG.OutputProof(genesisOutputID).Verify(utxoDAG.StateRoot()))
+	assert.True(t, utxoDAG.OutputProof(genesisOutputID).Included())
+
+	var genesisOutput Output
+	utxoDAG.Output(genesisOutputID).Consume(func(output Output) {
+		genesisOutput = output
+	})
+	tx := aliasTransaction([]Output{genesisOutput}, []Output{NewSigLockedSingleOutput(100, wallets[1].address)}, func(txEssence *TransactionEssence, _ Output) UnlockBlock {
+		return NewSignatureUnlockBlock(wallets[0].sign(txEssence))
+	})
+	_, err := utxoDAG.BookTransaction(tx)
+	require.NoError(t, err)
+
+	// pending transactions are not committed
+	rootBeforeConfirmation := utxoDAG.StateRoot()
+	assert.Error(t, utxoDAG.CommitConfirmedTransaction(tx.ID()))
+	assert.Equal(t, rootBeforeConfirmation, utxoDAG.StateRoot())
+
+	utxoDAG.TransactionMetadata(tx.ID()).Consume(func(transactionMetadata *TransactionMetadata) {
+		transactionMetadata.SetFinalized(true)
+	})
+	require.NoError(t, utxoDAG.CommitConfirmedTransaction(tx.ID()))
+
+	root := utxoDAG.StateRoot()
+	assert.NotEqual(t, rootBeforeConfirmation, root)
+	spentProof := utxoDAG.OutputProof(genesisOutputID)
+	assert.False(t, spentProof.Included())
+	assert.True(t, spentProof.Verify(root))
+	utxoDAG.Output(NewOutputID(tx.ID(), 0)).Consume(func(output Output) {
+		assert.True(t, utxoDAG.OutputProof(output.ID()).VerifyOutput(root, output))
+	})
+}
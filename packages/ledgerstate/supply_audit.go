@@ -0,0 +1,138 @@
+package ledgerstate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iotaledger/hive.go/stringify"
+	"golang.org/x/crypto/blake2b"
+)
+
+// region SupplyAudit //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SupplyAudit contains the result of an audit of the token supply of the confirmed ledger state. Since Transactions can
+// neither create nor destroy tokens, the total supply always has to equal the supply of the genesis. Colored tokens can
+// be minted from and uncolored back into IOTA, so the supply of a Color must never exceed the sum of its genesis supply
+// and the amount of tokens that were minted with it.
+type SupplyAudit struct {
+	// Time contains the time when the audit was performed.
+	Time time.Time
+
+	// Supply contains the total balance per Color of the confirmed unspent Outputs.
+	Supply map[Color]uint64
+
+	// GenesisSupply contains the total balance per Color of the Outputs that were loaded from a snapshot.
+	GenesisSupply map[Color]uint64
+
+	// MintedSupply contains the amount of tokens per Color that were minted by confirmed Transactions.
+	MintedSupply map[Color]uint64
+}
+
+// NewSupplyAudit creates an empty SupplyAudit.
+func NewSupplyAudit() *SupplyAudit {
+	return &SupplyAudit{
+		Time:          time.Now(),
+		Supply:        make(map[Color]uint64),
+		GenesisSupply: make(map[Color]uint64),
+		MintedSupply:  make(map[Color]uint64),
+	}
+}
+
+// TotalSupply returns the sum of the balances of all Colors of the confirmed unspent Outputs.
+func (s *SupplyAudit) TotalSupply() uint64 {
+	return sumOfBalances(s.Supply)
+}
+
+// ExpectedTotalSupply returns the sum of the balances of all Colors of the genesis.
+func (s *SupplyAudit) ExpectedTotalSupply() uint64 {
+	return sumOfBalances(s.GenesisSupply)
+}
+
+// DivergentColors returns the Colors whose supply exceeds the sum of their genesis supply and the amount of tokens that
+// were minted with them.
+func (s *SupplyAudit) DivergentColors() (divergentColors []Color) {
+	divergentColors = make([]Color, 0)
+	for color, supply := range s.Supply {
+		if color != ColorIOTA && supply > s.GenesisSupply[color]+s.MintedSupply[color] {
+			divergentColors = append(divergentColors, color)
+		}
+	}
+
+	return
+}
+
+// Divergent returns true if the audited supply violates the invariants of the ledger.
+func (s *SupplyAudit) Divergent() bool {
+	return s.TotalSupply() != s.ExpectedTotalSupply() || len(s.DivergentColors()) != 0
+}
+
+// String returns a human readable version of the SupplyAudit.
+func (s *SupplyAudit) String() string {
+	structBuilder := stringify.StructBuilder("SupplyAudit",
+		stringify.StructField("time", s.Time),
+		stringify.StructField("totalSupply", s.TotalSupply()),
+		stringify.StructField("expectedTotalSupply", s.ExpectedTotalSupply()),
+	)
+	for color, supply := range s.Supply {
+		structBuilder.AddField(stringify.StructField(color.String(), fmt.Sprintf("%d (genesis: %d, minted: %d)", supply, s.GenesisSupply[color], s.MintedSupply[color])))
+	}
+
+	return structBuilder.String()
+}
+
+// sumOfBalances is an internal utility function that returns the sum of the given balances.
+func sumOfBalances(balances map[Color]uint64) (sum uint64) {
+	for _, balance := range balances {
+		sum += balance
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region UTXODAG API //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// AuditSupply walks all Outputs of the confirmed Transactions (in the MasterBranch or in confirmed Branches) and sums up
+// their balances. Outputs whose Transaction is not stored were loaded from a snapshot and make up the genesis supply.
+// Outputs of pending or rejected Branches are ignored.
+func (u *UTXODAG) AuditSupply() (supplyAudit *SupplyAudit) {
+	supplyAudit = NewSupplyAudit()
+	u.ForEachOutput(func(output Output) bool {
+		transactionID := output.ID().TransactionID()
+		if inclusionState, err := u.InclusionState(transactionID); err != nil || inclusionState != Confirmed {
+			return true
+		}
+
+		if !u.transactionStorage.Contains(transactionID.Bytes()) {
+			output.Balances().ForEach(func(color Color, balance uint64) bool {
+				supplyAudit.GenesisSupply[color] += balance
+				return true
+			})
+		} else {
+			// minted tokens receive the hash of the OutputID as their Color when the Output is booked
+			mintedColor := Color(blake2b.Sum256(output.ID().Bytes()))
+			if mintedBalance, minted := output.Balances().Get(mintedColor); minted {
+				supplyAudit.MintedSupply[mintedColor] += mintedBalance
+			}
+
+			// assets with a mintable SupplyPolicy can be re-minted by the Transactions that continue their AssetOutput
+			if assetOutput, isAssetOutput := output.(*AssetOutput); isAssetOutput && assetOutput.AssetColor() != mintedColor {
+				supplyAudit.MintedSupply[assetOutput.AssetColor()] += u.remintedSupply(assetOutput)
+			}
+		}
+
+		if !u.outputSpentByConfirmedTransaction(output.ID()) {
+			output.Balances().ForEach(func(color Color, balance uint64) bool {
+				supplyAudit.Supply[color] += balance
+				return true
+			})
+		}
+
+		return true
+	})
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,67 @@
+package ledgerstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUTXODAG_AuditSupply(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(2)
+	genesisOutput := NewSigLockedSingleOutput(100, wallets[0].address)
+	genesisOutput.SetID(NewOutputID(GenesisTransactionID, 1))
+	genesisOutputMetadata := NewOutputMetadata(genesisOutput.ID())
+	genesisOutputMetadata.SetBranchID(MasterBranchID)
+	genesisOutputMetadata.SetSolid(true)
+	genesisOutputMetadata.SetFinalized(true)
+	require.NoError(t, utxoDAG.LoadSnapshotOutput(genesisOutput, genesisOutputMetadata))
+
+	supplyAudit := utxoDAG.AuditSupply()
+	assert.Equal(t, map[Color]uint64{ColorIOTA: 100}, supplyAudit.Supply)
+	assert.Equal(t, map[Color]uint64{ColorIOTA: 100}, supplyAudit.GenesisSupply)
+	assert.False(t, supplyAudit.Divergent())
+
+	// mint 40 colored tokens
+	tx := aliasTransaction([]Output{genesisOutput}, []Output{
+		NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{ColorMint: 40}), wallets[1].address),
+		NewSigLockedSingleOutput(60, wallets[0].address),
+	}, func(txEssence *TransactionEssence, _ Output) UnlockBlock {
+		return NewSignatureUnlockBlock(wallets[0].sign(txEssence))
+	})
+	_, err := utxoDAG.BookTransaction(tx)
+	require.NoError(t, err)
+
+	// pending transactions are not audited
+	assert.Equal(t, map[Color]uint64{ColorIOTA: 100}, utxoDAG.AuditSupply().Supply)
+
+	utxoDAG.TransactionMetadata(tx.ID()).Consume(func(transactionMetadata *TransactionMetadata) {
+		transactionMetadata.SetFinalized(true)
+	})
+	supplyAudit = utxoDAG.AuditSupply()
+	require.Len(t, supplyAudit.MintedSupply, 1)
+	var mintedColor Color
+	for color, mintedSupply := range supplyAudit.MintedSupply {
+		mintedColor = color
+		assert.Equal(t, uint64(40), mintedSupply)
+	}
+	assert.Equal(t, map[Color]uint64{ColorIOTA: 60, mintedColor: 40}, supplyAudit.Supply)
+	assert.Equal(t, uint64(100), supplyAudit.TotalSupply())
+	assert.False(t, supplyAudit.Divergent())
+
+	// an Output that was created out of thin air makes the supply diverge
+	forgedOutput := NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{mintedColor: 10}), wallets[1].address)
+	forgedOutput.SetID(NewOutputID(tx.ID(), 2))
+	cachedOutput, stored := utxoDAG.outputStorage.StoreIfAbsent(forgedOutput)
+	require.True(t, stored)
+	cachedOutput.Release()
+
+	supplyAudit = utxoDAG.AuditSupply()
+	assert.True(t, supplyAudit.Divergent())
+	assert.Equal(t, uint64(110), supplyAudit.TotalSupply())
+	assert.Equal(t, uint64(100), supplyAudit.ExpectedTotalSupply())
+	assert.Equal(t, []Color{mintedColor}, supplyAudit.DivergentColors())
+}
@@ -1,12 +1,22 @@
 package ledgerstate
 
 import (
+	"bytes"
+	"sort"
 	"strconv"
+	"sync"
 
 	"github.com/iotaledger/hive.go/byteutils"
 	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/crypto/bls"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/iotaledger/hive.go/stringify"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/pairing/bn256"
+	"go.dedis.ch/kyber/v3/sign"
+	"go.dedis.ch/kyber/v3/sign/bdn"
+	"golang.org/x/crypto/blake2b"
 	"golang.org/x/xerrors"
 )
 
@@ -18,6 +28,15 @@ const (
 
 	// ReferenceUnlockBlockType represents the type of a ReferenceUnlockBlock.
 	ReferenceUnlockBlockType
+
+	// AliasUnlockBlockType represents the type of an AliasUnlockBlock.
+	AliasUnlockBlockType
+
+	// MultiSignatureUnlockBlockType represents the type of a MultiSignatureUnlockBlock.
+	MultiSignatureUnlockBlockType
+
+	// AggregatedBLSUnlockBlockType represents the type of an AggregatedBLSUnlockBlock.
+	AggregatedBLSUnlockBlockType
 )
 
 // UnlockBlockType represents the type of the UnlockBlock. Different types of UnlockBlocks can unlock different types of
@@ -29,6 +48,9 @@ func (a UnlockBlockType) String() string {
 	return [...]string{
 		"SignatureUnlockBlockType",
 		"ReferenceUnlockBlockType",
+		"AliasUnlockBlockType",
+		"MultiSignatureUnlockBlockType",
+		"AggregatedBLSUnlockBlockType",
 	}[a]
 }
 
@@ -81,6 +103,21 @@ func UnlockBlockFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (unlockBlo
 			err = xerrors.Errorf("failed to parse ReferenceUnlockBlock from MarshalUtil: %w", err)
 			return
 		}
+	case AliasUnlockBlockType:
+		if unlockBlock, err = AliasUnlockBlockFromMarshalUtil(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse AliasUnlockBlock from MarshalUtil: %w", err)
+			return
+		}
+	case MultiSignatureUnlockBlockType:
+		if unlockBlock, err = MultiSignatureUnlockBlockFromMarshalUtil(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse MultiSignatureUnlockBlock from MarshalUtil: %w", err)
+			return
+		}
+	case AggregatedBLSUnlockBlockType:
+		if unlockBlock, err = AggregatedBLSUnlockBlockFromMarshalUtil(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse AggregatedBLSUnlockBlock from MarshalUtil: %w", err)
+			return
+		}
 	default:
 		err = xerrors.Errorf("unsupported UnlockBlockType (%X): %w", unlockBlockType, cerrors.ErrParseBytesFailed)
 		return
@@ -299,3 +336,550 @@ func (r *ReferenceUnlockBlock) String() string {
 var _ UnlockBlock = &ReferenceUnlockBlock{}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AliasUnlockBlock /////////////////////////////////////////////////////////////////////////////////////////////
+
+// AliasUnlockBlock defines an UnlockBlock which unlocks an Output that is locked by an AliasAddress. It references the
+// Input that consumes the AliasOutput of the same alias (which has to be state transitioned by the same Transaction).
+type AliasUnlockBlock struct {
+	referencedIndex uint16
+}
+
+// NewAliasUnlockBlock is the constructor for AliasUnlockBlocks.
+func NewAliasUnlockBlock(referencedIndex uint16) *AliasUnlockBlock {
+	return &AliasUnlockBlock{
+		referencedIndex: referencedIndex,
+	}
+}
+
+// AliasUnlockBlockFromBytes unmarshals an AliasUnlockBlock from a sequence of bytes.
+func AliasUnlockBlockFromBytes(bytes []byte) (unlockBlock *AliasUnlockBlock, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if unlockBlock, err = AliasUnlockBlockFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse AliasUnlockBlock from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AliasUnlockBlockFromMarshalUtil unmarshals an AliasUnlockBlock using a MarshalUtil (for easier unmarshaling).
+func AliasUnlockBlockFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (unlockBlock *AliasUnlockBlock, err error) {
+	unlockBlockType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse UnlockBlockType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if UnlockBlockType(unlockBlockType) != AliasUnlockBlockType {
+		err = xerrors.Errorf("invalid UnlockBlockType (%X): %w", unlockBlockType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	unlockBlock = &AliasUnlockBlock{}
+	if unlockBlock.referencedIndex, err = marshalUtil.ReadUint16(); err != nil {
+		err = xerrors.Errorf("failed to parse referencedIndex (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	return
+}
+
+// AliasInputIndex returns the index of the Input that consumes the AliasOutput.
+func (a *AliasUnlockBlock) AliasInputIndex() uint16 {
+	return a.referencedIndex
+}
+
+// Type returns the UnlockBlockType of the UnlockBlock.
+func (a *AliasUnlockBlock) Type() UnlockBlockType {
+	return AliasUnlockBlockType
+}
+
+// Bytes returns a marshaled version of the UnlockBlock.
+func (a *AliasUnlockBlock) Bytes() []byte {
+	return marshalutil.New(1 + marshalutil.Uint16Size).
+		WriteByte(byte(AliasUnlockBlockType)).
+		WriteUint16(a.referencedIndex).
+		Bytes()
+}
+
+// String returns a human readable version of the UnlockBlock.
+func (a *AliasUnlockBlock) String() string {
+	return stringify.Struct("AliasUnlockBlock",
+		stringify.StructField("referencedIndex", int(a.referencedIndex)),
+	)
+}
+
+// code contract (make sure the type implements all required methods)
+var _ UnlockBlock = &AliasUnlockBlock{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region MultiSignatureUnlockBlock ////////////////////////////////////////////////////////////////////////////////////
+
+// MultiSignatureUnlockBlock defines an UnlockBlock which unlocks an Output that is locked by a ThresholdAddress. It
+// reveals the threshold and the public keys that the ThresholdAddress commits to and carries exactly threshold many
+// signatures of distinct keys.
+type MultiSignatureUnlockBlock struct {
+	threshold  uint8
+	publicKeys []ed25519.PublicKey
+	signatures []*keySignature
+}
+
+// keySignature is an internal utility type that associates a signature with the index of the public key that created it.
+type keySignature struct {
+	keyIndex  uint8
+	signature ed25519.Signature
+}
+
+// NewMultiSignatureUnlockBlock is the constructor for MultiSignatureUnlockBlocks. The signatures are added by the
+// co-signers using AddSignature.
+func NewMultiSignatureUnlockBlock(threshold uint8, publicKeys ...ed25519.PublicKey) *MultiSignatureUnlockBlock {
+	return &MultiSignatureUnlockBlock{
+		threshold:  threshold,
+		publicKeys: SortThresholdPublicKeys(publicKeys),
+		signatures: make([]*keySignature, 0),
+	}
+}
+
+// MultiSignatureUnlockBlockFromBytes unmarshals a MultiSignatureUnlockBlock from a sequence of bytes.
+func MultiSignatureUnlockBlockFromBytes(bytes []byte) (unlockBlock *MultiSignatureUnlockBlock, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if unlockBlock, err = MultiSignatureUnlockBlockFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse MultiSignatureUnlockBlock from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// MultiSignatureUnlockBlockFromMarshalUtil unmarshals a MultiSignatureUnlockBlock using a MarshalUtil (for easier
+// unmarshaling).
+func MultiSignatureUnlockBlockFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (unlockBlock *MultiSignatureUnlockBlock, err error) {
+	unlockBlockType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse UnlockBlockType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if UnlockBlockType(unlockBlockType) != MultiSignatureUnlockBlockType {
+		err = xerrors.Errorf("invalid UnlockBlockType (%X): %w", unlockBlockType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	unlockBlock = &MultiSignatureUnlockBlock{}
+	if unlockBlock.threshold, err = marshalUtil.ReadUint8(); err != nil {
+		err = xerrors.Errorf("failed to parse threshold (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	publicKeyCount, err := marshalUtil.ReadUint8()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse public key count (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	unlockBlock.publicKeys = make([]ed25519.PublicKey, publicKeyCount)
+	for i := range unlockBlock.publicKeys {
+		if unlockBlock.publicKeys[i], err = ed25519.ParsePublicKey(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse public key (%v): %w", err, cerrors.ErrParseBytesFailed)
+			return
+		}
+	}
+	if _, err = thresholdAddressDigest(unlockBlock.threshold, unlockBlock.publicKeys); err != nil {
+		err = xerrors.Errorf("invalid threshold parameters (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	signatureCount, err := marshalUtil.ReadUint8()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse signature count (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if signatureCount != unlockBlock.threshold {
+		err = xerrors.Errorf("signature count (%d) does not match threshold (%d): %w", signatureCount, unlockBlock.threshold, cerrors.ErrParseBytesFailed)
+		return
+	}
+	unlockBlock.signatures = make([]*keySignature, signatureCount)
+	for i := range unlockBlock.signatures {
+		signature := &keySignature{}
+		if signature.keyIndex, err = marshalUtil.ReadUint8(); err != nil {
+			err = xerrors.Errorf("failed to parse key index (%v): %w", err, cerrors.ErrParseBytesFailed)
+			return
+		}
+		if int(signature.keyIndex) >= len(unlockBlock.publicKeys) || (i > 0 && signature.keyIndex <= unlockBlock.signatures[i-1].keyIndex) {
+			err = xerrors.Errorf("key indexes must be unique, sorted and reference existing public keys: %w", cerrors.ErrParseBytesFailed)
+			return
+		}
+		if signature.signature, err = ed25519.ParseSignature(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse signature (%v): %w", err, cerrors.ErrParseBytesFailed)
+			return
+		}
+		unlockBlock.signatures[i] = signature
+	}
+
+	return
+}
+
+// Threshold returns the amount of signatures that are required to unlock the ThresholdAddress.
+func (m *MultiSignatureUnlockBlock) Threshold() uint8 {
+	return m.threshold
+}
+
+// PublicKeys returns the (sorted) public keys that the ThresholdAddress commits to.
+func (m *MultiSignatureUnlockBlock) PublicKeys() []ed25519.PublicKey {
+	return m.publicKeys
+}
+
+// Signatures returns the signatures that were added to the UnlockBlock so far (indexed by the public key that created
+// them).
+func (m *MultiSignatureUnlockBlock) Signatures() (signatures map[ed25519.PublicKey]ed25519.Signature) {
+	signatures = make(map[ed25519.PublicKey]ed25519.Signature, len(m.signatures))
+	for _, signature := range m.signatures {
+		signatures[m.publicKeys[signature.keyIndex]] = signature.signature
+	}
+
+	return
+}
+
+// Address returns the ThresholdAddress that is unlocked by the UnlockBlock.
+func (m *MultiSignatureUnlockBlock) Address() (address *ThresholdAddress, err error) {
+	digest, err := thresholdAddressDigest(m.threshold, m.publicKeys)
+	if err != nil {
+		return
+	}
+
+	return &ThresholdAddress{digest: digest}, nil
+}
+
+// AddSignature adds the signature of one of the co-signers to the UnlockBlock.
+func (m *MultiSignatureUnlockBlock) AddSignature(publicKey ed25519.PublicKey, signature ed25519.Signature) (err error) {
+	keyIndex := -1
+	for i, existingPublicKey := range m.publicKeys {
+		if existingPublicKey == publicKey {
+			keyIndex = i
+			break
+		}
+	}
+	if keyIndex == -1 {
+		return xerrors.Errorf("public key is not part of the ThresholdAddress: %w", ErrInvalidThresholdAddress)
+	}
+	if m.Complete() {
+		return xerrors.Errorf("UnlockBlock already carries the required %d signatures: %w", m.threshold, ErrInvalidThresholdAddress)
+	}
+
+	insertIndex := sort.Search(len(m.signatures), func(i int) bool {
+		return int(m.signatures[i].keyIndex) >= keyIndex
+	})
+	if insertIndex < len(m.signatures) && int(m.signatures[insertIndex].keyIndex) == keyIndex {
+		return xerrors.Errorf("UnlockBlock already carries a signature of the given public key: %w", ErrInvalidThresholdAddress)
+	}
+	m.signatures = append(m.signatures, nil)
+	copy(m.signatures[insertIndex+1:], m.signatures[insertIndex:])
+	m.signatures[insertIndex] = &keySignature{keyIndex: uint8(keyIndex), signature: signature}
+
+	return
+}
+
+// Complete returns true if the UnlockBlock carries the required amount of signatures.
+func (m *MultiSignatureUnlockBlock) Complete() bool {
+	return len(m.signatures) == int(m.threshold)
+}
+
+// AddressSignatureValid returns true if the UnlockBlock correctly signs the given Address.
+func (m *MultiSignatureUnlockBlock) AddressSignatureValid(address Address, signedData []byte) bool {
+	if address.Type() != ThresholdAddressType || !m.Complete() {
+		return false
+	}
+
+	digest, err := thresholdAddressDigest(m.threshold, m.publicKeys)
+	if err != nil || !bytes.Equal(digest, address.Digest()) {
+		return false
+	}
+
+	for _, signature := range m.signatures {
+		if !m.publicKeys[signature.keyIndex].VerifySignature(signedData, signature.signature) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Type returns the UnlockBlockType of the UnlockBlock.
+func (m *MultiSignatureUnlockBlock) Type() UnlockBlockType {
+	return MultiSignatureUnlockBlockType
+}
+
+// Bytes returns a marshaled version of the UnlockBlock.
+func (m *MultiSignatureUnlockBlock) Bytes() []byte {
+	marshalUtil := marshalutil.New().
+		WriteByte(byte(MultiSignatureUnlockBlockType)).
+		WriteUint8(m.threshold).
+		WriteUint8(uint8(len(m.publicKeys)))
+	for _, publicKey := range m.publicKeys {
+		marshalUtil.WriteBytes(publicKey.Bytes())
+	}
+	marshalUtil.WriteUint8(uint8(len(m.signatures)))
+	for _, signature := range m.signatures {
+		marshalUtil.WriteUint8(signature.keyIndex).WriteBytes(signature.signature.Bytes())
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// String returns a human readable version of the UnlockBlock.
+func (m *MultiSignatureUnlockBlock) String() string {
+	signatures := stringify.StructBuilder("Signatures")
+	for _, signature := range m.signatures {
+		signatures.AddField(stringify.StructField(strconv.Itoa(int(signature.keyIndex)), signature.signature))
+	}
+
+	return stringify.Struct("MultiSignatureUnlockBlock",
+		stringify.StructField("threshold", int(m.threshold)),
+		stringify.StructField("publicKeys", m.publicKeys),
+		stringify.StructField("signatures", signatures),
+	)
+}
+
+// code contract (make sure the type implements all required methods)
+var _ UnlockBlock = &MultiSignatureUnlockBlock{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AggregatedBLSUnlockBlock /////////////////////////////////////////////////////////////////////////////////////
+
+// blsSuite is the pairing suite that is used to verify aggregated BLS signatures.
+var blsSuite = bn256.NewSuite()
+
+// AggregatedBLSUnlockBlock defines an UnlockBlock which unlocks the Outputs of several BLSAddresses with a single
+// aggregated signature. It reveals the (sorted) public keys of the BLSAddresses and carries the aggregate of their
+// signatures of the TransactionEssence. Inputs of the same Transaction reference it with ReferenceUnlockBlocks.
+type AggregatedBLSUnlockBlock struct {
+	publicKeys []bls.PublicKey
+	signature  bls.Signature
+
+	verifiedData      []byte
+	verifiedSignature bool
+	verificationMutex sync.Mutex
+}
+
+// NewAggregatedBLSUnlockBlock is the constructor for AggregatedBLSUnlockBlocks. It aggregates the given signatures
+// which all need to sign the same data with distinct keys.
+func NewAggregatedBLSUnlockBlock(signatures ...bls.SignatureWithPublicKey) (unlockBlock *AggregatedBLSUnlockBlock, err error) {
+	if len(signatures) < 2 {
+		err = xerrors.Errorf("an AggregatedBLSUnlockBlock needs at least 2 signatures (use a SignatureUnlockBlock instead): %w", cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	sortedSignatures := make([]bls.SignatureWithPublicKey, len(signatures))
+	copy(sortedSignatures, signatures)
+	sort.Slice(sortedSignatures, func(i, j int) bool {
+		return bytes.Compare(sortedSignatures[i].PublicKey.Bytes(), sortedSignatures[j].PublicKey.Bytes()) < 0
+	})
+
+	unlockBlock = &AggregatedBLSUnlockBlock{
+		publicKeys: make([]bls.PublicKey, len(sortedSignatures)),
+	}
+	for i, signature := range sortedSignatures {
+		unlockBlock.publicKeys[i] = signature.PublicKey
+	}
+	if err = aggregatedBLSPublicKeysValid(unlockBlock.publicKeys); err != nil {
+		return nil, err
+	}
+
+	aggregatedSignature, err := bls.AggregateSignatures(sortedSignatures...)
+	if err != nil {
+		err = xerrors.Errorf("failed to aggregate signatures: %w", err)
+		return nil, err
+	}
+	unlockBlock.signature = aggregatedSignature.Signature
+
+	return
+}
+
+// AggregatedBLSUnlockBlockFromBytes unmarshals an AggregatedBLSUnlockBlock from a sequence of bytes.
+func AggregatedBLSUnlockBlockFromBytes(bytes []byte) (unlockBlock *AggregatedBLSUnlockBlock, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if unlockBlock, err = AggregatedBLSUnlockBlockFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse AggregatedBLSUnlockBlock from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AggregatedBLSUnlockBlockFromMarshalUtil unmarshals an AggregatedBLSUnlockBlock using a MarshalUtil (for easier
+// unmarshaling).
+func AggregatedBLSUnlockBlockFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (unlockBlock *AggregatedBLSUnlockBlock, err error) {
+	unlockBlockType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse UnlockBlockType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if UnlockBlockType(unlockBlockType) != AggregatedBLSUnlockBlockType {
+		err = xerrors.Errorf("invalid UnlockBlockType (%X): %w", unlockBlockType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	publicKeyCount, err := marshalUtil.ReadUint8()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse public key count (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	unlockBlock = &AggregatedBLSUnlockBlock{
+		publicKeys: make([]bls.PublicKey, publicKeyCount),
+	}
+	for i := range unlockBlock.publicKeys {
+		if unlockBlock.publicKeys[i], err = BLSPublicKeyFromMarshalUtil(marshalUtil); err != nil {
+			err = xerrors.Errorf("failed to parse public key (%v): %w", err, cerrors.ErrParseBytesFailed)
+			return
+		}
+	}
+	if err = aggregatedBLSPublicKeysValid(unlockBlock.publicKeys); err != nil {
+		return
+	}
+	if unlockBlock.signature, err = bls.SignatureFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse signature (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// PublicKeys returns the (sorted) public keys of the BLSAddresses that are unlocked by the UnlockBlock.
+func (a *AggregatedBLSUnlockBlock) PublicKeys() []bls.PublicKey {
+	return a.publicKeys
+}
+
+// Addresses returns the BLSAddresses that are unlocked by the UnlockBlock.
+func (a *AggregatedBLSUnlockBlock) Addresses() (addresses []*BLSAddress) {
+	addresses = make([]*BLSAddress, len(a.publicKeys))
+	for i, publicKey := range a.publicKeys {
+		addresses[i] = NewBLSAddress(publicKey.Bytes())
+	}
+
+	return
+}
+
+// Signature returns the aggregated signature of the UnlockBlock.
+func (a *AggregatedBLSUnlockBlock) Signature() bls.Signature {
+	return a.signature
+}
+
+// SignatureValid returns true if the aggregated signature signs the given data with all public keys. The result of the
+// (expensive) pairing check is cached, so the UnlockBlock is verified only once for all Inputs that it unlocks.
+func (a *AggregatedBLSUnlockBlock) SignatureValid(signedData []byte) bool {
+	a.verificationMutex.Lock()
+	defer a.verificationMutex.Unlock()
+
+	if a.verifiedData != nil && bytes.Equal(a.verifiedData, signedData) {
+		return a.verifiedSignature
+	}
+
+	a.verifiedData = signedData
+	a.verifiedSignature = false
+	aggregatedPublicKey, err := aggregateBLSPublicKeys(a.publicKeys)
+	if err == nil {
+		a.verifiedSignature = bdn.Verify(blsSuite, aggregatedPublicKey, signedData, a.signature.Bytes()) == nil
+	}
+
+	return a.verifiedSignature
+}
+
+// AddressSignatureValid returns true if the UnlockBlock correctly signs the given Address.
+func (a *AggregatedBLSUnlockBlock) AddressSignatureValid(address Address, signedData []byte) bool {
+	if address.Type() != BLSAddressType {
+		return false
+	}
+
+	for _, publicKey := range a.publicKeys {
+		hashedPublicKey := blake2b.Sum256(publicKey.Bytes())
+		if bytes.Equal(hashedPublicKey[:], address.Digest()) {
+			return a.SignatureValid(signedData)
+		}
+	}
+
+	return false
+}
+
+// Type returns the UnlockBlockType of the UnlockBlock.
+func (a *AggregatedBLSUnlockBlock) Type() UnlockBlockType {
+	return AggregatedBLSUnlockBlockType
+}
+
+// Bytes returns a marshaled version of the UnlockBlock.
+func (a *AggregatedBLSUnlockBlock) Bytes() []byte {
+	marshalUtil := marshalutil.New(1 + marshalutil.Uint8Size + len(a.publicKeys)*bls.PublicKeySize + bls.SignatureSize).
+		WriteByte(byte(AggregatedBLSUnlockBlockType)).
+		WriteUint8(uint8(len(a.publicKeys)))
+	for _, publicKey := range a.publicKeys {
+		marshalUtil.WriteBytes(publicKey.Bytes())
+	}
+
+	return marshalUtil.WriteBytes(a.signature.Bytes()).Bytes()
+}
+
+// String returns a human readable version of the UnlockBlock.
+func (a *AggregatedBLSUnlockBlock) String() string {
+	return stringify.Struct("AggregatedBLSUnlockBlock",
+		stringify.StructField("publicKeys", a.publicKeys),
+		stringify.StructField("signature", a.signature),
+	)
+}
+
+// BLSPublicKeyFromMarshalUtil unmarshals a BLS public key using a MarshalUtil (the point needs to be initialized with
+// the group of the suite before it can be unmarshaled).
+func BLSPublicKeyFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (publicKey bls.PublicKey, err error) {
+	publicKeyBytes, err := marshalUtil.ReadBytes(bls.PublicKeySize)
+	if err != nil {
+		err = xerrors.Errorf("failed to read PublicKey bytes (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	publicKey.Point = blsSuite.G2().Point()
+	if err = publicKey.Point.UnmarshalBinary(publicKeyBytes); err != nil {
+		err = xerrors.Errorf("failed to unmarshal PublicKey (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// aggregatedBLSPublicKeysValid is an internal utility function that checks that there are at least two public keys and
+// that they are sorted and unique (which makes the aggregation deterministic).
+func aggregatedBLSPublicKeysValid(publicKeys []bls.PublicKey) (err error) {
+	if len(publicKeys) < 2 {
+		return xerrors.Errorf("an AggregatedBLSUnlockBlock needs at least 2 public keys: %w", cerrors.ErrParseBytesFailed)
+	}
+	for i := 1; i < len(publicKeys); i++ {
+		if bytes.Compare(publicKeys[i-1].Bytes(), publicKeys[i].Bytes()) >= 0 {
+			return xerrors.Errorf("public keys of an AggregatedBLSUnlockBlock must be sorted and unique: %w", cerrors.ErrParseBytesFailed)
+		}
+	}
+
+	return
+}
+
+// aggregateBLSPublicKeys is an internal utility function that computes the aggregated public key that verifies the
+// aggregated signature of the given public keys (the coefficients protect against rogue public key attacks).
+func aggregateBLSPublicKeys(publicKeys []bls.PublicKey) (aggregatedPublicKey kyber.Point, err error) {
+	points := make([]kyber.Point, len(publicKeys))
+	for i, publicKey := range publicKeys {
+		points[i] = publicKey.Point
+	}
+
+	mask, err := sign.NewMask(blsSuite, points, nil)
+	if err != nil {
+		return
+	}
+	for i := range points {
+		if err = mask.SetBit(i, true); err != nil {
+			return
+		}
+	}
+
+	return bdn.AggregatePublicKeys(blsSuite, mask)
+}
+
+// code contract (make sure the type implements all required methods)
+var _ UnlockBlock = &AggregatedBLSUnlockBlock{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -33,12 +33,20 @@ type UTXODAG struct {
 	outputMetadataStorage       *objectstorage.ObjectStorage
 	consumerStorage             *objectstorage.ObjectStorage
 	addressOutputMappingStorage *objectstorage.ObjectStorage
+	addressHistoryStorage       *objectstorage.ObjectStorage
+	assetOutputMappingStorage   *objectstorage.ObjectStorage
+	stateTree                   *StateTree
 	branchDAG                   *BranchDAG
+	dustProtectionParams        DustProtectionParams
 	shutdownOnce                sync.Once
 }
 
+// UTXODAGOption represents the return type of the optional parameters that can be handed into the constructor of the
+// UTXODAG.
+type UTXODAGOption func(utxoDAG *UTXODAG)
+
 // NewUTXODAG create a new UTXODAG from the given details.
-func NewUTXODAG(store kvstore.KVStore, branchDAG *BranchDAG) (utxoDAG *UTXODAG) {
+func NewUTXODAG(store kvstore.KVStore, branchDAG *BranchDAG, options ...UTXODAGOption) (utxoDAG *UTXODAG) {
 	osFactory := objectstorage.NewFactory(store, database.PrefixLedgerState)
 	utxoDAG = &UTXODAG{
 		Events: &UTXODAGEvents{
@@ -50,8 +58,16 @@ func NewUTXODAG(store kvstore.KVStore, branchDAG *BranchDAG) (utxoDAG *UTXODAG)
 		outputMetadataStorage:       osFactory.New(PrefixOutputMetadataStorage, OutputMetadataFromObjectStorage, outputMetadataStorageOptions...),
 		consumerStorage:             osFactory.New(PrefixConsumerStorage, ConsumerFromObjectStorage, consumerStorageOptions...),
 		addressOutputMappingStorage: osFactory.New(PrefixAddressOutputMappingStorage, AddressOutputMappingFromObjectStorage, addressOutputMappingStorageOptions...),
+		addressHistoryStorage:       osFactory.New(PrefixAddressHistoryStorage, AddressHistoryEntryFromObjectStorage, addressHistoryStorageOptions...),
+		assetOutputMappingStorage:   osFactory.New(PrefixAssetOutputMappingStorage, AssetOutputMappingFromObjectStorage, assetOutputMappingStorageOptions...),
+		stateTree:                   NewStateTree(store.WithRealm([]byte{database.PrefixLedgerState, PrefixStateTreeStorage})),
 		branchDAG:                   branchDAG,
 	}
+	for _, option := range options {
+		option(utxoDAG)
+	}
+	branchDAG.Events.BranchRejected.Attach(events.NewClosure(utxoDAG.onBranchRejected))
+
 	return
 }
 
@@ -64,26 +80,45 @@ func (u *UTXODAG) Shutdown() {
 		u.outputMetadataStorage.Shutdown()
 		u.consumerStorage.Shutdown()
 		u.addressOutputMappingStorage.Shutdown()
+		u.addressHistoryStorage.Shutdown()
+		u.assetOutputMappingStorage.Shutdown()
 	})
 }
 
-// CheckTransaction contains fast checks that have to be performed before booking a Transaction.
+// CheckTransaction contains fast checks that have to be performed before booking a Transaction. The returned error
+// contains a TransactionValidationError that describes the failure in detail.
 func (u *UTXODAG) CheckTransaction(transaction *Transaction) (valid bool, err error) {
 	cachedConsumedOutputs := u.consumedOutputs(transaction)
 	defer cachedConsumedOutputs.Release()
 	consumedOutputs := cachedConsumedOutputs.Unwrap()
 
 	// perform cheap checks
-	if !u.allOutputsExist(consumedOutputs) {
-		err = xerrors.Errorf("not all consumedOutputs of transaction are solid: %w", ErrTransactionNotSolid)
+	if err = u.allOutputsExist(consumedOutputs); err != nil {
+		err = xerrors.Errorf("not all consumedOutputs of transaction are solid: %w", err)
+		return
+	}
+	if err = u.transactionBalancesValid(consumedOutputs, transaction.Essence().Outputs()); err != nil {
+		err = xerrors.Errorf("sum of consumed and spent balances is not 0: %w", err)
+		return
+	}
+	if err = u.assetBurnsValid(consumedOutputs, transaction.Essence().Outputs()); err != nil {
+		err = xerrors.Errorf("transaction burns tokens of an asset that can not be burned: %w", err)
+		return
+	}
+	if err = u.minimumOutputAmountsValid(transaction.Essence().Outputs()); err != nil {
+		err = xerrors.Errorf("created outputs violate the dust protection: %w", err)
 		return
 	}
-	if !u.transactionBalancesValid(consumedOutputs, transaction.Essence().Outputs()) {
-		err = xerrors.Errorf("sum of consumed and spent balances is not 0: %w", ErrTransactionInvalid)
+	if err = u.aliasChainsValid(consumedOutputs, transaction.Essence().Outputs()); err != nil {
+		err = xerrors.Errorf("AliasOutputs of transaction violate the chain constraint: %w", err)
 		return
 	}
-	if !u.unlockBlocksValid(consumedOutputs, transaction) {
-		err = xerrors.Errorf("spending of referenced consumedOutputs is not authorized: %w", ErrTransactionInvalid)
+	if err = u.assetChainsValid(consumedOutputs, transaction.Essence().Outputs()); err != nil {
+		err = xerrors.Errorf("AssetOutputs of transaction violate the chain constraint: %w", err)
+		return
+	}
+	if err = u.unlockBlocksValid(consumedOutputs, transaction); err != nil {
+		err = xerrors.Errorf("spending of referenced consumedOutputs is not authorized: %w", err)
 		return
 	}
 
@@ -91,6 +126,26 @@ func (u *UTXODAG) CheckTransaction(transaction *Transaction) (valid bool, err er
 	return
 }
 
+// CheckTransactionPastCone checks if any of the Inputs of the given Transaction is spent in the past cone of another
+// Input of the same Transaction (which would make the Transaction permanently invalid when it gets booked).
+func (u *UTXODAG) CheckTransactionPastCone(transaction *Transaction) (err error) {
+	cachedConsumedOutputs := u.consumedOutputs(transaction)
+	defer cachedConsumedOutputs.Release()
+	consumedOutputs := cachedConsumedOutputs.Unwrap()
+	if err = u.allOutputsExist(consumedOutputs); err != nil {
+		return xerrors.Errorf("not all consumedOutputs of transaction are solid: %w", err)
+	}
+
+	cachedInputsMetadata := u.transactionInputsMetadata(transaction)
+	defer cachedInputsMetadata.Release()
+
+	if err = u.consumedOutputsPastConeValid(consumedOutputs, cachedInputsMetadata.Unwrap()); err != nil {
+		err = xerrors.Errorf("consumedOutputs of transaction reference each other: %w", err)
+	}
+
+	return
+}
+
 // BookTransaction books a Transaction into the ledger state.
 func (u *UTXODAG) BookTransaction(transaction *Transaction) (targetBranch BranchID, err error) {
 	cachedConsumedOutputs := u.consumedOutputs(transaction)
@@ -119,6 +174,13 @@ func (u *UTXODAG) BookTransaction(transaction *Transaction) (targetBranch Branch
 	}
 	defer cachedTransactionMetadata.Release()
 
+	// store the AddressHistoryEntries after the Transaction was booked successfully
+	defer func() {
+		if err == nil && targetBranch != InvalidBranchID {
+			u.storeAddressHistory(transaction, consumedOutputs, targetBranch)
+		}
+	}()
+
 	// store Transaction
 	u.transactionStorage.Store(transaction).Release()
 
@@ -151,7 +213,7 @@ func (u *UTXODAG) BookTransaction(transaction *Transaction) (targetBranch Branch
 	}
 
 	// mark transaction as "permanently rejected"
-	if !u.consumedOutputsPastConeValid(consumedOutputs, inputsMetadata) {
+	if u.consumedOutputsPastConeValid(consumedOutputs, inputsMetadata) != nil {
 		u.bookInvalidTransaction(transaction, transactionMetadata, inputsMetadata)
 		targetBranch = InvalidBranchID
 		return
@@ -246,16 +308,24 @@ func (u *UTXODAG) Consumers(outputID OutputID) (cachedConsumers CachedConsumers)
 	return
 }
 
+// ForEachOutput iterates through all the Outputs that are stored in the UTXODAG and calls the consumer for each of them
+// until it returns false.
+func (u *UTXODAG) ForEachOutput(consumer func(output Output) bool) {
+	u.outputStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		continueIteration := true
+		(&CachedOutput{CachedObject: cachedObject}).Consume(func(output Output) {
+			continueIteration = consumer(output)
+		})
+
+		return continueIteration
+	})
+}
+
 // LoadSnapshot creates a set of outputs in the UTXO-DAG, that are forming the genesis for future transactions.
 func (u *UTXODAG) LoadSnapshot(snapshot map[TransactionID]map[Address]*ColoredBalances) {
 	index := uint16(0)
-	fmt.Println("Loading snapshot...")
 	for transactionID, addressBalance := range snapshot {
-		fmt.Println("TransactionID: ", transactionID.Base58())
 		for address, balance := range addressBalance {
-			fmt.Println("Address: ", address)
-			fmt.Println("Address Base58: ", address.Base58())
-			fmt.Println("Balance: ", balance)
 			output := NewSigLockedColoredOutput(balance, address)
 			output.SetID(NewOutputID(transactionID, index))
 			cachedOutput, stored := u.outputStorage.StoreIfAbsent(output)
@@ -266,6 +336,9 @@ func (u *UTXODAG) LoadSnapshot(snapshot map[TransactionID]map[Address]*ColoredBa
 			//store addressOutputMapping
 			u.StoreAddressOutputMapping(address, output.ID())
 
+			// the Outputs of the snapshot are confirmed
+			u.stateTree.Add(output)
+
 			// store OutputMetadata
 			metadata := NewOutputMetadata(output.ID())
 			metadata.SetBranchID(MasterBranchID)
@@ -293,6 +366,43 @@ func (u *UTXODAG) LoadSnapshot(snapshot map[TransactionID]map[Address]*ColoredBa
 	}
 }
 
+// LoadSnapshotOutput stores a confirmed Output of a snapshot together with its OutputMetadata in the UTXO-DAG. The
+// Transaction that created the Output is considered to be confirmed.
+func (u *UTXODAG) LoadSnapshotOutput(output Output, outputMetadata *OutputMetadata) (err error) {
+	if output.ID() != outputMetadata.ID() {
+		err = xerrors.Errorf("OutputMetadata with %s does not belong to Output with %s: %w", outputMetadata.ID(), output.ID(), cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	if cachedOutput, stored := u.outputStorage.StoreIfAbsent(output); stored {
+		cachedOutput.Release()
+	}
+	if cachedOutputMetadata, stored := u.outputMetadataStorage.StoreIfAbsent(outputMetadata); stored {
+		cachedOutputMetadata.Release()
+	}
+
+	u.StoreAddressOutputMapping(output.Address(), output.ID())
+	if extendedLockedOutput, isExtendedLockedOutput := output.(*ExtendedLockedOutput); isExtendedLockedOutput && extendedLockedOutput.FallbackAddress() != nil {
+		u.StoreAddressOutputMapping(extendedLockedOutput.FallbackAddress(), output.ID())
+	}
+
+	transactionID := output.ID().TransactionID()
+	(&CachedTransactionMetadata{CachedObject: u.transactionMetadataStorage.ComputeIfAbsent(transactionID.Bytes(), func(key []byte) objectstorage.StorableObject {
+		transactionMetadata := NewTransactionMetadata(transactionID)
+		transactionMetadata.SetSolid(true)
+		transactionMetadata.SetBranchID(MasterBranchID)
+		transactionMetadata.SetFinalized(true)
+		transactionMetadata.Persist()
+		transactionMetadata.SetModified()
+
+		return transactionMetadata
+	})}).Release()
+
+	u.stateTree.Add(output)
+
+	return
+}
+
 // AddressOutputMapping retrieves the outputs for the given address.
 func (u *UTXODAG) AddressOutputMapping(address Address) (cachedAddressOutputMappings CachedAddressOutputMappings) {
 	u.addressOutputMappingStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
@@ -302,6 +412,146 @@ func (u *UTXODAG) AddressOutputMapping(address Address) (cachedAddressOutputMapp
 	return
 }
 
+// StateRoot returns the MerkleRoot of the StateTree which commits to the confirmed unspent Outputs of the ledger.
+func (u *UTXODAG) StateRoot() MerkleRoot {
+	return u.stateTree.Root()
+}
+
+// OutputProof returns an OutputProof that proves the inclusion (or the non-inclusion) of the Output with the given
+// OutputID in the confirmed unspent Outputs of the ledger.
+func (u *UTXODAG) OutputProof(outputID OutputID) *OutputProof {
+	return u.stateTree.Proof(outputID)
+}
+
+// TransactionsConfirmedByBranch returns the finalized Transactions that are booked into the given Branch and that
+// therefore became confirmed when the Branch got confirmed. The future cone of the ConflictBranches that the Branch
+// consists of is walked as long as it stays confirmed.
+func (u *UTXODAG) TransactionsConfirmedByBranch(branchID BranchID) (confirmedTransactions TransactionIDs) {
+	confirmedTransactions = make(TransactionIDs)
+
+	conflictBranchIDs := NewBranchIDs(branchID)
+	u.branchDAG.Branch(branchID).Consume(func(branch Branch) {
+		if branch.Type() == AggregatedBranchType {
+			conflictBranchIDs = branch.Parents()
+		}
+	})
+
+	// collectConfirmedTransaction adds the given Transaction if it is booked into the Branch and returns its Outputs if
+	// its future cone can still contain Transactions of the Branch.
+	collectConfirmedTransaction := func(transactionID TransactionID) (nextOutputsToVisit []OutputID) {
+		u.TransactionMetadata(transactionID).Consume(func(transactionMetadata *TransactionMetadata) {
+			if transactionMetadata.BranchID() == branchID && transactionMetadata.Finalized() {
+				confirmedTransactions[transactionID] = types.Void
+			}
+
+			u.branchDAG.Branch(transactionMetadata.BranchID()).Consume(func(branch Branch) {
+				if branch.InclusionState() == Confirmed {
+					nextOutputsToVisit = u.createdOutputIDsOfTransaction(transactionID)
+				}
+			})
+		})
+
+		return
+	}
+
+	entryPoints := make([]OutputID, 0)
+	for conflictBranchID := range conflictBranchIDs {
+		entryPoints = append(entryPoints, collectConfirmedTransaction(TransactionID(conflictBranchID))...)
+	}
+	u.walkFutureCone(entryPoints, collectConfirmedTransaction)
+
+	return
+}
+
+// CommitConfirmedTransaction updates the StateTree after the Transaction with the given TransactionID was confirmed.
+// The consumed Outputs are removed and the created Outputs that were not spent by a confirmed Transaction, yet, are
+// added.
+func (u *UTXODAG) CommitConfirmedTransaction(transactionID TransactionID) (err error) {
+	inclusionState, err := u.InclusionState(transactionID)
+	if err != nil {
+		err = xerrors.Errorf("failed to determine InclusionState of Transaction with %s: %w", transactionID, err)
+		return
+	}
+	if inclusionState != Confirmed {
+		err = xerrors.Errorf("Transaction with %s is not confirmed: %w", transactionID, ErrInvalidStateTransition)
+		return
+	}
+
+	for _, consumedOutputID := range u.consumedOutputIDsOfTransaction(transactionID) {
+		u.stateTree.Remove(consumedOutputID)
+	}
+
+	for _, createdOutputID := range u.createdOutputIDsOfTransaction(transactionID) {
+		if u.outputSpentByConfirmedTransaction(createdOutputID) {
+			continue
+		}
+
+		if !u.Output(createdOutputID).Consume(func(output Output) {
+			u.stateTree.Add(output)
+		}) {
+			err = xerrors.Errorf("failed to load Output with %s: %w", createdOutputID, cerrors.ErrFatal)
+			return
+		}
+	}
+
+	return
+}
+
+// MoveBranches rewrites the BranchIDs of the given Transaction and its future cone (and their Outputs) according to the
+// given mapping of old to new BranchIDs that is returned by BranchDAG.MergeToMaster or BranchDAG.RemovableBranches.
+// The walk stops at Transactions that are not affected by the mapping. It returns the Transactions that were moved.
+func (u *UTXODAG) MoveBranches(transactionID TransactionID, movedBranches map[BranchID]BranchID) (movedTransactions TransactionIDs) {
+	movedTransactions = make(TransactionIDs)
+	if !u.moveBranchOfTransaction(transactionID, movedBranches) {
+		return
+	}
+	movedTransactions[transactionID] = types.Void
+
+	u.walkFutureCone(u.createdOutputIDsOfTransaction(transactionID), func(transactionID TransactionID) (nextOutputsToVisit []OutputID) {
+		if !u.moveBranchOfTransaction(transactionID, movedBranches) {
+			return
+		}
+		movedTransactions[transactionID] = types.Void
+
+		return u.createdOutputIDsOfTransaction(transactionID)
+	})
+
+	return
+}
+
+// AliasOutput retrieves the current (unspent) AliasOutput of the alias with the given AliasAddress. If the alias was
+// forked by conflicting Transactions, the successor with the highest state index is returned.
+func (u *UTXODAG) AliasOutput(aliasAddress *AliasAddress) (cachedOutput *CachedOutput, err error) {
+	var currentOutputID OutputID
+	var currentStateIndex uint32
+	found := false
+	u.AddressOutputMapping(aliasAddress).Consume(func(addressOutputMapping *AddressOutputMapping) {
+		u.Output(addressOutputMapping.OutputID()).Consume(func(output Output) {
+			aliasOutput, isAliasOutput := output.(*AliasOutput)
+			if !isAliasOutput || (found && aliasOutput.StateIndex() < currentStateIndex) {
+				return
+			}
+
+			u.OutputMetadata(output.ID()).Consume(func(outputMetadata *OutputMetadata) {
+				if outputMetadata.ConsumerCount() != 0 {
+					return
+				}
+
+				currentOutputID = output.ID()
+				currentStateIndex = aliasOutput.StateIndex()
+				found = true
+			})
+		})
+	})
+
+	if !found {
+		err = xerrors.Errorf("failed to find unspent AliasOutput of %s: %w", aliasAddress.Base58(), ErrAliasOutputNotFound)
+		return
+	}
+
+	return u.Output(currentOutputID), nil
+}
+
 // region booking functions ////////////////////////////////////////////////////////////////////////////////////////////
 
 // bookInvalidTransaction is an internal utility function that books the given Transaction into the Branch identified by
@@ -408,11 +658,15 @@ func (u *UTXODAG) bookConflictingTransaction(transaction *Transaction, transacti
 func (u *UTXODAG) forkConsumer(transactionID TransactionID, conflictingInputs OutputsMetadataByID) {
 	if !u.TransactionMetadata(transactionID).Consume(func(txMetadata *TransactionMetadata) {
 		conflictBranchID := NewBranchID(transactionID)
+		conflictIDs := conflictingInputs.Filter(u.consumedOutputIDsOfTransaction(transactionID)).ConflictIDs()
+
+		// Transactions that are ConflictBranches already only become members of the new Conflicts
 		if txMetadata.BranchID() == conflictBranchID {
+			u.branchDAG.addConflicts(conflictBranchID, conflictIDs)
+
 			return
 		}
 		conflictBranchParents := NewBranchIDs(txMetadata.BranchID())
-		conflictIDs := conflictingInputs.Filter(u.consumedOutputIDsOfTransaction(transactionID)).ConflictIDs()
 
 		cachedConsumingConflictBranch, _, err := u.branchDAG.CreateConflictBranch(conflictBranchID, conflictBranchParents, conflictIDs)
 		if err != nil {
@@ -514,8 +768,17 @@ func (u *UTXODAG) bookConsumers(inputsMetadata OutputsMetadata, transactionID Tr
 func (u *UTXODAG) bookOutputs(transaction *Transaction, targetBranch BranchID) {
 	for _, output := range transaction.Essence().Outputs() {
 		// replace ColorMint color with unique color based on OutputID
-		if output.Type() == SigLockedColoredOutputType {
+		switch output.Type() {
+		case SigLockedColoredOutputType:
 			output = output.(*SigLockedColoredOutput).UpdateMintingColor()
+		case AliasOutputType:
+			output = output.(*AliasOutput).UpdateMintingColor()
+		case ExtendedLockedOutputType:
+			output = output.(*ExtendedLockedOutput).UpdateMintingColor()
+		case AssetOutputType:
+			assetOutput := output.(*AssetOutput).UpdateMintingColor()
+			u.storeAssetOutputMapping(assetOutput)
+			output = assetOutput
 		}
 
 		// store Output
@@ -572,72 +835,158 @@ func (u *UTXODAG) consumedOutputs(transaction *Transaction) (cachedInputs Cached
 }
 
 // allOutputsExist is an internal utility function that checks if all of the given Inputs exist.
-func (u *UTXODAG) allOutputsExist(inputs Outputs) (solid bool) {
-	for _, input := range inputs {
+func (u *UTXODAG) allOutputsExist(inputs Outputs) (err error) {
+	for i, input := range inputs {
 		if typeutils.IsInterfaceNil(input) {
-			return false
+			return newInputValidationError(ErrUnknownInput, i)
 		}
 	}
 
-	return true
+	return nil
 }
 
 // transactionBalancesValid is an internal utility function that checks if the sum of the balance changes equals to 0.
-func (u *UTXODAG) transactionBalancesValid(inputs Outputs, outputs Outputs) (valid bool) {
+func (u *UTXODAG) transactionBalancesValid(inputs Outputs, outputs Outputs) (err error) {
+	// the total of the consumed coins is tracked as well, so the left over coins of all colors can not overflow below
 	consumedCoins := make(map[Color]uint64)
-	for _, input := range inputs {
+	totalConsumedCoins := uint64(0)
+	for i, input := range inputs {
+		valid := true
 		input.Balances().ForEach(func(color Color, balance uint64) bool {
-			consumedCoins[color], valid = SafeAddUint64(consumedCoins[color], balance)
+			if consumedCoins[color], valid = SafeAddUint64(consumedCoins[color], balance); valid {
+				totalConsumedCoins, valid = SafeAddUint64(totalConsumedCoins, balance)
+			}
 
 			return valid
 		})
 
 		if !valid {
-			return
+			return newInputValidationError(ErrBalanceOverflow, i)
 		}
 	}
 
+	// assets with a mintable SupplyPolicy can be re-minted (from IOTA) by their controller
+	mintableColors := mintableAssetColors(inputs, outputs)
+
 	recoloredCoins := uint64(0)
-	for _, output := range outputs {
+	for i, output := range outputs {
 		output.Balances().ForEach(func(color Color, balance uint64) bool {
+			valid := true
 			switch color {
 			case ColorIOTA:
 				fallthrough
 			case ColorMint:
-				recoloredCoins, valid = SafeAddUint64(recoloredCoins, balance)
+				if recoloredCoins, valid = SafeAddUint64(recoloredCoins, balance); !valid {
+					err = newOutputValidationError(ErrBalanceOverflow, i)
+				}
 			default:
-				consumedCoins[color], valid = SafeSubUint64(consumedCoins[color], balance)
+				consumedBalance := consumedCoins[color]
+				if _, mintable := mintableColors[color]; mintable && balance > consumedBalance {
+					consumedCoins[color] = 0
+					if recoloredCoins, valid = SafeAddUint64(recoloredCoins, balance-consumedBalance); !valid {
+						err = newOutputValidationError(ErrBalanceOverflow, i)
+					}
+					break
+				}
+
+				if consumedCoins[color], valid = SafeSubUint64(consumedBalance, balance); !valid {
+					err = newBalanceMismatchError(color, consumedBalance, balance, i)
+				}
 			}
 
 			return valid
 		})
 
-		if !valid {
+		if err != nil {
 			return
 		}
 	}
 
 	unspentCoins := uint64(0)
 	for _, remainingBalance := range consumedCoins {
-		if unspentCoins, valid = SafeAddUint64(unspentCoins, remainingBalance); !valid {
-			return
-		}
+		unspentCoins += remainingBalance
 	}
 
-	return unspentCoins == recoloredCoins
+	if unspentCoins != recoloredCoins {
+		return newUncoloredBalanceMismatchError(unspentCoins, recoloredCoins)
+	}
+
+	return nil
 }
 
 // unlockBlocksValid is an internal utility function that checks if the UnlockBlocks are matching the referenced Inputs.
-func (u *UTXODAG) unlockBlocksValid(inputs Outputs, transaction *Transaction) (valid bool) {
+func (u *UTXODAG) unlockBlocksValid(inputs Outputs, transaction *Transaction) (err error) {
 	unlockBlocks := transaction.UnlockBlocks()
+
+	// verify every aggregated signature once (the result is cached, so the Inputs that reference it do not repeat the
+	// expensive pairing check)
+	essenceBytes := transaction.Essence().Bytes()
+	for i, unlockBlock := range unlockBlocks {
+		if aggregatedBLSUnlockBlock, isAggregatedBLSUnlockBlock := unlockBlock.(*AggregatedBLSUnlockBlock); isAggregatedBLSUnlockBlock && !aggregatedBLSUnlockBlock.SignatureValid(essenceBytes) {
+			return newInputValidationError(ErrUnlockBlockInvalid, i)
+		}
+	}
+
 	for i, input := range inputs {
-		unlockValid, unlockErr := input.UnlockValid(transaction, unlockBlocks[i])
+		unlockBlock := unlockBlocks[i]
+		if referenceUnlockBlock, isReferenceUnlockBlock := unlockBlock.(*ReferenceUnlockBlock); isReferenceUnlockBlock {
+			if int(referenceUnlockBlock.ReferencedIndex()) >= len(unlockBlocks) || unlockBlocks[referenceUnlockBlock.ReferencedIndex()].Type() == ReferenceUnlockBlockType {
+				return newInputValidationError(ErrInvalidUnlockBlockIndex, i)
+			}
+			unlockBlock = unlockBlocks[referenceUnlockBlock.ReferencedIndex()]
+		}
+
+		unlockValid, unlockErr := input.UnlockValid(transaction, unlockBlock, inputs)
 		if !unlockValid || unlockErr != nil {
-			return false
+			return newInputValidationError(ErrUnlockBlockInvalid, i)
 		}
 	}
 
-	return true
+	return nil
+}
+
+// aliasChainsValid is an internal utility function that checks if the AliasOutputs of a Transaction form valid chains:
+// every alias is consumed at most once, it is continued by at most one successor with a consistent state index and
+// there is no successor without the consumed predecessor.
+func (u *UTXODAG) aliasChainsValid(inputs Outputs, outputs Outputs) (err error) {
+	consumedAliases := make(map[[AddressLength]byte]*AliasOutput)
+	for i, input := range inputs {
+		if aliasInput, isAliasOutput := input.(*AliasOutput); isAliasOutput {
+			aliasAddress := aliasInput.AliasAddress().Array()
+			if _, exists := consumedAliases[aliasAddress]; exists {
+				return newInputValidationError(ErrDuplicateAliasInput, i)
+			}
+			consumedAliases[aliasAddress] = aliasInput
+		}
+	}
+
+	continuedAliases := make(map[[AddressLength]byte]types.Empty)
+	for i, output := range outputs {
+		aliasOutput, isAliasOutput := output.(*AliasOutput)
+		if !isAliasOutput || aliasOutput.IsOrigin() {
+			continue
+		}
+
+		aliasAddress := aliasOutput.AliasAddress().Array()
+		predecessor, exists := consumedAliases[aliasAddress]
+		if !exists {
+			return newOutputValidationError(ErrAliasSuccessorWithoutPredecessor, i)
+		}
+		if _, exists := continuedAliases[aliasAddress]; exists {
+			return newOutputValidationError(ErrDuplicateAliasSuccessor, i)
+		}
+		continuedAliases[aliasAddress] = types.Void
+
+		expectedStateIndex := predecessor.StateIndex()
+		if !aliasOutput.IsGovernanceUpdate() {
+			expectedStateIndex++
+		}
+		if aliasOutput.StateIndex() != expectedStateIndex {
+			return newOutputValidationError(ErrInvalidAliasStateIndex, i)
+		}
+	}
+
+	return nil
 }
 
 // transactionInputsMetadata is an internal utility function that returns the Metadata of the Outputs that are used as
@@ -685,17 +1034,16 @@ func (u *UTXODAG) inputsInRejectedBranch(inputsMetadata OutputsMetadata) (reject
 }
 
 // consumedOutputsPastConeValid is an internal utility function that checks if the given Outputs do not directly or
-// indirectly reference each other in their own past cone.
-func (u *UTXODAG) consumedOutputsPastConeValid(outputs Outputs, outputsMetadata OutputsMetadata) (pastConeValid bool) {
+// indirectly reference each other in their own past cone. The returned error names the offending Input.
+func (u *UTXODAG) consumedOutputsPastConeValid(outputs Outputs, outputsMetadata OutputsMetadata) (err error) {
 	if u.outputsUnspent(outputsMetadata) {
-		pastConeValid = true
-		return
+		return nil
 	}
 
 	stack := list.New()
-	consumedInputIDs := make(map[OutputID]types.Empty)
-	for _, input := range outputs {
-		consumedInputIDs[input.ID()] = types.Void
+	consumedInputIndexes := make(map[OutputID]int)
+	for i, input := range outputs {
+		consumedInputIndexes[input.ID()] = i
 		stack.PushBack(input.ID())
 	}
 
@@ -719,10 +1067,10 @@ func (u *UTXODAG) consumedOutputsPastConeValid(outputs Outputs, outputsMetadata
 			}
 
 			for _, output := range transaction.Essence().Outputs() {
-				if _, exists := consumedInputIDs[output.ID()]; exists {
+				if inputIndex, exists := consumedInputIndexes[output.ID()]; exists {
 					cachedTransaction.Release()
 					cachedConsumers.Release()
-					return false
+					return newInputValidationError(ErrInputsSpentInPastCone, inputIndex)
 				}
 
 				stack.PushBack(output.ID())
@@ -733,7 +1081,22 @@ func (u *UTXODAG) consumedOutputsPastConeValid(outputs Outputs, outputsMetadata
 		cachedConsumers.Release()
 	}
 
-	return true
+	return nil
+}
+
+// outputSpentByConfirmedTransaction is an internal utility function that checks if the Output with the given OutputID
+// is spent by a confirmed Transaction.
+func (u *UTXODAG) outputSpentByConfirmedTransaction(outputID OutputID) (spent bool) {
+	u.Consumers(outputID).Consume(func(consumer *Consumer) {
+		if spent {
+			return
+		}
+
+		inclusionState, err := u.InclusionState(consumer.TransactionID())
+		spent = err == nil && inclusionState == Confirmed
+	})
+
+	return
 }
 
 // outputsUnspent is an internal utility function that checks if the given outputs are unspent (do not have a valid
@@ -832,6 +1195,33 @@ func (u *UTXODAG) walkFutureCone(entryPoints []OutputID, callback func(transacti
 	}
 }
 
+// moveBranchOfTransaction is an internal utility function that rewrites the BranchID of the given Transaction and its
+// Outputs if they are contained in the given mapping. It returns true if the Transaction was moved.
+func (u *UTXODAG) moveBranchOfTransaction(transactionID TransactionID, movedBranches map[BranchID]BranchID) (moved bool) {
+	u.TransactionMetadata(transactionID).Consume(func(transactionMetadata *TransactionMetadata) {
+		newBranchID, branchMoved := movedBranches[transactionMetadata.BranchID()]
+		if !branchMoved {
+			return
+		}
+
+		transactionMetadata.SetBranchID(newBranchID)
+		moved = true
+	})
+	if !moved {
+		return
+	}
+
+	for _, outputID := range u.createdOutputIDsOfTransaction(transactionID) {
+		u.OutputMetadata(outputID).Consume(func(outputMetadata *OutputMetadata) {
+			if newBranchID, branchMoved := movedBranches[outputMetadata.BranchID()]; branchMoved {
+				outputMetadata.SetBranchID(newBranchID)
+			}
+		})
+	}
+
+	return
+}
+
 // consumedBranchIDs is an internal utility function that determines the list of BranchIDs that were consumed by the
 // Inputs of the given Transaction.
 func (u *UTXODAG) consumedBranchIDs(transactionID TransactionID) (branchIDs BranchIDs) {
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/iotaledger/hive.go/crypto/bls"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/kvstore/mapdb"
@@ -12,6 +13,7 @@ import (
 	"github.com/iotaledger/hive.go/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
 )
 
 var (
@@ -449,6 +451,39 @@ func TestBookConflictingTransaction(t *testing.T) {
 	assert.False(t, utxoDAG.outputsUnspent(inputsMetadata2))
 }
 
+func TestBookConflictingTransaction_ExistingConflictBranch(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(3)
+	input1 := generateOutput(utxoDAG, wallets[0].address, 0)
+	input2 := generateOutput(utxoDAG, wallets[0].address, 1)
+
+	tx1 := buildTransaction(utxoDAG, wallets[0], wallets[1], []*SigLockedSingleOutput{input1, input2})
+	_, err := utxoDAG.BookTransaction(tx1)
+	require.NoError(t, err)
+
+	// the first double spend turns tx1 into a ConflictBranch
+	tx2 := buildTransaction(utxoDAG, wallets[0], wallets[2], []*SigLockedSingleOutput{input1})
+	_, err = utxoDAG.BookTransaction(tx2)
+	require.NoError(t, err)
+
+	// the second double spend adds another Conflict to the existing ConflictBranch of tx1
+	tx3 := buildTransaction(utxoDAG, wallets[0], wallets[2], []*SigLockedSingleOutput{input2})
+	_, err = utxoDAG.BookTransaction(tx3)
+	require.NoError(t, err)
+
+	branchDAG.Branch(NewBranchID(tx1.ID())).Consume(func(branch Branch) {
+		assert.Equal(t, NewConflictIDs(NewConflictID(input1.ID()), NewConflictID(input2.ID())), branch.(*ConflictBranch).Conflicts())
+	})
+
+	conflictMembers := NewBranchIDs()
+	branchDAG.ConflictMembers(NewConflictID(input2.ID())).Consume(func(conflictMember *ConflictMember) {
+		conflictMembers.Add(conflictMember.BranchID())
+	})
+	assert.Equal(t, NewBranchIDs(NewBranchID(tx1.ID()), NewBranchID(tx3.ID())), conflictMembers)
+}
+
 func TestInclusionState(t *testing.T) {
 
 	{
@@ -654,7 +689,7 @@ func TestAllOutputsExist(t *testing.T) {
 	cachedInputs := utxoDAG.consumedOutputs(tx)
 	inputs := cachedInputs.Unwrap()
 
-	assert.True(t, utxoDAG.allOutputsExist(inputs))
+	assert.NoError(t, utxoDAG.allOutputsExist(inputs))
 
 	cachedInputs.Release()
 
@@ -663,7 +698,10 @@ func TestAllOutputsExist(t *testing.T) {
 	cachedInputs = utxoDAG.consumedOutputs(tx)
 	inputs = cachedInputs.Unwrap()
 
-	assert.False(t, utxoDAG.allOutputsExist(inputs))
+	err := utxoDAG.allOutputsExist(inputs)
+	assert.True(t, xerrors.Is(err, ErrUnknownInput))
+	assert.True(t, xerrors.Is(err, ErrTransactionNotSolid))
+	assert.False(t, xerrors.Is(err, ErrTransactionInvalid))
 
 	cachedInputs.Release()
 }
@@ -689,7 +727,7 @@ func TestTransactionBalancesValid(t *testing.T) {
 		ColorMint: 70,
 	}), wallets[1].address)
 
-	assert.True(t, utxoDAG.transactionBalancesValid(Outputs{iColored1}, Outputs{oColored1}))
+	assert.NoError(t, utxoDAG.transactionBalancesValid(Outputs{iColored1}, Outputs{oColored1}))
 
 	iColored2 := NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{
 		ColorIOTA: 1337,
@@ -704,7 +742,7 @@ func TestTransactionBalancesValid(t *testing.T) {
 		ColorMint: 10,
 	}), wallets[1].address)
 
-	assert.True(t, utxoDAG.transactionBalancesValid(Outputs{iColored2}, Outputs{oColored2}))
+	assert.NoError(t, utxoDAG.transactionBalancesValid(Outputs{iColored2}, Outputs{oColored2}))
 
 	iColored3 := NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{
 		color1: 1337,
@@ -714,7 +752,7 @@ func TestTransactionBalancesValid(t *testing.T) {
 		ColorMint: 1337,
 	}), wallets[1].address)
 
-	assert.True(t, utxoDAG.transactionBalancesValid(Outputs{iColored3}, Outputs{oColored3}))
+	assert.NoError(t, utxoDAG.transactionBalancesValid(Outputs{iColored3}, Outputs{oColored3}))
 
 	// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////
 
@@ -724,22 +762,53 @@ func TestTransactionBalancesValid(t *testing.T) {
 	// testing happy case
 	o := NewSigLockedSingleOutput(200, wallets[1].address)
 
-	assert.True(t, utxoDAG.transactionBalancesValid(Outputs{i1, i2}, Outputs{o}))
+	assert.NoError(t, utxoDAG.transactionBalancesValid(Outputs{i1, i2}, Outputs{o}))
 
 	// testing creating 1 iota out of thin air
 	i2 = NewSigLockedSingleOutput(99, wallets[0].address)
 
-	assert.False(t, utxoDAG.transactionBalancesValid(Outputs{i1, i2}, Outputs{o}))
+	err := utxoDAG.transactionBalancesValid(Outputs{i1, i2}, Outputs{o})
+	assert.True(t, xerrors.Is(err, ErrUncoloredBalanceMismatch))
+	assert.True(t, xerrors.Is(err, ErrTransactionInvalid))
 
 	// testing burning 1 iota
 	i2 = NewSigLockedSingleOutput(101, wallets[0].address)
 
-	assert.False(t, utxoDAG.transactionBalancesValid(Outputs{i1, i2}, Outputs{o}))
+	err = utxoDAG.transactionBalancesValid(Outputs{i1, i2}, Outputs{o})
+	var validationErr *TransactionValidationError
+	require.True(t, xerrors.As(err, &validationErr))
+	assert.Equal(t, ErrUncoloredBalanceMismatch, validationErr.Reason)
+	assert.Equal(t, uint64(201), validationErr.Consumed)
+	assert.Equal(t, uint64(200), validationErr.Spent)
+	assert.Equal(t, -1, validationErr.InputIndex)
+	assert.Equal(t, -1, validationErr.OutputIndex)
 
 	// testing unit64 overflow
 	i2 = NewSigLockedSingleOutput(math.MaxUint64, wallets[0].address)
 
-	assert.False(t, utxoDAG.transactionBalancesValid(Outputs{i1, i2}, Outputs{o}))
+	err = utxoDAG.transactionBalancesValid(Outputs{i1, i2}, Outputs{o})
+	require.True(t, xerrors.As(err, &validationErr))
+	assert.Equal(t, ErrBalanceOverflow, validationErr.Reason)
+	assert.Equal(t, 1, validationErr.InputIndex)
+
+	// testing overflow of the consumed coins of different colors
+	err = utxoDAG.transactionBalancesValid(Outputs{i1, NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{
+		color1: math.MaxUint64,
+	}), wallets[0].address)}, Outputs{o})
+	require.True(t, xerrors.As(err, &validationErr))
+	assert.Equal(t, ErrBalanceOverflow, validationErr.Reason)
+	assert.Equal(t, 1, validationErr.InputIndex)
+
+	// testing spending more colored coins than consumed
+	err = utxoDAG.transactionBalancesValid(Outputs{iColored3}, Outputs{NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{
+		color1: 1338,
+	}), wallets[1].address)})
+	require.True(t, xerrors.As(err, &validationErr))
+	assert.Equal(t, ErrBalanceMismatch, validationErr.Reason)
+	assert.Equal(t, color1, validationErr.Color)
+	assert.Equal(t, uint64(1337), validationErr.Consumed)
+	assert.Equal(t, uint64(1338), validationErr.Spent)
+	assert.Equal(t, 0, validationErr.OutputIndex)
 }
 
 func TestUnlockBlocksValid(t *testing.T) {
@@ -752,12 +821,50 @@ func TestUnlockBlocksValid(t *testing.T) {
 
 	// testing valid signature
 	tx, _ := singleInputTransaction(utxoDAG, wallets[0], wallets[1], input, true)
-	assert.True(t, utxoDAG.unlockBlocksValid(Outputs{input}, tx))
+	assert.NoError(t, utxoDAG.unlockBlocksValid(Outputs{input}, tx))
 
 	// testing invalid signature
 	tx, _ = singleInputTransaction(utxoDAG, wallets[1], wallets[0], input, true)
-	assert.False(t, utxoDAG.unlockBlocksValid(Outputs{input}, tx))
+	err := utxoDAG.unlockBlocksValid(Outputs{input}, tx)
+	var validationErr *TransactionValidationError
+	require.True(t, xerrors.As(err, &validationErr))
+	assert.Equal(t, ErrUnlockBlockInvalid, validationErr.Reason)
+	assert.Equal(t, 0, validationErr.InputIndex)
+
+	// testing reference to a non-existing unlock block
+	tx = NewTransaction(tx.Essence(), UnlockBlocks{NewReferenceUnlockBlock(1)})
+	err = utxoDAG.unlockBlocksValid(Outputs{input}, tx)
+	require.True(t, xerrors.As(err, &validationErr))
+	assert.Equal(t, ErrInvalidUnlockBlockIndex, validationErr.Reason)
+
+}
+
+func TestCheckTransactionPastCone(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(1)
+	signedByOwner := func(txEssence *TransactionEssence, _ Output) UnlockBlock {
+		return NewSignatureUnlockBlock(wallets[0].sign(txEssence))
+	}
 
+	input := generateOutput(utxoDAG, wallets[0].address, 1)
+	spendingTx := aliasTransaction([]Output{input}, []Output{NewSigLockedSingleOutput(100, wallets[0].address)}, signedByOwner)
+	_, err := utxoDAG.BookTransaction(spendingTx)
+	require.NoError(t, err)
+
+	// testing inputs that are independent of each other
+	independentTx := aliasTransaction([]Output{spendingTx.Essence().Outputs()[0]}, []Output{NewSigLockedSingleOutput(100, wallets[0].address)}, signedByOwner)
+	assert.NoError(t, utxoDAG.CheckTransactionPastCone(independentTx))
+
+	// testing an input that is spent in the past cone of another input
+	doubleSpendingTx := aliasTransaction([]Output{input, spendingTx.Essence().Outputs()[0]}, []Output{NewSigLockedSingleOutput(200, wallets[0].address)}, signedByOwner)
+	err = utxoDAG.CheckTransactionPastCone(doubleSpendingTx)
+	var validationErr *TransactionValidationError
+	require.True(t, xerrors.As(err, &validationErr))
+	assert.Equal(t, ErrInputsSpentInPastCone, validationErr.Reason)
+	assert.True(t, xerrors.Is(err, ErrTransactionInvalid))
+	assert.Equal(t, spendingTx.Essence().Outputs()[0].ID(), doubleSpendingTx.Essence().Inputs()[validationErr.InputIndex].(*UTXOInput).ReferencedOutputID())
 }
 
 func TestAddressOutputMapping(t *testing.T) {
@@ -776,6 +883,234 @@ func TestAddressOutputMapping(t *testing.T) {
 	assert.Equal(t, 1, len(res))
 }
 
+func TestAliasOutputChain(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(3)
+	owner, stateController, governanceController := wallets[0], wallets[1], wallets[2]
+	signedBy := func(w wallet) func(*TransactionEssence, Output) UnlockBlock {
+		return func(txEssence *TransactionEssence, _ Output) UnlockBlock {
+			return NewSignatureUnlockBlock(w.sign(txEssence))
+		}
+	}
+	bookTransaction := func(tx *Transaction) {
+		_, err := utxoDAG.BookTransaction(tx)
+		require.NoError(t, err)
+		for _, output := range tx.Essence().Outputs() {
+			utxoDAG.StoreAddressOutputMapping(output.Address(), output.ID())
+		}
+	}
+
+	// create the origin of the alias
+	genesisOutput := generateOutput(utxoDAG, owner.address, 0)
+	originTx := aliasTransaction([]Output{genesisOutput}, []Output{
+		NewAliasOutput(NewColoredBalances(map[Color]uint64{ColorIOTA: 100}), stateController.address, governanceController.address, []byte("immutable")),
+	}, signedBy(owner))
+	_, err := utxoDAG.CheckTransaction(originTx)
+	require.NoError(t, err)
+	bookTransaction(originTx)
+
+	origin := originTx.Essence().Outputs()[0].(*AliasOutput)
+	aliasAddress := origin.AliasAddress()
+	assertCurrentAliasOutput(t, utxoDAG, aliasAddress, origin.ID())
+
+	// state transitions need to be signed by the state controller
+	next := origin.NewAliasOutputNext(false)
+	next.SetStateData([]byte("state1"))
+	_, err = utxoDAG.CheckTransaction(aliasTransaction([]Output{origin}, []Output{next}, signedBy(governanceController)))
+	assert.True(t, xerrors.Is(err, ErrTransactionInvalid))
+	stateTx := aliasTransaction([]Output{origin}, []Output{next}, signedBy(stateController))
+	_, err = utxoDAG.CheckTransaction(stateTx)
+	require.NoError(t, err)
+
+	// the state index has to be increased by exactly one
+	invalidNext := origin.NewAliasOutputNext(false)
+	invalidNext.stateIndex = 2
+	_, err = utxoDAG.CheckTransaction(aliasTransaction([]Output{origin}, []Output{invalidNext}, signedBy(stateController)))
+	assert.True(t, xerrors.Is(err, ErrTransactionInvalid))
+	assertValidationError(t, err, ErrInvalidAliasStateIndex, -1, 0)
+
+	// a transaction can create at most one successor per alias
+	firstSuccessor := origin.NewAliasOutputNext(false)
+	firstSuccessor.SetBalances(NewColoredBalances(map[Color]uint64{ColorIOTA: 50}))
+	secondSuccessor := origin.NewAliasOutputNext(false)
+	secondSuccessor.SetBalances(NewColoredBalances(map[Color]uint64{ColorIOTA: 50}))
+	secondSuccessor.SetStateData([]byte("fork"))
+	_, err = utxoDAG.CheckTransaction(aliasTransaction([]Output{origin}, []Output{firstSuccessor, secondSuccessor}, signedBy(stateController)))
+	assert.True(t, xerrors.Is(err, ErrTransactionInvalid))
+	assertValidationError(t, err, ErrDuplicateAliasSuccessor, -1, 1)
+
+	bookTransaction(stateTx)
+	next = stateTx.Essence().Outputs()[0].(*AliasOutput)
+	assertCurrentAliasOutput(t, utxoDAG, aliasAddress, next.ID())
+
+	// a successor can not be created without consuming its predecessor
+	fakeSuccessor := next.NewAliasOutputNext(false)
+	_, err = utxoDAG.CheckTransaction(aliasTransaction([]Output{generateOutput(utxoDAG, owner.address, 1)}, []Output{fakeSuccessor}, signedBy(owner)))
+	assert.True(t, xerrors.Is(err, ErrTransactionInvalid))
+	assertValidationError(t, err, ErrAliasSuccessorWithoutPredecessor, -1, 0)
+
+	// an alias can not be consumed twice
+	assertValidationError(t, utxoDAG.aliasChainsValid(Outputs{next, next}, Outputs{fakeSuccessor}), ErrDuplicateAliasInput, 1, -1)
+
+	// a governance transition keeps the state index
+	invalidGovernanceNext := next.NewAliasOutputNext(true)
+	invalidGovernanceNext.stateIndex++
+	assertValidationError(t, utxoDAG.aliasChainsValid(Outputs{next}, Outputs{invalidGovernanceNext}), ErrInvalidAliasStateIndex, -1, 0)
+
+	// governance transitions need to be signed by the governance controller
+	governanceNext := next.NewAliasOutputNext(true)
+	governanceNext.SetStateAddress(owner.address)
+	_, err = utxoDAG.CheckTransaction(aliasTransaction([]Output{next}, []Output{governanceNext}, signedBy(stateController)))
+	assert.True(t, xerrors.Is(err, ErrTransactionInvalid))
+	_, err = utxoDAG.CheckTransaction(aliasTransaction([]Output{next}, []Output{governanceNext}, signedBy(governanceController)))
+	require.NoError(t, err)
+
+	// funds that are locked by the alias are unlocked by a state transition of the alias
+	aliasLockedOutput := generateOutput(utxoDAG, aliasAddress, 2)
+	collectingNext := next.NewAliasOutputNext(false)
+	collectingNext.SetBalances(NewColoredBalances(map[Color]uint64{ColorIOTA: 200}))
+	collectTx := aliasTransaction([]Output{next, aliasLockedOutput}, []Output{collectingNext}, func(txEssence *TransactionEssence, input Output) UnlockBlock {
+		if input.ID() == aliasLockedOutput.ID() {
+			for i, essenceInput := range txEssence.Inputs() {
+				if essenceInput.(*UTXOInput).ReferencedOutputID() == next.ID() {
+					return NewAliasUnlockBlock(uint16(i))
+				}
+			}
+		}
+
+		return NewSignatureUnlockBlock(stateController.sign(txEssence))
+	})
+	_, err = utxoDAG.CheckTransaction(collectTx)
+	require.NoError(t, err)
+	bookTransaction(collectTx)
+	assertCurrentAliasOutput(t, utxoDAG, aliasAddress, collectTx.Essence().Outputs()[0].ID())
+}
+
+func assertValidationError(t *testing.T, err error, reason error, inputIndex int, outputIndex int) {
+	var validationErr *TransactionValidationError
+	require.True(t, xerrors.As(err, &validationErr))
+	assert.Equal(t, reason, validationErr.Reason)
+	assert.Equal(t, inputIndex, validationErr.InputIndex)
+	assert.Equal(t, outputIndex, validationErr.OutputIndex)
+}
+
+func TestThresholdAddressSpending(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+
+	wallets := createWallets(4)
+	treasury, err := NewThresholdAddress(2, wallets[0].publicKey(), wallets[1].publicKey(), wallets[2].publicKey())
+	require.NoError(t, err)
+
+	inputs := []Output{generateOutput(utxoDAG, treasury, 0), generateOutput(utxoDAG, treasury, 1)}
+	multiSignatureTransaction := func(signers ...wallet) *Transaction {
+		return aliasTransaction(inputs, []Output{NewSigLockedSingleOutput(200, wallets[3].address)}, func(txEssence *TransactionEssence, input Output) UnlockBlock {
+			if txEssence.Inputs()[0].(*UTXOInput).ReferencedOutputID() != input.ID() {
+				return NewReferenceUnlockBlock(0)
+			}
+
+			unlockBlock := NewMultiSignatureUnlockBlock(2, wallets[0].publicKey(), wallets[1].publicKey(), wallets[2].publicKey())
+			for _, signer := range signers {
+				require.NoError(t, unlockBlock.AddSignature(signer.publicKey(), signer.privateKey().Sign(txEssence.Bytes())))
+			}
+
+			return unlockBlock
+		})
+	}
+
+	// a single co-signer can not spend the funds
+	_, err = utxoDAG.CheckTransaction(multiSignatureTransaction(wallets[1]))
+	assert.True(t, xerrors.Is(err, ErrTransactionInvalid))
+
+	// a single signature of a key that is not part of the ThresholdAddress can not unlock it
+	_, err = utxoDAG.CheckTransaction(aliasTransaction(inputs, []Output{NewSigLockedSingleOutput(200, wallets[3].address)}, func(txEssence *TransactionEssence, _ Output) UnlockBlock {
+		return NewSignatureUnlockBlock(wallets[3].sign(txEssence))
+	}))
+	assert.True(t, xerrors.Is(err, ErrTransactionInvalid))
+
+	// two co-signers can spend the funds
+	_, err = utxoDAG.CheckTransaction(multiSignatureTransaction(wallets[2], wallets[0]))
+	require.NoError(t, err)
+}
+
+func TestAggregatedBLSSpending(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+
+	privateKeys := []bls.PrivateKey{bls.PrivateKeyFromRandomness(), bls.PrivateKeyFromRandomness(), bls.PrivateKeyFromRandomness()}
+	inputs := make([]Output, len(privateKeys))
+	for i, privateKey := range privateKeys {
+		inputs[i] = generateOutput(utxoDAG, NewBLSAddress(privateKey.PublicKey().Bytes()), uint16(i))
+	}
+	receiver := createWallets(1)[0]
+
+	aggregatedTransaction := func(signers ...bls.PrivateKey) *Transaction {
+		return aliasTransaction(inputs, []Output{NewSigLockedSingleOutput(300, receiver.address)}, func(txEssence *TransactionEssence, input Output) UnlockBlock {
+			if txEssence.Inputs()[0].(*UTXOInput).ReferencedOutputID() != input.ID() {
+				return NewReferenceUnlockBlock(0)
+			}
+
+			signatures := make([]bls.SignatureWithPublicKey, len(signers))
+			for i, signer := range signers {
+				signature, err := signer.Sign(txEssence.Bytes())
+				require.NoError(t, err)
+				signatures[i] = signature
+			}
+			unlockBlock, err := NewAggregatedBLSUnlockBlock(signatures...)
+			require.NoError(t, err)
+
+			return unlockBlock
+		})
+	}
+
+	// an Input whose key is not part of the aggregate can not be unlocked
+	_, err := utxoDAG.CheckTransaction(aggregatedTransaction(privateKeys[0], privateKeys[1]))
+	assert.True(t, xerrors.Is(err, ErrUnlockBlockInvalid))
+
+	// a single aggregated signature unlocks the Inputs of all addresses
+	tx := aggregatedTransaction(privateKeys...)
+	_, err = utxoDAG.CheckTransaction(tx)
+	require.NoError(t, err)
+
+	// a forged aggregate fails the batch verification
+	forgedUnlockBlock := tx.UnlockBlocks()[0].(*AggregatedBLSUnlockBlock)
+	forgedSignature, err := privateKeys[0].Sign(tx.Essence().Bytes())
+	require.NoError(t, err)
+	forgedUnlockBlock = &AggregatedBLSUnlockBlock{publicKeys: forgedUnlockBlock.PublicKeys(), signature: forgedSignature.Signature}
+	forgedUnlockBlocks := make(UnlockBlocks, len(tx.UnlockBlocks()))
+	copy(forgedUnlockBlocks, tx.UnlockBlocks())
+	forgedUnlockBlocks[0] = forgedUnlockBlock
+	_, err = utxoDAG.CheckTransaction(NewTransaction(tx.Essence(), forgedUnlockBlocks))
+	assert.True(t, xerrors.Is(err, ErrUnlockBlockInvalid))
+}
+
+func assertCurrentAliasOutput(t *testing.T, utxoDAG *UTXODAG, aliasAddress *AliasAddress, expectedOutputID OutputID) {
+	cachedOutput, err := utxoDAG.AliasOutput(aliasAddress)
+	require.NoError(t, err)
+	assert.True(t, cachedOutput.Consume(func(output Output) {
+		assert.Equal(t, expectedOutputID, output.ID())
+	}))
+}
+
+func aliasTransaction(inputs []Output, outputs []Output, unlockBlock func(txEssence *TransactionEssence, input Output) UnlockBlock) *Transaction {
+	inputsByID := make(map[OutputID]Output)
+	txInputs := make([]Input, len(inputs))
+	for i, input := range inputs {
+		inputsByID[input.ID()] = input
+		txInputs[i] = NewUTXOInput(input.ID())
+	}
+
+	txEssence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(txInputs...), NewOutputs(outputs...))
+	unlockBlocks := make(UnlockBlocks, len(txEssence.Inputs()))
+	for i, input := range txEssence.Inputs() {
+		unlockBlocks[i] = unlockBlock(txEssence, inputsByID[input.(*UTXOInput).ReferencedOutputID()])
+	}
+
+	return NewTransaction(txEssence, unlockBlocks)
+}
+
 func setupDependencies(t *testing.T) (*BranchDAG, *UTXODAG) {
 	store := mapdb.NewMapDB()
 	branchDAG := NewBranchDAG(store)
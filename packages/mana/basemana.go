@@ -0,0 +1,106 @@
+package mana
+
+import (
+	"math"
+	"time"
+
+	"github.com/iotaledger/hive.go/stringify"
+	"golang.org/x/xerrors"
+)
+
+// region BaseMana /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// BaseMana represents the mana of a single node. The base value is the amount of mana that was pledged to the node
+// (decaying over time for AccessMana) while the effective value is an exponential moving average of the base value that
+// smooths out sudden changes.
+type BaseMana struct {
+	baseValue      float64
+	effectiveValue float64
+	lastUpdated    time.Time
+}
+
+// NewBaseMana creates a new BaseMana with the given values.
+func NewBaseMana(baseValue float64, effectiveValue float64, lastUpdated time.Time) *BaseMana {
+	return &BaseMana{
+		baseValue:      baseValue,
+		effectiveValue: effectiveValue,
+		lastUpdated:    lastUpdated,
+	}
+}
+
+// BaseValue returns the base value of the BaseMana.
+func (b *BaseMana) BaseValue() float64 {
+	return b.baseValue
+}
+
+// EffectiveValue returns the effective value of the BaseMana.
+func (b *BaseMana) EffectiveValue() float64 {
+	return b.effectiveValue
+}
+
+// LastUpdated returns the time of the last update of the BaseMana.
+func (b *BaseMana) LastUpdated() time.Time {
+	return b.lastUpdated
+}
+
+// String returns a human readable version of the BaseMana.
+func (b *BaseMana) String() string {
+	return stringify.Struct("BaseMana",
+		stringify.StructField("baseValue", b.baseValue),
+		stringify.StructField("effectiveValue", b.effectiveValue),
+		stringify.StructField("lastUpdated", b.lastUpdated),
+	)
+}
+
+// update decays the base value (with the given decay rate) and moves the effective value towards the base value until
+// the given time.
+func (b *BaseMana) update(t time.Time, decay float64) error {
+	if t.Before(b.lastUpdated) {
+		return ErrAlreadyUpdated
+	}
+
+	elapsed := t.Sub(b.lastUpdated).Seconds()
+	b.effectiveValue = updatedEffectiveValue(b.effectiveValue, b.baseValue, decay, elapsed)
+	b.baseValue *= math.Exp(-decay * elapsed)
+	b.lastUpdated = t
+
+	return nil
+}
+
+// pledge updates the BaseMana to the given time and adds the pledged amount to its base value. Pledges that are older
+// than the last update are added without updating the BaseMana.
+func (b *BaseMana) pledge(amount float64, t time.Time, decay float64) error {
+	if err := b.update(t, decay); err != nil && !xerrors.Is(err, ErrAlreadyUpdated) {
+		return err
+	}
+	b.baseValue += amount
+
+	return nil
+}
+
+// revoke updates the BaseMana to the given time and removes the revoked amount from its base value.
+func (b *BaseMana) revoke(amount float64, t time.Time, decay float64) error {
+	if err := b.update(t, decay); err != nil && !xerrors.Is(err, ErrAlreadyUpdated) {
+		return err
+	}
+	if b.baseValue-amount < 0 {
+		return ErrBaseManaNegative
+	}
+	b.baseValue -= amount
+
+	return nil
+}
+
+// updatedEffectiveValue solves the differential equation of an exponential moving average that follows an
+// exponentially decaying base value for the given amount of elapsed seconds.
+func updatedEffectiveValue(effectiveValue float64, baseValue float64, decay float64, elapsed float64) float64 {
+	emaDecay := math.Exp(-EMACoefficient * elapsed)
+
+	if EMACoefficient == decay {
+		return emaDecay*effectiveValue + EMACoefficient*baseValue*elapsed*emaDecay
+	}
+
+	return emaDecay*effectiveValue + EMACoefficient*baseValue*(math.Exp(-decay*elapsed)-emaDecay)/(EMACoefficient-decay)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,53 @@
+package mana
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+)
+
+func TestBaseMana_Update(t *testing.T) {
+	start := time.Now()
+	halfLife := time.Duration(math.Ln2/Decay) * time.Second
+
+	baseMana := NewBaseMana(1000, 0, start)
+	require.NoError(t, baseMana.update(start.Add(halfLife), Decay))
+	assert.InDelta(t, 500, baseMana.BaseValue(), 0.01)
+	// effective value follows the base value
+	assert.Greater(t, baseMana.EffectiveValue(), 0.)
+	assert.Less(t, baseMana.EffectiveValue(), baseMana.BaseValue())
+
+	// updating to an earlier time is not allowed
+	assert.True(t, xerrors.Is(baseMana.update(start, Decay), ErrAlreadyUpdated))
+}
+
+func TestBaseMana_UpdateWithoutDecay(t *testing.T) {
+	start := time.Now()
+	halfLife := time.Duration(math.Ln2/EMACoefficient) * time.Second
+
+	baseMana := NewBaseMana(1000, 0, start)
+	require.NoError(t, baseMana.update(start.Add(halfLife), 0))
+	assert.Equal(t, 1000., baseMana.BaseValue())
+	assert.InDelta(t, 500, baseMana.EffectiveValue(), 0.01)
+}
+
+func TestBaseMana_PledgeAndRevoke(t *testing.T) {
+	start := time.Now()
+
+	baseMana := NewBaseMana(0, 0, start)
+	require.NoError(t, baseMana.pledge(100, start, 0))
+	assert.Equal(t, 100., baseMana.BaseValue())
+
+	// pledges in the past are still added
+	require.NoError(t, baseMana.pledge(50, start.Add(-time.Minute), 0))
+	assert.Equal(t, 150., baseMana.BaseValue())
+	assert.Equal(t, start, baseMana.LastUpdated())
+
+	require.NoError(t, baseMana.revoke(150, start.Add(time.Minute), 0))
+	assert.Equal(t, 0., baseMana.BaseValue())
+	assert.True(t, xerrors.Is(baseMana.revoke(1, start.Add(time.Minute), 0), ErrBaseManaNegative))
+}
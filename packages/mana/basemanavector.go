@@ -0,0 +1,276 @@
+package mana
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"golang.org/x/xerrors"
+)
+
+// region BaseManaVector ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// BaseManaVector keeps track of the BaseMana of all known nodes for a single mana Type.
+type BaseManaVector struct {
+	// Events is a dictionary for the BaseManaVector related Events.
+	Events *Events
+
+	manaType Type
+	vector   map[identity.ID]*BaseMana
+	mutex    sync.RWMutex
+}
+
+// NewBaseManaVector is the constructor of the BaseManaVector for the given mana Type.
+func NewBaseManaVector(manaType Type) (baseManaVector *BaseManaVector, err error) {
+	if manaType != AccessMana && manaType != ConsensusMana {
+		err = xerrors.Errorf("failed to create BaseManaVector of %s: %w", manaType, ErrUnknownManaType)
+		return
+	}
+
+	baseManaVector = &BaseManaVector{
+		Events:   newEvents(),
+		manaType: manaType,
+		vector:   make(map[identity.ID]*BaseMana),
+	}
+
+	return
+}
+
+// Type returns the mana Type of the BaseManaVector.
+func (b *BaseManaVector) Type() Type {
+	return b.manaType
+}
+
+// Size returns the amount of nodes that are tracked by the BaseManaVector.
+func (b *BaseManaVector) Size() int {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return len(b.vector)
+}
+
+// Has returns true if the given node is tracked by the BaseManaVector.
+func (b *BaseManaVector) Has(nodeID identity.ID) bool {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	_, exists := b.vector[nodeID]
+	return exists
+}
+
+// Book books the mana changes that are caused by the given Transaction. AccessMana is pledged based on the amount of
+// consumed tokens while ConsensusMana moves the balance of the consumed Outputs from their previous pledge nodes to the
+// new pledge node.
+func (b *BaseManaVector) Book(txInfo *TxInfo) (err error) {
+	pledgedEvents := make([]*PledgedEvent, 0)
+	revokedEvents := make([]*RevokedEvent, 0)
+
+	b.mutex.Lock()
+	switch b.manaType {
+	case AccessMana:
+		amount := txInfo.sumInputs()
+		if err = b.baseMana(txInfo.PledgeID[AccessMana]).pledge(amount, txInfo.TimeStamp, b.decay()); err != nil {
+			err = xerrors.Errorf("failed to pledge %s mana to %s: %w", b.manaType, txInfo.PledgeID[AccessMana], err)
+			break
+		}
+		pledgedEvents = append(pledgedEvents, b.pledgedEvent(txInfo, amount))
+	case ConsensusMana:
+		for _, inputInfo := range txInfo.InputInfos {
+			// outputs of the genesis are not pledged to any node
+			revokedNodeID, exists := inputInfo.PledgeID[ConsensusMana]
+			if !exists || revokedNodeID == (identity.ID{}) {
+				continue
+			}
+
+			if err = b.baseMana(revokedNodeID).revoke(inputInfo.Amount, txInfo.TimeStamp, b.decay()); err != nil {
+				err = xerrors.Errorf("failed to revoke %s mana from %s: %w", b.manaType, revokedNodeID, err)
+				break
+			}
+			revokedEvents = append(revokedEvents, &RevokedEvent{
+				NodeID:        revokedNodeID,
+				Amount:        inputInfo.Amount,
+				Time:          txInfo.TimeStamp,
+				ManaType:      b.manaType,
+				TransactionID: txInfo.TransactionID,
+			})
+		}
+		if err != nil {
+			break
+		}
+
+		if err = b.baseMana(txInfo.PledgeID[ConsensusMana]).pledge(txInfo.TotalBalance, txInfo.TimeStamp, b.decay()); err != nil {
+			err = xerrors.Errorf("failed to pledge %s mana to %s: %w", b.manaType, txInfo.PledgeID[ConsensusMana], err)
+			break
+		}
+		pledgedEvents = append(pledgedEvents, b.pledgedEvent(txInfo, txInfo.TotalBalance))
+	}
+	b.mutex.Unlock()
+
+	// trigger the events after releasing the lock so handlers can query the BaseManaVector
+	for _, revokedEvent := range revokedEvents {
+		b.Events.Revoked.Trigger(revokedEvent)
+	}
+	for _, pledgedEvent := range pledgedEvents {
+		b.Events.Pledged.Trigger(pledgedEvent)
+	}
+
+	return
+}
+
+// Update updates the BaseMana of the given node to the given time.
+func (b *BaseManaVector) Update(nodeID identity.ID, t time.Time) (err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	baseMana, exists := b.vector[nodeID]
+	if !exists {
+		return ErrNodeNotFoundInBaseManaVector
+	}
+
+	return baseMana.update(t, b.decay())
+}
+
+// UpdateAll updates the BaseMana of all nodes to the given time.
+func (b *BaseManaVector) UpdateAll(t time.Time) (err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for nodeID, baseMana := range b.vector {
+		if err = baseMana.update(t, b.decay()); err != nil {
+			return xerrors.Errorf("failed to update %s mana of %s: %w", b.manaType, nodeID, err)
+		}
+	}
+
+	return
+}
+
+// GetMana returns the effective mana of the given node at the given time (or now if no time is provided).
+func (b *BaseManaVector) GetMana(nodeID identity.ID, optionalUpdateTime ...time.Time) (mana float64, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	baseMana, exists := b.vector[nodeID]
+	if !exists {
+		err = ErrNodeNotFoundInBaseManaVector
+		return
+	}
+
+	return b.effectiveMana(baseMana, updateTime(optionalUpdateTime...))
+}
+
+// GetManaMap returns the effective mana of all nodes at the given time (or now if no time is provided).
+func (b *BaseManaVector) GetManaMap(optionalUpdateTime ...time.Time) (nodeMap NodeMap, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	t := updateTime(optionalUpdateTime...)
+	nodeMap = make(NodeMap, len(b.vector))
+	for nodeID, baseMana := range b.vector {
+		if nodeMap[nodeID], err = b.effectiveMana(baseMana, t); err != nil {
+			err = xerrors.Errorf("failed to retrieve %s mana of %s: %w", b.manaType, nodeID, err)
+			return
+		}
+	}
+
+	return
+}
+
+// GetHighestManaNodes returns the n nodes with the highest effective mana (ordered by their mana).
+func (b *BaseManaVector) GetHighestManaNodes(n uint) (nodes []Node, err error) {
+	nodeMap, err := b.GetManaMap()
+	if err != nil {
+		return
+	}
+
+	nodes = nodeMap.Sorted()
+	if n != 0 && uint(len(nodes)) > n {
+		nodes = nodes[:n]
+	}
+
+	return
+}
+
+// ToPersistables converts the BaseManaVector into a list of PersistableBaseMana objects.
+func (b *BaseManaVector) ToPersistables() (persistables []*PersistableBaseMana) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	persistables = make([]*PersistableBaseMana, 0, len(b.vector))
+	for nodeID, baseMana := range b.vector {
+		persistables = append(persistables, NewPersistableBaseMana(b.manaType, nodeID, baseMana))
+	}
+
+	return
+}
+
+// FromPersistable restores the BaseMana of a single node from the given PersistableBaseMana.
+func (b *BaseManaVector) FromPersistable(persistable *PersistableBaseMana) (err error) {
+	if persistable.ManaType() != b.manaType {
+		return xerrors.Errorf("persistable of %s mana can not be loaded into a BaseManaVector of %s mana: %w", persistable.ManaType(), b.manaType, ErrUnknownManaType)
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.vector[persistable.NodeID()] = persistable.BaseMana()
+
+	return
+}
+
+// baseMana returns the BaseMana of the given node and creates it if it doesn't exist, yet.
+func (b *BaseManaVector) baseMana(nodeID identity.ID) (baseMana *BaseMana) {
+	baseMana, exists := b.vector[nodeID]
+	if !exists {
+		baseMana = &BaseMana{}
+		b.vector[nodeID] = baseMana
+	}
+
+	return
+}
+
+// effectiveMana returns the effective value of the given BaseMana at the given time without modifying it.
+func (b *BaseManaVector) effectiveMana(baseMana *BaseMana, t time.Time) (mana float64, err error) {
+	updatedBaseMana := *baseMana
+	if err = updatedBaseMana.update(t, b.decay()); err != nil {
+		if !xerrors.Is(err, ErrAlreadyUpdated) {
+			return
+		}
+
+		// the BaseMana was updated by a transaction with a timestamp that lies in the future
+		err = nil
+	}
+	mana = updatedBaseMana.EffectiveValue()
+
+	return
+}
+
+// decay returns the decay rate of the base value of the tracked mana Type.
+func (b *BaseManaVector) decay() float64 {
+	if b.manaType == AccessMana {
+		return Decay
+	}
+
+	return 0
+}
+
+// pledgedEvent is an internal utility function that creates the PledgedEvent for the given Transaction.
+func (b *BaseManaVector) pledgedEvent(txInfo *TxInfo, amount float64) *PledgedEvent {
+	return &PledgedEvent{
+		NodeID:        txInfo.PledgeID[b.manaType],
+		Amount:        amount,
+		Time:          txInfo.TimeStamp,
+		ManaType:      b.manaType,
+		TransactionID: txInfo.TransactionID,
+	}
+}
+
+// updateTime is an internal utility function that returns the optional time or the current time if it was omitted.
+func updateTime(optionalUpdateTime ...time.Time) time.Time {
+	if len(optionalUpdateTime) >= 1 {
+		return optionalUpdateTime[0]
+	}
+
+	return time.Now()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,146 @@
+package mana
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+)
+
+func TestNewBaseManaVector(t *testing.T) {
+	_, err := NewBaseManaVector(Type(42))
+	assert.True(t, xerrors.Is(err, ErrUnknownManaType))
+
+	accessVector, err := NewBaseManaVector(AccessMana)
+	require.NoError(t, err)
+	assert.Equal(t, AccessMana, accessVector.Type())
+	assert.Equal(t, 0, accessVector.Size())
+}
+
+func TestBaseManaVector_BookAccessMana(t *testing.T) {
+	nodeA := randomID(t)
+	now := time.Now()
+
+	accessVector, err := NewBaseManaVector(AccessMana)
+	require.NoError(t, err)
+
+	pledged := 0.
+	accessVector.Events.Pledged.Attach(events.NewClosure(func(ev *PledgedEvent) {
+		assert.Equal(t, nodeA, ev.NodeID)
+		pledged += ev.Amount
+	}))
+
+	require.NoError(t, accessVector.Book(&TxInfo{
+		TimeStamp:     now,
+		TransactionID: ledgerstate.GenesisTransactionID,
+		TotalBalance:  30,
+		PledgeID:      map[Type]identity.ID{AccessMana: nodeA, ConsensusMana: nodeA},
+		InputInfos: []InputInfo{
+			{Amount: 10, TimeStamp: now},
+			{Amount: 20, TimeStamp: now},
+		},
+	}))
+
+	assert.Equal(t, 30., pledged)
+	assert.True(t, accessVector.Has(nodeA))
+
+	mana, err := accessVector.GetMana(nodeA, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Greater(t, mana, 0.)
+
+	_, err = accessVector.GetMana(randomID(t))
+	assert.True(t, xerrors.Is(err, ErrNodeNotFoundInBaseManaVector))
+}
+
+func TestBaseManaVector_BookConsensusMana(t *testing.T) {
+	nodeA := randomID(t)
+	nodeB := randomID(t)
+	now := time.Now()
+
+	consensusVector, err := NewBaseManaVector(ConsensusMana)
+	require.NoError(t, err)
+
+	// genesis funds are pledged to nodeA
+	require.NoError(t, consensusVector.Book(&TxInfo{
+		TimeStamp:    now,
+		TotalBalance: 100,
+		PledgeID:     map[Type]identity.ID{ConsensusMana: nodeA},
+		InputInfos:   []InputInfo{{Amount: 100}},
+	}))
+
+	revoked := 0.
+	consensusVector.Events.Revoked.Attach(events.NewClosure(func(ev *RevokedEvent) {
+		assert.Equal(t, nodeA, ev.NodeID)
+		revoked += ev.Amount
+	}))
+
+	// nodeA moves 40 tokens to nodeB
+	require.NoError(t, consensusVector.Book(&TxInfo{
+		TimeStamp:    now.Add(time.Minute),
+		TotalBalance: 40,
+		PledgeID:     map[Type]identity.ID{ConsensusMana: nodeB},
+		InputInfos:   []InputInfo{{Amount: 40, PledgeID: map[Type]identity.ID{ConsensusMana: nodeA}}},
+	}))
+	assert.Equal(t, 40., revoked)
+
+	later := now.Add(24 * time.Hour)
+	manaMap, err := consensusVector.GetManaMap(later)
+	require.NoError(t, err)
+	assert.InDelta(t, 60, manaMap[nodeA], 5)
+	assert.InDelta(t, 40, manaMap[nodeB], 5)
+
+	highest, err := consensusVector.GetHighestManaNodes(1)
+	require.NoError(t, err)
+	require.Len(t, highest, 1)
+	assert.Equal(t, nodeA, highest[0].ID)
+
+	percentile, err := manaMap.Percentile(nodeB)
+	require.NoError(t, err)
+	assert.Equal(t, 0., percentile)
+	percentile, err = manaMap.Percentile(nodeA)
+	require.NoError(t, err)
+	assert.Equal(t, 100., percentile)
+}
+
+func TestBaseManaVector_Persistables(t *testing.T) {
+	nodeA := randomID(t)
+	now := time.Now()
+
+	consensusVector, err := NewBaseManaVector(ConsensusMana)
+	require.NoError(t, err)
+	require.NoError(t, consensusVector.Book(&TxInfo{
+		TimeStamp:    now,
+		TotalBalance: 100,
+		PledgeID:     map[Type]identity.ID{ConsensusMana: nodeA},
+	}))
+
+	restoredVector, err := NewBaseManaVector(ConsensusMana)
+	require.NoError(t, err)
+	for _, persistable := range consensusVector.ToPersistables() {
+		restored, _, err := PersistableBaseManaFromBytes(persistable.Bytes())
+		require.NoError(t, err)
+		require.NoError(t, restoredVector.FromPersistable(restored))
+	}
+
+	expectedMana, err := consensusVector.GetMana(nodeA, now.Add(time.Hour))
+	require.NoError(t, err)
+	restoredMana, err := restoredVector.GetMana(nodeA, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, expectedMana, restoredMana)
+
+	accessVector, err := NewBaseManaVector(AccessMana)
+	require.NoError(t, err)
+	assert.Error(t, accessVector.FromPersistable(consensusVector.ToPersistables()[0]))
+}
+
+func randomID(t *testing.T) identity.ID {
+	id, err := identity.RandomID()
+	require.NoError(t, err)
+
+	return id
+}
@@ -0,0 +1,22 @@
+package mana
+
+import (
+	"errors"
+)
+
+var (
+	// ErrUnknownManaType is returned if a mana Type is not known.
+	ErrUnknownManaType = errors.New("unknown mana type")
+
+	// ErrNodeNotFoundInBaseManaVector is returned if a node is not tracked by a BaseManaVector.
+	ErrNodeNotFoundInBaseManaVector = errors.New("node not present in base mana vector")
+
+	// ErrAlreadyUpdated is returned if mana is tried to be updated at a time that lies before its last update.
+	ErrAlreadyUpdated = errors.New("already updated to a later timestamp")
+
+	// ErrBaseManaNegative is returned if revoking mana would result in a negative base mana value.
+	ErrBaseManaNegative = errors.New("base mana should never be negative")
+
+	// ErrRevokeNotSupported is returned if mana is revoked from a Type that does not support revoking.
+	ErrRevokeNotSupported = errors.New("revoking mana is not supported for this mana type")
+)
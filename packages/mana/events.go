@@ -0,0 +1,64 @@
+package mana
+
+import (
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+)
+
+// region Events ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Events represents events happening in a BaseManaVector.
+type Events struct {
+	// Pledged is triggered when mana was pledged to a node.
+	Pledged *events.Event
+
+	// Revoked is triggered when mana was revoked from a node.
+	Revoked *events.Event
+}
+
+// newEvents is the constructor of the Events.
+func newEvents() *Events {
+	return &Events{
+		Pledged: events.NewEvent(pledgedEventCaller),
+		Revoked: events.NewEvent(revokedEventCaller),
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region PledgedEvent /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// PledgedEvent holds the information provided by the Pledged event.
+type PledgedEvent struct {
+	NodeID        identity.ID
+	Amount        float64
+	Time          time.Time
+	ManaType      Type
+	TransactionID ledgerstate.TransactionID
+}
+
+func pledgedEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*PledgedEvent))(params[0].(*PledgedEvent))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region RevokedEvent /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RevokedEvent holds the information provided by the Revoked event.
+type RevokedEvent struct {
+	NodeID        identity.ID
+	Amount        float64
+	Time          time.Time
+	ManaType      Type
+	TransactionID ledgerstate.TransactionID
+}
+
+func revokedEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*RevokedEvent))(params[0].(*RevokedEvent))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,103 @@
+package mana
+
+import (
+	"sort"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/mr-tron/base58"
+)
+
+// region Node /////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Node represents a node and its mana value.
+type Node struct {
+	ID   identity.ID
+	Mana float64
+}
+
+// NodeStr is a version of Node that is used in the web API.
+type NodeStr struct {
+	ShortNodeID string  `json:"shortNodeID"`
+	NodeID      string  `json:"nodeID"`
+	Mana        float64 `json:"mana"`
+}
+
+// ToNodeStr converts a Node into its web API representation.
+func (n Node) ToNodeStr() NodeStr {
+	return NodeStr{
+		ShortNodeID: n.ID.String(),
+		NodeID:      base58.Encode(n.ID.Bytes()),
+		Mana:        n.Mana,
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region NodeMap //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NodeMap is a map of the mana values of a set of nodes.
+type NodeMap map[identity.ID]float64
+
+// Sorted returns the nodes of the NodeMap ordered by their mana value (highest first).
+func (n NodeMap) Sorted() (nodes []Node) {
+	nodes = make([]Node, 0, len(n))
+	for nodeID, mana := range n {
+		nodes = append(nodes, Node{ID: nodeID, Mana: mana})
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Mana == nodes[j].Mana {
+			return string(nodes[i].ID.Bytes()) < string(nodes[j].ID.Bytes())
+		}
+
+		return nodes[i].Mana > nodes[j].Mana
+	})
+
+	return
+}
+
+// ToNodeStrList converts the NodeMap into a list of NodeStr ordered by their mana value (highest first).
+func (n NodeMap) ToNodeStrList() (nodeStrs []NodeStr) {
+	nodes := n.Sorted()
+	nodeStrs = make([]NodeStr, len(nodes))
+	for i, node := range nodes {
+		nodeStrs[i] = node.ToNodeStr()
+	}
+
+	return
+}
+
+// Percentile returns the share of the other nodes (in percent) that have less mana than the given node. A node that is
+// the only member of the NodeMap is in the 100th percentile.
+func (n NodeMap) Percentile(nodeID identity.ID) (percentile float64, err error) {
+	nodeMana, exists := n[nodeID]
+	if !exists {
+		err = ErrNodeNotFoundInBaseManaVector
+		return
+	}
+	if len(n) == 1 {
+		percentile = 100
+		return
+	}
+
+	lessMana := 0
+	for _, mana := range n {
+		if mana < nodeMana {
+			lessMana++
+		}
+	}
+	percentile = float64(lessMana) / float64(len(n)-1) * 100
+
+	return
+}
+
+// TotalMana returns the sum of the mana of all nodes in the NodeMap.
+func (n NodeMap) TotalMana() (total float64) {
+	for _, mana := range n {
+		total += mana
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,15 @@
+package mana
+
+import (
+	"math"
+)
+
+var (
+	// Decay is the rate (per second) at which the base value of AccessMana decays. The default corresponds to a
+	// half-life of 6 hours.
+	Decay = math.Ln2 / (6 * 60 * 60)
+
+	// EMACoefficient is the rate (per second) at which the effective mana value follows the base mana value (exponential
+	// moving average). The default corresponds to a half-life of 6 hours.
+	EMACoefficient = math.Ln2 / (6 * 60 * 60)
+)
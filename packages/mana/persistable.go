@@ -0,0 +1,160 @@
+package mana
+
+import (
+	"math"
+	"time"
+
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+	"golang.org/x/xerrors"
+)
+
+// region PersistableBaseMana //////////////////////////////////////////////////////////////////////////////////////////
+
+// PersistableBaseMana is a version of the BaseMana of a single node that can be stored in the object storage.
+type PersistableBaseMana struct {
+	manaType       Type
+	nodeID         identity.ID
+	baseValue      float64
+	effectiveValue float64
+	lastUpdated    time.Time
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewPersistableBaseMana creates a new PersistableBaseMana from the BaseMana of the given node.
+func NewPersistableBaseMana(manaType Type, nodeID identity.ID, baseMana *BaseMana) *PersistableBaseMana {
+	return &PersistableBaseMana{
+		manaType:       manaType,
+		nodeID:         nodeID,
+		baseValue:      baseMana.BaseValue(),
+		effectiveValue: baseMana.EffectiveValue(),
+		lastUpdated:    baseMana.LastUpdated(),
+	}
+}
+
+// PersistableBaseManaFromBytes unmarshals a PersistableBaseMana from a sequence of bytes.
+func PersistableBaseManaFromBytes(bytes []byte) (persistableBaseMana *PersistableBaseMana, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if persistableBaseMana, err = PersistableBaseManaFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse PersistableBaseMana from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// PersistableBaseManaFromMarshalUtil unmarshals a PersistableBaseMana using a MarshalUtil (for easier unmarshaling).
+func PersistableBaseManaFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (persistableBaseMana *PersistableBaseMana, err error) {
+	persistableBaseMana = &PersistableBaseMana{}
+
+	manaType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse mana Type (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	persistableBaseMana.manaType = Type(manaType)
+
+	nodeIDBytes, err := marshalUtil.ReadBytes(len(identity.ID{}))
+	if err != nil {
+		err = xerrors.Errorf("failed to parse NodeID (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	copy(persistableBaseMana.nodeID[:], nodeIDBytes)
+
+	baseValue, err := marshalUtil.ReadUint64()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse base value (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	persistableBaseMana.baseValue = math.Float64frombits(baseValue)
+
+	effectiveValue, err := marshalUtil.ReadUint64()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse effective value (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	persistableBaseMana.effectiveValue = math.Float64frombits(effectiveValue)
+
+	if persistableBaseMana.lastUpdated, err = marshalUtil.ReadTime(); err != nil {
+		err = xerrors.Errorf("failed to parse last updated time (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// PersistableBaseManaFromObjectStorage restores a PersistableBaseMana that was stored in the object storage.
+func PersistableBaseManaFromObjectStorage(key []byte, data []byte) (persistableBaseMana objectstorage.StorableObject, err error) {
+	if persistableBaseMana, _, err = PersistableBaseManaFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = xerrors.Errorf("failed to parse PersistableBaseMana from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// ManaType returns the mana Type of the PersistableBaseMana.
+func (p *PersistableBaseMana) ManaType() Type {
+	return p.manaType
+}
+
+// NodeID returns the ID of the node that the PersistableBaseMana belongs to.
+func (p *PersistableBaseMana) NodeID() identity.ID {
+	return p.nodeID
+}
+
+// BaseMana returns the BaseMana that is represented by the PersistableBaseMana.
+func (p *PersistableBaseMana) BaseMana() *BaseMana {
+	return NewBaseMana(p.baseValue, p.effectiveValue, p.lastUpdated)
+}
+
+// Bytes returns a marshaled version of the PersistableBaseMana.
+func (p *PersistableBaseMana) Bytes() []byte {
+	return byteutils.ConcatBytes(p.ObjectStorageKey(), p.ObjectStorageValue())
+}
+
+// String returns a human readable version of the PersistableBaseMana.
+func (p *PersistableBaseMana) String() string {
+	return stringify.Struct("PersistableBaseMana",
+		stringify.StructField("manaType", p.manaType),
+		stringify.StructField("nodeID", p.nodeID),
+		stringify.StructField("baseValue", p.baseValue),
+		stringify.StructField("effectiveValue", p.effectiveValue),
+		stringify.StructField("lastUpdated", p.lastUpdated),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (p *PersistableBaseMana) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (p *PersistableBaseMana) ObjectStorageKey() []byte {
+	return marshalutil.New(1 + len(identity.ID{})).
+		WriteByte(byte(p.manaType)).
+		WriteBytes(p.nodeID.Bytes()).
+		Bytes()
+}
+
+// ObjectStorageValue marshals the PersistableBaseMana into a sequence of bytes that are used as the value part in the
+// object storage.
+func (p *PersistableBaseMana) ObjectStorageValue() []byte {
+	return marshalutil.New(2*marshalutil.Uint64Size + marshalutil.TimeSize).
+		WriteUint64(math.Float64bits(p.baseValue)).
+		WriteUint64(math.Float64bits(p.effectiveValue)).
+		WriteTime(p.lastUpdated).
+		Bytes()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &PersistableBaseMana{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
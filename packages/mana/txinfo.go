@@ -0,0 +1,55 @@
+package mana
+
+import (
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/identity"
+)
+
+// region TxInfo ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// TxInfo contains the information of a Transaction that is relevant for the mana calculations.
+type TxInfo struct {
+	// TimeStamp is the timestamp of the Transaction.
+	TimeStamp time.Time
+
+	// TransactionID is the ID of the Transaction.
+	TransactionID ledgerstate.TransactionID
+
+	// TotalBalance is the amount of tokens that are moved by the Transaction.
+	TotalBalance float64
+
+	// PledgeID contains the nodes that the different types of mana are pledged to.
+	PledgeID map[Type]identity.ID
+
+	// InputInfos contains the information about the Inputs of the Transaction.
+	InputInfos []InputInfo
+}
+
+// sumInputs returns the sum of the amounts of all Inputs.
+func (t *TxInfo) sumInputs() (sum float64) {
+	for _, inputInfo := range t.InputInfos {
+		sum += inputInfo.Amount
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region InputInfo ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// InputInfo contains the information about a consumed Output that is relevant for the mana calculations.
+type InputInfo struct {
+	// TimeStamp is the timestamp of the Transaction that created the Output.
+	TimeStamp time.Time
+
+	// Amount is the amount of tokens held by the Output.
+	Amount float64
+
+	// PledgeID contains the nodes that the different types of mana of the Output were pledged to.
+	PledgeID map[Type]identity.ID
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
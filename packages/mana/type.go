@@ -0,0 +1,48 @@
+package mana
+
+import (
+	"fmt"
+
+	"golang.org/x/xerrors"
+)
+
+// region Type /////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// AccessMana is the Type of mana that is used to gain access to the network (i.e. for rate control).
+	AccessMana Type = iota
+
+	// ConsensusMana is the Type of mana that is used as a weight in the consensus mechanisms.
+	ConsensusMana
+)
+
+// Type defines the different kinds of mana that are tracked by the node.
+type Type byte
+
+// TypeFromString parses a Type from its human readable representation.
+func TypeFromString(typeString string) (manaType Type, err error) {
+	switch typeString {
+	case "Access":
+		manaType = AccessMana
+	case "Consensus":
+		manaType = ConsensusMana
+	default:
+		err = xerrors.Errorf("unknown mana type '%s': %w", typeString, ErrUnknownManaType)
+	}
+
+	return
+}
+
+// String returns a human readable version of the Type.
+func (t Type) String() string {
+	switch t {
+	case AccessMana:
+		return "Access"
+	case ConsensusMana:
+		return "Consensus"
+	default:
+		return fmt.Sprintf("Type(%X)", uint8(t))
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -5,6 +5,12 @@ const (
 	PriorityDatabase = iota
 	// PriorityTangle defines the shutdown priority for the tangle.
 	PriorityTangle
+	// PriorityLocalSnapshot defines the shutdown priority for the local snapshots.
+	PriorityLocalSnapshot
+	// PrioritySupplyAudit defines the shutdown priority for the supply audits.
+	PrioritySupplyAudit
+	// PriorityBranchGC defines the shutdown priority for the clean up of the branch DAG.
+	PriorityBranchGC
 	// PriorityValueTangle defines the shutdown priority for the value tangle.
 	PriorityFPC
 	// PriorityFaucet defines the shutdown priority for the faucet.
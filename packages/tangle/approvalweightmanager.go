@@ -0,0 +1,995 @@
+package tangle
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/markers"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/datastructure/walker"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+	"github.com/iotaledger/hive.go/types"
+	"golang.org/x/xerrors"
+)
+
+// DefaultApprovalWeightThreshold defines the default share of the total weight that needs to approve a Marker or a
+// Branch for it to be confirmed.
+const DefaultApprovalWeightThreshold = 0.66
+
+// region ApprovalWeightManager ////////////////////////////////////////////////////////////////////////////////////////
+
+// ApprovalWeightManager is a Tangle component that keeps track of the issuers (supporters) that approve the Markers and
+// the Branches of the Tangle and that confirms them once the weight of their supporters crosses the configured
+// threshold.
+type ApprovalWeightManager struct {
+	// Events is a dictionary for the ApprovalWeightManager related Events.
+	Events *ApprovalWeightManagerEvents
+
+	tangle *Tangle
+}
+
+// NewApprovalWeightManager is the constructor of the ApprovalWeightManager. It panics if the configured threshold is
+// not a share of the total weight.
+func NewApprovalWeightManager(tangle *Tangle) (approvalWeightManager *ApprovalWeightManager) {
+	if threshold := tangle.Options.ApprovalWeightThreshold; threshold <= 0 || threshold > 1 {
+		panic(fmt.Sprintf("approval weight threshold %f is not within (0, 1]", threshold))
+	}
+
+	approvalWeightManager = &ApprovalWeightManager{
+		Events: &ApprovalWeightManagerEvents{
+			MarkerConfirmed: events.NewEvent(markerEventHandler),
+			BranchConfirmed: events.NewEvent(branchIDEventHandler),
+		},
+		tangle: tangle,
+	}
+
+	return
+}
+
+// Setup sets up the behavior of the component by making it attach to the relevant events of other components.
+func (a *ApprovalWeightManager) Setup() {
+	a.tangle.Booker.Events.MessageBooked.Attach(events.NewClosure(a.ProcessMessage))
+}
+
+// ProcessMessage adds the issuer of the given Message to the supporters of all Markers and Branches that are approved
+// by the Message.
+func (a *ApprovalWeightManager) ProcessMessage(messageID MessageID) {
+	a.tangle.Storage.Message(messageID).Consume(func(message *Message) {
+		issuerID := identity.NewID(message.IssuerPublicKey())
+		a.tangle.Options.WeightProvider.Update(issuerID)
+
+		a.tangle.Storage.MessageMetadata(messageID).Consume(func(messageMetadata *MessageMetadata) {
+			a.addSupporterToMarkers(messageMetadata.StructureDetails().PastMarkers, issuerID)
+			a.addSupporterToBranch(messageMetadata.BranchID(), issuerID, message.SequenceNumber())
+		})
+	})
+}
+
+// WeightOfMarker returns the share of the total weight that approves the given Marker.
+func (a *ApprovalWeightManager) WeightOfMarker(marker *markers.Marker) (weight float64) {
+	a.tangle.Storage.SequenceSupporters(marker.SequenceID()).Consume(func(sequenceSupporters *SequenceSupporters) {
+		for supporter, index := range sequenceSupporters.Supporters() {
+			if index >= marker.Index() {
+				weight += a.tangle.Options.WeightProvider.Weight(supporter)
+			}
+		}
+	})
+
+	return a.normalizedWeight(weight)
+}
+
+// WeightOfBranch returns the share of the total weight that approves the given Branch.
+func (a *ApprovalWeightManager) WeightOfBranch(branchID ledgerstate.BranchID) (weight float64) {
+	a.tangle.Storage.BranchSupporters(branchID).Consume(func(branchSupporters *BranchSupporters) {
+		weight = a.weightOfSupporters(branchSupporters.Supporters())
+	})
+
+	return a.normalizedWeight(weight)
+}
+
+// IsMarkerConfirmed returns true if the given Marker has been confirmed by the approval weight of its supporters.
+func (a *ApprovalWeightManager) IsMarkerConfirmed(marker *markers.Marker) (confirmed bool) {
+	a.tangle.Storage.SequenceSupporters(marker.SequenceID()).Consume(func(sequenceSupporters *SequenceSupporters) {
+		confirmed = marker.Index() <= sequenceSupporters.ConfirmedIndex()
+	})
+
+	return
+}
+
+// IsBranchConfirmed returns true if the given Branch has been confirmed by the approval weight of its supporters.
+func (a *ApprovalWeightManager) IsBranchConfirmed(branchID ledgerstate.BranchID) (confirmed bool) {
+	if branchID == ledgerstate.MasterBranchID {
+		return true
+	}
+
+	a.tangle.Storage.BranchSupporters(branchID).Consume(func(branchSupporters *BranchSupporters) {
+		confirmed = branchSupporters.Confirmed()
+	})
+
+	return
+}
+
+// IsMessageConfirmed returns true if the given Message is referenced by a confirmed Marker (or is a confirmed Marker
+// itself).
+func (a *ApprovalWeightManager) IsMessageConfirmed(messageID MessageID) (confirmed bool) {
+	a.tangle.Storage.MessageMetadata(messageID).Consume(func(messageMetadata *MessageMetadata) {
+		structureDetails := messageMetadata.StructureDetails()
+		if structureDetails == nil {
+			return
+		}
+
+		if structureDetails.IsPastMarker {
+			confirmed = a.IsMarkerConfirmed(structureDetails.PastMarkers.FirstMarker())
+			return
+		}
+
+		structureDetails.FutureMarkers.ForEach(func(sequenceID markers.SequenceID, index markers.Index) bool {
+			confirmed = a.IsMarkerConfirmed(markers.NewMarker(sequenceID, index))
+			return !confirmed
+		})
+	})
+
+	return
+}
+
+// addSupporterToMarkers adds the issuer to the supporters of the given Markers and all the Markers that are referenced
+// by them.
+func (a *ApprovalWeightManager) addSupporterToMarkers(pastMarkers *markers.Markers, issuerID identity.ID) {
+	markerWalker := walker.New()
+	pastMarkers.ForEach(func(sequenceID markers.SequenceID, index markers.Index) bool {
+		markerWalker.Push(*markers.NewMarker(sequenceID, index))
+		return true
+	})
+
+	for markerWalker.HasNext() {
+		marker := markerWalker.Next().(markers.Marker)
+
+		supportAdded := false
+		a.tangle.Storage.SequenceSupporters(marker.SequenceID(), NewSequenceSupporters).Consume(func(sequenceSupporters *SequenceSupporters) {
+			supportAdded = sequenceSupporters.AddSupporter(issuerID, marker.Index())
+		})
+		if !supportAdded {
+			continue
+		}
+
+		a.booker().MarkersManager.Sequence(marker.SequenceID()).Consume(func(sequence *markers.Sequence) {
+			a.updateSequenceConfirmation(sequence)
+
+			sequence.HighestReferencedParentMarkers(marker.Index()).ForEach(func(sequenceID markers.SequenceID, index markers.Index) bool {
+				markerWalker.Push(*markers.NewMarker(sequenceID, index))
+				return true
+			})
+		})
+	}
+}
+
+// updateSequenceConfirmation confirms the Markers of the given Sequence whose approval weight crossed the threshold.
+func (a *ApprovalWeightManager) updateSequenceConfirmation(sequence *markers.Sequence) {
+	confirmedMarkers := make([]*markers.Marker, 0)
+	a.tangle.Storage.SequenceSupporters(sequence.ID()).Consume(func(sequenceSupporters *SequenceSupporters) {
+		supporters := sequenceSupporters.Supporters()
+		supporterIDs := make([]identity.ID, 0, len(supporters))
+		for supporter := range supporters {
+			supporterIDs = append(supporterIDs, supporter)
+		}
+		sort.Slice(supporterIDs, func(i, j int) bool {
+			return supporters[supporterIDs[i]] > supporters[supporterIDs[j]]
+		})
+
+		// the weight of a Marker is the weight of all supporters that approve an equal or higher Index
+		weight := float64(0)
+		for _, supporterID := range supporterIDs {
+			weight += a.tangle.Options.WeightProvider.Weight(supporterID)
+			if a.thresholdReached(weight) {
+				confirmedIndex := supporters[supporterID]
+				previouslyConfirmedIndex := sequenceSupporters.ConfirmedIndex()
+				if !sequenceSupporters.SetConfirmedIndex(confirmedIndex) {
+					return
+				}
+
+				for index := previouslyConfirmedIndex + 1; index <= confirmedIndex; index++ {
+					if index >= sequence.LowestIndex() {
+						confirmedMarkers = append(confirmedMarkers, markers.NewMarker(sequence.ID(), index))
+					}
+				}
+				return
+			}
+		}
+	})
+
+	for _, confirmedMarker := range confirmedMarkers {
+		a.Events.MarkerConfirmed.Trigger(confirmedMarker)
+	}
+}
+
+// addSupporterToBranch adds the issuer to the supporters of the conflict Branches that make up the given Branch (and
+// their parents) and removes it from the supporters of the Branches that conflict with them.
+func (a *ApprovalWeightManager) addSupporterToBranch(branchID ledgerstate.BranchID, issuerID identity.ID, sequenceNumber uint64) {
+	branchWalker := walker.New()
+	branchWalker.Push(branchID)
+
+	for branchWalker.HasNext() {
+		currentBranchID := branchWalker.Next().(ledgerstate.BranchID)
+		if currentBranchID == ledgerstate.MasterBranchID {
+			continue
+		}
+
+		a.tangle.LedgerState.branchDAG.Branch(currentBranchID).Consume(func(branch ledgerstate.Branch) {
+			for parentBranchID := range branch.Parents() {
+				branchWalker.Push(parentBranchID)
+			}
+
+			if branch.Type() == ledgerstate.ConflictBranchType {
+				a.addSupporterToConflictBranch(branch.(*ledgerstate.ConflictBranch), issuerID, sequenceNumber)
+			}
+		})
+	}
+}
+
+// addSupporterToConflictBranch adds the issuer to the supporters of the given ConflictBranch unless the issuer already
+// issued a more recent Message that supports a conflicting Branch.
+func (a *ApprovalWeightManager) addSupporterToConflictBranch(conflictBranch *ledgerstate.ConflictBranch, issuerID identity.ID, sequenceNumber uint64) {
+	conflictingBranchIDs := a.conflictingBranchIDs(conflictBranch)
+	for _, conflictingBranchID := range conflictingBranchIDs {
+		newerStatementExists := false
+		a.tangle.Storage.BranchSupporters(conflictingBranchID).Consume(func(branchSupporters *BranchSupporters) {
+			supporterSequenceNumber, supported := branchSupporters.Supporters()[issuerID]
+			newerStatementExists = supported && supporterSequenceNumber > sequenceNumber
+		})
+		if newerStatementExists {
+			return
+		}
+	}
+
+	for _, conflictingBranchID := range conflictingBranchIDs {
+		a.revokeSupport(conflictingBranchID, issuerID)
+	}
+
+	branchConfirmed := false
+	a.tangle.Storage.BranchSupporters(conflictBranch.ID(), NewBranchSupporters).Consume(func(branchSupporters *BranchSupporters) {
+		if !branchSupporters.AddSupporter(issuerID, sequenceNumber) || branchSupporters.Confirmed() {
+			return
+		}
+
+		if a.thresholdReached(a.weightOfSupporters(branchSupporters.Supporters())) {
+			branchConfirmed = branchSupporters.SetConfirmed()
+		}
+	})
+
+	if branchConfirmed {
+		a.Events.BranchConfirmed.Trigger(conflictBranch.ID())
+	}
+}
+
+// revokeSupport removes the issuer from the supporters of the given Branch and all of its child Branches.
+func (a *ApprovalWeightManager) revokeSupport(branchID ledgerstate.BranchID, issuerID identity.ID) {
+	branchWalker := walker.New()
+	branchWalker.Push(branchID)
+
+	for branchWalker.HasNext() {
+		currentBranchID := branchWalker.Next().(ledgerstate.BranchID)
+
+		a.tangle.Storage.BranchSupporters(currentBranchID).Consume(func(branchSupporters *BranchSupporters) {
+			branchSupporters.DeleteSupporter(issuerID)
+		})
+
+		a.tangle.LedgerState.branchDAG.ChildBranches(currentBranchID).Consume(func(childBranch *ledgerstate.ChildBranch) {
+			branchWalker.Push(childBranch.ChildBranchID())
+		})
+	}
+}
+
+// conflictingBranchIDs returns the IDs of the Branches that are conflicting with the given ConflictBranch.
+func (a *ApprovalWeightManager) conflictingBranchIDs(conflictBranch *ledgerstate.ConflictBranch) (conflictingBranchIDs []ledgerstate.BranchID) {
+	conflictingBranchIDs = make([]ledgerstate.BranchID, 0)
+	for conflictID := range conflictBranch.Conflicts() {
+		a.tangle.LedgerState.branchDAG.ConflictMembers(conflictID).Consume(func(conflictMember *ledgerstate.ConflictMember) {
+			if conflictMember.BranchID() != conflictBranch.ID() {
+				conflictingBranchIDs = append(conflictingBranchIDs, conflictMember.BranchID())
+			}
+		})
+	}
+
+	return
+}
+
+// weightOfSupporters returns the accumulated weight of the given supporters.
+func (a *ApprovalWeightManager) weightOfSupporters(supporters map[identity.ID]uint64) (weight float64) {
+	for supporter := range supporters {
+		weight += a.tangle.Options.WeightProvider.Weight(supporter)
+	}
+
+	return
+}
+
+// thresholdReached returns true if the given weight reaches the configured share of the total weight.
+func (a *ApprovalWeightManager) thresholdReached(weight float64) bool {
+	totalWeight := a.tangle.Options.WeightProvider.TotalWeight()
+
+	return totalWeight > 0 && weight >= a.tangle.Options.ApprovalWeightThreshold*totalWeight
+}
+
+// normalizedWeight returns the given weight as a share of the total weight.
+func (a *ApprovalWeightManager) normalizedWeight(weight float64) float64 {
+	totalWeight := a.tangle.Options.WeightProvider.TotalWeight()
+	if totalWeight == 0 {
+		return 0
+	}
+
+	return weight / totalWeight
+}
+
+// booker is an internal utility function that returns the Booker of the Tangle.
+func (a *ApprovalWeightManager) booker() *Booker {
+	return a.tangle.Booker
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ApprovalWeightManagerEvents //////////////////////////////////////////////////////////////////////////////////
+
+// ApprovalWeightManagerEvents represents events happening in the ApprovalWeightManager.
+type ApprovalWeightManagerEvents struct {
+	// MarkerConfirmed is triggered when the approval weight of a Marker crosses the confirmation threshold.
+	MarkerConfirmed *events.Event
+
+	// BranchConfirmed is triggered when the approval weight of a Branch crosses the confirmation threshold.
+	BranchConfirmed *events.Event
+}
+
+func markerEventHandler(handler interface{}, params ...interface{}) {
+	handler.(func(*markers.Marker))(params[0].(*markers.Marker))
+}
+
+func branchIDEventHandler(handler interface{}, params ...interface{}) {
+	handler.(func(ledgerstate.BranchID))(params[0].(ledgerstate.BranchID))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region WeightProvider ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// WeightProvider is the interface of the components that determine the weight of the issuers that is used by the
+// ApprovalWeightManager to confirm Markers and Branches.
+type WeightProvider interface {
+	// Update notifies the WeightProvider that the given issuer has issued a Message.
+	Update(issuerID identity.ID)
+
+	// Weight returns the weight of the given issuer.
+	Weight(issuerID identity.ID) float64
+
+	// TotalWeight returns the accumulated weight of all issuers.
+	TotalWeight() float64
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ManaWeightProvider ///////////////////////////////////////////////////////////////////////////////////////////
+
+// DefaultWeightProviderActivityWindow defines the default time after which an issuer that did not issue any Message is
+// not considered to be active anymore (and loses its weight).
+const DefaultWeightProviderActivityWindow = 30 * time.Minute
+
+// ManaRetrieverFunc is the type of the function that returns the (consensus) mana of an issuer.
+type ManaRetrieverFunc func(issuerID identity.ID) float64
+
+// ManaWeightProvider is a WeightProvider that weighs the active issuers (the issuers that issued a Message within the
+// activity window) by their mana, so that creating identities does not increase the weight of an attacker. As long as
+// none of the active issuers has any mana (i.e. while a network is bootstrapped or if no ManaRetrieverFunc is given),
+// every active issuer has the same weight.
+type ManaWeightProvider struct {
+	manaRetriever  ManaRetrieverFunc
+	activityWindow time.Duration
+	lastActive     map[identity.ID]time.Time
+	lastCleanup    time.Time
+	mutex          sync.RWMutex
+}
+
+// NewManaWeightProvider is the constructor of the ManaWeightProvider.
+func NewManaWeightProvider(manaRetriever ManaRetrieverFunc, activityWindow time.Duration) *ManaWeightProvider {
+	return &ManaWeightProvider{
+		manaRetriever:  manaRetriever,
+		activityWindow: activityWindow,
+		lastActive:     make(map[identity.ID]time.Time),
+		lastCleanup:    time.Now(),
+	}
+}
+
+// Update marks the issuer as active and removes the issuers that are not active anymore.
+func (m *ManaWeightProvider) Update(issuerID identity.ID) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	m.lastActive[issuerID] = now
+
+	if now.Sub(m.lastCleanup) < m.activityWindow {
+		return
+	}
+	for activeIssuerID, lastActive := range m.lastActive {
+		if now.Sub(lastActive) >= m.activityWindow {
+			delete(m.lastActive, activeIssuerID)
+		}
+	}
+	m.lastCleanup = now
+}
+
+// Weight returns the mana of the given issuer if it is active and 0 otherwise.
+func (m *ManaWeightProvider) Weight(issuerID identity.ID) float64 {
+	activeIssuers := m.activeIssuers()
+	if _, active := activeIssuers[issuerID]; !active {
+		return 0
+	}
+
+	if m.totalMana(activeIssuers) == 0 {
+		return 1
+	}
+
+	return m.mana(issuerID)
+}
+
+// TotalWeight returns the accumulated mana of the active issuers.
+func (m *ManaWeightProvider) TotalWeight() float64 {
+	activeIssuers := m.activeIssuers()
+	if totalMana := m.totalMana(activeIssuers); totalMana != 0 {
+		return totalMana
+	}
+
+	return float64(len(activeIssuers))
+}
+
+// activeIssuers is an internal utility function that returns the issuers that issued a Message within the activity
+// window.
+func (m *ManaWeightProvider) activeIssuers() (activeIssuers map[identity.ID]types.Empty) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	now := time.Now()
+	activeIssuers = make(map[identity.ID]types.Empty, len(m.lastActive))
+	for issuerID, lastActive := range m.lastActive {
+		if now.Sub(lastActive) < m.activityWindow {
+			activeIssuers[issuerID] = types.Void
+		}
+	}
+
+	return
+}
+
+// totalMana is an internal utility function that returns the accumulated mana of the given issuers.
+func (m *ManaWeightProvider) totalMana(issuers map[identity.ID]types.Empty) (totalMana float64) {
+	for issuerID := range issuers {
+		totalMana += m.mana(issuerID)
+	}
+
+	return
+}
+
+// mana is an internal utility function that returns the mana of the given issuer.
+func (m *ManaWeightProvider) mana(issuerID identity.ID) float64 {
+	if m.manaRetriever == nil {
+		return 0
+	}
+
+	return m.manaRetriever(issuerID)
+}
+
+// code contract (make sure the type implements all required methods)
+var _ WeightProvider = &ManaWeightProvider{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SequenceSupporters ///////////////////////////////////////////////////////////////////////////////////////////
+
+// SequenceSupporters is a data structure that keeps track of the highest Index of a Sequence that each supporter
+// approves (approving a Marker implies approving all Markers of the same Sequence with a lower Index).
+type SequenceSupporters struct {
+	sequenceID     markers.SequenceID
+	supporters     map[identity.ID]markers.Index
+	confirmedIndex markers.Index
+	mutex          sync.RWMutex
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewSequenceSupporters is the constructor for the SequenceSupporters object.
+func NewSequenceSupporters(sequenceID markers.SequenceID) (sequenceSupporters *SequenceSupporters) {
+	sequenceSupporters = &SequenceSupporters{
+		sequenceID: sequenceID,
+		supporters: make(map[identity.ID]markers.Index),
+	}
+
+	sequenceSupporters.Persist()
+	sequenceSupporters.SetModified()
+
+	return
+}
+
+// SequenceSupportersFromBytes unmarshals a SequenceSupporters object from a sequence of bytes.
+func SequenceSupportersFromBytes(bytes []byte) (sequenceSupporters *SequenceSupporters, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if sequenceSupporters, err = SequenceSupportersFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse SequenceSupporters from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// SequenceSupportersFromMarshalUtil unmarshals a SequenceSupporters object using a MarshalUtil (for easier
+// unmarshaling).
+func SequenceSupportersFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (sequenceSupporters *SequenceSupporters, err error) {
+	sequenceSupporters = &SequenceSupporters{}
+	if sequenceSupporters.sequenceID, err = markers.SequenceIDFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse SequenceID from MarshalUtil: %w", err)
+		return
+	}
+	if sequenceSupporters.confirmedIndex, err = markers.IndexFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse confirmed Index from MarshalUtil: %w", err)
+		return
+	}
+	supportersCount, err := marshalUtil.ReadUint64()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse supporters count (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	sequenceSupporters.supporters = make(map[identity.ID]markers.Index, supportersCount)
+	for i := uint64(0); i < supportersCount; i++ {
+		supporter, supporterErr := identityIDFromMarshalUtil(marshalUtil)
+		if supporterErr != nil {
+			err = xerrors.Errorf("failed to parse supporter: %w", supporterErr)
+			return
+		}
+
+		index, indexErr := markers.IndexFromMarshalUtil(marshalUtil)
+		if indexErr != nil {
+			err = xerrors.Errorf("failed to parse Index from MarshalUtil: %w", indexErr)
+			return
+		}
+
+		sequenceSupporters.supporters[supporter] = index
+	}
+
+	return
+}
+
+// SequenceSupportersFromObjectStorage restores a SequenceSupporters object that was stored in the object storage.
+func SequenceSupportersFromObjectStorage(key []byte, data []byte) (sequenceSupporters objectstorage.StorableObject, err error) {
+	if sequenceSupporters, _, err = SequenceSupportersFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = xerrors.Errorf("failed to parse SequenceSupporters from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// SequenceID returns the SequenceID that these SequenceSupporters belong to.
+func (s *SequenceSupporters) SequenceID() markers.SequenceID {
+	return s.sequenceID
+}
+
+// AddSupporter raises the highest Index that is approved by the given supporter. It returns true if the Index was
+// raised.
+func (s *SequenceSupporters) AddSupporter(supporter identity.ID, index markers.Index) (added bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if highestApprovedIndex, exists := s.supporters[supporter]; exists && highestApprovedIndex >= index {
+		return
+	}
+
+	s.supporters[supporter] = index
+	s.SetModified()
+	added = true
+
+	return
+}
+
+// Supporters returns a copy of the supporters and the highest Index that they approve.
+func (s *SequenceSupporters) Supporters() (supporters map[identity.ID]markers.Index) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	supporters = make(map[identity.ID]markers.Index, len(s.supporters))
+	for supporter, index := range s.supporters {
+		supporters[supporter] = index
+	}
+
+	return
+}
+
+// ConfirmedIndex returns the highest Index of the Sequence that has been confirmed.
+func (s *SequenceSupporters) ConfirmedIndex() markers.Index {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.confirmedIndex
+}
+
+// SetConfirmedIndex raises the highest Index of the Sequence that has been confirmed. It returns true if the Index was
+// raised.
+func (s *SequenceSupporters) SetConfirmedIndex(index markers.Index) (modified bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if index <= s.confirmedIndex {
+		return
+	}
+
+	s.confirmedIndex = index
+	s.SetModified()
+	modified = true
+
+	return
+}
+
+// Bytes returns a marshaled version of the SequenceSupporters.
+func (s *SequenceSupporters) Bytes() []byte {
+	return byteutils.ConcatBytes(s.ObjectStorageKey(), s.ObjectStorageValue())
+}
+
+// String returns a human readable version of the SequenceSupporters.
+func (s *SequenceSupporters) String() string {
+	structBuilder := stringify.StructBuilder("SequenceSupporters",
+		stringify.StructField("sequenceID", s.sequenceID),
+		stringify.StructField("confirmedIndex", s.ConfirmedIndex()),
+	)
+	for supporter, index := range s.Supporters() {
+		structBuilder.AddField(stringify.StructField(supporter.String(), index))
+	}
+
+	return structBuilder.String()
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (s *SequenceSupporters) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (s *SequenceSupporters) ObjectStorageKey() []byte {
+	return s.sequenceID.Bytes()
+}
+
+// ObjectStorageValue marshals the SequenceSupporters into a sequence of bytes that are used as the value part in the
+// object storage.
+func (s *SequenceSupporters) ObjectStorageValue() []byte {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	marshalUtil := marshalutil.New()
+	marshalUtil.Write(s.confirmedIndex)
+	marshalUtil.WriteUint64(uint64(len(s.supporters)))
+	for supporter, index := range s.supporters {
+		marshalUtil.WriteBytes(supporter.Bytes())
+		marshalUtil.Write(index)
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &SequenceSupporters{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedSequenceSupporters /////////////////////////////////////////////////////////////////////////////////////
+
+// CachedSequenceSupporters is a wrapper for the generic CachedObject returned by the object storage that overrides the
+// accessor methods with a type-casted one.
+type CachedSequenceSupporters struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks the CachedObject to still be in use by the program.
+func (c *CachedSequenceSupporters) Retain() *CachedSequenceSupporters {
+	return &CachedSequenceSupporters{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedSequenceSupporters) Unwrap() *SequenceSupporters {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*SequenceSupporters)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (c *CachedSequenceSupporters) Consume(consumer func(sequenceSupporters *SequenceSupporters), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*SequenceSupporters))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedSequenceSupporters.
+func (c *CachedSequenceSupporters) String() string {
+	return stringify.Struct("CachedSequenceSupporters",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region BranchSupporters /////////////////////////////////////////////////////////////////////////////////////////////
+
+// BranchSupporters is a data structure that keeps track of the supporters of a Branch together with the sequence number
+// of the latest Message that they issued in support of the Branch.
+type BranchSupporters struct {
+	branchID   ledgerstate.BranchID
+	supporters map[identity.ID]uint64
+	confirmed  bool
+	mutex      sync.RWMutex
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewBranchSupporters is the constructor for the BranchSupporters object.
+func NewBranchSupporters(branchID ledgerstate.BranchID) (branchSupporters *BranchSupporters) {
+	branchSupporters = &BranchSupporters{
+		branchID:   branchID,
+		supporters: make(map[identity.ID]uint64),
+	}
+
+	branchSupporters.Persist()
+	branchSupporters.SetModified()
+
+	return
+}
+
+// BranchSupportersFromBytes unmarshals a BranchSupporters object from a sequence of bytes.
+func BranchSupportersFromBytes(bytes []byte) (branchSupporters *BranchSupporters, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if branchSupporters, err = BranchSupportersFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse BranchSupporters from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// BranchSupportersFromMarshalUtil unmarshals a BranchSupporters object using a MarshalUtil (for easier unmarshaling).
+func BranchSupportersFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (branchSupporters *BranchSupporters, err error) {
+	branchSupporters = &BranchSupporters{}
+	if branchSupporters.branchID, err = ledgerstate.BranchIDFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse BranchID from MarshalUtil: %w", err)
+		return
+	}
+	if branchSupporters.confirmed, err = marshalUtil.ReadBool(); err != nil {
+		err = xerrors.Errorf("failed to parse confirmed flag (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	supportersCount, err := marshalUtil.ReadUint64()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse supporters count (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	branchSupporters.supporters = make(map[identity.ID]uint64, supportersCount)
+	for i := uint64(0); i < supportersCount; i++ {
+		supporter, supporterErr := identityIDFromMarshalUtil(marshalUtil)
+		if supporterErr != nil {
+			err = xerrors.Errorf("failed to parse supporter: %w", supporterErr)
+			return
+		}
+
+		sequenceNumber, sequenceNumberErr := marshalUtil.ReadUint64()
+		if sequenceNumberErr != nil {
+			err = xerrors.Errorf("failed to parse sequence number (%v): %w", sequenceNumberErr, cerrors.ErrParseBytesFailed)
+			return
+		}
+
+		branchSupporters.supporters[supporter] = sequenceNumber
+	}
+
+	return
+}
+
+// BranchSupportersFromObjectStorage restores a BranchSupporters object that was stored in the object storage.
+func BranchSupportersFromObjectStorage(key []byte, data []byte) (branchSupporters objectstorage.StorableObject, err error) {
+	if branchSupporters, _, err = BranchSupportersFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = xerrors.Errorf("failed to parse BranchSupporters from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// BranchID returns the BranchID that these BranchSupporters belong to.
+func (b *BranchSupporters) BranchID() ledgerstate.BranchID {
+	return b.branchID
+}
+
+// AddSupporter adds the given supporter with the sequence number of its latest Message. It returns true if the
+// supporter was not supporting the Branch before.
+func (b *BranchSupporters) AddSupporter(supporter identity.ID, sequenceNumber uint64) (added bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	previousSequenceNumber, exists := b.supporters[supporter]
+	if exists && previousSequenceNumber >= sequenceNumber {
+		return
+	}
+
+	b.supporters[supporter] = sequenceNumber
+	b.SetModified()
+	added = !exists
+
+	return
+}
+
+// DeleteSupporter removes the given supporter. It returns true if the supporter existed.
+func (b *BranchSupporters) DeleteSupporter(supporter identity.ID) (deleted bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if _, deleted = b.supporters[supporter]; !deleted {
+		return
+	}
+
+	delete(b.supporters, supporter)
+	b.SetModified()
+
+	return
+}
+
+// Supporters returns a copy of the supporters and the sequence numbers of their latest supporting Messages.
+func (b *BranchSupporters) Supporters() (supporters map[identity.ID]uint64) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	supporters = make(map[identity.ID]uint64, len(b.supporters))
+	for supporter, sequenceNumber := range b.supporters {
+		supporters[supporter] = sequenceNumber
+	}
+
+	return
+}
+
+// Confirmed returns true if the Branch has been confirmed.
+func (b *BranchSupporters) Confirmed() bool {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return b.confirmed
+}
+
+// SetConfirmed marks the Branch as confirmed. It returns true if the Branch was not confirmed before.
+func (b *BranchSupporters) SetConfirmed() (modified bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.confirmed {
+		return
+	}
+
+	b.confirmed = true
+	b.SetModified()
+	modified = true
+
+	return
+}
+
+// Bytes returns a marshaled version of the BranchSupporters.
+func (b *BranchSupporters) Bytes() []byte {
+	return byteutils.ConcatBytes(b.ObjectStorageKey(), b.ObjectStorageValue())
+}
+
+// String returns a human readable version of the BranchSupporters.
+func (b *BranchSupporters) String() string {
+	structBuilder := stringify.StructBuilder("BranchSupporters",
+		stringify.StructField("branchID", b.branchID),
+		stringify.StructField("confirmed", b.Confirmed()),
+	)
+	for supporter, sequenceNumber := range b.Supporters() {
+		structBuilder.AddField(stringify.StructField(supporter.String(), sequenceNumber))
+	}
+
+	return structBuilder.String()
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (b *BranchSupporters) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (b *BranchSupporters) ObjectStorageKey() []byte {
+	return b.branchID.Bytes()
+}
+
+// ObjectStorageValue marshals the BranchSupporters into a sequence of bytes that are used as the value part in the
+// object storage.
+func (b *BranchSupporters) ObjectStorageValue() []byte {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteBool(b.confirmed)
+	marshalUtil.WriteUint64(uint64(len(b.supporters)))
+	for supporter, sequenceNumber := range b.supporters {
+		marshalUtil.WriteBytes(supporter.Bytes())
+		marshalUtil.WriteUint64(sequenceNumber)
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &BranchSupporters{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedBranchSupporters ///////////////////////////////////////////////////////////////////////////////////////
+
+// CachedBranchSupporters is a wrapper for the generic CachedObject returned by the object storage that overrides the
+// accessor methods with a type-casted one.
+type CachedBranchSupporters struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks the CachedObject to still be in use by the program.
+func (c *CachedBranchSupporters) Retain() *CachedBranchSupporters {
+	return &CachedBranchSupporters{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedBranchSupporters) Unwrap() *BranchSupporters {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*BranchSupporters)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (c *CachedBranchSupporters) Consume(consumer func(branchSupporters *BranchSupporters), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*BranchSupporters))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedBranchSupporters.
+func (c *CachedBranchSupporters) String() string {
+	return stringify.Struct("CachedBranchSupporters",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// identityIDFromMarshalUtil is an internal utility function that unmarshals an identity.ID using a MarshalUtil.
+func identityIDFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (identityID identity.ID, err error) {
+	identityIDBytes, err := marshalUtil.ReadBytes(len(identity.ID{}))
+	if err != nil {
+		err = xerrors.Errorf("failed to parse identity.ID (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	copy(identityID[:], identityIDBytes)
+
+	return
+}
@@ -0,0 +1,206 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/markers"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApprovalWeightManager_MarkerConfirmation(t *testing.T) {
+	issuers := make([]*identity.LocalIdentity, 3)
+	weightProvider := NewManaWeightProvider(nil, DefaultWeightProviderActivityWindow)
+	for i := range issuers {
+		issuers[i] = identity.GenerateLocalIdentity()
+		weightProvider.Update(identity.NewID(issuers[i].PublicKey()))
+	}
+
+	tangle := New(WithoutOpinionFormer(true), ApprovalWeightProvider(weightProvider))
+	defer tangle.Shutdown()
+	tangle.Booker.Setup()
+	tangle.ApprovalWeightManager.Setup()
+
+	confirmedMarkers := make(map[markers.Marker]int)
+	tangle.ApprovalWeightManager.Events.MarkerConfirmed.Attach(events.NewClosure(func(marker *markers.Marker) {
+		confirmedMarkers[*marker]++
+	}))
+
+	messages := make([]*Message, len(issuers))
+	parent := EmptyMessageID
+	for i, issuer := range issuers {
+		messages[i] = newTestIssuerDataMessage(issuer.PublicKey(), []MessageID{parent})
+		tangle.Storage.StoreMessage(messages[i])
+		require.NoError(t, tangle.Booker.Book(messages[i].ID()))
+		parent = messages[i].ID()
+	}
+
+	messageMarkers := make([]*markers.Marker, len(messages))
+	for i, message := range messages {
+		tangle.Storage.MessageMetadata(message.ID()).Consume(func(messageMetadata *MessageMetadata) {
+			require.True(t, messageMetadata.StructureDetails().IsPastMarker)
+			messageMarkers[i] = messageMetadata.StructureDetails().PastMarkers.FirstMarker()
+		})
+	}
+
+	assert.Equal(t, float64(1), tangle.ApprovalWeightManager.WeightOfMarker(messageMarkers[0]))
+	assert.InDelta(t, 2.0/3.0, tangle.ApprovalWeightManager.WeightOfMarker(messageMarkers[1]), 1e-9)
+	assert.InDelta(t, 1.0/3.0, tangle.ApprovalWeightManager.WeightOfMarker(messageMarkers[2]), 1e-9)
+
+	assert.True(t, tangle.ApprovalWeightManager.IsMarkerConfirmed(messageMarkers[0]))
+	assert.True(t, tangle.ApprovalWeightManager.IsMarkerConfirmed(messageMarkers[1]))
+	assert.False(t, tangle.ApprovalWeightManager.IsMarkerConfirmed(messageMarkers[2]))
+
+	assert.True(t, tangle.ApprovalWeightManager.IsMessageConfirmed(messages[0].ID()))
+	assert.True(t, tangle.ApprovalWeightManager.IsMessageConfirmed(messages[1].ID()))
+	assert.False(t, tangle.ApprovalWeightManager.IsMessageConfirmed(messages[2].ID()))
+
+	assert.Equal(t, 1, confirmedMarkers[*messageMarkers[0]])
+	assert.Equal(t, 1, confirmedMarkers[*messageMarkers[1]])
+	assert.Equal(t, 0, confirmedMarkers[*messageMarkers[2]])
+}
+
+func TestApprovalWeightManager_BranchConfirmation(t *testing.T) {
+	issuers := make([]identity.ID, 3)
+	weightProvider := NewManaWeightProvider(nil, DefaultWeightProviderActivityWindow)
+	for i := range issuers {
+		issuers[i] = identity.NewID(identity.GenerateLocalIdentity().PublicKey())
+		weightProvider.Update(issuers[i])
+	}
+
+	tangle := New(WithoutOpinionFormer(true), ApprovalWeightProvider(weightProvider))
+	defer tangle.Shutdown()
+
+	confirmedBranches := make(map[ledgerstate.BranchID]int)
+	tangle.ApprovalWeightManager.Events.BranchConfirmed.Attach(events.NewClosure(func(branchID ledgerstate.BranchID) {
+		confirmedBranches[branchID]++
+	}))
+
+	// create two conflicting Branches by double spending the same Output
+	wallets := createWallets(3)
+	genesisOutput := ledgerstate.NewSigLockedSingleOutput(100, wallets[0].address)
+	genesisOutput.SetID(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 1))
+	require.NoError(t, tangle.LedgerState.LoadSnapshotOutput(genesisOutput, snapshotOutputMetadata(genesisOutput)))
+	spend := func(receiver wallet) ledgerstate.BranchID {
+		txEssence := ledgerstate.NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, ledgerstate.NewInputs(ledgerstate.NewUTXOInput(genesisOutput.ID())), ledgerstate.NewOutputs(ledgerstate.NewSigLockedSingleOutput(100, receiver.address)))
+		tx := ledgerstate.NewTransaction(txEssence, wallets[0].unlockBlocks(txEssence))
+		_, err := tangle.LedgerState.BookTransaction(tx, EmptyMessageID)
+		require.NoError(t, err)
+
+		return ledgerstate.NewBranchID(tx.ID())
+	}
+	branch1 := spend(wallets[1])
+	branch2 := spend(wallets[2])
+
+	supporters := func(branchID ledgerstate.BranchID) (supporters map[identity.ID]uint64) {
+		supporters = make(map[identity.ID]uint64)
+		tangle.Storage.BranchSupporters(branchID).Consume(func(branchSupporters *BranchSupporters) {
+			supporters = branchSupporters.Supporters()
+		})
+
+		return
+	}
+
+	// the supporters of the conflicting Branches are tracked separately
+	tangle.ApprovalWeightManager.addSupporterToBranch(branch1, issuers[0], 1)
+	tangle.ApprovalWeightManager.addSupporterToBranch(branch2, issuers[1], 1)
+	assert.Equal(t, map[identity.ID]uint64{issuers[0]: 1}, supporters(branch1))
+	assert.Equal(t, map[identity.ID]uint64{issuers[1]: 1}, supporters(branch2))
+	assert.InDelta(t, 1.0/3.0, tangle.ApprovalWeightManager.WeightOfBranch(branch1), 1e-9)
+	assert.False(t, tangle.ApprovalWeightManager.IsBranchConfirmed(branch1))
+	assert.False(t, tangle.ApprovalWeightManager.IsBranchConfirmed(branch2))
+
+	// supporting a conflicting Branch revokes the support of the previously supported Branch
+	tangle.ApprovalWeightManager.addSupporterToBranch(branch2, issuers[0], 2)
+	assert.Empty(t, supporters(branch1))
+	assert.Equal(t, map[identity.ID]uint64{issuers[0]: 2, issuers[1]: 1}, supporters(branch2))
+	assert.Equal(t, float64(0), tangle.ApprovalWeightManager.WeightOfBranch(branch1))
+	assert.InDelta(t, 2.0/3.0, tangle.ApprovalWeightManager.WeightOfBranch(branch2), 1e-9)
+
+	// the Branch is confirmed once its supporters cross the threshold
+	assert.True(t, tangle.ApprovalWeightManager.IsBranchConfirmed(branch2))
+	assert.False(t, tangle.ApprovalWeightManager.IsBranchConfirmed(branch1))
+	assert.Equal(t, 1, confirmedBranches[branch2])
+
+	// an older statement does not override the support of a conflicting Branch
+	tangle.ApprovalWeightManager.addSupporterToBranch(branch1, issuers[1], 0)
+	assert.Empty(t, supporters(branch1))
+	assert.Equal(t, map[identity.ID]uint64{issuers[0]: 2, issuers[1]: 1}, supporters(branch2))
+
+	// additional supporters of a confirmed Branch do not confirm it again
+	tangle.ApprovalWeightManager.addSupporterToBranch(branch2, issuers[2], 1)
+	assert.Equal(t, float64(1), tangle.ApprovalWeightManager.WeightOfBranch(branch2))
+	assert.Equal(t, 1, confirmedBranches[branch2])
+	assert.Equal(t, 0, confirmedBranches[branch1])
+}
+
+func TestSupporters_Marshaling(t *testing.T) {
+	supporter := identity.GenerateLocalIdentity().ID()
+
+	sequenceSupporters := NewSequenceSupporters(markers.SequenceID(3))
+	assert.True(t, sequenceSupporters.AddSupporter(supporter, 5))
+	assert.False(t, sequenceSupporters.AddSupporter(supporter, 4))
+	assert.True(t, sequenceSupporters.SetConfirmedIndex(2))
+
+	restoredSequenceSupporters, _, err := SequenceSupportersFromBytes(sequenceSupporters.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, sequenceSupporters.SequenceID(), restoredSequenceSupporters.SequenceID())
+	assert.Equal(t, sequenceSupporters.ConfirmedIndex(), restoredSequenceSupporters.ConfirmedIndex())
+	assert.Equal(t, sequenceSupporters.Supporters(), restoredSequenceSupporters.Supporters())
+
+	branchSupporters := NewBranchSupporters(ledgerstate.BranchID{2})
+	assert.True(t, branchSupporters.AddSupporter(supporter, 7))
+	assert.True(t, branchSupporters.SetConfirmed())
+
+	restoredBranchSupporters, _, err := BranchSupportersFromBytes(branchSupporters.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, branchSupporters.BranchID(), restoredBranchSupporters.BranchID())
+	assert.True(t, restoredBranchSupporters.Confirmed())
+	assert.Equal(t, branchSupporters.Supporters(), restoredBranchSupporters.Supporters())
+
+	assert.True(t, restoredBranchSupporters.DeleteSupporter(supporter))
+	assert.Empty(t, restoredBranchSupporters.Supporters())
+}
+
+func newTestIssuerDataMessage(issuerPublicKey ed25519.PublicKey, strongParents []MessageID) *Message {
+	return NewMessage(strongParents, []MessageID{}, time.Now(), issuerPublicKey, 0, payload.NewGenericDataPayload([]byte("test")), 0, ed25519.Signature{})
+}
+
+func TestManaWeightProvider(t *testing.T) {
+	issuers := []identity.ID{identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()}
+	mana := map[identity.ID]float64{}
+	weightProvider := NewManaWeightProvider(func(issuerID identity.ID) float64 {
+		return mana[issuerID]
+	}, 100*time.Millisecond)
+
+	// as long as none of the active issuers has mana, every active issuer has the same weight
+	weightProvider.Update(issuers[0])
+	weightProvider.Update(issuers[1])
+	assert.Equal(t, 1.0, weightProvider.Weight(issuers[0]))
+	assert.Equal(t, 0.0, weightProvider.Weight(issuers[2]))
+	assert.Equal(t, 2.0, weightProvider.TotalWeight())
+
+	// the active issuers are weighted by their mana (creating identities without mana does not add any weight)
+	mana[issuers[0]] = 30
+	mana[issuers[2]] = 70
+	assert.Equal(t, 30.0, weightProvider.Weight(issuers[0]))
+	assert.Equal(t, 0.0, weightProvider.Weight(issuers[1]))
+	assert.Equal(t, 0.0, weightProvider.Weight(issuers[2]))
+	assert.Equal(t, 30.0, weightProvider.TotalWeight())
+
+	weightProvider.Update(issuers[2])
+	assert.Equal(t, 100.0, weightProvider.TotalWeight())
+
+	// issuers that stop issuing messages lose their weight and are removed eventually
+	time.Sleep(100 * time.Millisecond)
+	weightProvider.Update(issuers[1])
+	assert.Equal(t, 0.0, weightProvider.Weight(issuers[0]))
+	assert.Equal(t, 1.0, weightProvider.TotalWeight())
+	assert.Len(t, weightProvider.lastActive, 1)
+}
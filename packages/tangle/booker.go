@@ -31,6 +31,7 @@ type Booker struct {
 	tangle                       *Tangle
 	MarkersManager               *MarkersManager
 	MarkerBranchIDMappingManager *MarkerBranchIDMappingManager
+	bookingMutex                 sync.Mutex
 }
 
 // NewBooker is the constructor of a Booker.
@@ -83,6 +84,9 @@ func (b *Booker) UpdateMessagesBranch(transactionID ledgerstate.TransactionID) {
 // Book tries to book the given Message (and potentially its contained Transaction) into the LedgerState and the Tangle.
 // It fires a MessageBooked event if it succeeds.
 func (b *Booker) Book(messageID MessageID) (err error) {
+	b.bookingMutex.Lock()
+	defer b.bookingMutex.Unlock()
+
 	b.tangle.Storage.Message(messageID).Consume(func(message *Message) {
 		b.tangle.Storage.MessageMetadata(messageID).Consume(func(messageMetadata *MessageMetadata) {
 			sequenceAlias := make([]markers.SequenceAlias, 0)
@@ -112,6 +116,11 @@ func (b *Booker) Book(messageID MessageID) (err error) {
 
 				for _, output := range transaction.Essence().Outputs() {
 					b.tangle.LedgerState.utxoDAG.StoreAddressOutputMapping(output.Address(), output.ID())
+
+					// make Outputs that fall back to another Address discoverable by the fallback Address as well
+					if extendedLockedOutput, isExtendedLockedOutput := output.(*ledgerstate.ExtendedLockedOutput); isExtendedLockedOutput && extendedLockedOutput.FallbackAddress() != nil {
+						b.tangle.LedgerState.utxoDAG.StoreAddressOutputMapping(extendedLockedOutput.FallbackAddress(), output.ID())
+					}
 				}
 
 				attachment, stored := b.tangle.Storage.StoreAttachment(transaction.ID(), messageID)
@@ -169,7 +178,7 @@ func (b *Booker) branchIDsOfParents(message *Message) (branchIDs ledgerstate.Bra
 	})
 
 	message.ForEachWeakParent(func(parentMessageID MessageID) {
-		if parentMessageID == EmptyMessageID {
+		if b.tangle.Storage.IsSolidEntryPoint(parentMessageID) {
 			return
 		}
 		if !b.tangle.Storage.Message(parentMessageID).Consume(func(message *Message) {
@@ -305,6 +314,14 @@ func (m *MarkerBranchIDMappingManager) SetBranchID(marker *markers.Marker, branc
 	})
 }
 
+// MoveBranchIDs replaces the BranchIDs that are associated with the Markers of the given Sequence according to the
+// given mapping of old to new BranchIDs.
+func (m *MarkerBranchIDMappingManager) MoveBranchIDs(sequenceID markers.SequenceID, movedBranches map[ledgerstate.BranchID]ledgerstate.BranchID) {
+	m.tangle.Storage.MarkerIndexBranchIDMapping(sequenceID).Consume(func(markerIndexBranchIDMapping *MarkerIndexBranchIDMapping) {
+		markerIndexBranchIDMapping.MoveBranchIDs(movedBranches)
+	})
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region MarkerIndexBranchIDMapping ///////////////////////////////////////////////////////////////////////////////////
@@ -410,6 +427,60 @@ func (m *MarkerIndexBranchIDMapping) SetBranchID(index markers.Index, branchID l
 	m.mapping.Set(index, branchID)
 }
 
+// MoveBranchIDs replaces the BranchIDs of the mapping according to the given mapping of old to new BranchIDs. It
+// returns true if at least one BranchID was replaced.
+func (m *MarkerIndexBranchIDMapping) MoveBranchIDs(movedBranches map[ledgerstate.BranchID]ledgerstate.BranchID) (modified bool) {
+	m.mappingMutex.Lock()
+	defer m.mappingMutex.Unlock()
+
+	movedIndexes := make(map[markers.Index]ledgerstate.BranchID)
+	m.mapping.ForEach(func(node *thresholdmap.Element) bool {
+		if newBranchID, branchMoved := movedBranches[node.Value().(ledgerstate.BranchID)]; branchMoved {
+			movedIndexes[node.Key().(markers.Index)] = newBranchID
+		}
+
+		return true
+	})
+
+	for index, branchID := range movedIndexes {
+		m.mapping.Set(index, branchID)
+		modified = true
+	}
+
+	if modified {
+		m.SetModified()
+	}
+
+	return
+}
+
+// DeleteBranchIDsBelow removes the mappings of all marker Indexes that are not needed anymore to resolve the BranchID
+// of the given marker Index or any higher one. It returns true if at least one mapping was removed.
+func (m *MarkerIndexBranchIDMapping) DeleteBranchIDsBelow(index markers.Index) (modified bool) {
+	m.mappingMutex.Lock()
+	defer m.mappingMutex.Unlock()
+
+	floorElement := m.mapping.GetElement(index)
+	if floorElement == nil {
+		return
+	}
+
+	for _, key := range m.mapping.Keys() {
+		if key.(markers.Index) >= floorElement.Key().(markers.Index) {
+			break
+		}
+
+		m.mapping.Delete(key)
+		modified = true
+	}
+
+	if modified {
+		m.SetModified()
+	}
+
+	return
+}
+
 // Bytes returns a marshaled version of the MarkerIndexBranchIDMapping.
 func (m *MarkerIndexBranchIDMapping) Bytes() []byte {
 	return byteutils.ConcatBytes(m.ObjectStorageKey(), m.ObjectStorageValue())
@@ -12,6 +12,7 @@ func TestScenario_1(t *testing.T) {
 	tangle := New(WithoutOpinionFormer(true))
 	defer tangle.Shutdown()
 	tangle.Booker.Setup()
+	tangle.BranchLifecycleManager.Setup()
 
 	wallets := make(map[string]wallet)
 	walletsByAddress := make(map[ledgerstate.Address]wallet)
@@ -231,4 +232,31 @@ func TestScenario_1(t *testing.T) {
 	txBranchID, err = transactionBranchID(tangle, transactions["7"].ID())
 	require.NoError(t, err)
 	assert.Equal(t, branches["yellow"], txBranchID)
+
+	////////// Branch lifecycle //////////////
+	{
+		confirmBranch(t, tangle, branches["yellow"])
+		require.NoError(t, tangle.BranchLifecycleManager.CollectGarbage())
+
+		assertBranchesRemoved(t, tangle, branches["yellow"], branches["red"])
+		assertTransactionBranchIDs(t, tangle, map[ledgerstate.TransactionID]ledgerstate.BranchID{
+			transactions["1"].ID(): branches["green"],
+			transactions["2"].ID(): branches["green"],
+			transactions["3"].ID(): branches["grey"],
+			transactions["4"].ID(): branches["green"],
+			transactions["5"].ID(): branches["green"],
+			transactions["6"].ID(): branches["grey"],
+			transactions["7"].ID(): branches["green"],
+		})
+		assertMessageBranchIDs(t, tangle, map[MessageID]ledgerstate.BranchID{
+			messages["1"].ID(): branches["green"],
+			messages["4"].ID(): branches["grey"],
+			messages["5"].ID(): branches["green"],
+			messages["6"].ID(): branches["green"],
+			messages["7"].ID(): branches["grey"],
+			messages["8"].ID(): branches["grey"],
+			messages["9"].ID(): branches["grey"],
+		})
+	}
+	/////////////////////////////////
 }
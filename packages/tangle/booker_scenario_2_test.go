@@ -13,6 +13,7 @@ func TestScenario_2(t *testing.T) {
 	tangle := New(WithoutOpinionFormer(true))
 	defer tangle.Shutdown()
 	tangle.Booker.Setup()
+	tangle.BranchLifecycleManager.Setup()
 
 	wallets := make(map[string]wallet)
 	walletsByAddress := make(map[ledgerstate.Address]wallet)
@@ -345,4 +346,38 @@ func TestScenario_2(t *testing.T) {
 		}
 	}
 	/////////////////////////////////
+
+	////////// Branch lifecycle //////////////
+	{
+		confirmBranch(t, tangle, branches["yellow"])
+		confirmBranch(t, tangle, branches["purple"])
+		require.NoError(t, tangle.BranchLifecycleManager.CollectGarbage())
+
+		assertBranchesRemoved(t, tangle,
+			branches["yellow"], branches["red"], branches["purple"], branches["orange"], branches["blue"],
+			branches["red+orange"], branches["yellow+purple"], branches["red+orange+blue"],
+		)
+		assertTransactionBranchIDs(t, tangle, map[ledgerstate.TransactionID]ledgerstate.BranchID{
+			transactions["1"].ID(): branches["green"],
+			transactions["2"].ID(): branches["green"],
+			transactions["3"].ID(): branches["grey"],
+			transactions["4"].ID(): branches["green"],
+			transactions["5"].ID(): branches["green"],
+			transactions["6"].ID(): branches["grey"],
+			transactions["7"].ID(): branches["grey"],
+			transactions["8"].ID(): branches["grey"],
+		})
+		assertMessageBranchIDs(t, tangle, map[MessageID]ledgerstate.BranchID{
+			messages["1"].ID(): branches["green"],
+			messages["2"].ID(): branches["green"],
+			messages["3"].ID(): branches["green"],
+			messages["4"].ID(): branches["grey"],
+			messages["5"].ID(): branches["green"],
+			messages["6"].ID(): branches["green"],
+			messages["7"].ID(): branches["grey"],
+			messages["8"].ID(): branches["grey"],
+			messages["9"].ID(): branches["grey"],
+		})
+	}
+	/////////////////////////////////
 }
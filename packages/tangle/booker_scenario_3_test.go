@@ -12,6 +12,7 @@ func TestScenario_3(t *testing.T) {
 	tangle := New(WithoutOpinionFormer(true))
 	defer tangle.Shutdown()
 	tangle.Booker.Setup()
+	tangle.BranchLifecycleManager.Setup()
 
 	wallets := make(map[string]wallet)
 	walletsByAddress := make(map[ledgerstate.Address]wallet)
@@ -278,4 +279,42 @@ func TestScenario_3(t *testing.T) {
 	txBranchID, err = transactionBranchID(tangle, transactions["2"].ID())
 	require.NoError(t, err)
 	assert.Equal(t, branches["purple"], txBranchID)
+
+	////////// Branch lifecycle //////////////
+	{
+		// the Conflict between purple and orange stays unresolved
+		confirmBranch(t, tangle, branches["red"])
+		confirmBranch(t, tangle, branches["blue"])
+		require.NoError(t, tangle.BranchLifecycleManager.CollectGarbage())
+
+		assertBranchesRemoved(t, tangle,
+			branches["yellow"], branches["red"], branches["purple"], branches["blue"],
+			branches["red+orange"], branches["yellow+purple"], branches["red+orange+blue"],
+		)
+		assert.True(t, tangle.LedgerState.Branch(branches["orange"]).Consume(func(branch ledgerstate.Branch) {
+			assert.Equal(t, ledgerstate.NewBranchIDs(branches["green"]), branch.Parents())
+		}))
+		assertTransactionBranchIDs(t, tangle, map[ledgerstate.TransactionID]ledgerstate.BranchID{
+			transactions["1"].ID(): branches["green"],
+			transactions["2"].ID(): branches["grey"],
+			transactions["3"].ID(): branches["green"],
+			transactions["4"].ID(): branches["grey"],
+			transactions["5"].ID(): branches["grey"],
+			transactions["6"].ID(): branches["orange"],
+			transactions["7"].ID(): branches["orange"],
+			transactions["8"].ID(): branches["green"],
+		})
+		assertMessageBranchIDs(t, tangle, map[MessageID]ledgerstate.BranchID{
+			messages["1"].ID(): branches["green"],
+			messages["2"].ID(): branches["grey"],
+			messages["3"].ID(): branches["grey"],
+			messages["4"].ID(): branches["green"],
+			messages["5"].ID(): branches["grey"],
+			messages["6"].ID(): branches["grey"],
+			messages["7"].ID(): branches["orange"],
+			messages["8"].ID(): branches["orange"],
+			messages["9"].ID(): branches["orange"],
+		})
+	}
+	/////////////////////////////////
 }
@@ -0,0 +1,229 @@
+package tangle
+
+import (
+	"sync"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/markers"
+	"github.com/iotaledger/hive.go/datastructure/walker"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/types"
+	"golang.org/x/xerrors"
+)
+
+// region BranchLifecycleManager ///////////////////////////////////////////////////////////////////////////////////////
+
+// BranchLifecycleManager is a Tangle component that cleans up the BranchDAG after conflicts have been resolved.
+// Confirmed ConflictBranches are merged into the MasterBranch and rejected ConflictBranches are removed together with
+// their AggregatedBranches. The BranchIDs of the affected Transactions, Outputs and Messages are rewritten accordingly.
+//
+// Since the BranchDAG triggers its events while it is still propagating the new InclusionState to the future cone, the
+// decided Branches are only queued by the event handlers and get processed by CollectGarbage, which holds the lock of
+// the Booker so that no Message gets booked while the BranchDAG and the BranchIDs are rewritten.
+type BranchLifecycleManager struct {
+	// Events is a dictionary for the BranchLifecycleManager related Events.
+	Events *BranchLifecycleManagerEvents
+
+	tangle            *Tangle
+	confirmedBranches ledgerstate.BranchIDs
+	rejectedBranches  ledgerstate.BranchIDs
+	queueMutex        sync.Mutex
+	collectMutex      sync.Mutex
+}
+
+// NewBranchLifecycleManager is the constructor of the BranchLifecycleManager.
+func NewBranchLifecycleManager(tangle *Tangle) (branchLifecycleManager *BranchLifecycleManager) {
+	branchLifecycleManager = &BranchLifecycleManager{
+		Events: &BranchLifecycleManagerEvents{
+			BranchMerged:     events.NewEvent(branchIDEventHandler),
+			BranchRemoved:    events.NewEvent(branchIDEventHandler),
+			TransactionMoved: events.NewEvent(transactionIDEventHandler),
+		},
+		tangle:            tangle,
+		confirmedBranches: make(ledgerstate.BranchIDs),
+		rejectedBranches:  make(ledgerstate.BranchIDs),
+	}
+
+	return
+}
+
+// Setup sets up the behavior of the component by making it attach to the relevant events of other components.
+func (b *BranchLifecycleManager) Setup() {
+	b.tangle.LedgerState.branchDAG.Events.BranchConfirmed.Attach(events.NewClosure(func(branchDAGEvent *ledgerstate.BranchDAGEvent) {
+		b.queueConflictBranch(branchDAGEvent, b.confirmedBranches)
+	}))
+	b.tangle.LedgerState.branchDAG.Events.BranchRejected.Attach(events.NewClosure(func(branchDAGEvent *ledgerstate.BranchDAGEvent) {
+		b.queueConflictBranch(branchDAGEvent, b.rejectedBranches)
+	}))
+}
+
+// CollectGarbage processes the queued Branches. Confirmed Branches are merged into the MasterBranch as soon as all of
+// their parents were merged, rejected Branches are removed. Confirmed Branches that can not be merged, yet, stay queued
+// for the next run.
+func (b *BranchLifecycleManager) CollectGarbage() (err error) {
+	b.collectMutex.Lock()
+	defer b.collectMutex.Unlock()
+	b.tangle.Booker.bookingMutex.Lock()
+	defer b.tangle.Booker.bookingMutex.Unlock()
+
+	// merging a Branch can make its confirmed children mergeable, so we repeat until we stop making progress
+	for progress := true; progress; {
+		progress = false
+		for branchID := range b.queuedBranches(b.confirmedBranches) {
+			merged, mergeErr := b.mergeToMaster(branchID)
+			if mergeErr != nil {
+				return xerrors.Errorf("failed to merge Branch with %s to Master: %w", branchID, mergeErr)
+			}
+			progress = progress || merged
+		}
+	}
+
+	for branchID := range b.queuedBranches(b.rejectedBranches) {
+		if removeErr := b.removeRejectedBranch(branchID); removeErr != nil {
+			return xerrors.Errorf("failed to remove rejected Branch with %s: %w", branchID, removeErr)
+		}
+	}
+
+	return
+}
+
+// mergeToMaster merges the given confirmed Branch into the MasterBranch if it is at the bottom of the BranchDAG. It
+// returns true if the Branch was merged or if it does not need to be merged anymore.
+func (b *BranchLifecycleManager) mergeToMaster(branchID ledgerstate.BranchID) (merged bool, err error) {
+	confirmed, atBottomOfBranchDAG := false, false
+	b.tangle.LedgerState.Branch(branchID).Consume(func(branch ledgerstate.Branch) {
+		_, masterBranchIsParent := branch.Parents()[ledgerstate.MasterBranchID]
+		confirmed = branch.InclusionState() == ledgerstate.Confirmed
+		atBottomOfBranchDAG = len(branch.Parents()) == 1 && masterBranchIsParent
+	})
+
+	// skip Branches that were merged already or that are not confirmed anymore
+	if !confirmed {
+		b.dequeue(branchID, b.confirmedBranches)
+		return true, nil
+	}
+	if !atBottomOfBranchDAG {
+		return
+	}
+
+	movedBranches, err := b.tangle.LedgerState.branchDAG.MergeToMaster(branchID)
+	if err != nil {
+		return
+	}
+	b.moveBranches(branchID, movedBranches)
+	b.dequeue(branchID, b.confirmedBranches)
+	b.Events.BranchMerged.Trigger(branchID)
+
+	return true, nil
+}
+
+// removeRejectedBranch removes the given rejected Branch from the BranchDAG after the affected Transactions, Outputs,
+// Messages and Markers were moved to the InvalidBranch, so that no references to the removed Branches remain.
+func (b *BranchLifecycleManager) removeRejectedBranch(branchID ledgerstate.BranchID) (err error) {
+	defer b.dequeue(branchID, b.rejectedBranches)
+
+	// skip Branches that were removed already or that are not rejected anymore
+	rejected := false
+	if !b.tangle.LedgerState.Branch(branchID).Consume(func(branch ledgerstate.Branch) {
+		rejected = branch.InclusionState() == ledgerstate.Rejected
+	}) || !rejected {
+		return
+	}
+
+	movedBranches, err := b.tangle.LedgerState.branchDAG.RemovableBranches(branchID)
+	if err != nil {
+		return
+	}
+	b.moveBranches(branchID, movedBranches)
+
+	if _, err = b.tangle.LedgerState.branchDAG.RemoveRejectedBranch(branchID); err != nil {
+		return
+	}
+	b.Events.BranchRemoved.Trigger(branchID)
+
+	return
+}
+
+// moveBranches rewrites the BranchIDs of the Transactions, Outputs, Messages and Markers that were booked in the given
+// ConflictBranch or in one of the other Branches of the given mapping.
+func (b *BranchLifecycleManager) moveBranches(branchID ledgerstate.BranchID, movedBranches map[ledgerstate.BranchID]ledgerstate.BranchID) {
+	entryPoints := make(MessageIDs, 0)
+	for transactionID := range b.tangle.LedgerState.utxoDAG.MoveBranches(ledgerstate.TransactionID(branchID), movedBranches) {
+		entryPoints = append(entryPoints, b.tangle.Storage.AttachmentMessageIDs(transactionID)...)
+		b.Events.TransactionMoved.Trigger(transactionID)
+	}
+	if len(entryPoints) == 0 {
+		return
+	}
+
+	sequenceIDs := make(map[markers.SequenceID]types.Empty)
+	b.tangle.Utils.WalkMessageMetadata(func(messageMetadata *MessageMetadata, walker *walker.Walker) {
+		newBranchID, branchMoved := movedBranches[messageMetadata.BranchID()]
+		if !branchMoved {
+			return
+		}
+		messageMetadata.SetBranchID(newBranchID)
+
+		if structureDetails := messageMetadata.StructureDetails(); structureDetails != nil && structureDetails.IsPastMarker {
+			sequenceIDs[structureDetails.PastMarkers.FirstMarker().SequenceID()] = types.Void
+		}
+
+		for _, approvingMessageID := range b.tangle.Utils.ApprovingMessageIDs(messageMetadata.ID()) {
+			walker.Push(approvingMessageID)
+		}
+	}, entryPoints)
+
+	for sequenceID := range sequenceIDs {
+		b.tangle.Booker.MarkerBranchIDMappingManager.MoveBranchIDs(sequenceID, movedBranches)
+	}
+}
+
+// queueConflictBranch is an internal utility function that queues the ConflictBranch of the given event for processing.
+func (b *BranchLifecycleManager) queueConflictBranch(branchDAGEvent *ledgerstate.BranchDAGEvent, queue ledgerstate.BranchIDs) {
+	defer branchDAGEvent.Release()
+
+	branch := branchDAGEvent.Branch.Unwrap()
+	if branch == nil || branch.Type() != ledgerstate.ConflictBranchType {
+		return
+	}
+
+	b.queueMutex.Lock()
+	defer b.queueMutex.Unlock()
+
+	queue[branch.ID()] = types.Void
+}
+
+// queuedBranches is an internal utility function that returns a copy of the given queue.
+func (b *BranchLifecycleManager) queuedBranches(queue ledgerstate.BranchIDs) (branchIDs ledgerstate.BranchIDs) {
+	b.queueMutex.Lock()
+	defer b.queueMutex.Unlock()
+
+	return queue.Clone()
+}
+
+// dequeue is an internal utility function that removes the given Branch from the given queue.
+func (b *BranchLifecycleManager) dequeue(branchID ledgerstate.BranchID, queue ledgerstate.BranchIDs) {
+	b.queueMutex.Lock()
+	defer b.queueMutex.Unlock()
+
+	delete(queue, branchID)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region BranchLifecycleManagerEvents /////////////////////////////////////////////////////////////////////////////////
+
+// BranchLifecycleManagerEvents represents events happening in the BranchLifecycleManager.
+type BranchLifecycleManagerEvents struct {
+	// BranchMerged is triggered when a confirmed ConflictBranch was merged into the MasterBranch.
+	BranchMerged *events.Event
+
+	// BranchRemoved is triggered when a rejected ConflictBranch was removed from the BranchDAG.
+	BranchRemoved *events.Event
+
+	// TransactionMoved is triggered for every Transaction that was moved to another Branch because its Branch was
+	// merged or removed.
+	TransactionMoved *events.Event
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
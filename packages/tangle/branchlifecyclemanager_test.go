@@ -0,0 +1,59 @@
+package tangle
+
+import (
+	"testing"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// confirmBranch likes and finalizes the given Branch which confirms it and rejects its conflicting Branches.
+func confirmBranch(t *testing.T, tangle *Tangle, branchID ledgerstate.BranchID) {
+	_, err := tangle.LedgerState.branchDAG.SetBranchLiked(branchID, true)
+	require.NoError(t, err)
+	_, err = tangle.LedgerState.branchDAG.SetBranchFinalized(branchID, true)
+	require.NoError(t, err)
+}
+
+// assertBranchesRemoved asserts that the given Branches do not exist in the BranchDAG anymore.
+func assertBranchesRemoved(t *testing.T, tangle *Tangle, branchIDs ...ledgerstate.BranchID) {
+	for _, branchID := range branchIDs {
+		assert.False(t, tangle.LedgerState.Branch(branchID).Consume(func(ledgerstate.Branch) {}), "Branch with %s should have been removed", branchID)
+	}
+}
+
+// assertMessageBranchIDs asserts that the given Messages are booked in the expected Branches.
+func assertMessageBranchIDs(t *testing.T, tangle *Tangle, expectedBranchIDs map[MessageID]ledgerstate.BranchID) {
+	for messageID, expectedBranchID := range expectedBranchIDs {
+		branchID, err := messageBranchID(tangle, messageID)
+		require.NoError(t, err)
+		assert.Equal(t, expectedBranchID, branchID, "wrong Branch of Message with %s", messageID)
+	}
+}
+
+// assertTransactionBranchIDs asserts that the given Transactions are booked in the expected Branches.
+func assertTransactionBranchIDs(t *testing.T, tangle *Tangle, expectedBranchIDs map[ledgerstate.TransactionID]ledgerstate.BranchID) {
+	for transactionID, expectedBranchID := range expectedBranchIDs {
+		branchID, err := transactionBranchID(tangle, transactionID)
+		require.NoError(t, err)
+		assert.Equal(t, expectedBranchID, branchID, "wrong Branch of Transaction with %s", transactionID)
+	}
+}
+
+func TestMarkerIndexBranchIDMapping_MoveBranchIDs(t *testing.T) {
+	rejectedBranchID := ledgerstate.BranchID{3}
+	otherBranchID := ledgerstate.BranchID{4}
+
+	mapping := NewMarkerIndexBranchIDMapping(1)
+	mapping.SetBranchID(1, ledgerstate.MasterBranchID)
+	mapping.SetBranchID(3, rejectedBranchID)
+	mapping.SetBranchID(5, otherBranchID)
+
+	assert.True(t, mapping.MoveBranchIDs(map[ledgerstate.BranchID]ledgerstate.BranchID{rejectedBranchID: ledgerstate.InvalidBranchID}))
+	assert.Equal(t, ledgerstate.MasterBranchID, mapping.BranchID(2))
+	assert.Equal(t, ledgerstate.InvalidBranchID, mapping.BranchID(4))
+	assert.Equal(t, otherBranchID, mapping.BranchID(6))
+
+	assert.False(t, mapping.MoveBranchIDs(map[ledgerstate.BranchID]ledgerstate.BranchID{rejectedBranchID: ledgerstate.InvalidBranchID}))
+}
@@ -0,0 +1,508 @@
+package tangle
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+	"golang.org/x/xerrors"
+)
+
+// region EquivocationFilter ///////////////////////////////////////////////////////////////////////////////////////////
+
+// EquivocationFilter is a MessageFilter that detects issuers that sign two different Messages with the same sequence
+// number. It indexes the sequence numbers of all stored Messages per issuer, stores the conflicting Messages as signed
+// evidence and (if enabled in the Options of the Tangle) adds the equivocating issuers to the IssuerBlocklist, which
+// rejects all of their further Messages.
+type EquivocationFilter struct {
+	tangle *Tangle
+
+	onAcceptCallback func(msg *Message, peer *peer.Peer)
+	onRejectCallback func(msg *Message, err error, peer *peer.Peer)
+
+	onAcceptCallbackMutex sync.RWMutex
+	onRejectCallbackMutex sync.RWMutex
+}
+
+// NewEquivocationFilter creates a new EquivocationFilter.
+func NewEquivocationFilter(tangle *Tangle) (filter *EquivocationFilter) {
+	return &EquivocationFilter{
+		tangle: tangle,
+	}
+}
+
+// Setup sets up the behavior of the component by making it attach to the relevant events of the other components.
+func (f *EquivocationFilter) Setup() {
+	f.tangle.Storage.Events.MessageStored.Attach(events.NewClosure(f.indexMessage))
+}
+
+// Filter checks the Message for equivocation and calls the corresponding callback.
+func (f *EquivocationFilter) Filter(msg *Message, peer *peer.Peer) {
+	if evidence := f.detectEquivocation(msg); evidence != nil {
+		f.reportEquivocation(evidence)
+
+		if f.tangle.Options.BlacklistEquivocatingIssuers {
+			f.getRejectCallback()(msg, ErrIssuerEquivocated, peer)
+			return
+		}
+	}
+
+	f.getAcceptCallback()(msg, peer)
+}
+
+// OnAccept registers the given callback as the acceptance function of the filter.
+func (f *EquivocationFilter) OnAccept(callback func(msg *Message, peer *peer.Peer)) {
+	f.onAcceptCallbackMutex.Lock()
+	defer f.onAcceptCallbackMutex.Unlock()
+	f.onAcceptCallback = callback
+}
+
+// OnReject registers the given callback as the rejection function of the filter.
+func (f *EquivocationFilter) OnReject(callback func(msg *Message, err error, peer *peer.Peer)) {
+	f.onRejectCallbackMutex.Lock()
+	defer f.onRejectCallbackMutex.Unlock()
+	f.onRejectCallback = callback
+}
+
+// detectEquivocation returns the EquivocationEvidence if a different Message with the same issuer and sequence number
+// has been indexed before.
+func (f *EquivocationFilter) detectEquivocation(msg *Message) (evidence *EquivocationEvidence) {
+	f.tangle.Storage.IssuerSequenceNumberMapping(msg.IssuerPublicKey(), msg.SequenceNumber()).Consume(func(mapping *IssuerSequenceNumberMapping) {
+		evidence = f.evidence(mapping.MessageID(), msg)
+	})
+
+	return
+}
+
+// indexMessage indexes the sequence number of the given stored Message. Messages that pass the filter concurrently are
+// only detected here, once the second of them is stored.
+func (f *EquivocationFilter) indexMessage(messageID MessageID) {
+	f.tangle.Storage.Message(messageID).Consume(func(msg *Message) {
+		cachedMapping, stored := f.tangle.Storage.StoreIssuerSequenceNumberMapping(NewIssuerSequenceNumberMapping(msg.IssuerPublicKey(), msg.SequenceNumber(), msg.ID()))
+		defer cachedMapping.Release()
+
+		if stored {
+			return
+		}
+
+		if evidence := f.evidence(cachedMapping.Unwrap().MessageID(), msg); evidence != nil {
+			f.reportEquivocation(evidence)
+		}
+	})
+}
+
+// evidence returns the EquivocationEvidence of the given Message and the indexed Message with the same issuer and
+// sequence number (or nil if it is the same Message).
+func (f *EquivocationFilter) evidence(indexedMessageID MessageID, msg *Message) (evidence *EquivocationEvidence) {
+	if indexedMessageID == msg.ID() {
+		return
+	}
+
+	// the evidence can only be created if the indexed Message is still available
+	f.tangle.Storage.Message(indexedMessageID).Consume(func(indexedMessage *Message) {
+		evidence = NewEquivocationEvidence(indexedMessage, msg)
+	})
+
+	return
+}
+
+// reportEquivocation stores the given EquivocationEvidence and (if enabled) adds the equivocating issuer to the
+// IssuerBlocklist.
+func (f *EquivocationFilter) reportEquivocation(evidence *EquivocationEvidence) {
+	if f.tangle.Options.BlacklistEquivocatingIssuers {
+		f.tangle.IssuerBlocklist.Block(evidence.IssuerPublicKey())
+	}
+
+	if f.tangle.Storage.StoreEquivocationEvidence(evidence) {
+		f.tangle.Events.IssuerEquivocated.Trigger(evidence)
+	}
+}
+
+func (f *EquivocationFilter) getAcceptCallback() (result func(msg *Message, peer *peer.Peer)) {
+	f.onAcceptCallbackMutex.RLock()
+	result = f.onAcceptCallback
+	f.onAcceptCallbackMutex.RUnlock()
+	return
+}
+
+func (f *EquivocationFilter) getRejectCallback() (result func(msg *Message, err error, peer *peer.Peer)) {
+	f.onRejectCallbackMutex.RLock()
+	result = f.onRejectCallback
+	f.onRejectCallbackMutex.RUnlock()
+	return
+}
+
+// code contract (make sure the type implements all required methods)
+var _ MessageFilter = &EquivocationFilter{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region IssuerSequenceNumberMapping //////////////////////////////////////////////////////////////////////////////////
+
+// IssuerSequenceNumberMapping is a data structure that maps the sequence number of an issuer to the MessageID that was
+// first seen with it.
+type IssuerSequenceNumberMapping struct {
+	issuerPublicKey ed25519.PublicKey
+	sequenceNumber  uint64
+	messageID       MessageID
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewIssuerSequenceNumberMapping creates a new IssuerSequenceNumberMapping.
+func NewIssuerSequenceNumberMapping(issuerPublicKey ed25519.PublicKey, sequenceNumber uint64, messageID MessageID) *IssuerSequenceNumberMapping {
+	return &IssuerSequenceNumberMapping{
+		issuerPublicKey: issuerPublicKey,
+		sequenceNumber:  sequenceNumber,
+		messageID:       messageID,
+	}
+}
+
+// IssuerSequenceNumberMappingFromBytes unmarshals an IssuerSequenceNumberMapping from a sequence of bytes.
+func IssuerSequenceNumberMappingFromBytes(bytes []byte) (mapping *IssuerSequenceNumberMapping, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if mapping, err = IssuerSequenceNumberMappingFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse IssuerSequenceNumberMapping from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// IssuerSequenceNumberMappingFromMarshalUtil unmarshals an IssuerSequenceNumberMapping using a MarshalUtil (for easier
+// unmarshaling).
+func IssuerSequenceNumberMappingFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (mapping *IssuerSequenceNumberMapping, err error) {
+	mapping = &IssuerSequenceNumberMapping{}
+	if mapping.issuerPublicKey, err = ed25519.ParsePublicKey(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse issuer public key from MarshalUtil: %w", err)
+		return
+	}
+	if mapping.sequenceNumber, err = marshalUtil.ReadUint64(); err != nil {
+		err = xerrors.Errorf("failed to parse sequence number: %w", err)
+		return
+	}
+	if mapping.messageID, err = MessageIDFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse MessageID from MarshalUtil: %w", err)
+		return
+	}
+
+	return
+}
+
+// IssuerSequenceNumberMappingFromObjectStorage is a factory method that creates a new IssuerSequenceNumberMapping
+// instance from a storage key of the object storage. It is used by the object storage, to create new instances of this
+// entity.
+func IssuerSequenceNumberMappingFromObjectStorage(key []byte, data []byte) (mapping objectstorage.StorableObject, err error) {
+	if mapping, _, err = IssuerSequenceNumberMappingFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = xerrors.Errorf("failed to parse IssuerSequenceNumberMapping from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// IssuerPublicKey returns the public key of the issuer.
+func (i *IssuerSequenceNumberMapping) IssuerPublicKey() ed25519.PublicKey {
+	return i.issuerPublicKey
+}
+
+// SequenceNumber returns the sequence number of the indexed Message.
+func (i *IssuerSequenceNumberMapping) SequenceNumber() uint64 {
+	return i.sequenceNumber
+}
+
+// MessageID returns the MessageID of the Message that was first seen with the issuer and sequence number.
+func (i *IssuerSequenceNumberMapping) MessageID() MessageID {
+	return i.messageID
+}
+
+// Bytes returns a marshaled version of the IssuerSequenceNumberMapping.
+func (i *IssuerSequenceNumberMapping) Bytes() []byte {
+	return byteutils.ConcatBytes(i.ObjectStorageKey(), i.ObjectStorageValue())
+}
+
+// String returns a human readable version of the IssuerSequenceNumberMapping.
+func (i *IssuerSequenceNumberMapping) String() string {
+	return stringify.Struct("IssuerSequenceNumberMapping",
+		stringify.StructField("issuerPublicKey", i.issuerPublicKey),
+		stringify.StructField("sequenceNumber", i.sequenceNumber),
+		stringify.StructField("messageID", i.messageID),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (i *IssuerSequenceNumberMapping) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (i *IssuerSequenceNumberMapping) ObjectStorageKey() []byte {
+	return issuerSequenceNumberKey(i.issuerPublicKey, i.sequenceNumber)
+}
+
+// ObjectStorageValue marshals the IssuerSequenceNumberMapping into a sequence of bytes that are used as the value part
+// in the object storage.
+func (i *IssuerSequenceNumberMapping) ObjectStorageValue() []byte {
+	return i.messageID.Bytes()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &IssuerSequenceNumberMapping{}
+
+// issuerSequenceNumberKey returns the storage key of the given issuer and sequence number.
+func issuerSequenceNumberKey(issuerPublicKey ed25519.PublicKey, sequenceNumber uint64) []byte {
+	return marshalutil.New(ed25519.PublicKeySize + marshalutil.Uint64Size).
+		WriteBytes(issuerPublicKey.Bytes()).
+		WriteUint64(sequenceNumber).
+		Bytes()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedIssuerSequenceNumberMapping ////////////////////////////////////////////////////////////////////////////
+
+// CachedIssuerSequenceNumberMapping is a wrapper for the generic CachedObject returned by the object storage that
+// overrides the accessor methods with a type-casted one.
+type CachedIssuerSequenceNumberMapping struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks the CachedObject to still be in use by the program.
+func (c *CachedIssuerSequenceNumberMapping) Retain() *CachedIssuerSequenceNumberMapping {
+	return &CachedIssuerSequenceNumberMapping{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedIssuerSequenceNumberMapping) Unwrap() *IssuerSequenceNumberMapping {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*IssuerSequenceNumberMapping)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (c *CachedIssuerSequenceNumberMapping) Consume(consumer func(mapping *IssuerSequenceNumberMapping), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*IssuerSequenceNumberMapping))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedIssuerSequenceNumberMapping.
+func (c *CachedIssuerSequenceNumberMapping) String() string {
+	return stringify.Struct("CachedIssuerSequenceNumberMapping",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region EquivocationEvidence /////////////////////////////////////////////////////////////////////////////////////////
+
+// EquivocationEvidence contains two different Messages that were signed by the same issuer with the same sequence
+// number. As both Messages are signed, it can be verified by anybody.
+type EquivocationEvidence struct {
+	firstMessage  *Message
+	secondMessage *Message
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewEquivocationEvidence creates a new EquivocationEvidence from the two conflicting Messages.
+func NewEquivocationEvidence(firstMessage, secondMessage *Message) *EquivocationEvidence {
+	return &EquivocationEvidence{
+		firstMessage:  firstMessage,
+		secondMessage: secondMessage,
+	}
+}
+
+// EquivocationEvidenceFromBytes unmarshals an EquivocationEvidence from a sequence of bytes.
+func EquivocationEvidenceFromBytes(bytes []byte) (evidence *EquivocationEvidence, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if evidence, err = EquivocationEvidenceFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse EquivocationEvidence from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// EquivocationEvidenceFromMarshalUtil unmarshals an EquivocationEvidence using a MarshalUtil (for easier unmarshaling).
+func EquivocationEvidenceFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (evidence *EquivocationEvidence, err error) {
+	// the key (issuer public key and sequence number) is redundant as it is contained in the Messages
+	if _, err = marshalUtil.ReadBytes(ed25519.PublicKeySize + marshalutil.Uint64Size); err != nil {
+		err = xerrors.Errorf("failed to parse EquivocationEvidence key: %w", err)
+		return
+	}
+
+	evidence = &EquivocationEvidence{}
+	if evidence.firstMessage, err = MessageFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse first Message from MarshalUtil: %w", err)
+		return
+	}
+	if evidence.secondMessage, err = MessageFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse second Message from MarshalUtil: %w", err)
+		return
+	}
+
+	return
+}
+
+// EquivocationEvidenceFromObjectStorage is a factory method that creates a new EquivocationEvidence instance from a
+// storage key of the object storage. It is used by the object storage, to create new instances of this entity.
+func EquivocationEvidenceFromObjectStorage(key []byte, data []byte) (evidence objectstorage.StorableObject, err error) {
+	if evidence, _, err = EquivocationEvidenceFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = xerrors.Errorf("failed to parse EquivocationEvidence from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// IssuerPublicKey returns the public key of the equivocating issuer.
+func (e *EquivocationEvidence) IssuerPublicKey() ed25519.PublicKey {
+	return e.firstMessage.IssuerPublicKey()
+}
+
+// SequenceNumber returns the sequence number that was used for both Messages.
+func (e *EquivocationEvidence) SequenceNumber() uint64 {
+	return e.firstMessage.SequenceNumber()
+}
+
+// Messages returns the two conflicting Messages.
+func (e *EquivocationEvidence) Messages() (firstMessage, secondMessage *Message) {
+	return e.firstMessage, e.secondMessage
+}
+
+// Verify checks that the evidence contains two different Messages with valid signatures of the same issuer and with the
+// same sequence number.
+func (e *EquivocationEvidence) Verify() (err error) {
+	if e.firstMessage.ID() == e.secondMessage.ID() {
+		return xerrors.Errorf("evidence contains the same Message twice: %w", ErrInvalidEquivocationEvidence)
+	}
+	if e.firstMessage.IssuerPublicKey() != e.secondMessage.IssuerPublicKey() {
+		return xerrors.Errorf("Messages were issued by different issuers: %w", ErrInvalidEquivocationEvidence)
+	}
+	if e.firstMessage.SequenceNumber() != e.secondMessage.SequenceNumber() {
+		return xerrors.Errorf("Messages have different sequence numbers: %w", ErrInvalidEquivocationEvidence)
+	}
+	if !e.firstMessage.VerifySignature() || !e.secondMessage.VerifySignature() {
+		return xerrors.Errorf("Messages are not signed correctly: %w", ErrInvalidEquivocationEvidence)
+	}
+
+	return nil
+}
+
+// Bytes returns a marshaled version of the EquivocationEvidence.
+func (e *EquivocationEvidence) Bytes() []byte {
+	return byteutils.ConcatBytes(e.ObjectStorageKey(), e.ObjectStorageValue())
+}
+
+// String returns a human readable version of the EquivocationEvidence.
+func (e *EquivocationEvidence) String() string {
+	return stringify.Struct("EquivocationEvidence",
+		stringify.StructField("issuerPublicKey", e.IssuerPublicKey()),
+		stringify.StructField("sequenceNumber", e.SequenceNumber()),
+		stringify.StructField("firstMessageID", e.firstMessage.ID()),
+		stringify.StructField("secondMessageID", e.secondMessage.ID()),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (e *EquivocationEvidence) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (e *EquivocationEvidence) ObjectStorageKey() []byte {
+	return issuerSequenceNumberKey(e.IssuerPublicKey(), e.SequenceNumber())
+}
+
+// ObjectStorageValue marshals the EquivocationEvidence into a sequence of bytes that are used as the value part in the
+// object storage.
+func (e *EquivocationEvidence) ObjectStorageValue() []byte {
+	return byteutils.ConcatBytes(e.firstMessage.Bytes(), e.secondMessage.Bytes())
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &EquivocationEvidence{}
+
+func equivocationEvidenceEventHandler(handler interface{}, params ...interface{}) {
+	handler.(func(*EquivocationEvidence))(params[0].(*EquivocationEvidence))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedEquivocationEvidence ///////////////////////////////////////////////////////////////////////////////////
+
+// CachedEquivocationEvidence is a wrapper for the generic CachedObject returned by the object storage that overrides
+// the accessor methods with a type-casted one.
+type CachedEquivocationEvidence struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks the CachedObject to still be in use by the program.
+func (c *CachedEquivocationEvidence) Retain() *CachedEquivocationEvidence {
+	return &CachedEquivocationEvidence{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedEquivocationEvidence) Unwrap() *EquivocationEvidence {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*EquivocationEvidence)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (c *CachedEquivocationEvidence) Consume(consumer func(evidence *EquivocationEvidence), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*EquivocationEvidence))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedEquivocationEvidence.
+func (c *CachedEquivocationEvidence) String() string {
+	return stringify.Struct("CachedEquivocationEvidence",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Errors ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	// ErrIssuerEquivocated is returned when an issuer signs two different Messages with the same sequence number.
+	ErrIssuerEquivocated = fmt.Errorf("issuer equivocated")
+
+	// ErrInvalidEquivocationEvidence is returned when an EquivocationEvidence does not prove an equivocation.
+	ErrInvalidEquivocationEvidence = fmt.Errorf("invalid equivocation evidence")
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,125 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+)
+
+func TestEquivocationFilter(t *testing.T) {
+	tangle := New(BlacklistEquivocatingIssuers(true))
+	defer tangle.Shutdown()
+
+	var equivocationEvidence *EquivocationEvidence
+	tangle.Events.IssuerEquivocated.Attach(events.NewClosure(func(evidence *EquivocationEvidence) {
+		equivocationEvidence = evidence
+	}))
+
+	var filterErr error
+	filter := NewEquivocationFilter(tangle)
+	filter.Setup()
+	filter.OnAccept(func(msg *Message, peer *peer.Peer) {
+		filterErr = nil
+		tangle.Storage.StoreMessage(msg)
+	})
+	filter.OnReject(func(msg *Message, err error, peer *peer.Peer) {
+		filterErr = err
+	})
+
+	issuer := identity.GenerateLocalIdentity()
+	firstMessage := newTestSignedDataMessage(issuer, 0, "first")
+	filter.Filter(firstMessage, nil)
+	require.NoError(t, filterErr)
+
+	// receiving the same message again is not an equivocation
+	filter.Filter(firstMessage, nil)
+	require.NoError(t, filterErr)
+	assert.Nil(t, equivocationEvidence)
+
+	secondMessage := newTestSignedDataMessage(issuer, 0, "second")
+	filter.Filter(secondMessage, nil)
+	assert.True(t, xerrors.Is(filterErr, ErrIssuerEquivocated))
+	require.NotNil(t, equivocationEvidence)
+	assert.NoError(t, equivocationEvidence.Verify())
+	assert.Equal(t, issuer.PublicKey(), equivocationEvidence.IssuerPublicKey())
+	assert.True(t, tangle.IssuerBlocklist.IsBlocked(issuer.PublicKey()))
+
+	assert.True(t, tangle.Storage.EquivocationEvidence(issuer.PublicKey(), 0).Consume(func(evidence *EquivocationEvidence) {
+		storedFirstMessage, storedSecondMessage := evidence.Messages()
+		assert.Equal(t, firstMessage.ID(), storedFirstMessage.ID())
+		assert.Equal(t, secondMessage.ID(), storedSecondMessage.ID())
+	}))
+	assertIndexedMessage(t, tangle, issuer.PublicKey(), 0, firstMessage.ID())
+
+	// all further messages of the equivocating issuer are rejected by the (persisted) IssuerBlocklist
+	var blocklistErr error
+	blocklist := NewIssuerBlocklist(tangle)
+	blocklist.OnAccept(func(msg *Message, peer *peer.Peer) {
+		blocklistErr = nil
+	})
+	blocklist.OnReject(func(msg *Message, err error, peer *peer.Peer) {
+		blocklistErr = err
+	})
+	blocklist.Filter(newTestSignedDataMessage(issuer, 1, "third"), nil)
+	assert.True(t, xerrors.Is(blocklistErr, ErrIssuerBlocked))
+
+	// messages of other issuers are not affected
+	otherMessage := newTestSignedDataMessage(identity.GenerateLocalIdentity(), 0, "other")
+	blocklist.Filter(otherMessage, nil)
+	assert.NoError(t, blocklistErr)
+	filter.Filter(otherMessage, nil)
+	assert.NoError(t, filterErr)
+
+	// messages that passed the filter concurrently are detected when they are stored
+	concurrentIssuer := identity.GenerateLocalIdentity()
+	firstConcurrentMessage := newTestSignedDataMessage(concurrentIssuer, 0, "first")
+	secondConcurrentMessage := newTestSignedDataMessage(concurrentIssuer, 0, "second")
+	tangle.Storage.StoreMessage(firstConcurrentMessage)
+	tangle.Storage.StoreMessage(secondConcurrentMessage)
+	require.NotNil(t, equivocationEvidence)
+	assert.Equal(t, concurrentIssuer.PublicKey(), equivocationEvidence.IssuerPublicKey())
+	assert.True(t, tangle.IssuerBlocklist.IsBlocked(concurrentIssuer.PublicKey()))
+
+	// the index is pruned together with the indexed message
+	tangle.Storage.PruneMessage(secondConcurrentMessage.ID())
+	assertIndexedMessage(t, tangle, concurrentIssuer.PublicKey(), 0, firstConcurrentMessage.ID())
+	tangle.Storage.PruneMessage(firstConcurrentMessage.ID())
+	assert.False(t, tangle.Storage.IssuerSequenceNumberMapping(concurrentIssuer.PublicKey(), 0).Consume(func(*IssuerSequenceNumberMapping) {}))
+}
+
+func assertIndexedMessage(t *testing.T, tangle *Tangle, issuerPublicKey ed25519.PublicKey, sequenceNumber uint64, messageID MessageID) {
+	assert.True(t, tangle.Storage.IssuerSequenceNumberMapping(issuerPublicKey, sequenceNumber).Consume(func(mapping *IssuerSequenceNumberMapping) {
+		assert.Equal(t, messageID, mapping.MessageID())
+	}))
+}
+
+func TestEquivocationEvidence_Marshaling(t *testing.T) {
+	issuer := identity.GenerateLocalIdentity()
+	evidence := NewEquivocationEvidence(newTestSignedDataMessage(issuer, 7, "first"), newTestSignedDataMessage(issuer, 7, "second"))
+	require.NoError(t, evidence.Verify())
+
+	restoredEvidence, _, err := EquivocationEvidenceFromBytes(evidence.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, evidence.Bytes(), restoredEvidence.Bytes())
+	assert.NoError(t, restoredEvidence.Verify())
+
+	invalidEvidence := NewEquivocationEvidence(newTestSignedDataMessage(issuer, 7, "first"), newTestSignedDataMessage(issuer, 8, "second"))
+	assert.True(t, xerrors.Is(invalidEvidence.Verify(), ErrInvalidEquivocationEvidence))
+}
+
+func newTestSignedDataMessage(issuer *identity.LocalIdentity, sequenceNumber uint64, payloadString string) *Message {
+	issuingTime := time.Now()
+	dataPayload := payload.NewGenericDataPayload([]byte(payloadString))
+	unsignedMessageBytes := NewMessage([]MessageID{EmptyMessageID}, []MessageID{}, issuingTime, issuer.PublicKey(), sequenceNumber, dataPayload, 0, ed25519.EmptySignature).Bytes()
+	signature := issuer.Sign(unsignedMessageBytes[:len(unsignedMessageBytes)-ed25519.SignatureSize])
+
+	return NewMessage([]MessageID{EmptyMessageID}, []MessageID{}, issuingTime, issuer.PublicKey(), sequenceNumber, dataPayload, 0, signature)
+}
@@ -0,0 +1,472 @@
+package tangle
+
+import (
+	"sync"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/async"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/stringify"
+	"github.com/iotaledger/hive.go/types"
+)
+
+// region InclusionStateNotifier ///////////////////////////////////////////////////////////////////////////////////////
+
+// inclusionStateNotifierWorkerCount defines the amount of workers that re-evaluate the tracked Transactions after
+// Branch related events.
+const inclusionStateNotifierWorkerCount = 4
+
+// InclusionStateNotifier is a Tangle component that pushes the changes of the inclusion state of Transactions to its
+// subscribers, so that clients do not have to poll the ledger state to learn whether a Transaction was confirmed.
+// Subscribers can register their interest in Transactions, Outputs and Addresses. Outputs and Addresses are resolved to
+// the Transactions that create or consume them.
+//
+// Every subscription indexes its tracked Transactions by their Branch, so that a Branch related event only re-evaluates
+// the Transactions of the affected Branch. The re-evaluation happens in a worker pool, so that the BranchDAG is not
+// blocked by slow subscribers.
+type InclusionStateNotifier struct {
+	tangle             *Tangle
+	subscriptions      map[uint64]*InclusionStateSubscription
+	nextSubscriptionID uint64
+	subscriptionsMutex sync.RWMutex
+	workerPool         async.WorkerPool
+}
+
+// NewInclusionStateNotifier is the constructor of the InclusionStateNotifier.
+func NewInclusionStateNotifier(tangle *Tangle) (inclusionStateNotifier *InclusionStateNotifier) {
+	inclusionStateNotifier = &InclusionStateNotifier{
+		tangle:        tangle,
+		subscriptions: make(map[uint64]*InclusionStateSubscription),
+	}
+	inclusionStateNotifier.workerPool.Tune(inclusionStateNotifierWorkerCount)
+
+	return
+}
+
+// Setup sets up the behavior of the component by making it attach to the relevant events of other components.
+func (i *InclusionStateNotifier) Setup() {
+	i.tangle.LedgerState.Events.TransactionBooked.Attach(events.NewClosure(i.onTransactionBooked))
+	i.tangle.LedgerState.Events.TransactionConfirmed.Attach(events.NewClosure(i.onTransactionUpdated))
+	i.tangle.LedgerState.utxoDAG.Events.TransactionBranchIDUpdated.Attach(events.NewClosure(i.onTransactionUpdated))
+
+	i.tangle.BranchLifecycleManager.Events.TransactionMoved.Attach(events.NewClosure(i.onTransactionUpdated))
+
+	onBranchDAGEvent := events.NewClosure(func(branchDAGEvent *ledgerstate.BranchDAGEvent) {
+		defer branchDAGEvent.Release()
+
+		i.onBranchUpdated(branchDAGEvent.Branch.ID())
+	})
+	i.tangle.LedgerState.branchDAG.Events.BranchLiked.Attach(onBranchDAGEvent)
+	i.tangle.LedgerState.branchDAG.Events.BranchConfirmed.Attach(onBranchDAGEvent)
+	i.tangle.LedgerState.branchDAG.Events.BranchRejected.Attach(onBranchDAGEvent)
+}
+
+// Shutdown waits for the pending updates of the subscriptions to finish.
+func (i *InclusionStateNotifier) Shutdown() {
+	i.workerPool.ShutdownGracefully()
+}
+
+// Subscribe creates a new InclusionStateSubscription that passes its InclusionStateNotifications to the given callback.
+// The callback is executed synchronously by the goroutine that processes the corresponding event, so it should not
+// block.
+func (i *InclusionStateNotifier) Subscribe(callback func(notification *InclusionStateNotification)) (subscription *InclusionStateSubscription) {
+	i.subscriptionsMutex.Lock()
+	defer i.subscriptionsMutex.Unlock()
+
+	subscription = &InclusionStateSubscription{
+		notifier:            i,
+		id:                  i.nextSubscriptionID,
+		callback:            callback,
+		transactionIDs:      make(map[ledgerstate.TransactionID]types.Empty),
+		outputIDs:           make(map[ledgerstate.OutputID]types.Empty),
+		addresses:           make(map[[ledgerstate.AddressLength]byte]types.Empty),
+		trackedTransactions: make(map[ledgerstate.TransactionID]*transactionInclusionState),
+		branchTransactions:  make(map[ledgerstate.BranchID]map[ledgerstate.TransactionID]types.Empty),
+	}
+	i.subscriptions[subscription.id] = subscription
+	i.nextSubscriptionID++
+
+	return
+}
+
+// SubscriptionCount returns the amount of active InclusionStateSubscriptions.
+func (i *InclusionStateNotifier) SubscriptionCount() int {
+	i.subscriptionsMutex.RLock()
+	defer i.subscriptionsMutex.RUnlock()
+
+	return len(i.subscriptions)
+}
+
+// onTransactionBooked is the event handler that starts the tracking of newly booked Transactions by the
+// InclusionStateSubscriptions that are interested in them.
+func (i *InclusionStateNotifier) onTransactionBooked(transactionID ledgerstate.TransactionID) {
+	subscriptions := i.activeSubscriptions()
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	i.tangle.LedgerState.Transaction(transactionID).Consume(func(transaction *ledgerstate.Transaction) {
+		consumedOutputs := make(ledgerstate.Outputs, 0)
+		for _, input := range transaction.Essence().Inputs() {
+			i.tangle.LedgerState.Output(input.(*ledgerstate.UTXOInput).ReferencedOutputID()).Consume(func(output ledgerstate.Output) {
+				consumedOutputs = append(consumedOutputs, output)
+			})
+		}
+
+		for _, subscription := range subscriptions {
+			if subscription.interestedIn(transaction, consumedOutputs) {
+				subscription.track(transactionID)
+			}
+		}
+	})
+}
+
+// onTransactionUpdated is the event handler that re-evaluates the state of a single Transaction.
+func (i *InclusionStateNotifier) onTransactionUpdated(transactionID ledgerstate.TransactionID) {
+	for _, subscription := range i.activeSubscriptions() {
+		subscription.update(transactionID)
+	}
+}
+
+// onBranchUpdated is the event handler that re-evaluates the state of the tracked Transactions of the given Branch in
+// the worker pool.
+func (i *InclusionStateNotifier) onBranchUpdated(branchID ledgerstate.BranchID) {
+	for _, subscription := range i.activeSubscriptions() {
+		for _, transactionID := range subscription.transactionsOfBranch(branchID) {
+			subscription, transactionID := subscription, transactionID
+			i.workerPool.Submit(func() {
+				subscription.update(transactionID)
+			})
+		}
+	}
+}
+
+// activeSubscriptions is an internal utility function that returns a snapshot of the active subscriptions.
+func (i *InclusionStateNotifier) activeSubscriptions() (subscriptions []*InclusionStateSubscription) {
+	i.subscriptionsMutex.RLock()
+	defer i.subscriptionsMutex.RUnlock()
+
+	subscriptions = make([]*InclusionStateSubscription, 0, len(i.subscriptions))
+	for _, subscription := range i.subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return
+}
+
+// unsubscribe is an internal utility function that removes the given subscription.
+func (i *InclusionStateNotifier) unsubscribe(subscriptionID uint64) {
+	i.subscriptionsMutex.Lock()
+	defer i.subscriptionsMutex.Unlock()
+
+	delete(i.subscriptions, subscriptionID)
+}
+
+// transactionInclusionState is an internal utility function that retrieves the current state of the given Transaction.
+func (i *InclusionStateNotifier) transactionInclusionState(transactionID ledgerstate.TransactionID) (state *transactionInclusionState) {
+	if !i.tangle.LedgerState.TransactionMetadata(transactionID).Consume(func(transactionMetadata *ledgerstate.TransactionMetadata) {
+		state = &transactionInclusionState{branchID: transactionMetadata.BranchID()}
+	}) {
+		return nil
+	}
+
+	i.tangle.LedgerState.Branch(state.branchID).Consume(func(branch ledgerstate.Branch) {
+		state.liked = branch.Liked()
+	})
+	if inclusionState, err := i.tangle.LedgerState.TransactionInclusionState(transactionID); err == nil {
+		state.confirmed = inclusionState == ledgerstate.Confirmed
+		state.rejected = inclusionState == ledgerstate.Rejected
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region InclusionStateSubscription ///////////////////////////////////////////////////////////////////////////////////
+
+// InclusionStateSubscription represents the interest of a subscriber in a set of Transactions, Outputs and Addresses.
+type InclusionStateSubscription struct {
+	notifier            *InclusionStateNotifier
+	id                  uint64
+	callback            func(notification *InclusionStateNotification)
+	transactionIDs      map[ledgerstate.TransactionID]types.Empty
+	outputIDs           map[ledgerstate.OutputID]types.Empty
+	addresses           map[[ledgerstate.AddressLength]byte]types.Empty
+	trackedTransactions map[ledgerstate.TransactionID]*transactionInclusionState
+	branchTransactions  map[ledgerstate.BranchID]map[ledgerstate.TransactionID]types.Empty
+	mutex               sync.Mutex
+}
+
+// SubscribeTransaction registers the interest in the Transaction with the given ID. If the Transaction was booked
+// already, its current state is reported immediately.
+func (i *InclusionStateSubscription) SubscribeTransaction(transactionID ledgerstate.TransactionID) {
+	i.mutex.Lock()
+	i.transactionIDs[transactionID] = types.Void
+	i.mutex.Unlock()
+
+	i.track(transactionID)
+}
+
+// SubscribeOutput registers the interest in the Transactions that create or consume the Output with the given ID. The
+// already booked Transactions are reported immediately.
+func (i *InclusionStateSubscription) SubscribeOutput(outputID ledgerstate.OutputID) {
+	i.mutex.Lock()
+	i.outputIDs[outputID] = types.Void
+	i.mutex.Unlock()
+
+	i.track(outputID.TransactionID())
+	i.notifier.tangle.LedgerState.utxoDAG.Consumers(outputID).Consume(func(consumer *ledgerstate.Consumer) {
+		i.track(consumer.TransactionID())
+	})
+}
+
+// SubscribeAddress registers the interest in the Transactions that move funds from or to the given Address. The
+// Transactions that created the unspent Outputs of the Address and that are not decided, yet, are reported
+// immediately.
+func (i *InclusionStateSubscription) SubscribeAddress(address ledgerstate.Address) {
+	i.mutex.Lock()
+	i.addresses[address.Array()] = types.Void
+	i.mutex.Unlock()
+
+	i.notifier.tangle.LedgerState.OutputsOnAddress(address).Consume(func(output ledgerstate.Output) {
+		i.notifier.tangle.LedgerState.OutputMetadata(output.ID()).Consume(func(outputMetadata *ledgerstate.OutputMetadata) {
+			if outputMetadata.ConsumerCount() != 0 {
+				return
+			}
+
+			if state := i.notifier.transactionInclusionState(output.ID().TransactionID()); state != nil && !state.confirmed && !state.rejected {
+				i.track(output.ID().TransactionID())
+			}
+		})
+	})
+}
+
+// InterestCount returns the amount of Transactions, Outputs and Addresses that the subscription is interested in.
+func (i *InclusionStateSubscription) InterestCount() int {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return len(i.transactionIDs) + len(i.outputIDs) + len(i.addresses)
+}
+
+// Unsubscribe removes the subscription from the InclusionStateNotifier (no further notifications are delivered).
+func (i *InclusionStateSubscription) Unsubscribe() {
+	i.notifier.unsubscribe(i.id)
+}
+
+// interestedIn returns true if the subscription is interested in the given Transaction.
+func (i *InclusionStateSubscription) interestedIn(transaction *ledgerstate.Transaction, consumedOutputs ledgerstate.Outputs) bool {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if _, interested := i.transactionIDs[transaction.ID()]; interested {
+		return true
+	}
+	for _, output := range consumedOutputs {
+		if i.outputOrAddressSubscribed(output) {
+			return true
+		}
+	}
+	for _, output := range transaction.Essence().Outputs() {
+		if i.outputOrAddressSubscribed(output) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// outputOrAddressSubscribed returns true if the subscription is interested in the given Output or its Address.
+func (i *InclusionStateSubscription) outputOrAddressSubscribed(output ledgerstate.Output) bool {
+	if _, interested := i.outputIDs[output.ID()]; interested {
+		return true
+	}
+	_, interested := i.addresses[output.Address().Array()]
+
+	return interested
+}
+
+// track starts the tracking of the given Transaction and reports its current state (if it was booked already).
+func (i *InclusionStateSubscription) track(transactionID ledgerstate.TransactionID) {
+	state := i.notifier.transactionInclusionState(transactionID)
+	if state == nil {
+		return
+	}
+
+	i.mutex.Lock()
+	if _, tracked := i.trackedTransactions[transactionID]; tracked {
+		i.mutex.Unlock()
+		return
+	}
+	i.trackedTransactions[transactionID] = &transactionInclusionState{branchID: state.branchID}
+	i.indexTransaction(transactionID, state.branchID)
+	notifications := append([]*InclusionStateNotification{
+		NewInclusionStateNotification(TransactionBookedNotification, transactionID, state.branchID),
+	}, i.applyState(transactionID, state)...)
+	i.mutex.Unlock()
+
+	i.deliver(notifications)
+}
+
+// update re-evaluates the state of the given Transaction if it is tracked by the subscription. The state is retrieved
+// while holding the lock, so that concurrent updates of the same Transaction can not apply an outdated state.
+func (i *InclusionStateSubscription) update(transactionID ledgerstate.TransactionID) {
+	i.mutex.Lock()
+	if _, tracked := i.trackedTransactions[transactionID]; !tracked {
+		i.mutex.Unlock()
+		return
+	}
+
+	var notifications []*InclusionStateNotification
+	if state := i.notifier.transactionInclusionState(transactionID); state != nil {
+		notifications = i.applyState(transactionID, state)
+	}
+	i.mutex.Unlock()
+
+	i.deliver(notifications)
+}
+
+// transactionsOfBranch returns the tracked Transactions that are booked into the given Branch.
+func (i *InclusionStateSubscription) transactionsOfBranch(branchID ledgerstate.BranchID) (transactionIDs []ledgerstate.TransactionID) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	transactionIDs = make([]ledgerstate.TransactionID, 0, len(i.branchTransactions[branchID]))
+	for transactionID := range i.branchTransactions[branchID] {
+		transactionIDs = append(transactionIDs, transactionID)
+	}
+
+	return
+}
+
+// indexTransaction adds the given Transaction to the index of the given Branch. It has to be called while holding the
+// lock of the subscription.
+func (i *InclusionStateSubscription) indexTransaction(transactionID ledgerstate.TransactionID, branchID ledgerstate.BranchID) {
+	if _, exists := i.branchTransactions[branchID]; !exists {
+		i.branchTransactions[branchID] = make(map[ledgerstate.TransactionID]types.Empty)
+	}
+	i.branchTransactions[branchID][transactionID] = types.Void
+}
+
+// unindexTransaction removes the given Transaction from the index of the given Branch. It has to be called while
+// holding the lock of the subscription.
+func (i *InclusionStateSubscription) unindexTransaction(transactionID ledgerstate.TransactionID, branchID ledgerstate.BranchID) {
+	delete(i.branchTransactions[branchID], transactionID)
+	if len(i.branchTransactions[branchID]) == 0 {
+		delete(i.branchTransactions, branchID)
+	}
+}
+
+// applyState updates the stored state of a tracked Transaction and returns the notifications that describe the
+// changes. Transactions that reached their final state are not tracked anymore. It has to be called while holding the
+// lock of the subscription.
+func (i *InclusionStateSubscription) applyState(transactionID ledgerstate.TransactionID, state *transactionInclusionState) (notifications []*InclusionStateNotification) {
+	previousState, tracked := i.trackedTransactions[transactionID]
+	if !tracked {
+		return
+	}
+
+	if state.branchID != previousState.branchID {
+		notifications = append(notifications, NewInclusionStateNotification(TransactionBranchChangedNotification, transactionID, state.branchID))
+	}
+	if state.liked && !previousState.liked {
+		notifications = append(notifications, NewInclusionStateNotification(TransactionLikedNotification, transactionID, state.branchID))
+	}
+	if state.confirmed && !previousState.confirmed {
+		notifications = append(notifications, NewInclusionStateNotification(TransactionConfirmedNotification, transactionID, state.branchID))
+	}
+	if state.rejected && !previousState.rejected {
+		notifications = append(notifications, NewInclusionStateNotification(TransactionRejectedNotification, transactionID, state.branchID))
+	}
+	i.trackedTransactions[transactionID] = state
+	i.unindexTransaction(transactionID, previousState.branchID)
+	i.indexTransaction(transactionID, state.branchID)
+
+	// confirmed Transactions in the MasterBranch and Transactions in the InvalidBranch will not change anymore
+	if (state.confirmed && state.branchID == ledgerstate.MasterBranchID) || state.branchID == ledgerstate.InvalidBranchID {
+		delete(i.trackedTransactions, transactionID)
+		i.unindexTransaction(transactionID, state.branchID)
+	}
+
+	return
+}
+
+// deliver passes the given notifications to the callback of the subscription.
+func (i *InclusionStateSubscription) deliver(notifications []*InclusionStateNotification) {
+	for _, notification := range notifications {
+		i.callback(notification)
+	}
+}
+
+// transactionInclusionState is an internal type that contains the last reported state of a tracked Transaction.
+type transactionInclusionState struct {
+	branchID  ledgerstate.BranchID
+	liked     bool
+	confirmed bool
+	rejected  bool
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region InclusionStateNotification ///////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// TransactionBookedNotification is sent when a Transaction was booked (or when an already booked Transaction is
+	// subscribed).
+	TransactionBookedNotification InclusionStateNotificationType = iota
+
+	// TransactionLikedNotification is sent when the Branch of a Transaction becomes liked.
+	TransactionLikedNotification
+
+	// TransactionConfirmedNotification is sent when a Transaction becomes confirmed.
+	TransactionConfirmedNotification
+
+	// TransactionRejectedNotification is sent when a Transaction becomes rejected.
+	TransactionRejectedNotification
+
+	// TransactionBranchChangedNotification is sent when a Transaction was moved to another Branch (i.e. because it
+	// became conflicting or because its Branch was merged or removed).
+	TransactionBranchChangedNotification
+)
+
+// InclusionStateNotificationType represents the kind of change that is described by an InclusionStateNotification.
+type InclusionStateNotificationType uint8
+
+// String returns a human readable representation of the InclusionStateNotificationType.
+func (i InclusionStateNotificationType) String() string {
+	return [...]string{
+		"booked",
+		"liked",
+		"confirmed",
+		"rejected",
+		"branch-changed",
+	}[i]
+}
+
+// InclusionStateNotification describes a change of the inclusion state of a Transaction.
+type InclusionStateNotification struct {
+	Type          InclusionStateNotificationType
+	TransactionID ledgerstate.TransactionID
+	BranchID      ledgerstate.BranchID
+}
+
+// NewInclusionStateNotification is the constructor of the InclusionStateNotification.
+func NewInclusionStateNotification(notificationType InclusionStateNotificationType, transactionID ledgerstate.TransactionID, branchID ledgerstate.BranchID) *InclusionStateNotification {
+	return &InclusionStateNotification{
+		Type:          notificationType,
+		TransactionID: transactionID,
+		BranchID:      branchID,
+	}
+}
+
+// String returns a human readable version of the InclusionStateNotification.
+func (i *InclusionStateNotification) String() string {
+	return stringify.Struct("InclusionStateNotification",
+		stringify.StructField("type", i.Type.String()),
+		stringify.StructField("transactionID", i.TransactionID),
+		stringify.StructField("branchID", i.BranchID),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,102 @@
+package tangle
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInclusionStateNotifier(t *testing.T) {
+	tangle := New(WithoutOpinionFormer(true))
+	defer tangle.Shutdown()
+	tangle.InclusionStateNotifier.Setup()
+
+	wallets := createWallets(3)
+	genesisOutput := ledgerstate.NewSigLockedSingleOutput(100, wallets[0].address)
+	genesisOutput.SetID(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 1))
+	require.NoError(t, tangle.LedgerState.LoadSnapshotOutput(genesisOutput, snapshotOutputMetadata(genesisOutput)))
+
+	var notificationsMutex sync.Mutex
+	notifications := make(map[ledgerstate.TransactionID][]InclusionStateNotificationType)
+	subscription := tangle.InclusionStateNotifier.Subscribe(func(notification *InclusionStateNotification) {
+		notificationsMutex.Lock()
+		defer notificationsMutex.Unlock()
+
+		notifications[notification.TransactionID] = append(notifications[notification.TransactionID], notification.Type)
+	})
+	notificationsOf := func(transactionID ledgerstate.TransactionID) []InclusionStateNotificationType {
+		notificationsMutex.Lock()
+		defer notificationsMutex.Unlock()
+
+		return append([]InclusionStateNotificationType{}, notifications[transactionID]...)
+	}
+	subscription.SubscribeAddress(wallets[1].address)
+	subscription.SubscribeOutput(genesisOutput.ID())
+	assert.Equal(t, 1, tangle.InclusionStateNotifier.SubscriptionCount())
+	assert.Equal(t, 2, subscription.InterestCount())
+
+	spend := func(receiver wallet) *ledgerstate.Transaction {
+		txEssence := ledgerstate.NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, ledgerstate.NewInputs(ledgerstate.NewUTXOInput(genesisOutput.ID())), ledgerstate.NewOutputs(ledgerstate.NewSigLockedSingleOutput(100, receiver.address)))
+		tx := ledgerstate.NewTransaction(txEssence, wallets[0].unlockBlocks(txEssence))
+		_, err := tangle.LedgerState.BookTransaction(tx, EmptyMessageID)
+		require.NoError(t, err)
+
+		return tx
+	}
+
+	// the first spend is booked into the liked MasterBranch
+	tx1 := spend(wallets[1])
+	assert.Equal(t, []InclusionStateNotificationType{TransactionBookedNotification, TransactionLikedNotification}, notificationsOf(tx1.ID()))
+
+	// the double spend moves the first spend into its own Branch
+	tx2 := spend(wallets[2])
+	assert.Equal(t, []InclusionStateNotificationType{TransactionBookedNotification}, notificationsOf(tx2.ID()))
+	assert.Contains(t, notificationsOf(tx1.ID()), TransactionBranchChangedNotification)
+
+	// confirming the Branch of the first spend rejects the double spend
+	// (the changes of Branches are processed asynchronously)
+	confirmBranch(t, tangle, ledgerstate.NewBranchID(tx1.ID()))
+	assert.Eventually(t, func() bool {
+		return containsNotification(notificationsOf(tx2.ID()), TransactionRejectedNotification)
+	}, time.Second, 10*time.Millisecond)
+	assert.NotContains(t, notificationsOf(tx1.ID()), TransactionConfirmedNotification)
+
+	tangle.LedgerState.TransactionMetadata(tx1.ID()).Consume(func(transactionMetadata *ledgerstate.TransactionMetadata) {
+		transactionMetadata.SetFinalized(true)
+	})
+	tangle.LedgerState.Events.TransactionConfirmed.Trigger(tx1.ID())
+	assert.Contains(t, notificationsOf(tx1.ID()), TransactionConfirmedNotification)
+
+	// Transactions that are not related to the subscription are not reported
+	unrelatedOutput := ledgerstate.NewSigLockedSingleOutput(100, wallets[2].address)
+	unrelatedOutput.SetID(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 2))
+	require.NoError(t, tangle.LedgerState.LoadSnapshotOutput(unrelatedOutput, snapshotOutputMetadata(unrelatedOutput)))
+	txEssence := ledgerstate.NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, ledgerstate.NewInputs(ledgerstate.NewUTXOInput(unrelatedOutput.ID())), ledgerstate.NewOutputs(ledgerstate.NewSigLockedSingleOutput(100, wallets[0].address)))
+	unrelatedTx := ledgerstate.NewTransaction(txEssence, wallets[2].unlockBlocks(txEssence))
+	_, err := tangle.LedgerState.BookTransaction(unrelatedTx, EmptyMessageID)
+	require.NoError(t, err)
+	assert.Empty(t, notificationsOf(unrelatedTx.ID()))
+
+	// subscribing to an already booked Transaction reports its current state
+	subscription.SubscribeTransaction(unrelatedTx.ID())
+	assert.Equal(t, []InclusionStateNotificationType{TransactionBookedNotification, TransactionLikedNotification}, notificationsOf(unrelatedTx.ID()))
+
+	subscription.Unsubscribe()
+	assert.Equal(t, 0, tangle.InclusionStateNotifier.SubscriptionCount())
+}
+
+// containsNotification returns true if the given InclusionStateNotificationTypes contain the given type.
+func containsNotification(notificationTypes []InclusionStateNotificationType, notificationType InclusionStateNotificationType) bool {
+	for _, existingNotificationType := range notificationTypes {
+		if existingNotificationType == notificationType {
+			return true
+		}
+	}
+
+	return false
+}
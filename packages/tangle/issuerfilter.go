@@ -0,0 +1,496 @@
+package tangle
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+	"golang.org/x/xerrors"
+)
+
+// region IssuerBlocklist //////////////////////////////////////////////////////////////////////////////////////////////
+
+// IssuerBlocklist is a persistent list of blocked issuers. It is a MessageFilter that rejects all Messages of the
+// blocked issuers.
+type IssuerBlocklist struct {
+	tangle *Tangle
+
+	blockedIssuers      map[ed25519.PublicKey]time.Time
+	blockedIssuersMutex sync.RWMutex
+
+	onAcceptCallback func(msg *Message, peer *peer.Peer)
+	onRejectCallback func(msg *Message, err error, peer *peer.Peer)
+
+	onAcceptCallbackMutex sync.RWMutex
+	onRejectCallbackMutex sync.RWMutex
+}
+
+// NewIssuerBlocklist creates a new IssuerBlocklist and restores the blocked issuers from the Storage.
+func NewIssuerBlocklist(tangle *Tangle) (issuerBlocklist *IssuerBlocklist) {
+	issuerBlocklist = &IssuerBlocklist{
+		tangle:         tangle,
+		blockedIssuers: make(map[ed25519.PublicKey]time.Time),
+	}
+
+	tangle.Storage.ForEachBlockedIssuer(func(blockedIssuer *BlockedIssuer) {
+		issuerBlocklist.blockedIssuers[blockedIssuer.IssuerPublicKey()] = blockedIssuer.BlockedSince()
+	})
+
+	return
+}
+
+// Block adds the given issuer to the blocklist and returns true if it was not blocked before.
+func (i *IssuerBlocklist) Block(issuerPublicKey ed25519.PublicKey) (blocked bool) {
+	i.blockedIssuersMutex.Lock()
+	defer i.blockedIssuersMutex.Unlock()
+
+	if _, exists := i.blockedIssuers[issuerPublicKey]; exists {
+		return false
+	}
+
+	blockedIssuer := NewBlockedIssuer(issuerPublicKey, time.Now())
+	i.tangle.Storage.StoreBlockedIssuer(blockedIssuer)
+	i.blockedIssuers[issuerPublicKey] = blockedIssuer.BlockedSince()
+
+	return true
+}
+
+// Unblock removes the given issuer from the blocklist and returns true if it was blocked before.
+func (i *IssuerBlocklist) Unblock(issuerPublicKey ed25519.PublicKey) (unblocked bool) {
+	i.blockedIssuersMutex.Lock()
+	defer i.blockedIssuersMutex.Unlock()
+
+	if _, exists := i.blockedIssuers[issuerPublicKey]; !exists {
+		return false
+	}
+
+	i.tangle.Storage.DeleteBlockedIssuer(issuerPublicKey)
+	delete(i.blockedIssuers, issuerPublicKey)
+
+	return true
+}
+
+// IsBlocked returns true if the given issuer is blocked.
+func (i *IssuerBlocklist) IsBlocked(issuerPublicKey ed25519.PublicKey) (blocked bool) {
+	i.blockedIssuersMutex.RLock()
+	defer i.blockedIssuersMutex.RUnlock()
+
+	_, blocked = i.blockedIssuers[issuerPublicKey]
+
+	return
+}
+
+// BlockedIssuers returns all the blocked issuers together with the time since when they are blocked.
+func (i *IssuerBlocklist) BlockedIssuers() (blockedIssuers map[ed25519.PublicKey]time.Time) {
+	i.blockedIssuersMutex.RLock()
+	defer i.blockedIssuersMutex.RUnlock()
+
+	blockedIssuers = make(map[ed25519.PublicKey]time.Time, len(i.blockedIssuers))
+	for issuerPublicKey, blockedSince := range i.blockedIssuers {
+		blockedIssuers[issuerPublicKey] = blockedSince
+	}
+
+	return
+}
+
+// Filter rejects the Message if its issuer is blocked and calls the corresponding callback.
+func (i *IssuerBlocklist) Filter(msg *Message, peer *peer.Peer) {
+	if i.IsBlocked(msg.IssuerPublicKey()) {
+		i.getRejectCallback()(msg, ErrIssuerBlocked, peer)
+		return
+	}
+
+	i.getAcceptCallback()(msg, peer)
+}
+
+// OnAccept registers the given callback as the acceptance function of the filter.
+func (i *IssuerBlocklist) OnAccept(callback func(msg *Message, peer *peer.Peer)) {
+	i.onAcceptCallbackMutex.Lock()
+	defer i.onAcceptCallbackMutex.Unlock()
+	i.onAcceptCallback = callback
+}
+
+// OnReject registers the given callback as the rejection function of the filter.
+func (i *IssuerBlocklist) OnReject(callback func(msg *Message, err error, peer *peer.Peer)) {
+	i.onRejectCallbackMutex.Lock()
+	defer i.onRejectCallbackMutex.Unlock()
+	i.onRejectCallback = callback
+}
+
+func (i *IssuerBlocklist) getAcceptCallback() (result func(msg *Message, peer *peer.Peer)) {
+	i.onAcceptCallbackMutex.RLock()
+	result = i.onAcceptCallback
+	i.onAcceptCallbackMutex.RUnlock()
+	return
+}
+
+func (i *IssuerBlocklist) getRejectCallback() (result func(msg *Message, err error, peer *peer.Peer)) {
+	i.onRejectCallbackMutex.RLock()
+	result = i.onRejectCallback
+	i.onRejectCallbackMutex.RUnlock()
+	return
+}
+
+// code contract (make sure the type implements all required methods)
+var _ MessageFilter = &IssuerBlocklist{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region BlockedIssuer ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// BlockedIssuer represents an entry of the IssuerBlocklist in the object storage.
+type BlockedIssuer struct {
+	issuerPublicKey ed25519.PublicKey
+	blockedSince    time.Time
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewBlockedIssuer creates a new BlockedIssuer.
+func NewBlockedIssuer(issuerPublicKey ed25519.PublicKey, blockedSince time.Time) *BlockedIssuer {
+	return &BlockedIssuer{
+		issuerPublicKey: issuerPublicKey,
+		blockedSince:    blockedSince,
+	}
+}
+
+// BlockedIssuerFromBytes unmarshals a BlockedIssuer from a sequence of bytes.
+func BlockedIssuerFromBytes(bytes []byte) (blockedIssuer *BlockedIssuer, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if blockedIssuer, err = BlockedIssuerFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse BlockedIssuer from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// BlockedIssuerFromMarshalUtil unmarshals a BlockedIssuer using a MarshalUtil (for easier unmarshaling).
+func BlockedIssuerFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (blockedIssuer *BlockedIssuer, err error) {
+	blockedIssuer = &BlockedIssuer{}
+	if blockedIssuer.issuerPublicKey, err = ed25519.ParsePublicKey(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse issuer public key from MarshalUtil: %w", err)
+		return
+	}
+	if blockedIssuer.blockedSince, err = marshalUtil.ReadTime(); err != nil {
+		err = xerrors.Errorf("failed to parse blocked since time from MarshalUtil: %w", err)
+		return
+	}
+
+	return
+}
+
+// BlockedIssuerFromObjectStorage is a factory method that creates a new BlockedIssuer instance from a storage key of
+// the object storage. It is used by the object storage, to create new instances of this entity.
+func BlockedIssuerFromObjectStorage(key []byte, data []byte) (blockedIssuer objectstorage.StorableObject, err error) {
+	if blockedIssuer, _, err = BlockedIssuerFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = xerrors.Errorf("failed to parse BlockedIssuer from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// IssuerPublicKey returns the public key of the blocked issuer.
+func (b *BlockedIssuer) IssuerPublicKey() ed25519.PublicKey {
+	return b.issuerPublicKey
+}
+
+// BlockedSince returns the time when the issuer was blocked.
+func (b *BlockedIssuer) BlockedSince() time.Time {
+	return b.blockedSince
+}
+
+// Bytes returns a marshaled version of the BlockedIssuer.
+func (b *BlockedIssuer) Bytes() []byte {
+	return byteutils.ConcatBytes(b.ObjectStorageKey(), b.ObjectStorageValue())
+}
+
+// String returns a human readable version of the BlockedIssuer.
+func (b *BlockedIssuer) String() string {
+	return stringify.Struct("BlockedIssuer",
+		stringify.StructField("issuerPublicKey", b.issuerPublicKey),
+		stringify.StructField("blockedSince", b.blockedSince),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (b *BlockedIssuer) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (b *BlockedIssuer) ObjectStorageKey() []byte {
+	return b.issuerPublicKey.Bytes()
+}
+
+// ObjectStorageValue marshals the BlockedIssuer into a sequence of bytes that are used as the value part in the object
+// storage.
+func (b *BlockedIssuer) ObjectStorageValue() []byte {
+	return marshalutil.New(marshalutil.TimeSize).
+		WriteTime(b.blockedSince).
+		Bytes()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &BlockedIssuer{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedBlockedIssuer //////////////////////////////////////////////////////////////////////////////////////////
+
+// CachedBlockedIssuer is a wrapper for the generic CachedObject returned by the object storage that overrides the
+// accessor methods with a type-casted one.
+type CachedBlockedIssuer struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks the CachedObject to still be in use by the program.
+func (c *CachedBlockedIssuer) Retain() *CachedBlockedIssuer {
+	return &CachedBlockedIssuer{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedBlockedIssuer) Unwrap() *BlockedIssuer {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*BlockedIssuer)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (c *CachedBlockedIssuer) Consume(consumer func(blockedIssuer *BlockedIssuer), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*BlockedIssuer))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedBlockedIssuer.
+func (c *CachedBlockedIssuer) String() string {
+	return stringify.Struct("CachedBlockedIssuer",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region IssuerRateLimitFilter ////////////////////////////////////////////////////////////////////////////////////////
+
+// IssuerRateLimitFilter is a MessageFilter that limits the amount of Messages of each issuer within a sliding time
+// window. The Messages are counted by their issuing time, so that all nodes agree on the Messages that exceed the limit
+// (independently of when they receive them). Messages that exceed the limit are delayed instead of being dropped and
+// Messages that are requested (e.g. during solidification) are exempt from the limit.
+type IssuerRateLimitFilter struct {
+	tangle *Tangle
+
+	recentMessages      map[ed25519.PublicKey][]time.Time
+	delayedMessages     map[ed25519.PublicKey]int
+	lastCleanup         time.Time
+	recentMessagesMutex sync.Mutex
+
+	onAcceptCallback func(msg *Message, peer *peer.Peer)
+	onRejectCallback func(msg *Message, err error, peer *peer.Peer)
+
+	onAcceptCallbackMutex sync.RWMutex
+	onRejectCallbackMutex sync.RWMutex
+}
+
+// NewIssuerRateLimitFilter creates a new IssuerRateLimitFilter.
+func NewIssuerRateLimitFilter(tangle *Tangle) *IssuerRateLimitFilter {
+	return &IssuerRateLimitFilter{
+		tangle:          tangle,
+		recentMessages:  make(map[ed25519.PublicKey][]time.Time),
+		delayedMessages: make(map[ed25519.PublicKey]int),
+		lastCleanup:     time.Now(),
+	}
+}
+
+// Filter accepts the Message if its issuer did not exceed the rate limit. Messages that exceed the rate limit are
+// accepted with a delay, unless the issuer already has too many delayed Messages, in which case they are rejected.
+func (f *IssuerRateLimitFilter) Filter(msg *Message, peer *peer.Peer) {
+	if f.tangle.Requester.IsRequested(msg.ID()) {
+		f.getAcceptCallback()(msg, peer)
+		return
+	}
+
+	delay, err := f.admit(msg.IssuerPublicKey(), msg.IssuingTime(), time.Now())
+	if err != nil {
+		f.getRejectCallback()(msg, err, peer)
+		return
+	}
+	if delay == 0 {
+		f.getAcceptCallback()(msg, peer)
+		return
+	}
+
+	time.AfterFunc(delay, func() {
+		f.release(msg.IssuerPublicKey())
+		f.getAcceptCallback()(msg, peer)
+	})
+}
+
+// OnAccept registers the given callback as the acceptance function of the filter.
+func (f *IssuerRateLimitFilter) OnAccept(callback func(msg *Message, peer *peer.Peer)) {
+	f.onAcceptCallbackMutex.Lock()
+	defer f.onAcceptCallbackMutex.Unlock()
+	f.onAcceptCallback = callback
+}
+
+// OnReject registers the given callback as the rejection function of the filter.
+func (f *IssuerRateLimitFilter) OnReject(callback func(msg *Message, err error, peer *peer.Peer)) {
+	f.onRejectCallbackMutex.Lock()
+	defer f.onRejectCallbackMutex.Unlock()
+	f.onRejectCallback = callback
+}
+
+// admit records a Message of the given issuer with the given issuing time and returns the delay after which it should
+// be accepted. The delay is zero if the issuer did not exceed the rate limit within the window that ends at the issuing
+// time. Otherwise, the Messages that exceed the limit are released at the rate of the limit and an error is returned if
+// the issuer already has a full window of delayed Messages.
+func (f *IssuerRateLimitFilter) admit(issuerPublicKey ed25519.PublicKey, issuingTime time.Time, now time.Time) (delay time.Duration, err error) {
+	params := f.tangle.Options.IssuerRateLimitParams
+	if params.MaxMessages <= 0 {
+		return 0, nil
+	}
+
+	f.recentMessagesMutex.Lock()
+	defer f.recentMessagesMutex.Unlock()
+
+	if f.lastCleanup.Before(now.Add(-params.Window)) {
+		f.cleanup(now.Add(-params.Window))
+		f.lastCleanup = now
+	}
+
+	recentMessages := f.recentMessages[issuerPublicKey]
+	if countTimestamps(recentMessages, issuingTime.Add(-params.Window), issuingTime) < params.MaxMessages {
+		f.recentMessages[issuerPublicKey] = insertTimestamp(recentMessages, issuingTime)
+		return 0, nil
+	}
+
+	if f.delayedMessages[issuerPublicKey] >= params.MaxMessages {
+		return 0, ErrIssuerRateLimitExceeded
+	}
+	f.delayedMessages[issuerPublicKey]++
+
+	return time.Duration(f.delayedMessages[issuerPublicKey]) * params.Window / time.Duration(params.MaxMessages), nil
+}
+
+// release removes a delayed Message of the given issuer once it was accepted.
+func (f *IssuerRateLimitFilter) release(issuerPublicKey ed25519.PublicKey) {
+	f.recentMessagesMutex.Lock()
+	defer f.recentMessagesMutex.Unlock()
+
+	if f.delayedMessages[issuerPublicKey]--; f.delayedMessages[issuerPublicKey] <= 0 {
+		delete(f.delayedMessages, issuerPublicKey)
+	}
+}
+
+// cleanup removes the issuers whose latest Message was issued before the given time (the issuing times are kept
+// sorted, so only the last one needs to be checked).
+func (f *IssuerRateLimitFilter) cleanup(threshold time.Time) {
+	for issuerPublicKey, recentMessages := range f.recentMessages {
+		if len(recentMessages) == 0 || recentMessages[len(recentMessages)-1].Before(threshold) {
+			delete(f.recentMessages, issuerPublicKey)
+			continue
+		}
+
+		// the timestamps before the window of the latest Message are not needed anymore
+		latestWindowStart := recentMessages[len(recentMessages)-1].Add(-f.tangle.Options.IssuerRateLimitParams.Window)
+		for len(recentMessages) > 0 && recentMessages[0].Before(latestWindowStart) {
+			recentMessages = recentMessages[1:]
+		}
+		f.recentMessages[issuerPublicKey] = recentMessages
+	}
+}
+
+func (f *IssuerRateLimitFilter) getAcceptCallback() (result func(msg *Message, peer *peer.Peer)) {
+	f.onAcceptCallbackMutex.RLock()
+	result = f.onAcceptCallback
+	f.onAcceptCallbackMutex.RUnlock()
+	return
+}
+
+func (f *IssuerRateLimitFilter) getRejectCallback() (result func(msg *Message, err error, peer *peer.Peer)) {
+	f.onRejectCallbackMutex.RLock()
+	result = f.onRejectCallback
+	f.onRejectCallbackMutex.RUnlock()
+	return
+}
+
+// countTimestamps returns the amount of the (sorted) timestamps that are after the given start and not after the given
+// end.
+func countTimestamps(timestamps []time.Time, start time.Time, end time.Time) (count int) {
+	for _, timestamp := range timestamps {
+		if timestamp.After(start) && !timestamp.After(end) {
+			count++
+		}
+	}
+
+	return
+}
+
+// insertTimestamp inserts the given timestamp into the (sorted) timestamps.
+func insertTimestamp(timestamps []time.Time, timestamp time.Time) []time.Time {
+	index := len(timestamps)
+	for index > 0 && timestamps[index-1].After(timestamp) {
+		index--
+	}
+
+	timestamps = append(timestamps, time.Time{})
+	copy(timestamps[index+1:], timestamps[index:])
+	timestamps[index] = timestamp
+
+	return timestamps
+}
+
+// code contract (make sure the type implements all required methods)
+var _ MessageFilter = &IssuerRateLimitFilter{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region IssuerRateLimitParams ////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// DefaultIssuerRateLimitWindow defines the default length of the sliding window of the IssuerRateLimitFilter.
+	DefaultIssuerRateLimitWindow = 10 * time.Second
+)
+
+// IssuerRateLimitParams defines the parameters of the IssuerRateLimitFilter.
+type IssuerRateLimitParams struct {
+	// Window is the length of the sliding time window.
+	Window time.Duration
+	// MaxMessages is the maximum amount of Messages per issuer within the window (0 disables the rate limit). It also
+	// limits the amount of delayed Messages per issuer.
+	MaxMessages int
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Errors ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	// ErrIssuerBlocked is returned when a Message of a blocked issuer is rejected.
+	ErrIssuerBlocked = fmt.Errorf("issuer is blocked")
+
+	// ErrIssuerRateLimitExceeded is returned when a Message of an issuer that exceeded the rate limit is rejected because
+	// the issuer already has too many delayed Messages.
+	ErrIssuerRateLimitExceeded = fmt.Errorf("issuer exceeded the rate limit")
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
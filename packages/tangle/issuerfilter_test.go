@@ -0,0 +1,121 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+)
+
+func TestIssuerBlocklist(t *testing.T) {
+	store := mapdb.NewMapDB()
+	tangle := New(Store(store))
+
+	var filterErr error
+	blocklist := tangle.IssuerBlocklist
+	blocklist.OnAccept(func(msg *Message, peer *peer.Peer) {
+		filterErr = nil
+	})
+	blocklist.OnReject(func(msg *Message, err error, peer *peer.Peer) {
+		filterErr = err
+	})
+
+	issuer := identity.GenerateLocalIdentity()
+	message := newTestIssuerDataMessage(issuer.PublicKey(), []MessageID{EmptyMessageID})
+	blocklist.Filter(message, nil)
+	require.NoError(t, filterErr)
+
+	assert.True(t, blocklist.Block(issuer.PublicKey()))
+	assert.False(t, blocklist.Block(issuer.PublicKey()))
+	blocklist.Filter(message, nil)
+	assert.True(t, xerrors.Is(filterErr, ErrIssuerBlocked))
+	assert.Contains(t, blocklist.BlockedIssuers(), issuer.PublicKey())
+	tangle.Shutdown()
+
+	// the blocklist is restored from the storage
+	tangle = New(Store(store))
+	defer tangle.Shutdown()
+	blocklist = tangle.IssuerBlocklist
+	assert.True(t, blocklist.IsBlocked(issuer.PublicKey()))
+
+	assert.True(t, blocklist.Unblock(issuer.PublicKey()))
+	assert.False(t, blocklist.Unblock(issuer.PublicKey()))
+	assert.False(t, blocklist.IsBlocked(issuer.PublicKey()))
+	assert.Empty(t, blocklist.BlockedIssuers())
+}
+
+func TestIssuerRateLimitFilter(t *testing.T) {
+	tangle := New(IssuerRateLimit(IssuerRateLimitParams{
+		Window:      time.Second,
+		MaxMessages: 2,
+	}))
+	defer tangle.Shutdown()
+
+	filter := NewIssuerRateLimitFilter(tangle)
+	issuer := identity.GenerateLocalIdentity().PublicKey()
+	otherIssuer := identity.GenerateLocalIdentity().PublicKey()
+
+	// the messages are counted by their issuing time instead of the time they are received
+	now := time.Now()
+	admit := func(issuerPublicKey ed25519.PublicKey, issuingTime time.Time) time.Duration {
+		delay, err := filter.admit(issuerPublicKey, issuingTime, now)
+		require.NoError(t, err)
+		return delay
+	}
+	assert.Zero(t, admit(issuer, now.Add(200*time.Millisecond)))
+	assert.Zero(t, admit(issuer, now))
+	assert.Equal(t, 500*time.Millisecond, admit(issuer, now.Add(300*time.Millisecond)))
+	assert.Zero(t, admit(otherIssuer, now.Add(200*time.Millisecond)))
+
+	// the first message left the window that ends at the issuing time
+	assert.Zero(t, admit(issuer, now.Add(1050*time.Millisecond)))
+	assert.Equal(t, time.Second, admit(issuer, now.Add(1100*time.Millisecond)))
+
+	// issuers with a full window of delayed messages are rejected
+	_, err := filter.admit(issuer, now.Add(1100*time.Millisecond), now)
+	assert.True(t, xerrors.Is(err, ErrIssuerRateLimitExceeded))
+	filter.release(issuer)
+	assert.Equal(t, time.Second, admit(issuer, now.Add(1100*time.Millisecond)))
+
+	// idle issuers get cleaned up
+	now = now.Add(5 * time.Second)
+	assert.Zero(t, admit(issuer, now))
+	assert.NotContains(t, filter.recentMessages, otherIssuer)
+
+	accepted := make(chan *Message, 10)
+	filter.OnAccept(func(msg *Message, peer *peer.Peer) {
+		accepted <- msg
+	})
+	filter.OnReject(func(msg *Message, err error, peer *peer.Peer) {
+		t.Errorf("message %s was rejected: %s", msg.ID(), err)
+	})
+
+	// messages exceeding the limit are delayed instead of being dropped
+	messages := make([]*Message, 3)
+	for i := range messages {
+		messages[i] = newTestIssuerDataMessage(otherIssuer, []MessageID{EmptyMessageID})
+		filter.Filter(messages[i], nil)
+	}
+	assert.Equal(t, messages[0], <-accepted)
+	assert.Equal(t, messages[1], <-accepted)
+	assert.Len(t, accepted, 0)
+	select {
+	case msg := <-accepted:
+		assert.Equal(t, messages[2], msg)
+	case <-time.After(2 * time.Second):
+		t.Fatal("delayed message was not accepted")
+	}
+
+	// requested messages are exempt from the rate limit
+	requestedMessage := newTestIssuerDataMessage(otherIssuer, []MessageID{EmptyMessageID})
+	tangle.Requester.StartRequest(requestedMessage.ID())
+	defer tangle.Requester.StopRequest(requestedMessage.ID())
+	filter.Filter(requestedMessage, nil)
+	assert.Equal(t, requestedMessage, <-accepted)
+}
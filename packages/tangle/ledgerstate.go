@@ -1,7 +1,10 @@
 package tangle
 
 import (
+	"time"
+
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/types"
 	"golang.org/x/xerrors"
 )
@@ -11,6 +14,9 @@ import (
 // LedgerState is a Tangle component that wraps the components of the ledgerstate package and makes them available at a
 // "single point of contact".
 type LedgerState struct {
+	// Events is a dictionary for the LedgerState related Events.
+	Events *LedgerStateEvents
+
 	tangle    *Tangle
 	branchDAG *ledgerstate.BranchDAG
 	utxoDAG   *ledgerstate.UTXODAG
@@ -19,11 +25,34 @@ type LedgerState struct {
 // NewLedgerState is the constructor of the LedgerState component.
 func NewLedgerState(tangle *Tangle) (ledgerState *LedgerState) {
 	branchDAG := ledgerstate.NewBranchDAG(tangle.Options.Store)
-	return &LedgerState{
+	ledgerState = &LedgerState{
+		Events: &LedgerStateEvents{
+			TransactionBooked:    events.NewEvent(transactionIDEventHandler),
+			TransactionConfirmed: events.NewEvent(transactionIDEventHandler),
+		},
 		tangle:    tangle,
 		branchDAG: branchDAG,
-		utxoDAG:   ledgerstate.NewUTXODAG(tangle.Options.Store, branchDAG),
+		utxoDAG:   ledgerstate.NewUTXODAG(tangle.Options.Store, branchDAG, ledgerstate.DustProtection(tangle.Options.DustProtectionParams)),
 	}
+
+	// Transactions that were finalized before their Branch got confirmed become confirmed together with the Branch
+	branchDAG.Events.BranchConfirmed.Attach(events.NewClosure(func(branchDAGEvent *ledgerstate.BranchDAGEvent) {
+		defer branchDAGEvent.Release()
+
+		for transactionID := range ledgerState.utxoDAG.TransactionsConfirmedByBranch(branchDAGEvent.Branch.ID()) {
+			ledgerState.Events.TransactionConfirmed.Trigger(transactionID)
+		}
+	}))
+
+	// keep the commitment to the confirmed unspent Outputs up to date (this includes the Transactions that get confirmed
+	// by the confirmation of their Branch after they were finalized)
+	ledgerState.Events.TransactionConfirmed.Attach(events.NewClosure(func(transactionID ledgerstate.TransactionID) {
+		if err := ledgerState.utxoDAG.CommitConfirmedTransaction(transactionID); err != nil {
+			tangle.Events.Error.Trigger(xerrors.Errorf("failed to commit confirmed Transaction with %s: %w", transactionID, err))
+		}
+	}))
+
+	return
 }
 
 // Shutdown shuts down the LedgerState and persists its state.
@@ -91,6 +120,10 @@ func (l *LedgerState) Transaction(transactionID ledgerstate.TransactionID) *ledg
 // BookTransaction books the given Transaction into the underlying LedgerState and returns the target Branch and an
 // eventual error.
 func (l *LedgerState) BookTransaction(transaction *ledgerstate.Transaction, messageID MessageID) (targetBranch ledgerstate.BranchID, err error) {
+	// the Booker processes Messages sequentially, so the Transaction can not be booked concurrently between this check
+	// and the actual booking
+	newTransaction := !l.utxoDAG.TransactionMetadata(transaction.ID()).Consume(func(*ledgerstate.TransactionMetadata) {})
+
 	targetBranch, err = l.utxoDAG.BookTransaction(transaction)
 	if err != nil {
 		if !xerrors.Is(err, ledgerstate.ErrTransactionInvalid) && !xerrors.Is(err, ledgerstate.ErrTransactionNotSolid) {
@@ -105,6 +138,10 @@ func (l *LedgerState) BookTransaction(transaction *ledgerstate.Transaction, mess
 		return
 	}
 
+	if newTransaction {
+		l.Events.TransactionBooked.Trigger(transaction.ID())
+	}
+
 	return
 }
 
@@ -157,10 +194,90 @@ func (l *LedgerState) Branch(branchID ledgerstate.BranchID) *ledgerstate.CachedB
 // LoadSnapshot creates a set of outputs in the UTXO-DAG, that are forming the genesis for future transactions.
 func (l *LedgerState) LoadSnapshot(snapshot map[ledgerstate.TransactionID]map[ledgerstate.Address]*ledgerstate.ColoredBalances) {
 	l.utxoDAG.LoadSnapshot(snapshot)
-	attachment, _ := l.tangle.Storage.StoreAttachment(ledgerstate.GenesisTransactionID, EmptyMessageID)
-	if attachment != nil {
+
+	// the Transactions of the snapshot are considered to be attached to the genesis
+	for transactionID := range snapshot {
+		attachment, _ := l.tangle.Storage.StoreAttachment(transactionID, EmptyMessageID)
+		if attachment != nil {
+			attachment.Release()
+		}
+	}
+}
+
+// LoadSnapshotOutput stores a confirmed Output of a snapshot together with its OutputMetadata in the UTXO-DAG.
+func (l *LedgerState) LoadSnapshotOutput(output ledgerstate.Output, outputMetadata *ledgerstate.OutputMetadata) (err error) {
+	if err = l.utxoDAG.LoadSnapshotOutput(output, outputMetadata); err != nil {
+		return
+	}
+
+	// the Transactions of the snapshot are considered to be attached to the genesis
+	if attachment, stored := l.tangle.Storage.StoreAttachment(output.ID().TransactionID(), EmptyMessageID); stored {
 		attachment.Release()
 	}
+
+	return
+}
+
+// SnapshotUTXO returns the confirmed UTXO set of the ledger, which consists of all the Outputs that were created by
+// confirmed Transactions and that were not spent by a confirmed Transaction, yet.
+func (l *LedgerState) SnapshotUTXO() (snapshot ledgerstate.Snapshot) {
+	balancesByTransaction := make(map[ledgerstate.TransactionID]map[ledgerstate.Address]map[ledgerstate.Color]uint64)
+	l.ForEachConfirmedUnspentOutput(func(output ledgerstate.Output) bool {
+		transactionID := output.ID().TransactionID()
+		if _, exists := balancesByTransaction[transactionID]; !exists {
+			balancesByTransaction[transactionID] = make(map[ledgerstate.Address]map[ledgerstate.Color]uint64)
+		}
+		if _, exists := balancesByTransaction[transactionID][output.Address()]; !exists {
+			balancesByTransaction[transactionID][output.Address()] = make(map[ledgerstate.Color]uint64)
+		}
+		output.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+			balancesByTransaction[transactionID][output.Address()][color] += balance
+			return true
+		})
+
+		return true
+	})
+
+	snapshot = make(ledgerstate.Snapshot, len(balancesByTransaction))
+	for transactionID, balancesByAddress := range balancesByTransaction {
+		snapshot[transactionID] = make(map[ledgerstate.Address]*ledgerstate.ColoredBalances, len(balancesByAddress))
+		for address, balances := range balancesByAddress {
+			snapshot[transactionID][address] = ledgerstate.NewColoredBalances(balances)
+		}
+	}
+
+	return
+}
+
+// ForEachConfirmedUnspentOutput iterates through the Outputs that were created by confirmed Transactions and that were
+// not spent by a confirmed Transaction, yet, and calls the consumer for each of them until it returns false. Outputs of
+// pending or rejected Branches are skipped.
+func (l *LedgerState) ForEachConfirmedUnspentOutput(consumer func(output ledgerstate.Output) bool) {
+	l.utxoDAG.ForEachOutput(func(output ledgerstate.Output) bool {
+		if !l.transactionConfirmed(output.ID().TransactionID()) || l.outputSpentByConfirmedTransaction(output.ID()) {
+			return true
+		}
+
+		return consumer(output)
+	})
+}
+
+// transactionConfirmed is an internal utility function that returns true if the Transaction with the given ID is
+// confirmed.
+func (l *LedgerState) transactionConfirmed(transactionID ledgerstate.TransactionID) bool {
+	inclusionState, err := l.TransactionInclusionState(transactionID)
+
+	return err == nil && inclusionState == ledgerstate.Confirmed
+}
+
+// outputSpentByConfirmedTransaction is an internal utility function that returns true if the Output with the given ID
+// is spent by a confirmed Transaction.
+func (l *LedgerState) outputSpentByConfirmedTransaction(outputID ledgerstate.OutputID) (spent bool) {
+	l.utxoDAG.Consumers(outputID).Consume(func(consumer *ledgerstate.Consumer) {
+		spent = spent || l.transactionConfirmed(consumer.TransactionID())
+	})
+
+	return
 }
 
 // Output returns the Output with the given ID.
@@ -181,9 +298,86 @@ func (l *LedgerState) OutputsOnAddress(address ledgerstate.Address) (cachedOutpu
 	return
 }
 
+// AddressHistory returns the AddressHistoryEntries of the given Address in chronological order.
+func (l *LedgerState) AddressHistory(address ledgerstate.Address) (addressHistory []*ledgerstate.AddressHistoryEntry) {
+	addressHistory = make([]*ledgerstate.AddressHistoryEntry, 0)
+	l.utxoDAG.AddressHistory(address).Consume(func(addressHistoryEntry *ledgerstate.AddressHistoryEntry) {
+		addressHistory = append(addressHistory, addressHistoryEntry)
+	})
+	ledgerstate.SortAddressHistoryEntries(addressHistory)
+
+	return
+}
+
+// OutputsOnAddressAt returns the Outputs that were held by the given Address in the confirmed ledger state at the given
+// time.
+func (l *LedgerState) OutputsOnAddressAt(address ledgerstate.Address, timestamp time.Time) (outputs ledgerstate.Outputs) {
+	return l.utxoDAG.OutputsOnAddressAt(address, timestamp)
+}
+
+// CheckpointTime returns the time of the confirmed ledger state that is defined by the given confirmed Transaction.
+func (l *LedgerState) CheckpointTime(transactionID ledgerstate.TransactionID) (time.Time, error) {
+	return l.utxoDAG.CheckpointTime(transactionID)
+}
+
+// AliasOutput returns the current (unspent) AliasOutput of the alias with the given AliasAddress.
+func (l *LedgerState) AliasOutput(aliasAddress *ledgerstate.AliasAddress) (cachedOutput *ledgerstate.CachedOutput, err error) {
+	return l.utxoDAG.AliasOutput(aliasAddress)
+}
+
+// AssetOutput returns the current AssetOutput that defines the metadata of the asset with the given Color.
+func (l *LedgerState) AssetOutput(color ledgerstate.Color) (cachedOutput *ledgerstate.CachedOutput, err error) {
+	return l.utxoDAG.AssetOutput(color)
+}
+
+// StateRoot returns the MerkleRoot that commits to the confirmed unspent Outputs of the ledger.
+func (l *LedgerState) StateRoot() ledgerstate.MerkleRoot {
+	return l.utxoDAG.StateRoot()
+}
+
+// OutputProof returns a proof of the inclusion (or the non-inclusion) of the Output with the given OutputID in the
+// confirmed unspent Outputs of the ledger.
+func (l *LedgerState) OutputProof(outputID ledgerstate.OutputID) *ledgerstate.OutputProof {
+	return l.utxoDAG.OutputProof(outputID)
+}
+
+// AuditSupply sums up the balances of the confirmed Outputs of the ledger and compares them to the genesis supply and
+// the minted Colors.
+func (l *LedgerState) AuditSupply() *ledgerstate.SupplyAudit {
+	return l.utxoDAG.AuditSupply()
+}
+
 // CheckTransaction contains fast checks that have to be performed before booking a Transaction.
 func (l *LedgerState) CheckTransaction(transaction *ledgerstate.Transaction) (valid bool, err error) {
 	return l.utxoDAG.CheckTransaction(transaction)
 }
 
+// CheckDustAllowance checks if the dust Outputs created by the Transaction exceed the limit of dust Outputs on their
+// Addresses. It is a local policy for the Transactions issued by the node and not part of the validity of a Transaction.
+func (l *LedgerState) CheckDustAllowance(transaction *ledgerstate.Transaction) (err error) {
+	return l.utxoDAG.CheckDustAllowance(transaction)
+}
+
+// CheckTransactionPastCone checks if any of the Inputs of the Transaction is spent in the past cone of another Input.
+func (l *LedgerState) CheckTransactionPastCone(transaction *ledgerstate.Transaction) (err error) {
+	return l.utxoDAG.CheckTransactionPastCone(transaction)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region LedgerStateEvents ////////////////////////////////////////////////////////////////////////////////////////////
+
+// LedgerStateEvents represents events happening in the LedgerState.
+type LedgerStateEvents struct {
+	// TransactionBooked is triggered when a Transaction is booked into the ledger for the first time.
+	TransactionBooked *events.Event
+
+	// TransactionConfirmed is triggered when a Transaction was finalized and its Branch is confirmed.
+	TransactionConfirmed *events.Event
+}
+
+func transactionIDEventHandler(handler interface{}, params ...interface{}) {
+	handler.(func(ledgerstate.TransactionID))(params[0].(ledgerstate.TransactionID))
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
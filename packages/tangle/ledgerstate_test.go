@@ -1,9 +1,15 @@
 package tangle
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/types"
 	"github.com/magiconair/properties/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -29,3 +35,87 @@ func TestLoadSnapshot(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, ledgerstate.Confirmed, inclusionState)
 }
+
+func TestLedgerState_TransactionConfirmedByBranch(t *testing.T) {
+	tangle := New(WithoutOpinionFormer(true))
+	defer tangle.Shutdown()
+
+	wallets := createWallets(3)
+	genesisOutput := ledgerstate.NewSigLockedSingleOutput(100, wallets[0].address)
+	genesisOutput.SetID(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 1))
+	require.NoError(t, tangle.LedgerState.LoadSnapshotOutput(genesisOutput, snapshotOutputMetadata(genesisOutput)))
+
+	var confirmedTransactionsMutex sync.Mutex
+	confirmedTransactions := make(ledgerstate.TransactionIDs)
+	tangle.LedgerState.Events.TransactionConfirmed.Attach(events.NewClosure(func(transactionID ledgerstate.TransactionID) {
+		confirmedTransactionsMutex.Lock()
+		defer confirmedTransactionsMutex.Unlock()
+
+		_, confirmedTwice := confirmedTransactions[transactionID]
+		require.False(t, confirmedTwice)
+		confirmedTransactions[transactionID] = types.Void
+	}))
+
+	// all Transactions are finalized before the conflict is resolved
+	tx1, tx2, tx3 := bookFinalizedDoubleSpend(t, tangle, wallets, genesisOutput)
+	require.Empty(t, confirmedTransactions)
+
+	confirmBranch(t, tangle, ledgerstate.NewBranchID(tx1.ID()))
+	require.Equal(t, ledgerstate.TransactionIDs{tx1.ID(): types.Void, tx3.ID(): types.Void}, confirmedTransactions)
+	require.NotContains(t, confirmedTransactions, tx2.ID())
+}
+
+func TestLedgerState_StateRootAfterConflictResolution(t *testing.T) {
+	tangle := New(WithoutOpinionFormer(true))
+	defer tangle.Shutdown()
+
+	wallets := createWallets(3)
+	genesisOutput := ledgerstate.NewSigLockedSingleOutput(100, wallets[0].address)
+	genesisOutput.SetID(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 1))
+	require.NoError(t, tangle.LedgerState.LoadSnapshotOutput(genesisOutput, snapshotOutputMetadata(genesisOutput)))
+	genesisRoot := tangle.LedgerState.StateRoot()
+
+	// the finalized Transactions of the pending conflict are not committed
+	tx1, tx2, tx3 := bookFinalizedDoubleSpend(t, tangle, wallets, genesisOutput)
+	require.Equal(t, genesisRoot, tangle.LedgerState.StateRoot())
+
+	// resolving the conflict commits the Transactions that got confirmed together with the Branch
+	confirmBranch(t, tangle, ledgerstate.NewBranchID(tx1.ID()))
+
+	expectedStateTree := ledgerstate.NewStateTree(mapdb.NewMapDB())
+	tangle.LedgerState.Output(ledgerstate.NewOutputID(tx3.ID(), 0)).Consume(func(output ledgerstate.Output) {
+		expectedStateTree.Add(output)
+	})
+	root := tangle.LedgerState.StateRoot()
+	require.Equal(t, expectedStateTree.Root(), root)
+
+	for _, spentOrRejectedOutputID := range []ledgerstate.OutputID{genesisOutput.ID(), ledgerstate.NewOutputID(tx1.ID(), 0), ledgerstate.NewOutputID(tx2.ID(), 0)} {
+		proof := tangle.LedgerState.OutputProof(spentOrRejectedOutputID)
+		require.False(t, proof.Included())
+		require.True(t, proof.Verify(root))
+	}
+}
+
+// bookFinalizedDoubleSpend books two conflicting spends of the given Output (tx1 and tx2) and a Transaction that spends
+// the Output of tx1 (tx3) and finalizes all of them without resolving the conflict.
+func bookFinalizedDoubleSpend(t *testing.T, tangle *Tangle, wallets []wallet, output ledgerstate.Output) (tx1, tx2, tx3 *ledgerstate.Transaction) {
+	spend := func(sender, receiver wallet, output ledgerstate.Output) *ledgerstate.Transaction {
+		txEssence := ledgerstate.NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, ledgerstate.NewInputs(ledgerstate.NewUTXOInput(output.ID())), ledgerstate.NewOutputs(ledgerstate.NewSigLockedSingleOutput(100, receiver.address)))
+		tx := ledgerstate.NewTransaction(txEssence, sender.unlockBlocks(txEssence))
+		_, err := tangle.LedgerState.BookTransaction(tx, EmptyMessageID)
+		require.NoError(t, err)
+
+		return tx
+	}
+
+	tx1 = spend(wallets[0], wallets[1], output)
+	tx2 = spend(wallets[0], wallets[2], output)
+	tx3 = spend(wallets[1], wallets[2], tx1.Essence().Outputs()[0])
+	for _, tx := range []*ledgerstate.Transaction{tx1, tx2, tx3} {
+		tangle.LedgerState.TransactionMetadata(tx.ID()).Consume(func(transactionMetadata *ledgerstate.TransactionMetadata) {
+			transactionMetadata.SetFinalized(true)
+		})
+	}
+
+	return
+}
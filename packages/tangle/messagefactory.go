@@ -71,6 +71,12 @@ func (f *MessageFactory) IssuePayload(p payload.Payload, t ...*Tangle) (*Message
 		return nil, err
 	}
 
+	if err := f.tangle.RateSetter.Issue(); err != nil {
+		err = xerrors.Errorf("issuance was throttled: %w", err)
+		f.Events.Error.Trigger(err)
+		return nil, err
+	}
+
 	f.issuanceMutex.Lock()
 	defer f.issuanceMutex.Unlock()
 	sequenceNumber, err := f.sequence.Next()
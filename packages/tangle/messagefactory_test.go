@@ -24,7 +24,8 @@ const (
 )
 
 func TestMessageFactory_BuildMessage(t *testing.T) {
-	tangle := New()
+	// the messages are issued as fast as possible
+	tangle := New(RateSetterConfig(RateSetterParams{Enabled: false}))
 	defer tangle.Shutdown()
 
 	tangle.MessageFactory = NewMessageFactory(
@@ -135,9 +135,16 @@ func (o *OpinionFormer) MessageEligible(messageID MessageID) (eligible bool) {
 func (o *OpinionFormer) onPayloadOpinionFormed(ev *OpinionFormedEvent) {
 	// set BranchLiked and BranchFinalized if this payload was a conflict
 	o.tangle.Utils.ComputeIfTransaction(ev.MessageID, func(transactionID ledgerstate.TransactionID) {
+		finalized := false
 		o.tangle.LedgerState.TransactionMetadata(transactionID).Consume(func(transactionMetadata *ledgerstate.TransactionMetadata) {
-			transactionMetadata.SetFinalized(true)
+			finalized = transactionMetadata.SetFinalized(true)
 		})
+
+		// Transactions in a Branch that is not confirmed, yet, are confirmed by the LedgerState once the Branch is
+		if finalized && o.tangle.LedgerState.transactionConfirmed(transactionID) {
+			o.tangle.LedgerState.Events.TransactionConfirmed.Trigger(transactionID)
+		}
+
 		if o.tangle.LedgerState.TransactionConflicting(transactionID) {
 			o.tangle.LedgerState.branchDAG.SetBranchLiked(o.tangle.LedgerState.BranchID(transactionID), ev.Opinion)
 			// TODO: move this to approval weight logic
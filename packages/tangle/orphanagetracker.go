@@ -0,0 +1,190 @@
+package tangle
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/timedexecutor"
+	"github.com/iotaledger/hive.go/timedqueue"
+)
+
+// region OrphanageTracker /////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// DefaultOrphanageThreshold defines the default time after which a Message without approvers is considered to be
+	// orphaned.
+	DefaultOrphanageThreshold = 30 * time.Second
+
+	// maxRecentOrphanedMessages defines the amount of recently orphaned Messages that are kept by the OrphanageTracker.
+	maxRecentOrphanedMessages = 100
+)
+
+// OrphanageTracker is a Tangle component that keeps track of the Messages that did not get any approver within the
+// configured OrphanageThreshold.
+type OrphanageTracker struct {
+	Events *OrphanageTrackerEvents
+
+	tangle         *Tangle
+	checkExecutor  *timedexecutor.TimedExecutor
+	stats          *OrphanageStats
+	recentOrphans  []*OrphanedMessage
+	orphanageMutex sync.RWMutex
+}
+
+// NewOrphanageTracker is the constructor of the OrphanageTracker.
+func NewOrphanageTracker(tangle *Tangle) (orphanageTracker *OrphanageTracker) {
+	orphanageTracker = &OrphanageTracker{
+		Events: &OrphanageTrackerEvents{
+			MessageOrphaned: events.NewEvent(orphanedMessageEventHandler),
+		},
+		tangle:        tangle,
+		checkExecutor: timedexecutor.New(1),
+		stats:         newOrphanageStats(),
+		recentOrphans: make([]*OrphanedMessage, 0, maxRecentOrphanedMessages),
+	}
+
+	return
+}
+
+// Setup sets up the behavior of the component by making it attach to the relevant events of other components.
+func (o *OrphanageTracker) Setup() {
+	o.tangle.Storage.Events.MessageStored.Attach(events.NewClosure(o.Track))
+}
+
+// Track schedules the check whether the given Message got approved within the OrphanageThreshold.
+func (o *OrphanageTracker) Track(messageID MessageID) {
+	o.checkExecutor.ExecuteAfter(func() {
+		o.CheckOrphanage(messageID)
+	}, o.tangle.Options.OrphanageThreshold)
+}
+
+// CheckOrphanage checks if the given Message has any approvers and records it as orphaned if it does not. It returns
+// true if the Message was recorded as orphaned.
+func (o *OrphanageTracker) CheckOrphanage(messageID MessageID) (orphaned bool) {
+	if o.tangle.Storage.Approvers(messageID).Consume(func(*Approver) {}) {
+		return
+	}
+
+	o.tangle.Storage.Message(messageID).Consume(func(message *Message) {
+		orphanedMessage := &OrphanedMessage{
+			MessageID:   messageID,
+			IssuerID:    identity.NewID(message.IssuerPublicKey()),
+			PayloadType: message.Payload().Type(),
+			IssuingTime: message.IssuingTime(),
+		}
+		o.record(orphanedMessage)
+		orphaned = true
+
+		o.Events.MessageOrphaned.Trigger(orphanedMessage)
+	})
+
+	return
+}
+
+// Stats returns a copy of the statistics about the orphaned Messages.
+func (o *OrphanageTracker) Stats() (stats *OrphanageStats) {
+	o.orphanageMutex.RLock()
+	defer o.orphanageMutex.RUnlock()
+
+	return o.stats.clone()
+}
+
+// RecentOrphanedMessages returns the most recently orphaned Messages (the newest one comes last).
+func (o *OrphanageTracker) RecentOrphanedMessages() (orphanedMessages []*OrphanedMessage) {
+	o.orphanageMutex.RLock()
+	defer o.orphanageMutex.RUnlock()
+
+	orphanedMessages = make([]*OrphanedMessage, len(o.recentOrphans))
+	copy(orphanedMessages, o.recentOrphans)
+
+	return
+}
+
+// Shutdown shuts down the OrphanageTracker and cancels all pending checks.
+func (o *OrphanageTracker) Shutdown() {
+	o.checkExecutor.Shutdown(timedqueue.CancelPendingElements)
+}
+
+// record adds the given OrphanedMessage to the statistics.
+func (o *OrphanageTracker) record(orphanedMessage *OrphanedMessage) {
+	o.orphanageMutex.Lock()
+	defer o.orphanageMutex.Unlock()
+
+	o.stats.TotalCount++
+	o.stats.CountPerIssuer[orphanedMessage.IssuerID]++
+	o.stats.CountPerPayloadType[orphanedMessage.PayloadType]++
+
+	if len(o.recentOrphans) == maxRecentOrphanedMessages {
+		o.recentOrphans = o.recentOrphans[1:]
+	}
+	o.recentOrphans = append(o.recentOrphans, orphanedMessage)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region OrphanageStats ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// OrphanageStats contains the statistics about the Messages that were orphaned since the start of the node.
+type OrphanageStats struct {
+	// TotalCount is the total amount of orphaned Messages.
+	TotalCount uint64
+
+	// CountPerIssuer is the amount of orphaned Messages per issuer.
+	CountPerIssuer map[identity.ID]uint64
+
+	// CountPerPayloadType is the amount of orphaned Messages per payload type.
+	CountPerPayloadType map[payload.Type]uint64
+}
+
+// newOrphanageStats returns new empty OrphanageStats.
+func newOrphanageStats() *OrphanageStats {
+	return &OrphanageStats{
+		CountPerIssuer:      make(map[identity.ID]uint64),
+		CountPerPayloadType: make(map[payload.Type]uint64),
+	}
+}
+
+// clone returns a deep copy of the OrphanageStats.
+func (o *OrphanageStats) clone() (clonedStats *OrphanageStats) {
+	clonedStats = newOrphanageStats()
+	clonedStats.TotalCount = o.TotalCount
+	for issuerID, count := range o.CountPerIssuer {
+		clonedStats.CountPerIssuer[issuerID] = count
+	}
+	for payloadType, count := range o.CountPerPayloadType {
+		clonedStats.CountPerPayloadType[payloadType] = count
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region OrphanedMessage //////////////////////////////////////////////////////////////////////////////////////////////
+
+// OrphanedMessage contains the information about a Message that did not get approved within the OrphanageThreshold.
+type OrphanedMessage struct {
+	MessageID   MessageID
+	IssuerID    identity.ID
+	PayloadType payload.Type
+	IssuingTime time.Time
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region OrphanageTrackerEvents ///////////////////////////////////////////////////////////////////////////////////////
+
+// OrphanageTrackerEvents represents events happening in the OrphanageTracker.
+type OrphanageTrackerEvents struct {
+	// MessageOrphaned is triggered when a Message did not get any approver within the OrphanageThreshold.
+	MessageOrphaned *events.Event
+}
+
+func orphanedMessageEventHandler(handler interface{}, params ...interface{}) {
+	handler.(func(*OrphanedMessage))(params[0].(*OrphanedMessage))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
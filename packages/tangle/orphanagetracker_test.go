@@ -0,0 +1,50 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrphanageTracker(t *testing.T) {
+	tangle := New(OrphanageThreshold(100 * time.Millisecond))
+	defer tangle.Shutdown()
+	tangle.OrphanageTracker.Setup()
+
+	orphanedMessages := make(chan MessageID, 2)
+	tangle.OrphanageTracker.Events.MessageOrphaned.Attach(events.NewClosure(func(orphanedMessage *OrphanedMessage) {
+		orphanedMessages <- orphanedMessage.MessageID
+	}))
+
+	issuer := identity.GenerateLocalIdentity()
+	approvedMessage := newTestIssuerDataMessage(issuer.PublicKey(), []MessageID{EmptyMessageID})
+	orphanedMessage := newTestIssuerDataMessage(issuer.PublicKey(), []MessageID{approvedMessage.ID()})
+	tangle.Storage.StoreMessage(approvedMessage)
+	tangle.Storage.StoreMessage(orphanedMessage)
+
+	select {
+	case messageID := <-orphanedMessages:
+		assert.Equal(t, orphanedMessage.ID(), messageID)
+	case <-time.After(5 * time.Second):
+		assert.FailNow(t, "message was not orphaned in time")
+	}
+
+	// wait for the check of the approved message as well
+	time.Sleep(200 * time.Millisecond)
+	assert.Empty(t, orphanedMessages)
+
+	stats := tangle.OrphanageTracker.Stats()
+	assert.Equal(t, uint64(1), stats.TotalCount)
+	assert.Equal(t, uint64(1), stats.CountPerIssuer[issuer.ID()])
+	assert.Equal(t, uint64(1), stats.CountPerPayloadType[payload.GenericDataPayloadType])
+
+	recentOrphanedMessages := tangle.OrphanageTracker.RecentOrphanedMessages()
+	if assert.Len(t, recentOrphanedMessages, 1) {
+		assert.Equal(t, orphanedMessage.ID(), recentOrphanedMessages[0].MessageID)
+		assert.Equal(t, issuer.ID(), recentOrphanedMessages[0].IssuerID)
+	}
+}
@@ -13,7 +13,7 @@ import (
 )
 
 func BenchmarkVerifyDataMessages(b *testing.B) {
-	tangle := New()
+	tangle := New(RateSetterConfig(RateSetterParams{Enabled: false}))
 
 	var pool async.WorkerPool
 	pool.Tune(runtime.GOMAXPROCS(0))
@@ -46,7 +46,7 @@ func BenchmarkVerifyDataMessages(b *testing.B) {
 }
 
 func BenchmarkVerifySignature(b *testing.B) {
-	tangle := New()
+	tangle := New(RateSetterConfig(RateSetterParams{Enabled: false}))
 
 	pool, _ := ants.NewPool(80, ants.WithNonblocking(false))
 
@@ -0,0 +1,231 @@
+package tangle
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// DefaultRateSetterInitialRate defines the default issuance rate (in messages per second) of the RateSetter.
+	DefaultRateSetterInitialRate = 10
+	// DefaultRateSetterMinRate defines the default lower bound of the issuance rate of the RateSetter.
+	DefaultRateSetterMinRate = 1
+	// DefaultRateSetterIncrease defines the default additive increase of the issuance rate per second of the RateSetter.
+	DefaultRateSetterIncrease = 1
+	// DefaultRateSetterDecrease defines the default multiplicative decrease factor of the RateSetter.
+	DefaultRateSetterDecrease = 0.5
+	// DefaultRateSetterOwnQueueThreshold defines the default size (in bytes) of the own queue in the Scheduler above
+	// which the RateSetter decreases the issuance rate.
+	DefaultRateSetterOwnQueueThreshold = 2 * MaxMessageSize
+	// DefaultRateSetterMaxOwnQueueSize defines the default size (in bytes) of the own queue in the Scheduler above which
+	// the RateSetter rejects the issuance of new messages.
+	DefaultRateSetterMaxOwnQueueSize = 10 * MaxMessageSize
+
+	// rateSetterDecreasePause defines the minimum time between two multiplicative decreases of the issuance rate.
+	rateSetterDecreasePause = time.Second
+
+	// rateSetterBufferThreshold defines the fill level of the Scheduler's buffer (relative to its maximum size) above
+	// which the RateSetter considers the node to be congested, even if its own queue is small.
+	rateSetterBufferThreshold = 0.5
+)
+
+// region RateSetter ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RateSetter is a Tangle component that limits the rate at which the node issues its own messages. It adjusts the
+// allowed rate with an additive increase / multiplicative decrease (AIMD) algorithm based on the backlog of the node's
+// own messages and the total backlog in the Scheduler.
+type RateSetter struct {
+	Events *RateSetterEvents
+
+	tangle   *Tangle
+	issuerID identity.ID
+
+	rate         float64
+	lastIssued   time.Time
+	lastDecrease time.Time
+	rateMutex    sync.RWMutex
+	issueMutex   sync.Mutex
+}
+
+// NewRateSetter returns a new RateSetter. It panics if the RateSetter is enabled with invalid parameters.
+func NewRateSetter(tangle *Tangle) *RateSetter {
+	if params := tangle.Options.RateSetterParams; params.Enabled {
+		if err := params.Validate(); err != nil {
+			panic(xerrors.Errorf("failed to create RateSetter: %w", err))
+		}
+	}
+
+	return &RateSetter{
+		Events: &RateSetterEvents{
+			RateUpdated: events.NewEvent(rateUpdatedEventHandler),
+		},
+
+		tangle:   tangle,
+		issuerID: identity.NewID(tangle.Options.Identity.PublicKey()),
+		rate:     tangle.Options.RateSetterParams.InitialRate,
+	}
+}
+
+// Setup sets up the behavior of the component by making it attach to the relevant events of the other components.
+func (r *RateSetter) Setup() {
+	r.tangle.Scheduler.Events.MessageScheduled.Attach(events.NewClosure(r.onMessageScheduled))
+}
+
+// Rate returns the current issuance rate (in messages per second).
+func (r *RateSetter) Rate() float64 {
+	r.rateMutex.RLock()
+	defer r.rateMutex.RUnlock()
+
+	return r.rate
+}
+
+// Issue blocks until the node is allowed to issue its next message according to the current rate. It returns an
+// ErrNodeIsOverloaded if the backlog of the node's own messages in the Scheduler exceeds its limit or if the buffer of
+// the Scheduler can not take another message without dropping one.
+func (r *RateSetter) Issue() (err error) {
+	if !r.tangle.Options.RateSetterParams.Enabled {
+		return
+	}
+
+	if ownQueueSize := r.tangle.Scheduler.IssuerQueueSize(r.issuerID); ownQueueSize > r.tangle.Options.RateSetterParams.MaxOwnQueueSize {
+		return xerrors.Errorf("%d bytes of own messages are waiting to be scheduled: %w", ownQueueSize, ErrNodeIsOverloaded)
+	}
+	if bufferSize := r.tangle.Scheduler.BufferSize(); bufferSize+MaxMessageSize > r.tangle.Options.SchedulerParams.MaxBufferSize {
+		return xerrors.Errorf("%d bytes of messages are waiting to be scheduled: %w", bufferSize, ErrNodeIsOverloaded)
+	}
+
+	// reserve the next slot while holding the lock and wait for it without blocking the other callers
+	r.issueMutex.Lock()
+	issueTime := r.lastIssued.Add(r.issueInterval())
+	if now := time.Now(); issueTime.Before(now) {
+		issueTime = now
+	}
+	r.lastIssued = issueTime
+	r.issueMutex.Unlock()
+
+	time.Sleep(time.Until(issueTime))
+
+	return
+}
+
+// onMessageScheduled adjusts the rate whenever one of the node's own messages gets scheduled.
+func (r *RateSetter) onMessageScheduled(messageID MessageID) {
+	if !r.tangle.Options.RateSetterParams.Enabled {
+		return
+	}
+
+	ownMessage := false
+	r.tangle.Storage.Message(messageID).Consume(func(message *Message) {
+		ownMessage = identity.NewID(message.IssuerPublicKey()) == r.issuerID
+	})
+	if !ownMessage {
+		return
+	}
+
+	params := r.tangle.Options.RateSetterParams
+	congested := r.tangle.Scheduler.IssuerQueueSize(r.issuerID) > params.OwnQueueThreshold ||
+		float64(r.tangle.Scheduler.BufferSize()) > rateSetterBufferThreshold*float64(r.tangle.Options.SchedulerParams.MaxBufferSize)
+
+	r.rateMutex.Lock()
+	switch {
+	case congested && time.Since(r.lastDecrease) >= rateSetterDecreasePause:
+		r.rate *= params.Decrease
+		r.lastDecrease = time.Now()
+	case !congested:
+		// increase the rate by params.Increase per second of issuance
+		r.rate += params.Increase / r.rate
+	default:
+		r.rateMutex.Unlock()
+		return
+	}
+	if r.rate < params.MinRate {
+		r.rate = params.MinRate
+	}
+	rate := r.rate
+	r.rateMutex.Unlock()
+
+	r.Events.RateUpdated.Trigger(rate)
+}
+
+// issueInterval returns the minimum time between two messages issued by the node.
+func (r *RateSetter) issueInterval() time.Duration {
+	return time.Duration(float64(time.Second) / r.Rate())
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region RateSetterParams /////////////////////////////////////////////////////////////////////////////////////////////
+
+// RateSetterParams defines the parameters of the RateSetter.
+type RateSetterParams struct {
+	// Enabled defines whether the RateSetter limits the issuance of messages.
+	Enabled bool
+	// InitialRate is the issuance rate (in messages per second) that is used at startup.
+	InitialRate float64
+	// MinRate is the lower bound of the issuance rate.
+	MinRate float64
+	// Increase is the additive increase of the issuance rate per second.
+	Increase float64
+	// Decrease is the factor that is applied to the issuance rate when the node is congested.
+	Decrease float64
+	// OwnQueueThreshold is the size (in bytes) of the own queue in the Scheduler above which the rate is decreased.
+	OwnQueueThreshold int
+	// MaxOwnQueueSize is the size (in bytes) of the own queue in the Scheduler above which issuance is rejected.
+	MaxOwnQueueSize int
+}
+
+// Validate returns an error if the parameters would not result in a positive issuance rate or if the thresholds are
+// not positive.
+func (r RateSetterParams) Validate() (err error) {
+	switch {
+	case r.InitialRate <= 0:
+		return xerrors.Errorf("initial rate %f is not positive: %w", r.InitialRate, ErrInvalidRateSetterParams)
+	case r.MinRate <= 0:
+		return xerrors.Errorf("minimum rate %f is not positive: %w", r.MinRate, ErrInvalidRateSetterParams)
+	case r.InitialRate < r.MinRate:
+		return xerrors.Errorf("initial rate %f is lower than the minimum rate %f: %w", r.InitialRate, r.MinRate, ErrInvalidRateSetterParams)
+	case r.Increase < 0:
+		return xerrors.Errorf("increase %f is negative: %w", r.Increase, ErrInvalidRateSetterParams)
+	case r.Decrease <= 0 || r.Decrease >= 1:
+		return xerrors.Errorf("decrease %f is not between 0 and 1: %w", r.Decrease, ErrInvalidRateSetterParams)
+	case r.OwnQueueThreshold <= 0:
+		return xerrors.Errorf("own queue threshold %d is not positive: %w", r.OwnQueueThreshold, ErrInvalidRateSetterParams)
+	case r.MaxOwnQueueSize <= 0:
+		return xerrors.Errorf("maximum own queue size %d is not positive: %w", r.MaxOwnQueueSize, ErrInvalidRateSetterParams)
+	}
+
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region RateSetterEvents /////////////////////////////////////////////////////////////////////////////////////////////
+
+// RateSetterEvents represents events happening in the RateSetter.
+type RateSetterEvents struct {
+	// RateUpdated is triggered when the issuance rate of the RateSetter changes.
+	RateUpdated *events.Event
+}
+
+func rateUpdatedEventHandler(handler interface{}, params ...interface{}) {
+	handler.(func(float64))(params[0].(float64))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Errors ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	// ErrNodeIsOverloaded is returned when the node has too many of its own messages waiting to be scheduled.
+	ErrNodeIsOverloaded = errors.New("node is overloaded: issuance rejected")
+
+	// ErrInvalidRateSetterParams is returned when the RateSetter is enabled with invalid parameters.
+	ErrInvalidRateSetterParams = errors.New("invalid rate setter parameters")
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,168 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+)
+
+func TestRateSetter_Issue(t *testing.T) {
+	tangle := New(RateSetterConfig(RateSetterParams{
+		Enabled:           true,
+		InitialRate:       20,
+		MinRate:           1,
+		Increase:          1,
+		Decrease:          0.5,
+		OwnQueueThreshold: MaxMessageSize,
+		MaxOwnQueueSize:   2 * MaxMessageSize,
+	}))
+	defer tangle.Shutdown()
+
+	// the messages are held back according to the rate
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, tangle.RateSetter.Issue())
+	}
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(4*50*time.Millisecond))
+
+	// the issuance is rejected if too many own messages are waiting to be scheduled
+	ownID := identity.NewID(tangle.Options.Identity.PublicKey())
+	for i := 0; i < 3; i++ {
+		tangle.Scheduler.buffer.Add(&MessageToSchedule{issuerID: ownID, size: MaxMessageSize})
+	}
+	assert.True(t, xerrors.Is(tangle.RateSetter.Issue(), ErrNodeIsOverloaded))
+
+	_, err := tangle.MessageFactory.IssuePayload(payload.NewGenericDataPayload([]byte("test")))
+	assert.True(t, xerrors.Is(err, ErrNodeIsOverloaded))
+}
+
+func TestRateSetter_IssueFullBuffer(t *testing.T) {
+	tangle := New(SchedulerConfig(SchedulerParams{
+		Rate:          time.Hour,
+		MaxBufferSize: 4 * MaxMessageSize,
+	}), RateSetterConfig(RateSetterParams{
+		Enabled:           true,
+		InitialRate:       1000,
+		MinRate:           1,
+		Increase:          1,
+		Decrease:          0.5,
+		OwnQueueThreshold: MaxMessageSize,
+		MaxOwnQueueSize:   2 * MaxMessageSize,
+	}))
+	defer tangle.Shutdown()
+
+	// the issuance is rejected if the buffer of the scheduler can not take another message, even if the own queue is empty
+	for i := 0; i < 4; i++ {
+		require.NoError(t, tangle.RateSetter.Issue())
+		tangle.Scheduler.buffer.Add(&MessageToSchedule{issuerID: identity.GenerateIdentity().ID(), size: MaxMessageSize})
+	}
+	assert.True(t, xerrors.Is(tangle.RateSetter.Issue(), ErrNodeIsOverloaded))
+}
+
+func TestRateSetter_AIMD(t *testing.T) {
+	tangle := New(RateSetterConfig(RateSetterParams{
+		Enabled:           true,
+		InitialRate:       10,
+		MinRate:           4,
+		Increase:          1,
+		Decrease:          0.5,
+		OwnQueueThreshold: MaxMessageSize,
+		MaxOwnQueueSize:   10 * MaxMessageSize,
+	}))
+	defer tangle.Shutdown()
+	tangle.RateSetter.Setup()
+
+	ownMessage, err := tangle.MessageFactory.IssuePayload(payload.NewGenericDataPayload([]byte("own")))
+	require.NoError(t, err)
+	tangle.Storage.StoreMessage(ownMessage)
+	foreignMessage := newTestDataMessage("foreign")
+	tangle.Storage.StoreMessage(foreignMessage)
+
+	// messages of other issuers don't change the rate
+	tangle.Scheduler.Events.MessageScheduled.Trigger(foreignMessage.ID())
+	assert.Equal(t, 10., tangle.RateSetter.Rate())
+
+	// the rate is increased additively if the node is not congested
+	tangle.Scheduler.Events.MessageScheduled.Trigger(ownMessage.ID())
+	assert.Equal(t, 10.1, tangle.RateSetter.Rate())
+
+	// the rate is decreased multiplicatively (at most once per pause) if the node is congested
+	ownID := identity.NewID(tangle.Options.Identity.PublicKey())
+	for i := 0; i < 2; i++ {
+		tangle.Scheduler.buffer.Add(&MessageToSchedule{issuerID: ownID, size: MaxMessageSize})
+	}
+	tangle.Scheduler.Events.MessageScheduled.Trigger(ownMessage.ID())
+	assert.Equal(t, 5.05, tangle.RateSetter.Rate())
+	tangle.Scheduler.Events.MessageScheduled.Trigger(ownMessage.ID())
+	assert.Equal(t, 5.05, tangle.RateSetter.Rate())
+
+	// the rate never drops below the minimum
+	tangle.RateSetter.lastDecrease = time.Time{}
+	tangle.Scheduler.Events.MessageScheduled.Trigger(ownMessage.ID())
+	assert.Equal(t, 4., tangle.RateSetter.Rate())
+}
+
+func TestRateSetter_AIMDFullBuffer(t *testing.T) {
+	tangle := New(SchedulerConfig(SchedulerParams{
+		Rate:          time.Hour,
+		MaxBufferSize: 4 * MaxMessageSize,
+	}), RateSetterConfig(RateSetterParams{
+		Enabled:           true,
+		InitialRate:       10,
+		MinRate:           1,
+		Increase:          1,
+		Decrease:          0.5,
+		OwnQueueThreshold: MaxMessageSize,
+		MaxOwnQueueSize:   10 * MaxMessageSize,
+	}))
+	defer tangle.Shutdown()
+	tangle.RateSetter.Setup()
+
+	ownMessage, err := tangle.MessageFactory.IssuePayload(payload.NewGenericDataPayload([]byte("own")))
+	require.NoError(t, err)
+	tangle.Storage.StoreMessage(ownMessage)
+
+	// the rate is decreased if the buffer of the scheduler fills up with messages of other issuers
+	for i := 0; i < 3; i++ {
+		tangle.Scheduler.buffer.Add(&MessageToSchedule{issuerID: identity.GenerateIdentity().ID(), size: MaxMessageSize})
+	}
+	tangle.Scheduler.Events.MessageScheduled.Trigger(ownMessage.ID())
+	assert.Equal(t, 5., tangle.RateSetter.Rate())
+}
+
+func TestRateSetterParams_Validate(t *testing.T) {
+	params := RateSetterParams{
+		Enabled:           true,
+		InitialRate:       10,
+		MinRate:           1,
+		Increase:          1,
+		Decrease:          0.5,
+		OwnQueueThreshold: MaxMessageSize,
+		MaxOwnQueueSize:   2 * MaxMessageSize,
+	}
+	require.NoError(t, params.Validate())
+
+	invalidParams := []func(params *RateSetterParams){
+		func(params *RateSetterParams) { params.InitialRate = 0 },
+		func(params *RateSetterParams) { params.MinRate = -1 },
+		func(params *RateSetterParams) { params.InitialRate = 0.5 },
+		func(params *RateSetterParams) { params.Increase = -1 },
+		func(params *RateSetterParams) { params.Decrease = 1 },
+		func(params *RateSetterParams) { params.OwnQueueThreshold = 0 },
+		func(params *RateSetterParams) { params.MaxOwnQueueSize = 0 },
+	}
+	for i, invalidate := range invalidParams {
+		invalidParams := params
+		invalidate(&invalidParams)
+		assert.True(t, xerrors.Is(invalidParams.Validate(), ErrInvalidRateSetterParams), "params %d", i)
+		assert.Panics(t, func() { New(RateSetterConfig(invalidParams)) }, "params %d", i)
+	}
+
+	// the parameters of a disabled RateSetter are not used
+	assert.NotPanics(t, func() { New(RateSetterConfig(RateSetterParams{Enabled: false})).Shutdown() })
+}
@@ -112,6 +112,16 @@ func (r *Requester) StopRequest(id MessageID) {
 	}
 }
 
+// IsRequested returns true if the given message is currently being requested.
+func (r *Requester) IsRequested(id MessageID) (requested bool) {
+	r.scheduledRequestsMutex.RLock()
+	defer r.scheduledRequestsMutex.RUnlock()
+
+	_, requested = r.scheduledRequests[id]
+
+	return
+}
+
 func (r *Requester) reRequest(id MessageID, count int) {
 	r.Events.SendRequest.Trigger(&SendRequestEvent{ID: id})
 
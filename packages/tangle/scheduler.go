@@ -1,12 +1,15 @@
 package tangle
 
 import (
+	"container/list"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/iotaledger/goshimmer/packages/clock"
 	"github.com/iotaledger/hive.go/async"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
 )
 
 var (
@@ -14,6 +17,25 @@ var (
 	numWorkers = 1
 )
 
+const (
+	// DefaultSchedulerRate defines the default time interval between two scheduled messages.
+	DefaultSchedulerRate = 5 * time.Millisecond
+	// DefaultSchedulerMaxBufferSize defines the default maximum amount of bytes that can be buffered by the scheduler.
+	DefaultSchedulerMaxBufferSize = 100 * MaxMessageSize
+
+	// minIssuerWeight defines the weight that is used for issuers whose weight is not positive (to guarantee progress).
+	minIssuerWeight = 1e-3
+)
+
+// IssuerWeightFunc is the type of the function that returns the relative weight of an issuer in the scheduler. An issuer
+// with weight 1 can schedule up to MaxMessageSize bytes per round.
+type IssuerWeightFunc func(issuerID identity.ID) float64
+
+// ConstantIssuerWeight is an IssuerWeightFunc that assigns the same weight to all issuers.
+func ConstantIssuerWeight(identity.ID) float64 {
+	return 1
+}
+
 // SchedulerParentPriorityMap maps parentIDs with their children messages.
 type SchedulerParentPriorityMap map[MessageID][]*MessageToSchedule
 
@@ -22,6 +44,8 @@ type MessageToSchedule struct {
 	ID          MessageID
 	parents     []MessageID
 	issuingTime time.Time
+	issuerID    identity.ID
+	size        int
 	scheduled   bool
 }
 
@@ -35,6 +59,7 @@ type Scheduler struct {
 	timeQueue        *TimeMessageQueue
 	parentsMap       SchedulerParentPriorityMap
 	messagesBooked   chan MessageID
+	buffer           *SchedulerBuffer
 	outboxWorkerPool async.WorkerPool
 	close            chan interface{}
 }
@@ -44,12 +69,14 @@ func NewScheduler(tangle *Tangle) (scheduler *Scheduler) {
 	scheduler = &Scheduler{
 		Events: &SchedulerEvents{
 			MessageScheduled: events.NewEvent(messageIDEventHandler),
+			MessageDiscarded: events.NewEvent(messageIDEventHandler),
 		},
 		tangle:         tangle,
 		inbox:          make(chan *MessageToSchedule, capacity),
 		timeQueue:      NewTimeMessageQueue(capacity),
 		parentsMap:     make(SchedulerParentPriorityMap),
 		messagesBooked: make(chan MessageID, capacity),
+		buffer:         NewSchedulerBuffer(tangle.Options.SchedulerParams.MaxBufferSize, tangle.Options.SchedulerParams.IssuerWeightFunc),
 		close:          make(chan interface{}),
 	}
 	scheduler.outboxWorkerPool.Tune(numWorkers)
@@ -70,6 +97,8 @@ func (s *Scheduler) onMessageSolid(messageID MessageID) {
 		s.inbox <- &MessageToSchedule{
 			ID:          messageID,
 			issuingTime: message.IssuingTime(),
+			issuerID:    identity.NewID(message.IssuerPublicKey()),
+			size:        len(message.Bytes()),
 			parents:     message.Parents(),
 		}
 	})
@@ -79,9 +108,37 @@ func (s *Scheduler) onMessageBooked(messageID MessageID) {
 	s.messagesBooked <- messageID
 }
 
+// Rate returns the time interval between two scheduled messages.
+func (s *Scheduler) Rate() time.Duration {
+	return s.tangle.Options.SchedulerParams.Rate
+}
+
+// BufferSize returns the amount of bytes that are currently buffered by the scheduler.
+func (s *Scheduler) BufferSize() int {
+	return s.buffer.Size()
+}
+
+// BufferLength returns the amount of messages that are currently buffered by the scheduler.
+func (s *Scheduler) BufferLength() int {
+	return s.buffer.Len()
+}
+
+// IssuerQueueSize returns the amount of bytes that are currently buffered for the given issuer.
+func (s *Scheduler) IssuerQueueSize(issuerID identity.ID) int {
+	return s.buffer.IssuerQueueSize(issuerID)
+}
+
+// IssuerQueueSizes returns the amount of bytes that are currently buffered for each issuer.
+func (s *Scheduler) IssuerQueueSizes() map[identity.ID]int {
+	return s.buffer.IssuerQueueSizes()
+}
+
 // start starts the scheduler.
 func (s *Scheduler) start() {
 	go func() {
+		ticker := time.NewTicker(s.Rate())
+		defer ticker.Stop()
+
 		s.timeQueue.Start()
 		for {
 			select {
@@ -104,11 +161,17 @@ func (s *Scheduler) start() {
 				for _, child := range s.parentsMap[messageID] {
 					if s.messageReady(child) && !child.scheduled {
 						child.scheduled = true
-						s.schedule(child.ID)
+						s.enqueue(child)
 					}
 				}
 				delete(s.parentsMap, messageID)
 
+			// schedule the next message of the buffer according to the node rate.
+			case <-ticker.C:
+				if message := s.buffer.PopNext(); message != nil {
+					s.schedule(message.ID)
+				}
+
 			case <-s.close:
 				return
 			}
@@ -131,15 +194,42 @@ func (s *Scheduler) schedule(messageID MessageID) {
 	})
 }
 
+// enqueue adds a message whose parents have been booked to the buffer and discards the messages that don't fit anymore.
+func (s *Scheduler) enqueue(message *MessageToSchedule) {
+	for _, discardedMessage := range s.buffer.Add(message) {
+		s.discard(discardedMessage)
+	}
+}
+
+// discard drops a message because of congestion and discards the children that are waiting for it to be booked, as it
+// never will be. The message is not marked as invalid, as it did not violate any rule and other nodes might still
+// schedule it.
+func (s *Scheduler) discard(message *MessageToSchedule) {
+	message.scheduled = true
+
+	discardedMessageID := message.ID
+	s.outboxWorkerPool.Submit(func() {
+		s.Events.MessageDiscarded.Trigger(discardedMessageID)
+	})
+
+	children := s.parentsMap[message.ID]
+	delete(s.parentsMap, message.ID)
+	for _, child := range children {
+		if !child.scheduled {
+			s.discard(child)
+		}
+	}
+}
+
 func (s *Scheduler) trySchedule(message *MessageToSchedule) {
 	if message == nil {
 		return
 	}
 
-	// schedule if all the parents have been booked already.
+	// enqueue if all the parents have been booked already.
 	parentsToBook := s.parentsToBook(message)
 	if len(parentsToBook) == 0 {
-		s.schedule(message.ID)
+		s.enqueue(message)
 		return
 	}
 
@@ -287,12 +377,254 @@ func (t *timeIssuanceSortedList) String() (s string) {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region SchedulerBuffer //////////////////////////////////////////////////////////////////////////////////////////////
+
+// SchedulerBuffer buffers the messages that are ready to be scheduled in one FIFO queue per issuer and selects the next
+// message using deficit round robin, so that every issuer gets a share of the node rate that is proportional to its
+// weight.
+type SchedulerBuffer struct {
+	maxSize    int
+	weightFunc IssuerWeightFunc
+
+	size         int
+	length       int
+	issuerQueues map[identity.ID]*IssuerQueue
+	activeQueues *list.List
+	current      *list.Element
+	mutex        sync.RWMutex
+}
+
+// NewSchedulerBuffer returns a new SchedulerBuffer that holds at most maxSize bytes.
+func NewSchedulerBuffer(maxSize int, weightFunc IssuerWeightFunc) *SchedulerBuffer {
+	if weightFunc == nil {
+		weightFunc = ConstantIssuerWeight
+	}
+
+	return &SchedulerBuffer{
+		maxSize:      maxSize,
+		weightFunc:   weightFunc,
+		issuerQueues: make(map[identity.ID]*IssuerQueue),
+		activeQueues: list.New(),
+	}
+}
+
+// Add adds a message to the queue of its issuer. If the buffer overflows, the newest messages of the issuers with the
+// largest queues are dropped and returned.
+func (b *SchedulerBuffer) Add(message *MessageToSchedule) (discarded []*MessageToSchedule) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	issuerQueue, exists := b.issuerQueues[message.issuerID]
+	if !exists {
+		issuerQueue = newIssuerQueue(message.issuerID)
+		b.issuerQueues[message.issuerID] = issuerQueue
+		b.activeQueues.PushBack(issuerQueue)
+	}
+	issuerQueue.push(message)
+	b.size += message.size
+	b.length++
+
+	for b.size > b.maxSize {
+		discarded = append(discarded, b.dropFromLargestQueue())
+	}
+
+	return
+}
+
+// PopNext removes and returns the next message that is supposed to be scheduled (or nil if the buffer is empty).
+func (b *SchedulerBuffer) PopNext() (message *MessageToSchedule) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.activeQueues.Len() == 0 {
+		return nil
+	}
+
+	if b.current == nil {
+		b.current = b.activeQueues.Front()
+		b.addQuantum(b.current.Value.(*IssuerQueue))
+	}
+
+	for {
+		issuerQueue := b.current.Value.(*IssuerQueue)
+		if issuerQueue.deficit >= float64(issuerQueue.front().size) {
+			message = issuerQueue.pop()
+			issuerQueue.deficit -= float64(message.size)
+			b.size -= message.size
+			b.length--
+
+			if issuerQueue.len() == 0 {
+				b.current = b.removeQueue(b.current)
+			}
+
+			return
+		}
+
+		b.current = b.next(b.current)
+		b.addQuantum(b.current.Value.(*IssuerQueue))
+	}
+}
+
+// Size returns the amount of bytes that are buffered.
+func (b *SchedulerBuffer) Size() int {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return b.size
+}
+
+// Len returns the amount of messages that are buffered.
+func (b *SchedulerBuffer) Len() int {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return b.length
+}
+
+// IssuerQueueSize returns the amount of bytes that are buffered for the given issuer.
+func (b *SchedulerBuffer) IssuerQueueSize(issuerID identity.ID) int {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	issuerQueue, exists := b.issuerQueues[issuerID]
+	if !exists {
+		return 0
+	}
+
+	return issuerQueue.size
+}
+
+// IssuerQueueSizes returns the amount of bytes that are buffered for each issuer.
+func (b *SchedulerBuffer) IssuerQueueSizes() (sizes map[identity.ID]int) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	sizes = make(map[identity.ID]int, len(b.issuerQueues))
+	for issuerID, issuerQueue := range b.issuerQueues {
+		sizes[issuerID] = issuerQueue.size
+	}
+
+	return
+}
+
+// addQuantum adds the quantum of the issuer to the deficit of its queue.
+func (b *SchedulerBuffer) addQuantum(issuerQueue *IssuerQueue) {
+	weight := b.weightFunc(issuerQueue.issuerID)
+	if weight <= 0 {
+		weight = minIssuerWeight
+	}
+
+	issuerQueue.deficit += weight * MaxMessageSize
+}
+
+// next returns the element following the given one in the round robin (wrapping around at the end).
+func (b *SchedulerBuffer) next(element *list.Element) *list.Element {
+	if next := element.Next(); next != nil {
+		return next
+	}
+
+	return b.activeQueues.Front()
+}
+
+// removeQueue removes an empty queue from the round robin and returns the element that is served next (or nil if it
+// has to start a new round).
+func (b *SchedulerBuffer) removeQueue(element *list.Element) (next *list.Element) {
+	issuerQueue := element.Value.(*IssuerQueue)
+	if b.activeQueues.Len() > 1 {
+		next = b.next(element)
+		b.addQuantum(next.Value.(*IssuerQueue))
+	}
+
+	b.activeQueues.Remove(element)
+	delete(b.issuerQueues, issuerQueue.issuerID)
+
+	return
+}
+
+// dropFromLargestQueue removes the newest message of the issuer that currently occupies the most space in the buffer.
+func (b *SchedulerBuffer) dropFromLargestQueue() (message *MessageToSchedule) {
+	var largestElement *list.Element
+	for element := b.activeQueues.Front(); element != nil; element = element.Next() {
+		if largestElement == nil || element.Value.(*IssuerQueue).size > largestElement.Value.(*IssuerQueue).size {
+			largestElement = element
+		}
+	}
+
+	issuerQueue := largestElement.Value.(*IssuerQueue)
+	message = issuerQueue.popBack()
+	b.size -= message.size
+	b.length--
+
+	if issuerQueue.len() == 0 {
+		if b.current == largestElement {
+			b.current = b.removeQueue(largestElement)
+		} else {
+			b.activeQueues.Remove(largestElement)
+			delete(b.issuerQueues, issuerQueue.issuerID)
+		}
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region IssuerQueue //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// IssuerQueue is the FIFO queue of the messages of a single issuer that are waiting to be scheduled.
+type IssuerQueue struct {
+	issuerID identity.ID
+	messages *list.List
+	size     int
+	deficit  float64
+}
+
+// newIssuerQueue returns a new empty IssuerQueue for the given issuer.
+func newIssuerQueue(issuerID identity.ID) *IssuerQueue {
+	return &IssuerQueue{
+		issuerID: issuerID,
+		messages: list.New(),
+	}
+}
+
+func (i *IssuerQueue) push(message *MessageToSchedule) {
+	i.messages.PushBack(message)
+	i.size += message.size
+}
+
+func (i *IssuerQueue) front() *MessageToSchedule {
+	return i.messages.Front().Value.(*MessageToSchedule)
+}
+
+func (i *IssuerQueue) pop() (message *MessageToSchedule) {
+	message = i.messages.Remove(i.messages.Front()).(*MessageToSchedule)
+	i.size -= message.size
+
+	return
+}
+
+func (i *IssuerQueue) popBack() (message *MessageToSchedule) {
+	message = i.messages.Remove(i.messages.Back()).(*MessageToSchedule)
+	i.size -= message.size
+
+	return
+}
+
+func (i *IssuerQueue) len() int {
+	return i.messages.Len()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region SchedulerEvents /////////////////////////////////////////////////////////////////////////////////////////////
 
 // SchedulerEvents represents events happening in the Scheduler.
 type SchedulerEvents struct {
 	// MessageScheduled is triggered when a message is ready to be scheduled.
 	MessageScheduled *events.Event
+
+	// MessageDiscarded is triggered when a message is dropped because the buffer of the Scheduler is full.
+	MessageDiscarded *events.Event
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
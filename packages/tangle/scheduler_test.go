@@ -7,6 +7,7 @@ import (
 
 	"github.com/iotaledger/goshimmer/packages/clock"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -90,6 +91,49 @@ func TestScheduler(t *testing.T) {
 	}
 }
 
+func TestScheduler_Discard(t *testing.T) {
+	// a buffer that is too small for any message discards everything
+	tangle := New(SchedulerConfig(SchedulerParams{MaxBufferSize: 1}))
+	defer tangle.Shutdown()
+
+	testScheduler := tangle.Scheduler
+	testScheduler.Setup()
+
+	parent := newTestDataMessage("parent")
+	child := newTestParentsDataMessage("child", []MessageID{parent.ID()}, []MessageID{})
+	for _, message := range []*Message{parent, child} {
+		tangle.Storage.messageMetadataStorage.Store(NewMessageMetadata(message.ID())).Release()
+		tangle.Storage.messageStorage.Store(message).Release()
+	}
+
+	var discardedMutex sync.Mutex
+	discarded := make(map[MessageID]bool)
+	testScheduler.Events.MessageDiscarded.Attach(events.NewClosure(func(messageID MessageID) {
+		discardedMutex.Lock()
+		defer discardedMutex.Unlock()
+
+		discarded[messageID] = true
+	}))
+
+	// the child waits for its parent to be booked, which never happens as the parent gets discarded
+	tangle.Solidifier.Events.MessageSolid.Trigger(child.ID())
+	tangle.Solidifier.Events.MessageSolid.Trigger(parent.ID())
+
+	assert.Eventually(t, func() bool {
+		discardedMutex.Lock()
+		defer discardedMutex.Unlock()
+
+		return discarded[parent.ID()] && discarded[child.ID()]
+	}, time.Second, 10*time.Millisecond)
+
+	for _, message := range []*Message{parent, child} {
+		tangle.Storage.MessageMetadata(message.ID()).Consume(func(messageMetadata *MessageMetadata) {
+			// messages that are dropped due to congestion are not invalid
+			assert.False(t, messageMetadata.IsInvalid())
+		})
+	}
+}
+
 func TestTimeIssuanceSortedList(t *testing.T) {
 	now := time.Now()
 	list := timeIssuanceSortedList{
@@ -111,3 +155,69 @@ func TestTimeIssuanceSortedList(t *testing.T) {
 	assert.Equal(t, between, list[3])
 
 }
+
+func TestSchedulerBuffer_Fairness(t *testing.T) {
+	spammer := identity.GenerateIdentity().ID()
+	honest := identity.GenerateIdentity().ID()
+
+	buffer := NewSchedulerBuffer(DefaultSchedulerMaxBufferSize, ConstantIssuerWeight)
+	for i := 0; i < 10; i++ {
+		assert.Empty(t, buffer.Add(&MessageToSchedule{issuerID: spammer, size: MaxMessageSize / 2}))
+	}
+	for i := 0; i < 2; i++ {
+		assert.Empty(t, buffer.Add(&MessageToSchedule{issuerID: honest, size: MaxMessageSize / 2}))
+	}
+	assert.Equal(t, 12, buffer.Len())
+	assert.Equal(t, 6*MaxMessageSize, buffer.Size())
+
+	// every issuer gets the same share, so the honest messages are scheduled within the first round
+	scheduledIssuers := make([]identity.ID, 0)
+	for i := 0; i < 4; i++ {
+		scheduledIssuers = append(scheduledIssuers, buffer.PopNext().issuerID)
+	}
+	assert.ElementsMatch(t, []identity.ID{spammer, spammer, honest, honest}, scheduledIssuers)
+
+	for i := 0; i < 8; i++ {
+		assert.Equal(t, spammer, buffer.PopNext().issuerID)
+	}
+	assert.Nil(t, buffer.PopNext())
+	assert.Equal(t, 0, buffer.Size())
+}
+
+func TestSchedulerBuffer_Weights(t *testing.T) {
+	heavy := identity.GenerateIdentity().ID()
+	light := identity.GenerateIdentity().ID()
+
+	buffer := NewSchedulerBuffer(DefaultSchedulerMaxBufferSize, func(issuerID identity.ID) float64 {
+		if issuerID == heavy {
+			return 3
+		}
+		return 1
+	})
+	for i := 0; i < 8; i++ {
+		buffer.Add(&MessageToSchedule{issuerID: heavy, size: MaxMessageSize})
+		buffer.Add(&MessageToSchedule{issuerID: light, size: MaxMessageSize})
+	}
+
+	scheduledCount := make(map[identity.ID]int)
+	for i := 0; i < 8; i++ {
+		scheduledCount[buffer.PopNext().issuerID]++
+	}
+	assert.Equal(t, 6, scheduledCount[heavy])
+	assert.Equal(t, 2, scheduledCount[light])
+}
+
+func TestSchedulerBuffer_Drop(t *testing.T) {
+	spammer := identity.GenerateIdentity().ID()
+	honest := identity.GenerateIdentity().ID()
+
+	buffer := NewSchedulerBuffer(3*MaxMessageSize, ConstantIssuerWeight)
+	assert.Empty(t, buffer.Add(&MessageToSchedule{issuerID: honest, size: MaxMessageSize}))
+	assert.Empty(t, buffer.Add(&MessageToSchedule{issuerID: spammer, size: MaxMessageSize}))
+	assert.Empty(t, buffer.Add(&MessageToSchedule{issuerID: spammer, size: MaxMessageSize}))
+
+	// the newest message of the largest queue is dropped
+	newest := &MessageToSchedule{issuerID: spammer, size: MaxMessageSize}
+	assert.Equal(t, []*MessageToSchedule{newest}, buffer.Add(newest))
+	assert.Equal(t, map[identity.ID]int{honest: MaxMessageSize, spammer: 2 * MaxMessageSize}, buffer.IssuerQueueSizes())
+}
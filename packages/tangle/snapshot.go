@@ -0,0 +1,861 @@
+package tangle
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/markers"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+	"github.com/iotaledger/hive.go/types"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/xerrors"
+)
+
+// region Snapshot /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Snapshot represents a local snapshot of the Tangle. It consists of the confirmed UTXO set of the ledger and the solid
+// entry points, that take the role of the genesis for all Messages that are attached after the snapshot.
+type Snapshot struct {
+	LedgerSnapshot   ledgerstate.Snapshot
+	SolidEntryPoints MessageIDs
+}
+
+// NewSnapshot creates an empty Snapshot.
+func NewSnapshot() *Snapshot {
+	return &Snapshot{
+		LedgerSnapshot:   make(ledgerstate.Snapshot),
+		SolidEntryPoints: make(MessageIDs, 0),
+	}
+}
+
+// WriteTo writes the snapshot data to the given writer in the following format:
+//	ledger_snapshot(see ledgerstate.Snapshot)
+//	solid_entry_point_count(int64)
+//	-> solid_entry_point_count * message_id(32byte)
+func (s *Snapshot) WriteTo(writer io.Writer) (int64, error) {
+	bytesWritten, err := s.LedgerSnapshot.WriteTo(writer)
+	if err != nil {
+		return bytesWritten, fmt.Errorf("unable to write ledger snapshot: %w", err)
+	}
+
+	if err := binary.Write(writer, binary.LittleEndian, int64(len(s.SolidEntryPoints))); err != nil {
+		return bytesWritten, fmt.Errorf("unable to write solid entry point count: %w", err)
+	}
+	bytesWritten += 8
+	for _, solidEntryPoint := range s.SolidEntryPoints {
+		if err := binary.Write(writer, binary.LittleEndian, solidEntryPoint); err != nil {
+			return bytesWritten, fmt.Errorf("unable to write solid entry point: %w", err)
+		}
+		bytesWritten += MessageIDLength
+	}
+
+	return bytesWritten, nil
+}
+
+// ReadFrom reads the snapshot bytes from the given reader. Snapshots that only contain the ledger (i.e. genesis
+// snapshots) are supported as well and result in an empty set of solid entry points.
+// This function overrides existing content of the snapshot.
+func (s *Snapshot) ReadFrom(reader io.Reader) (int64, error) {
+	s.LedgerSnapshot = make(ledgerstate.Snapshot)
+	s.SolidEntryPoints = make(MessageIDs, 0)
+
+	bytesRead, err := s.LedgerSnapshot.ReadFrom(reader)
+	if err != nil {
+		return bytesRead, fmt.Errorf("unable to read ledger snapshot: %w", err)
+	}
+
+	var solidEntryPointCount int64
+	if err := binary.Read(reader, binary.LittleEndian, &solidEntryPointCount); err != nil {
+		if errors.Is(err, io.EOF) {
+			return bytesRead, nil
+		}
+
+		return bytesRead, fmt.Errorf("unable to read solid entry point count: %w", err)
+	}
+	bytesRead += 8
+
+	for i := int64(0); i < solidEntryPointCount; i++ {
+		var solidEntryPoint MessageID
+		if err := binary.Read(reader, binary.LittleEndian, &solidEntryPoint); err != nil {
+			return bytesRead, fmt.Errorf("unable to read solid entry point: %w", err)
+		}
+		bytesRead += MessageIDLength
+
+		s.SolidEntryPoints = append(s.SolidEntryPoints, solidEntryPoint)
+	}
+
+	return bytesRead, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SnapshotHeader ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// SnapshotVersion contains the version of the snapshot format that is written by the SnapshotWriter.
+const SnapshotVersion = 2
+
+// snapshotMagic marks the beginning of a versioned snapshot (unversioned snapshots start with their transaction count).
+var snapshotMagic = []byte("GSNP")
+
+// snapshotHeaderLength contains the amount of bytes that a marshaled version of the SnapshotHeader contains.
+var snapshotHeaderLength = len(snapshotMagic) + marshalutil.Uint8Size + marshalutil.Uint32Size + marshalutil.TimeSize + ledgerstate.MerkleRootLength
+
+// SnapshotHeader contains the information that precedes the content of a versioned snapshot.
+type SnapshotHeader struct {
+	// Version contains the version of the snapshot format.
+	Version uint8
+
+	// NetworkID contains the identifier of the network that the snapshot belongs to.
+	NetworkID uint32
+
+	// Timestamp contains the time at which the snapshot was taken.
+	Timestamp time.Time
+
+	// LedgerRoot contains the MerkleRoot of the confirmed unspent Outputs at the time the snapshot was taken.
+	LedgerRoot ledgerstate.MerkleRoot
+}
+
+// SnapshotHeaderFromBytes unmarshals a SnapshotHeader from a sequence of bytes.
+func SnapshotHeaderFromBytes(bytes []byte) (header *SnapshotHeader, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if header, err = SnapshotHeaderFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse SnapshotHeader from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// SnapshotHeaderFromMarshalUtil unmarshals a SnapshotHeader using a MarshalUtil (for easier unmarshaling).
+func SnapshotHeaderFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (header *SnapshotHeader, err error) {
+	magic, err := marshalUtil.ReadBytes(len(snapshotMagic))
+	if err != nil {
+		err = xerrors.Errorf("failed to parse magic bytes (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if !bytes.Equal(magic, snapshotMagic) {
+		err = xerrors.Errorf("invalid magic bytes %x: %w", magic, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	header = &SnapshotHeader{}
+	if header.Version, err = marshalUtil.ReadUint8(); err != nil {
+		err = xerrors.Errorf("failed to parse version (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if header.Version != SnapshotVersion {
+		err = xerrors.Errorf("unsupported snapshot version %d: %w", header.Version, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if header.NetworkID, err = marshalUtil.ReadUint32(); err != nil {
+		err = xerrors.Errorf("failed to parse network ID (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if header.Timestamp, err = marshalUtil.ReadTime(); err != nil {
+		err = xerrors.Errorf("failed to parse timestamp (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if header.LedgerRoot, err = ledgerstate.MerkleRootFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse ledger root: %w", err)
+		return
+	}
+
+	return
+}
+
+// Bytes returns a marshaled version of the SnapshotHeader.
+func (s *SnapshotHeader) Bytes() []byte {
+	return marshalutil.New(snapshotHeaderLength).
+		WriteBytes(snapshotMagic).
+		WriteUint8(s.Version).
+		WriteUint32(s.NetworkID).
+		WriteTime(s.Timestamp).
+		Write(s.LedgerRoot).
+		Bytes()
+}
+
+// String returns a human readable version of the SnapshotHeader.
+func (s *SnapshotHeader) String() string {
+	return stringify.Struct("SnapshotHeader",
+		stringify.StructField("version", s.Version),
+		stringify.StructField("networkID", s.NetworkID),
+		stringify.StructField("timestamp", s.Timestamp),
+		stringify.StructField("ledgerRoot", s.LedgerRoot),
+	)
+}
+
+// IsVersionedSnapshot checks if the given reader starts with the header of a versioned snapshot (without consuming
+// any bytes).
+func IsVersionedSnapshot(reader *bufio.Reader) bool {
+	magic, err := reader.Peek(len(snapshotMagic))
+
+	return err == nil && bytes.Equal(magic, snapshotMagic)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SnapshotWriter ///////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// snapshotRecordEnd marks the end of the records of a snapshot (it is followed by the checksum).
+	snapshotRecordEnd byte = iota
+
+	// snapshotRecordOutput marks a record that contains an Output and its OutputMetadata.
+	snapshotRecordOutput
+
+	// snapshotRecordSolidEntryPoint marks a record that contains the MessageID of a solid entry point.
+	snapshotRecordSolidEntryPoint
+)
+
+// maxSnapshotRecordSize contains the maximum amount of bytes of a single record of a snapshot.
+const maxSnapshotRecordSize = MaxMessageSize
+
+// SnapshotWriter writes a versioned snapshot record by record, so that the snapshot never needs to be held in memory.
+// The format of the snapshot is:
+//	header(see SnapshotHeader)
+//	-> records: type(1byte) + length(uint32) + content(length bytes)
+//		-> output: output_id + output(see Output.Bytes) + output_metadata(see OutputMetadata.Bytes)
+//		-> solid entry point: message_id
+//	end(1byte)
+//	checksum(blake2b-256 of all preceding bytes)
+type SnapshotWriter struct {
+	writer io.Writer
+	hash   hash.Hash
+	closed bool
+}
+
+// NewSnapshotWriter creates a SnapshotWriter that writes to the given writer and immediately writes the header.
+func NewSnapshotWriter(writer io.Writer, networkID uint32, timestamp time.Time, ledgerRoot ledgerstate.MerkleRoot) (snapshotWriter *SnapshotWriter, err error) {
+	checksum, err := blake2b.New256(nil)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create checksum: %w", err)
+	}
+
+	snapshotWriter = &SnapshotWriter{
+		writer: io.MultiWriter(writer, checksum),
+		hash:   checksum,
+	}
+
+	header := &SnapshotHeader{
+		Version:    SnapshotVersion,
+		NetworkID:  networkID,
+		Timestamp:  timestamp,
+		LedgerRoot: ledgerRoot,
+	}
+	if _, err = snapshotWriter.writer.Write(header.Bytes()); err != nil {
+		return nil, xerrors.Errorf("failed to write snapshot header: %w", err)
+	}
+
+	return
+}
+
+// WriteOutput writes a record that contains the given Output and its OutputMetadata.
+func (s *SnapshotWriter) WriteOutput(output ledgerstate.Output, outputMetadata *ledgerstate.OutputMetadata) (err error) {
+	return s.writeRecord(snapshotRecordOutput, byteutils.ConcatBytes(output.ID().Bytes(), output.Bytes(), outputMetadata.Bytes()))
+}
+
+// WriteSolidEntryPoint writes a record that contains the given solid entry point.
+func (s *SnapshotWriter) WriteSolidEntryPoint(messageID MessageID) (err error) {
+	return s.writeRecord(snapshotRecordSolidEntryPoint, messageID.Bytes())
+}
+
+// Close writes the end of the snapshot together with its checksum. It does not close the underlying writer.
+func (s *SnapshotWriter) Close() (err error) {
+	if s.closed {
+		return
+	}
+	s.closed = true
+
+	if _, err = s.writer.Write([]byte{snapshotRecordEnd}); err != nil {
+		return xerrors.Errorf("failed to write end of snapshot: %w", err)
+	}
+	if _, err = s.writer.Write(s.hash.Sum(nil)); err != nil {
+		return xerrors.Errorf("failed to write checksum of snapshot: %w", err)
+	}
+
+	return
+}
+
+// writeRecord is an internal utility function that writes a single record of the snapshot.
+func (s *SnapshotWriter) writeRecord(recordType byte, content []byte) (err error) {
+	if s.closed {
+		return xerrors.New("failed to write record: SnapshotWriter is closed")
+	}
+	if len(content) > maxSnapshotRecordSize {
+		return xerrors.Errorf("failed to write record: size of %d bytes exceeds the maximum of %d bytes", len(content), maxSnapshotRecordSize)
+	}
+
+	if _, err = s.writer.Write(marshalutil.New(marshalutil.Uint8Size + marshalutil.Uint32Size + len(content)).
+		WriteByte(recordType).
+		WriteUint32(uint32(len(content))).
+		WriteBytes(content).
+		Bytes()); err != nil {
+		return xerrors.Errorf("failed to write record: %w", err)
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SnapshotReader ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// SnapshotReader reads a versioned snapshot (see SnapshotWriter) record by record, so that the snapshot never needs to
+// be held in memory.
+type SnapshotReader struct {
+	reader io.Reader
+	hash   hash.Hash
+	header *SnapshotHeader
+}
+
+// NewSnapshotReader creates a SnapshotReader that reads from the given reader and immediately reads the header.
+func NewSnapshotReader(reader io.Reader) (snapshotReader *SnapshotReader, err error) {
+	checksum, err := blake2b.New256(nil)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create checksum: %w", err)
+	}
+
+	snapshotReader = &SnapshotReader{
+		reader: io.TeeReader(reader, checksum),
+		hash:   checksum,
+	}
+
+	headerBytes := make([]byte, snapshotHeaderLength)
+	if _, err = io.ReadFull(snapshotReader.reader, headerBytes); err != nil {
+		return nil, xerrors.Errorf("failed to read snapshot header: %w", err)
+	}
+	if snapshotReader.header, _, err = SnapshotHeaderFromBytes(headerBytes); err != nil {
+		return nil, xerrors.Errorf("failed to parse snapshot header: %w", err)
+	}
+
+	return
+}
+
+// Header returns the SnapshotHeader of the snapshot.
+func (s *SnapshotReader) Header() *SnapshotHeader {
+	return s.header
+}
+
+// ReadRecords reads the remaining records of the snapshot and passes them to the given callbacks. It verifies the
+// checksum after the last record and returns an error if the snapshot is corrupted.
+func (s *SnapshotReader) ReadRecords(outputCallback func(output ledgerstate.Output, outputMetadata *ledgerstate.OutputMetadata) error, solidEntryPointCallback func(messageID MessageID) error) (err error) {
+	recordHeader := make([]byte, marshalutil.Uint8Size+marshalutil.Uint32Size)
+	for {
+		if _, err = io.ReadFull(s.reader, recordHeader[:marshalutil.Uint8Size]); err != nil {
+			return xerrors.Errorf("failed to read record type: %w", err)
+		}
+		if recordHeader[0] == snapshotRecordEnd {
+			return s.verifyChecksum()
+		}
+
+		if _, err = io.ReadFull(s.reader, recordHeader[marshalutil.Uint8Size:]); err != nil {
+			return xerrors.Errorf("failed to read record length: %w", err)
+		}
+		recordLength, _ := marshalutil.New(recordHeader[marshalutil.Uint8Size:]).ReadUint32()
+		if recordLength > maxSnapshotRecordSize {
+			return xerrors.Errorf("size of record (%d bytes) exceeds the maximum of %d bytes: %w", recordLength, maxSnapshotRecordSize, cerrors.ErrParseBytesFailed)
+		}
+		content := make([]byte, recordLength)
+		if _, err = io.ReadFull(s.reader, content); err != nil {
+			return xerrors.Errorf("failed to read record: %w", err)
+		}
+
+		switch recordHeader[0] {
+		case snapshotRecordOutput:
+			output, outputMetadata, parseErr := parseSnapshotOutput(content)
+			if parseErr != nil {
+				return xerrors.Errorf("failed to parse output record: %w", parseErr)
+			}
+			if err = outputCallback(output, outputMetadata); err != nil {
+				return
+			}
+		case snapshotRecordSolidEntryPoint:
+			messageID, _, parseErr := MessageIDFromBytes(content)
+			if parseErr != nil {
+				return xerrors.Errorf("failed to parse solid entry point record: %w", parseErr)
+			}
+			if err = solidEntryPointCallback(messageID); err != nil {
+				return
+			}
+		default:
+			return xerrors.Errorf("unsupported record type %d: %w", recordHeader[0], cerrors.ErrParseBytesFailed)
+		}
+	}
+}
+
+// verifyChecksum is an internal utility function that reads the checksum at the end of the snapshot and compares it
+// to the checksum of the bytes that were read.
+func (s *SnapshotReader) verifyChecksum() (err error) {
+	expectedChecksum := s.hash.Sum(nil)
+	checksum := make([]byte, len(expectedChecksum))
+	if _, err = io.ReadFull(s.reader, checksum); err != nil {
+		return xerrors.Errorf("failed to read checksum: %w", err)
+	}
+	if !bytes.Equal(checksum, expectedChecksum) {
+		return xerrors.Errorf("checksum of snapshot does not match: %w", cerrors.ErrParseBytesFailed)
+	}
+
+	return
+}
+
+// parseSnapshotOutput is an internal utility function that parses the content of an output record.
+func parseSnapshotOutput(content []byte) (output ledgerstate.Output, outputMetadata *ledgerstate.OutputMetadata, err error) {
+	marshalUtil := marshalutil.New(content)
+	outputID, err := ledgerstate.OutputIDFromMarshalUtil(marshalUtil)
+	if err != nil {
+		err = xerrors.Errorf("failed to parse OutputID: %w", err)
+		return
+	}
+	if output, err = ledgerstate.OutputFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Output: %w", err)
+		return
+	}
+	output.SetID(outputID)
+	if outputMetadata, err = ledgerstate.OutputMetadataFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse OutputMetadata: %w", err)
+		return
+	}
+	if outputMetadata.ID() != outputID {
+		err = xerrors.Errorf("OutputMetadata with %s does not belong to Output with %s: %w", outputMetadata.ID(), outputID, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SnapshotSummary //////////////////////////////////////////////////////////////////////////////////////////////
+
+// SnapshotSummary contains the key figures of an exported snapshot. The total supply per Color allows to sanity check
+// the snapshot before it is used to bootstrap other nodes.
+type SnapshotSummary struct {
+	Header           *SnapshotHeader
+	Outputs          int
+	SolidEntryPoints int
+	Supply           map[ledgerstate.Color]uint64
+}
+
+// String returns a human readable version of the SnapshotSummary.
+func (s *SnapshotSummary) String() string {
+	structBuilder := stringify.StructBuilder("SnapshotSummary",
+		stringify.StructField("header", s.Header),
+		stringify.StructField("outputs", s.Outputs),
+		stringify.StructField("solidEntryPoints", s.SolidEntryPoints),
+	)
+	for color, supply := range s.Supply {
+		structBuilder.AddField(stringify.StructField(color.String(), supply))
+	}
+
+	return structBuilder.String()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SnapshotManager //////////////////////////////////////////////////////////////////////////////////////////////
+
+// maxSnapshotExportAttempts contains the maximum amount of times the confirmed ledger state is walked while trying to
+// find a consistent point for an exported snapshot.
+const maxSnapshotExportAttempts = 3
+
+// SnapshotManager is a Tangle component that creates and loads local snapshots. After a snapshot has been created, it
+// prunes the Messages below the snapshot cut in the background.
+type SnapshotManager struct {
+	// Events is a dictionary for the SnapshotManager related Events.
+	Events *SnapshotManagerEvents
+
+	tangle         *Tangle
+	snapshotMutex  sync.Mutex
+	pruningWorkers sync.WaitGroup
+}
+
+// NewSnapshotManager is the constructor of the SnapshotManager.
+func NewSnapshotManager(tangle *Tangle) (snapshotManager *SnapshotManager) {
+	snapshotManager = &SnapshotManager{
+		Events: &SnapshotManagerEvents{
+			MessagesPruned: events.NewEvent(events.IntCaller),
+		},
+		tangle: tangle,
+	}
+
+	return
+}
+
+// LoadSnapshot loads the given Snapshot into the ledger and marks its solid entry points, so that a node can start
+// from the Snapshot instead of the genesis.
+func (s *SnapshotManager) LoadSnapshot(snapshot *Snapshot) {
+	s.tangle.LedgerState.LoadSnapshot(snapshot.LedgerSnapshot)
+
+	for _, solidEntryPoint := range snapshot.SolidEntryPoints {
+		s.tangle.Storage.StoreSolidEntryPoint(solidEntryPoint)
+	}
+
+	if len(snapshot.SolidEntryPoints) != 0 {
+		s.tangle.TipManager.Set(snapshot.SolidEntryPoints...)
+	}
+}
+
+// ImportSnapshot loads the records of the given versioned snapshot into the ledger and marks its solid entry points, so
+// that a node can start from the snapshot instead of the genesis. The snapshot is read twice (both times from its
+// start): the first pass verifies the network and the checksum before anything is written and the second pass imports
+// the records. After the import, the state root of the ledger has to match the ledger root of the header.
+func (s *SnapshotManager) ImportSnapshot(snapshot io.ReadSeeker, networkID uint32) (header *SnapshotHeader, err error) {
+	if _, err = snapshot.Seek(0, io.SeekStart); err != nil {
+		err = xerrors.Errorf("failed to rewind snapshot: %w", err)
+		return
+	}
+	if header, err = verifySnapshot(snapshot, networkID); err != nil {
+		err = xerrors.Errorf("failed to import snapshot: %w", err)
+		return
+	}
+	if _, err = snapshot.Seek(0, io.SeekStart); err != nil {
+		err = xerrors.Errorf("failed to rewind snapshot: %w", err)
+		return
+	}
+
+	snapshotReader, err := NewSnapshotReader(snapshot)
+	if err != nil {
+		err = xerrors.Errorf("failed to import snapshot: %w", err)
+		return
+	}
+
+	solidEntryPoints := make(MessageIDs, 0)
+	if err = snapshotReader.ReadRecords(func(output ledgerstate.Output, outputMetadata *ledgerstate.OutputMetadata) error {
+		return s.tangle.LedgerState.LoadSnapshotOutput(output, outputMetadata)
+	}, func(messageID MessageID) error {
+		s.tangle.Storage.StoreSolidEntryPoint(messageID)
+		solidEntryPoints = append(solidEntryPoints, messageID)
+
+		return nil
+	}); err != nil {
+		err = xerrors.Errorf("failed to import snapshot: %w", err)
+		return
+	}
+
+	if len(solidEntryPoints) != 0 {
+		s.tangle.TipManager.Set(solidEntryPoints...)
+	}
+
+	if stateRoot := s.tangle.LedgerState.StateRoot(); stateRoot != header.LedgerRoot {
+		err = xerrors.Errorf("state root %s after import does not match ledger root %s of snapshot: %w", stateRoot.Base58(), header.LedgerRoot.Base58(), cerrors.ErrFatal)
+	}
+
+	return
+}
+
+// verifySnapshot is an internal utility function that reads the given snapshot without importing it and checks that it
+// belongs to the given network and that its checksum is valid.
+func verifySnapshot(snapshot io.Reader, networkID uint32) (header *SnapshotHeader, err error) {
+	snapshotReader, err := NewSnapshotReader(snapshot)
+	if err != nil {
+		return
+	}
+
+	if header = snapshotReader.Header(); header.NetworkID != networkID {
+		err = xerrors.Errorf("snapshot belongs to network %d instead of %d: %w", header.NetworkID, networkID, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	err = snapshotReader.ReadRecords(func(ledgerstate.Output, *ledgerstate.OutputMetadata) error {
+		return nil
+	}, func(MessageID) error {
+		return nil
+	})
+
+	return
+}
+
+// ExportSnapshot writes a versioned snapshot of the confirmed ledger state of the running node to the given writer. It
+// contains the Outputs that were created by confirmed Transactions and that were not spent by a confirmed Transaction,
+// yet (Outputs of pending or rejected Branches are skipped) and uses the confirmed frontier of the Tangle as solid entry
+// points. Contrary to CreateSnapshot, no Messages are pruned.
+func (s *SnapshotManager) ExportSnapshot(writer io.Writer, networkID uint32) (summary *SnapshotSummary, err error) {
+	s.snapshotMutex.Lock()
+	defer s.snapshotMutex.Unlock()
+
+	if summary, err = s.writeSnapshot(writer, networkID, s.confirmedFrontier()); err != nil {
+		err = xerrors.Errorf("failed to export snapshot: %w", err)
+	}
+
+	return
+}
+
+// CreateSnapshot writes a versioned snapshot of the confirmed ledger state and the solid entry points at the given cut
+// to the given writer. The solid entry points are the Messages issued before the cut that are either approved by
+// Messages issued after the cut or that are not approved at all. All of them need to be confirmed. Once the snapshot
+// has been written, the remaining Messages before the cut are pruned in the background.
+func (s *SnapshotManager) CreateSnapshot(writer io.Writer, networkID uint32, cutTime time.Time) (summary *SnapshotSummary, err error) {
+	s.snapshotMutex.Lock()
+	defer s.snapshotMutex.Unlock()
+
+	messagesBeforeCut := s.messagesBeforeCut(cutTime)
+
+	solidEntryPoints := make(MessageIDs, 0)
+	for messageID := range messagesBeforeCut {
+		approvingMessageIDs := s.tangle.Utils.ApprovingMessageIDs(messageID)
+		if len(approvingMessageIDs) == 0 {
+			solidEntryPoints = append(solidEntryPoints, messageID)
+			continue
+		}
+
+		for _, approvingMessageID := range approvingMessageIDs {
+			if _, beforeCut := messagesBeforeCut[approvingMessageID]; !beforeCut {
+				solidEntryPoints = append(solidEntryPoints, messageID)
+				break
+			}
+		}
+	}
+
+	for _, solidEntryPoint := range solidEntryPoints {
+		if !s.tangle.Storage.IsSolidEntryPoint(solidEntryPoint) && !s.tangle.ApprovalWeightManager.IsMessageConfirmed(solidEntryPoint) {
+			err = xerrors.Errorf("failed to create snapshot with Message with %s as solid entry point: %w", solidEntryPoint, ErrSnapshotCutNotConfirmed)
+			return
+		}
+	}
+
+	if summary, err = s.writeSnapshot(writer, networkID, solidEntryPoints); err != nil {
+		err = xerrors.Errorf("failed to create snapshot: %w", err)
+		return
+	}
+
+	for _, solidEntryPoint := range solidEntryPoints {
+		s.tangle.Storage.StoreSolidEntryPoint(solidEntryPoint)
+		delete(messagesBeforeCut, solidEntryPoint)
+	}
+
+	s.pruningWorkers.Add(1)
+	go func() {
+		defer s.pruningWorkers.Done()
+
+		s.prune(messagesBeforeCut)
+	}()
+
+	return
+}
+
+// Shutdown waits for the background pruning to finish.
+func (s *SnapshotManager) Shutdown() {
+	s.pruningWorkers.Wait()
+}
+
+// writeSnapshot is an internal utility function that writes the confirmed unspent Outputs (together with their type
+// and ID) and the given solid entry points as a versioned snapshot to the given writer.
+func (s *SnapshotManager) writeSnapshot(writer io.Writer, networkID uint32, solidEntryPoints MessageIDs) (summary *SnapshotSummary, err error) {
+	outputIDs, ledgerRoot, err := s.confirmedUnspentOutputs()
+	if err != nil {
+		return
+	}
+
+	timestamp := time.Now()
+	snapshotWriter, err := NewSnapshotWriter(writer, networkID, timestamp, ledgerRoot)
+	if err != nil {
+		return
+	}
+
+	summary = &SnapshotSummary{
+		Header: &SnapshotHeader{
+			Version:    SnapshotVersion,
+			NetworkID:  networkID,
+			Timestamp:  timestamp,
+			LedgerRoot: ledgerRoot,
+		},
+		Outputs:          len(outputIDs),
+		SolidEntryPoints: len(solidEntryPoints),
+		Supply:           make(map[ledgerstate.Color]uint64),
+	}
+
+	for _, outputID := range outputIDs {
+		// the Outputs become part of the MasterBranch of the bootstrapped node and are not spent, yet
+		outputMetadata := ledgerstate.NewOutputMetadata(outputID)
+		outputMetadata.SetBranchID(ledgerstate.MasterBranchID)
+		outputMetadata.SetSolid(true)
+		outputMetadata.SetFinalized(true)
+
+		if !s.tangle.LedgerState.Output(outputID).Consume(func(output ledgerstate.Output) {
+			if err = snapshotWriter.WriteOutput(output, outputMetadata); err != nil {
+				return
+			}
+
+			output.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+				summary.Supply[color] += balance
+				return true
+			})
+		}) {
+			err = xerrors.Errorf("failed to load Output with %s: %w", outputID, cerrors.ErrFatal)
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	for _, solidEntryPoint := range solidEntryPoints {
+		if err = snapshotWriter.WriteSolidEntryPoint(solidEntryPoint); err != nil {
+			return
+		}
+	}
+
+	err = snapshotWriter.Close()
+
+	return
+}
+
+// confirmedUnspentOutputs is an internal utility function that collects the IDs of the confirmed unspent Outputs
+// together with their MerkleRoot. Since Transactions can be confirmed while the ledger is walked, it repeats the walk
+// until two consecutive walks agree on the MerkleRoot.
+func (s *SnapshotManager) confirmedUnspentOutputs() (outputIDs []ledgerstate.OutputID, ledgerRoot ledgerstate.MerkleRoot, err error) {
+	outputIDs, ledgerRoot = s.walkConfirmedUnspentOutputs()
+	for attempt := 1; attempt < maxSnapshotExportAttempts; attempt++ {
+		nextOutputIDs, nextLedgerRoot := s.walkConfirmedUnspentOutputs()
+		if nextLedgerRoot == ledgerRoot {
+			return nextOutputIDs, nextLedgerRoot, nil
+		}
+
+		outputIDs, ledgerRoot = nextOutputIDs, nextLedgerRoot
+	}
+
+	err = xerrors.Errorf("confirmed ledger state changed in each of %d attempts: %w", maxSnapshotExportAttempts, ErrSnapshotInconsistent)
+	return
+}
+
+// walkConfirmedUnspentOutputs is an internal utility function that walks the confirmed unspent Outputs once and
+// returns their IDs and the MerkleRoot of a StateTree that contains them.
+func (s *SnapshotManager) walkConfirmedUnspentOutputs() (outputIDs []ledgerstate.OutputID, ledgerRoot ledgerstate.MerkleRoot) {
+	stateTree := ledgerstate.NewStateTree(mapdb.NewMapDB())
+	outputIDs = make([]ledgerstate.OutputID, 0)
+	s.tangle.LedgerState.ForEachConfirmedUnspentOutput(func(output ledgerstate.Output) bool {
+		stateTree.Add(output)
+		outputIDs = append(outputIDs, output.ID())
+
+		return true
+	})
+
+	return outputIDs, stateTree.Root()
+}
+
+// confirmedFrontier is an internal utility function that returns the solid entry points and confirmed Messages that
+// are not approved by another confirmed Message (or solid entry point).
+func (s *SnapshotManager) confirmedFrontier() (frontier MessageIDs) {
+	confirmed := func(messageID MessageID) bool {
+		return s.tangle.Storage.IsSolidEntryPoint(messageID) || s.tangle.ApprovalWeightManager.IsMessageConfirmed(messageID)
+	}
+
+	frontier = make(MessageIDs, 0)
+	s.tangle.Storage.messageMetadataStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedMessageMetadata{CachedObject: cachedObject}).Consume(func(messageMetadata *MessageMetadata) {
+			messageID := messageMetadata.ID()
+			if messageID == EmptyMessageID || !confirmed(messageID) {
+				return
+			}
+
+			for _, approvingMessageID := range s.tangle.Utils.ApprovingMessageIDs(messageID) {
+				if confirmed(approvingMessageID) {
+					return
+				}
+			}
+
+			frontier = append(frontier, messageID)
+		})
+
+		return true
+	})
+
+	return
+}
+
+// messagesBeforeCut returns the IDs of all Messages (except the genesis) that were issued before the given cut. Solid
+// entry points of previous snapshots whose Message is not known are considered to be before the cut as well.
+func (s *SnapshotManager) messagesBeforeCut(cutTime time.Time) (messagesBeforeCut map[MessageID]types.Empty) {
+	messagesBeforeCut = make(map[MessageID]types.Empty)
+	s.tangle.Storage.messageMetadataStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedMessageMetadata{CachedObject: cachedObject}).Consume(func(messageMetadata *MessageMetadata) {
+			messageID := messageMetadata.ID()
+			if messageID == EmptyMessageID {
+				return
+			}
+
+			beforeCut := true
+			s.tangle.Storage.Message(messageID).Consume(func(message *Message) {
+				beforeCut = message.IssuingTime().Before(cutTime)
+			})
+
+			if beforeCut {
+				messagesBeforeCut[messageID] = types.Void
+			}
+		})
+
+		return true
+	})
+
+	return
+}
+
+// prune deletes the given Messages and the MarkerIndexBranchIDMappings that are not needed by the remaining Messages.
+func (s *SnapshotManager) prune(messageIDs map[MessageID]types.Empty) {
+	for messageID := range messageIDs {
+		s.tangle.Storage.PruneMessage(messageID)
+	}
+
+	lowestReferencedIndexes := make(map[markers.SequenceID]markers.Index)
+	s.tangle.Storage.messageMetadataStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedMessageMetadata{CachedObject: cachedObject}).Consume(func(messageMetadata *MessageMetadata) {
+			if structureDetails := messageMetadata.StructureDetails(); structureDetails != nil {
+				structureDetails.PastMarkers.ForEach(func(sequenceID markers.SequenceID, index markers.Index) bool {
+					if lowestIndex, exists := lowestReferencedIndexes[sequenceID]; !exists || index < lowestIndex {
+						lowestReferencedIndexes[sequenceID] = index
+					}
+					return true
+				})
+			}
+		})
+
+		return true
+	})
+
+	for sequenceID, lowestIndex := range lowestReferencedIndexes {
+		s.tangle.Storage.MarkerIndexBranchIDMapping(sequenceID).Consume(func(markerIndexBranchIDMapping *MarkerIndexBranchIDMapping) {
+			markerIndexBranchIDMapping.DeleteBranchIDsBelow(lowestIndex)
+		})
+	}
+
+	s.Events.MessagesPruned.Trigger(len(messageIDs))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SnapshotManagerEvents ////////////////////////////////////////////////////////////////////////////////////////
+
+// SnapshotManagerEvents represents events happening in the SnapshotManager.
+type SnapshotManagerEvents struct {
+	// MessagesPruned is triggered when the background pruning after a snapshot has finished. It contains the amount of
+	// pruned Messages.
+	MessagesPruned *events.Event
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Errors ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ErrSnapshotCutNotConfirmed is returned when a snapshot is requested at a cut whose solid entry points are not
+// confirmed, yet.
+var ErrSnapshotCutNotConfirmed = errors.New("solid entry point of snapshot is not confirmed")
+
+// ErrSnapshotInconsistent is returned when no consistent point of the confirmed ledger state could be found while
+// exporting a snapshot.
+var ErrSnapshotInconsistent = errors.New("confirmed ledger state changed during the export of the snapshot")
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,328 @@
+package tangle
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot_ReadWrite(t *testing.T) {
+	address := createWallets(1)[0].address
+	ledgerSnapshot := ledgerstate.Snapshot{
+		ledgerstate.GenesisTransactionID: {
+			address: ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 100}),
+		},
+	}
+
+	snapshot := NewSnapshot()
+	snapshot.LedgerSnapshot = ledgerSnapshot
+	snapshot.SolidEntryPoints = MessageIDs{randomMessageID(), randomMessageID()}
+
+	var buffer bytes.Buffer
+	written, err := snapshot.WriteTo(&buffer)
+	require.NoError(t, err)
+
+	restoredSnapshot := NewSnapshot()
+	read, err := restoredSnapshot.ReadFrom(&buffer)
+	require.NoError(t, err)
+	assert.Equal(t, written, read)
+	assert.Equal(t, snapshot.SolidEntryPoints, restoredSnapshot.SolidEntryPoints)
+	require.Len(t, restoredSnapshot.LedgerSnapshot[ledgerstate.GenesisTransactionID], 1)
+	for restoredAddress, restoredBalances := range restoredSnapshot.LedgerSnapshot[ledgerstate.GenesisTransactionID] {
+		assert.Equal(t, address.Bytes(), restoredAddress.Bytes())
+		assert.Equal(t, ledgerSnapshot[ledgerstate.GenesisTransactionID][address].Bytes(), restoredBalances.Bytes())
+	}
+
+	// snapshots that only contain the ledger are still supported
+	buffer.Reset()
+	_, err = ledgerSnapshot.WriteTo(&buffer)
+	require.NoError(t, err)
+
+	restoredSnapshot = NewSnapshot()
+	_, err = restoredSnapshot.ReadFrom(&buffer)
+	require.NoError(t, err)
+	assert.Len(t, restoredSnapshot.LedgerSnapshot, 1)
+	assert.Empty(t, restoredSnapshot.SolidEntryPoints)
+}
+
+func TestSnapshotWriterReader(t *testing.T) {
+	wallets := createWallets(2)
+	outputs := []ledgerstate.Output{
+		ledgerstate.NewSigLockedSingleOutput(100, wallets[0].address),
+		ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 200}), wallets[1].address),
+	}
+	outputsMetadata := make([]*ledgerstate.OutputMetadata, len(outputs))
+	for i, output := range outputs {
+		output.SetID(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, uint16(i)))
+		outputsMetadata[i] = ledgerstate.NewOutputMetadata(output.ID())
+		outputsMetadata[i].SetBranchID(ledgerstate.MasterBranchID)
+		outputsMetadata[i].SetSolid(true)
+		outputsMetadata[i].SetFinalized(true)
+	}
+	solidEntryPoint := randomMessageID()
+	ledgerRoot := ledgerstate.MerkleRoot{1, 2, 3}
+	timestamp := time.Unix(1337, 0)
+
+	var buffer bytes.Buffer
+	snapshotWriter, err := NewSnapshotWriter(&buffer, 42, timestamp, ledgerRoot)
+	require.NoError(t, err)
+	for i, output := range outputs {
+		require.NoError(t, snapshotWriter.WriteOutput(output, outputsMetadata[i]))
+	}
+	require.NoError(t, snapshotWriter.WriteSolidEntryPoint(solidEntryPoint))
+	require.NoError(t, snapshotWriter.Close())
+	snapshotBytes := buffer.Bytes()
+
+	assert.True(t, IsVersionedSnapshot(bufio.NewReader(bytes.NewReader(snapshotBytes))))
+
+	snapshotReader, err := NewSnapshotReader(bytes.NewReader(snapshotBytes))
+	require.NoError(t, err)
+	assert.Equal(t, uint8(SnapshotVersion), snapshotReader.Header().Version)
+	assert.Equal(t, uint32(42), snapshotReader.Header().NetworkID)
+	assert.True(t, timestamp.Equal(snapshotReader.Header().Timestamp))
+	assert.Equal(t, ledgerRoot, snapshotReader.Header().LedgerRoot)
+
+	readOutputs := make([]ledgerstate.Output, 0)
+	readSolidEntryPoints := make(MessageIDs, 0)
+	require.NoError(t, snapshotReader.ReadRecords(func(output ledgerstate.Output, outputMetadata *ledgerstate.OutputMetadata) error {
+		assert.Equal(t, output.ID(), outputMetadata.ID())
+		assert.True(t, outputMetadata.Finalized())
+		readOutputs = append(readOutputs, output)
+		return nil
+	}, func(messageID MessageID) error {
+		readSolidEntryPoints = append(readSolidEntryPoints, messageID)
+		return nil
+	}))
+	require.Len(t, readOutputs, len(outputs))
+	for i, output := range outputs {
+		assert.Equal(t, output.ID(), readOutputs[i].ID())
+		assert.Equal(t, output.Type(), readOutputs[i].Type())
+		assert.Equal(t, output.Bytes(), readOutputs[i].Bytes())
+	}
+	assert.Equal(t, MessageIDs{solidEntryPoint}, readSolidEntryPoints)
+
+	// corrupted snapshots are rejected
+	corruptedBytes := append([]byte{}, snapshotBytes...)
+	corruptedBytes[len(corruptedBytes)-40] ^= 0xff
+	snapshotReader, err = NewSnapshotReader(bytes.NewReader(corruptedBytes))
+	require.NoError(t, err)
+	assert.Error(t, snapshotReader.ReadRecords(func(ledgerstate.Output, *ledgerstate.OutputMetadata) error { return nil }, func(MessageID) error { return nil }))
+
+	// unversioned snapshots are detected
+	buffer.Reset()
+	_, err = NewSnapshot().WriteTo(&buffer)
+	require.NoError(t, err)
+	assert.False(t, IsVersionedSnapshot(bufio.NewReader(&buffer)))
+}
+
+func TestSnapshotManager_ImportSnapshot(t *testing.T) {
+	address := createWallets(1)[0].address
+	output := ledgerstate.NewSigLockedSingleOutput(100, address)
+	output.SetID(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 1))
+	outputMetadata := ledgerstate.NewOutputMetadata(output.ID())
+	outputMetadata.SetBranchID(ledgerstate.MasterBranchID)
+	outputMetadata.SetSolid(true)
+	outputMetadata.SetFinalized(true)
+	solidEntryPoint := randomMessageID()
+
+	stateTree := ledgerstate.NewStateTree(mapdb.NewMapDB())
+	stateTree.Add(output)
+
+	writeSnapshot := func(ledgerRoot ledgerstate.MerkleRoot) []byte {
+		var buffer bytes.Buffer
+		snapshotWriter, err := NewSnapshotWriter(&buffer, 1, time.Now(), ledgerRoot)
+		require.NoError(t, err)
+		require.NoError(t, snapshotWriter.WriteOutput(output, outputMetadata))
+		require.NoError(t, snapshotWriter.WriteSolidEntryPoint(solidEntryPoint))
+		require.NoError(t, snapshotWriter.Close())
+
+		return buffer.Bytes()
+	}
+	snapshotBytes := writeSnapshot(stateTree.Root())
+
+	t.Run("CASE: Valid snapshot", func(t *testing.T) {
+		tangle := New()
+		defer tangle.Shutdown()
+
+		header, err := tangle.SnapshotManager.ImportSnapshot(bytes.NewReader(snapshotBytes), 1)
+		require.NoError(t, err)
+		assert.Equal(t, stateTree.Root(), header.LedgerRoot)
+
+		assert.True(t, tangle.Storage.IsSolidEntryPoint(solidEntryPoint))
+		assert.True(t, tangle.LedgerState.transactionConfirmed(ledgerstate.GenesisTransactionID))
+		cachedOutputs := tangle.LedgerState.OutputsOnAddress(address)
+		assert.Len(t, cachedOutputs.Unwrap(), 1)
+		cachedOutputs.Release()
+		assert.True(t, tangle.LedgerState.OutputProof(output.ID()).VerifyOutput(tangle.LedgerState.StateRoot(), output))
+	})
+
+	t.Run("CASE: Corrupted snapshot", func(t *testing.T) {
+		tangle := New()
+		defer tangle.Shutdown()
+
+		// the checksum is verified before anything is written
+		corruptedBytes := append([]byte{}, snapshotBytes...)
+		corruptedBytes[len(corruptedBytes)-40] ^= 0xff
+		_, err := tangle.SnapshotManager.ImportSnapshot(bytes.NewReader(corruptedBytes), 1)
+		assert.Error(t, err)
+		assert.False(t, tangle.Storage.IsSolidEntryPoint(solidEntryPoint))
+		assert.False(t, tangle.LedgerState.Output(output.ID()).Consume(func(ledgerstate.Output) {}))
+		assert.Equal(t, ledgerstate.EmptyMerkleRoot, tangle.LedgerState.StateRoot())
+	})
+
+	t.Run("CASE: Wrong network", func(t *testing.T) {
+		tangle := New()
+		defer tangle.Shutdown()
+
+		_, err := tangle.SnapshotManager.ImportSnapshot(bytes.NewReader(snapshotBytes), 2)
+		assert.Error(t, err)
+		assert.False(t, tangle.LedgerState.Output(output.ID()).Consume(func(ledgerstate.Output) {}))
+	})
+
+	t.Run("CASE: Wrong ledger root", func(t *testing.T) {
+		tangle := New()
+		defer tangle.Shutdown()
+
+		_, err := tangle.SnapshotManager.ImportSnapshot(bytes.NewReader(writeSnapshot(ledgerstate.MerkleRoot{1, 2, 3})), 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestSnapshotManager_ExportSnapshot(t *testing.T) {
+	tangle := New(WithoutOpinionFormer(true))
+	defer tangle.Shutdown()
+
+	wallets := createWallets(2)
+	balances := ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 100, {1}: 10})
+	genesisOutput := ledgerstate.NewSigLockedColoredOutput(balances, wallets[0].address)
+	genesisOutput.SetID(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 1))
+	genesisOutputMetadata := ledgerstate.NewOutputMetadata(genesisOutput.ID())
+	genesisOutputMetadata.SetBranchID(ledgerstate.MasterBranchID)
+	genesisOutputMetadata.SetSolid(true)
+	genesisOutputMetadata.SetFinalized(true)
+	require.NoError(t, tangle.LedgerState.LoadSnapshotOutput(genesisOutput, genesisOutputMetadata))
+
+	txEssence := ledgerstate.NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, ledgerstate.NewInputs(ledgerstate.NewUTXOInput(genesisOutput.ID())), ledgerstate.NewOutputs(ledgerstate.NewSigLockedColoredOutput(balances, wallets[1].address)))
+	tx := ledgerstate.NewTransaction(txEssence, wallets[0].unlockBlocks(txEssence))
+	_, err := tangle.LedgerState.utxoDAG.BookTransaction(tx)
+	require.NoError(t, err)
+
+	exportSnapshot := func() (summary *SnapshotSummary, freshTangle *Tangle) {
+		var buffer bytes.Buffer
+		summary, err := tangle.SnapshotManager.ExportSnapshot(&buffer, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, summary.Outputs)
+		assert.Equal(t, 0, summary.SolidEntryPoints)
+		assert.Equal(t, map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 100, {1}: 10}, summary.Supply)
+
+		freshTangle = New(WithoutOpinionFormer(true))
+		header, err := freshTangle.SnapshotManager.ImportSnapshot(bytes.NewReader(buffer.Bytes()), 1)
+		require.NoError(t, err)
+		assert.Equal(t, summary.Header.LedgerRoot, header.LedgerRoot)
+		assert.Equal(t, summary.Header.LedgerRoot, freshTangle.LedgerState.StateRoot())
+
+		return
+	}
+
+	// the Transaction is still pending, so the snapshot contains the genesis Output
+	summary, freshTangle := exportSnapshot()
+	assert.Equal(t, tangle.LedgerState.StateRoot(), summary.Header.LedgerRoot)
+	assert.True(t, freshTangle.LedgerState.Output(genesisOutput.ID()).Consume(func(ledgerstate.Output) {}))
+	assert.False(t, freshTangle.LedgerState.Output(ledgerstate.NewOutputID(tx.ID(), 0)).Consume(func(ledgerstate.Output) {}))
+	freshTangle.Shutdown()
+
+	// after the confirmation of the Transaction, the snapshot contains its Output instead
+	tangle.LedgerState.TransactionMetadata(tx.ID()).Consume(func(transactionMetadata *ledgerstate.TransactionMetadata) {
+		transactionMetadata.SetFinalized(true)
+	})
+	summary, freshTangle = exportSnapshot()
+	assert.False(t, freshTangle.LedgerState.Output(genesisOutput.ID()).Consume(func(ledgerstate.Output) {}))
+	assert.True(t, freshTangle.LedgerState.Output(ledgerstate.NewOutputID(tx.ID(), 0)).Consume(func(ledgerstate.Output) {}))
+	freshTangle.Shutdown()
+}
+
+func TestSnapshotManager(t *testing.T) {
+	tangle := New(WithoutOpinionFormer(true))
+	defer tangle.Shutdown()
+	tangle.Booker.Setup()
+	tangle.ApprovalWeightManager.Setup()
+
+	// the snapshot has to preserve the IDs and the types of the Outputs
+	address := createWallets(1)[0].address
+	outputs := []ledgerstate.Output{
+		ledgerstate.NewSigLockedSingleOutput(100, address),
+		ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{{1}: 10}), address),
+		ledgerstate.NewExtendedLockedOutput(ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 50}), address),
+	}
+	for i, output := range outputs {
+		output.SetID(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, uint16(2*i+1)))
+		require.NoError(t, tangle.LedgerState.LoadSnapshotOutput(output, snapshotOutputMetadata(output)))
+	}
+
+	cutTime := time.Now().Add(-time.Minute)
+	messages := make([]*Message, 5)
+	parent := EmptyMessageID
+	for i := range messages {
+		issuingTime := cutTime.Add(time.Duration(i-3) * time.Second)
+		messages[i] = newTestParentsDataWithTimestamp("test", []MessageID{parent}, []MessageID{}, issuingTime)
+		tangle.Storage.StoreMessage(messages[i])
+		require.NoError(t, tangle.Booker.Book(messages[i].ID()))
+		parent = messages[i].ID()
+	}
+
+	pruned := make(chan int, 1)
+	tangle.SnapshotManager.Events.MessagesPruned.Attach(events.NewClosure(func(prunedMessagesCount int) {
+		pruned <- prunedMessagesCount
+	}))
+
+	var buffer bytes.Buffer
+	summary, err := tangle.SnapshotManager.CreateSnapshot(&buffer, 1, cutTime)
+	require.NoError(t, err)
+	assert.Equal(t, len(outputs), summary.Outputs)
+	assert.Equal(t, 1, summary.SolidEntryPoints)
+	assert.Equal(t, tangle.LedgerState.StateRoot(), summary.Header.LedgerRoot)
+
+	select {
+	case prunedMessagesCount := <-pruned:
+		assert.Equal(t, 2, prunedMessagesCount)
+	case <-time.After(5 * time.Second):
+		t.Fatal("pruning did not finish in time")
+	}
+
+	for i, message := range messages {
+		assert.Equal(t, i >= 2, tangle.Storage.Message(message.ID()).Consume(func(message *Message) {}), "message %d", i)
+	}
+	assert.True(t, tangle.Storage.IsSolidEntryPoint(messages[2].ID()))
+	assert.False(t, tangle.Storage.IsSolidEntryPoint(messages[1].ID()))
+	assert.Empty(t, tangle.Utils.ApprovingMessageIDs(messages[1].ID()))
+
+	// a fresh node starts from the snapshot instead of the genesis
+	freshTangle := New(WithoutOpinionFormer(true))
+	defer freshTangle.Shutdown()
+	_, err = freshTangle.SnapshotManager.ImportSnapshot(bytes.NewReader(buffer.Bytes()), 1)
+	require.NoError(t, err)
+	assert.Equal(t, summary.Header.LedgerRoot, freshTangle.LedgerState.StateRoot())
+	for _, output := range outputs {
+		assert.True(t, freshTangle.LedgerState.Output(output.ID()).Consume(func(importedOutput ledgerstate.Output) {
+			assert.Equal(t, output.Type(), importedOutput.Type())
+			assert.Equal(t, output.Bytes(), importedOutput.Bytes())
+		}), "output %s", output.ID())
+	}
+	assert.True(t, freshTangle.Storage.IsSolidEntryPoint(messages[2].ID()))
+	assert.Equal(t, 1, freshTangle.TipManager.StrongTipCount())
+
+	freshTangle.Storage.StoreMessage(messages[3])
+	freshTangle.Solidifier.Solidify(messages[3].ID())
+	assert.True(t, freshTangle.Storage.MessageMetadata(messages[3].ID()).Consume(func(messageMetadata *MessageMetadata) {
+		assert.True(t, messageMetadata.IsSolid())
+	}))
+	require.NoError(t, freshTangle.Booker.Book(messages[3].ID()))
+}
@@ -93,7 +93,7 @@ func (s *Solidifier) isMessageSolid(message *Message, messageMetadata *MessageMe
 
 // isMessageMarkedAsSolid checks whether the given message is solid and marks it as missing if it isn't known.
 func (s *Solidifier) isMessageMarkedAsSolid(messageID MessageID) (solid bool) {
-	if messageID == EmptyMessageID {
+	if s.tangle.Storage.IsSolidEntryPoint(messageID) {
 		return true
 	}
 
@@ -126,7 +126,7 @@ func (s *Solidifier) areParentMessagesValid(message *Message) (valid bool) {
 
 // isParentMessageValid checks whether the given parent Message is valid.
 func (s *Solidifier) isParentMessageValid(parentMessageID MessageID, childMessageIssuingTime time.Time) (valid bool) {
-	if parentMessageID == EmptyMessageID {
+	if s.tangle.Storage.IsSolidEntryPoint(parentMessageID) {
 		return true
 	}
 
@@ -11,6 +11,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/vote/opinion"
 	"github.com/iotaledger/hive.go/byteutils"
 	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/iotaledger/hive.go/objectstorage"
@@ -40,6 +41,24 @@ const (
 	// PrefixFCoB defines the storage prefix for FCoB.
 	PrefixFCoB
 
+	// PrefixSequenceSupporters defines the storage prefix for the SequenceSupporters.
+	PrefixSequenceSupporters
+
+	// PrefixBranchSupporters defines the storage prefix for the BranchSupporters.
+	PrefixBranchSupporters
+
+	// PrefixSolidEntryPoints defines the storage prefix for the solid entry points.
+	PrefixSolidEntryPoints
+
+	// PrefixIssuerSequenceNumberMapping defines the storage prefix for the IssuerSequenceNumberMapping.
+	PrefixIssuerSequenceNumberMapping
+
+	// PrefixEquivocationEvidence defines the storage prefix for the EquivocationEvidence.
+	PrefixEquivocationEvidence
+
+	// PrefixBlockedIssuers defines the storage prefix for the BlockedIssuers.
+	PrefixBlockedIssuers
+
 	cacheTime = 20 * time.Second
 
 	// DBSequenceNumber defines the db sequence number.
@@ -57,6 +76,12 @@ type Storage struct {
 	missingMessageStorage             *objectstorage.ObjectStorage
 	attachmentStorage                 *objectstorage.ObjectStorage
 	markerIndexBranchIDMappingStorage *objectstorage.ObjectStorage
+	sequenceSupportersStorage         *objectstorage.ObjectStorage
+	branchSupportersStorage           *objectstorage.ObjectStorage
+	solidEntryPointStorage            *objectstorage.ObjectStorage
+	issuerSequenceNumberStorage       *objectstorage.ObjectStorage
+	equivocationEvidenceStorage       *objectstorage.ObjectStorage
+	blockedIssuerStorage              *objectstorage.ObjectStorage
 
 	Events   *StorageEvents
 	shutdown chan struct{}
@@ -75,6 +100,12 @@ func NewStorage(tangle *Tangle) (storage *Storage) {
 		missingMessageStorage:             osFactory.New(PrefixMissingMessage, MissingMessageFromObjectStorage, objectstorage.CacheTime(cacheTime), objectstorage.LeakDetectionEnabled(false)),
 		attachmentStorage:                 osFactory.New(PrefixAttachments, AttachmentFromObjectStorage, objectstorage.CacheTime(cacheTime), objectstorage.PartitionKey(ledgerstate.TransactionIDLength, MessageIDLength), objectstorage.LeakDetectionEnabled(false)),
 		markerIndexBranchIDMappingStorage: osFactory.New(PrefixMarkerBranchIDMapping, MarkerIndexBranchIDMappingFromObjectStorage, objectstorage.CacheTime(cacheTime), objectstorage.LeakDetectionEnabled(false)),
+		sequenceSupportersStorage:         osFactory.New(PrefixSequenceSupporters, SequenceSupportersFromObjectStorage, objectstorage.CacheTime(cacheTime), objectstorage.LeakDetectionEnabled(false)),
+		branchSupportersStorage:           osFactory.New(PrefixBranchSupporters, BranchSupportersFromObjectStorage, objectstorage.CacheTime(cacheTime), objectstorage.LeakDetectionEnabled(false)),
+		solidEntryPointStorage:            osFactory.New(PrefixSolidEntryPoints, SolidEntryPointFromObjectStorage, objectstorage.CacheTime(cacheTime), objectstorage.LeakDetectionEnabled(false)),
+		issuerSequenceNumberStorage:       osFactory.New(PrefixIssuerSequenceNumberMapping, IssuerSequenceNumberMappingFromObjectStorage, objectstorage.CacheTime(cacheTime), objectstorage.PartitionKey(ed25519.PublicKeySize, marshalutil.Uint64Size), objectstorage.LeakDetectionEnabled(false)),
+		equivocationEvidenceStorage:       osFactory.New(PrefixEquivocationEvidence, EquivocationEvidenceFromObjectStorage, objectstorage.CacheTime(cacheTime), objectstorage.PartitionKey(ed25519.PublicKeySize, marshalutil.Uint64Size), objectstorage.LeakDetectionEnabled(false)),
+		blockedIssuerStorage:              osFactory.New(PrefixBlockedIssuers, BlockedIssuerFromObjectStorage, objectstorage.CacheTime(cacheTime), objectstorage.LeakDetectionEnabled(false)),
 
 		Events: &StorageEvents{
 			MessageStored:        events.NewEvent(messageIDEventHandler),
@@ -256,32 +287,184 @@ func (s *Storage) MarkerIndexBranchIDMapping(sequenceID markers.SequenceID, comp
 	return &CachedMarkerIndexBranchIDMapping{CachedObject: s.messageMetadataStorage.Load(sequenceID.Bytes())}
 }
 
-func (s *Storage) storeGenesis() {
-	s.MessageMetadata(EmptyMessageID, func() *MessageMetadata {
-		genesisMetadata := &MessageMetadata{
-			messageID: EmptyMessageID,
-			solid:     true,
-			branchID:  ledgerstate.MasterBranchID,
-			structureDetails: &markers.StructureDetails{
-				Rank:          0,
-				IsPastMarker:  false,
-				PastMarkers:   markers.NewMarkers(),
-				FutureMarkers: markers.NewMarkers(),
-			},
-			timestampOpinion: TimestampOpinion{
-				Value: opinion.Like,
-				LoK:   Three,
-			},
-			booked:   true,
-			eligible: true,
+// SequenceSupporters retrieves the SequenceSupporters for the given SequenceID. It accepts an optional computeIfAbsent
+// callback that can be used to dynamically create the SequenceSupporters if they don't exist, yet.
+func (s *Storage) SequenceSupporters(sequenceID markers.SequenceID, computeIfAbsentCallback ...func(sequenceID markers.SequenceID) *SequenceSupporters) *CachedSequenceSupporters {
+	if len(computeIfAbsentCallback) >= 1 {
+		return &CachedSequenceSupporters{s.sequenceSupportersStorage.ComputeIfAbsent(sequenceID.Bytes(), func(key []byte) objectstorage.StorableObject {
+			return computeIfAbsentCallback[0](sequenceID)
+		})}
+	}
+
+	return &CachedSequenceSupporters{CachedObject: s.sequenceSupportersStorage.Load(sequenceID.Bytes())}
+}
+
+// BranchSupporters retrieves the BranchSupporters for the given BranchID. It accepts an optional computeIfAbsent
+// callback that can be used to dynamically create the BranchSupporters if they don't exist, yet.
+func (s *Storage) BranchSupporters(branchID ledgerstate.BranchID, computeIfAbsentCallback ...func(branchID ledgerstate.BranchID) *BranchSupporters) *CachedBranchSupporters {
+	if len(computeIfAbsentCallback) >= 1 {
+		return &CachedBranchSupporters{s.branchSupportersStorage.ComputeIfAbsent(branchID.Bytes(), func(key []byte) objectstorage.StorableObject {
+			return computeIfAbsentCallback[0](branchID)
+		})}
+	}
+
+	return &CachedBranchSupporters{CachedObject: s.branchSupportersStorage.Load(branchID.Bytes())}
+}
+
+// StoreSolidEntryPoint marks the given MessageID as a solid entry point. Solid entry points are treated like the genesis:
+// they are considered to be solid, booked and eligible, even if the Message itself is not known (i.e. because it was
+// pruned or because the node was bootstrapped from a local snapshot). Solid entry points that are stored again (i.e. by
+// a later snapshot) are left untouched.
+func (s *Storage) StoreSolidEntryPoint(messageID MessageID) {
+	if cachedSolidEntryPoint, stored := s.solidEntryPointStorage.StoreIfAbsent(NewSolidEntryPoint(messageID)); stored {
+		cachedSolidEntryPoint.Release()
+	}
+	s.MessageMetadata(messageID, func() *MessageMetadata {
+		return newSolidEntryPointMetadata(messageID)
+	}).Release()
+}
+
+// IsSolidEntryPoint returns true if the given MessageID is the genesis or a solid entry point.
+func (s *Storage) IsSolidEntryPoint(messageID MessageID) bool {
+	return messageID == EmptyMessageID || s.solidEntryPointStorage.Contains(messageID.Bytes())
+}
+
+// SolidEntryPoints returns the MessageIDs of all the solid entry points.
+func (s *Storage) SolidEntryPoints() (solidEntryPoints MessageIDs) {
+	solidEntryPoints = make(MessageIDs, 0)
+	s.solidEntryPointStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		cachedObject.Consume(func(object objectstorage.StorableObject) {
+			solidEntryPoints = append(solidEntryPoints, object.(*SolidEntryPoint).MessageID())
+		})
+		return true
+	})
+
+	return
+}
+
+// StoreIssuerSequenceNumberMapping stores the given IssuerSequenceNumberMapping if no Message with the same issuer and
+// sequence number has been indexed before. It returns the stored or the previously indexed mapping.
+func (s *Storage) StoreIssuerSequenceNumberMapping(mapping *IssuerSequenceNumberMapping) (cachedMapping *CachedIssuerSequenceNumberMapping, stored bool) {
+	cachedMapping = &CachedIssuerSequenceNumberMapping{CachedObject: s.issuerSequenceNumberStorage.ComputeIfAbsent(mapping.ObjectStorageKey(), func(key []byte) objectstorage.StorableObject {
+		stored = true
+
+		return mapping
+	})}
+
+	return
+}
+
+// IssuerSequenceNumberMapping retrieves the IssuerSequenceNumberMapping of the given issuer and sequence number.
+func (s *Storage) IssuerSequenceNumberMapping(issuerPublicKey ed25519.PublicKey, sequenceNumber uint64) *CachedIssuerSequenceNumberMapping {
+	return &CachedIssuerSequenceNumberMapping{CachedObject: s.issuerSequenceNumberStorage.Load(issuerSequenceNumberKey(issuerPublicKey, sequenceNumber))}
+}
+
+// StoreEquivocationEvidence stores the given EquivocationEvidence and returns true if no evidence for the same issuer
+// and sequence number has been stored before.
+func (s *Storage) StoreEquivocationEvidence(evidence *EquivocationEvidence) (stored bool) {
+	cachedEvidence, stored := s.equivocationEvidenceStorage.StoreIfAbsent(evidence)
+	if stored {
+		cachedEvidence.Release()
+	}
+
+	return
+}
+
+// EquivocationEvidence retrieves the EquivocationEvidence of the given issuer and sequence number.
+func (s *Storage) EquivocationEvidence(issuerPublicKey ed25519.PublicKey, sequenceNumber uint64) *CachedEquivocationEvidence {
+	return &CachedEquivocationEvidence{CachedObject: s.equivocationEvidenceStorage.Load(issuerSequenceNumberKey(issuerPublicKey, sequenceNumber))}
+}
+
+// ForEachEquivocationEvidence iterates over all the stored EquivocationEvidence.
+func (s *Storage) ForEachEquivocationEvidence(consumer func(evidence *EquivocationEvidence)) {
+	s.equivocationEvidenceStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedEquivocationEvidence{CachedObject: cachedObject}).Consume(consumer)
+		return true
+	})
+}
+
+// StoreBlockedIssuer stores the given BlockedIssuer and returns true if the issuer was not blocked before.
+func (s *Storage) StoreBlockedIssuer(blockedIssuer *BlockedIssuer) (stored bool) {
+	cachedBlockedIssuer, stored := s.blockedIssuerStorage.StoreIfAbsent(blockedIssuer)
+	if stored {
+		cachedBlockedIssuer.Release()
+	}
+
+	return
+}
+
+// DeleteBlockedIssuer removes the given issuer from the stored BlockedIssuers and returns true if it was blocked.
+func (s *Storage) DeleteBlockedIssuer(issuerPublicKey ed25519.PublicKey) (deleted bool) {
+	return s.blockedIssuerStorage.DeleteIfPresent(issuerPublicKey.Bytes())
+}
+
+// ForEachBlockedIssuer iterates over all the stored BlockedIssuers.
+func (s *Storage) ForEachBlockedIssuer(consumer func(blockedIssuer *BlockedIssuer)) {
+	s.blockedIssuerStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedBlockedIssuer{CachedObject: cachedObject}).Consume(consumer)
+		return true
+	})
+}
+
+// PruneMessage deletes the given Message together with its metadata, its Approvers and its Attachments. Transactions
+// that were attached to the pruned Message are considered to be attached to the genesis afterwards.
+func (s *Storage) PruneMessage(messageID MessageID) {
+	s.Approvers(messageID).Consume(func(approver *Approver) {
+		approver.Delete()
+	})
+
+	s.Message(messageID).Consume(func(message *Message) {
+		// the sequence number of an equivocating issuer might be indexed for a different Message
+		s.IssuerSequenceNumberMapping(message.IssuerPublicKey(), message.SequenceNumber()).Consume(func(mapping *IssuerSequenceNumberMapping) {
+			if mapping.MessageID() == messageID {
+				mapping.Delete()
+			}
+		})
+
+		if payload := message.Payload(); payload != nil && payload.Type() == ledgerstate.TransactionType {
+			transactionID := payload.(*ledgerstate.Transaction).ID()
+			s.attachmentStorage.Delete(NewAttachment(transactionID, messageID).ObjectStorageKey())
+			if cachedAttachment, stored := s.StoreAttachment(transactionID, EmptyMessageID); stored {
+				cachedAttachment.Release()
+			}
 		}
+	})
 
-		genesisMetadata.Persist()
-		genesisMetadata.SetModified()
+	s.solidEntryPointStorage.Delete(messageID.Bytes())
+	s.DeleteMissingMessage(messageID)
+	s.DeleteMessage(messageID)
+}
 
-		return genesisMetadata
+func (s *Storage) storeGenesis() {
+	s.MessageMetadata(EmptyMessageID, func() *MessageMetadata {
+		return newSolidEntryPointMetadata(EmptyMessageID)
 	}).Release()
+}
+
+// newSolidEntryPointMetadata creates the MessageMetadata of the genesis or a solid entry point.
+func newSolidEntryPointMetadata(messageID MessageID) (solidEntryPointMetadata *MessageMetadata) {
+	solidEntryPointMetadata = &MessageMetadata{
+		messageID: messageID,
+		solid:     true,
+		branchID:  ledgerstate.MasterBranchID,
+		structureDetails: &markers.StructureDetails{
+			Rank:          0,
+			IsPastMarker:  false,
+			PastMarkers:   markers.NewMarkers(),
+			FutureMarkers: markers.NewMarkers(),
+		},
+		timestampOpinion: TimestampOpinion{
+			Value: opinion.Like,
+			LoK:   Three,
+		},
+		booked:   true,
+		eligible: true,
+	}
 
+	solidEntryPointMetadata.Persist()
+	solidEntryPointMetadata.SetModified()
+
+	return
 }
 
 // deleteStrongApprover deletes an Approver from the object storage that was created by a strong parent.
@@ -301,6 +484,12 @@ func (s *Storage) Shutdown() {
 	s.approverStorage.Shutdown()
 	s.missingMessageStorage.Shutdown()
 	s.attachmentStorage.Shutdown()
+	s.sequenceSupportersStorage.Shutdown()
+	s.branchSupportersStorage.Shutdown()
+	s.solidEntryPointStorage.Shutdown()
+	s.issuerSequenceNumberStorage.Shutdown()
+	s.equivocationEvidenceStorage.Shutdown()
+	s.blockedIssuerStorage.Shutdown()
 
 	close(s.shutdown)
 }
@@ -313,6 +502,12 @@ func (s *Storage) Prune() error {
 		s.approverStorage,
 		s.missingMessageStorage,
 		s.attachmentStorage,
+		s.sequenceSupportersStorage,
+		s.branchSupportersStorage,
+		s.solidEntryPointStorage,
+		s.issuerSequenceNumberStorage,
+		s.equivocationEvidenceStorage,
+		s.blockedIssuerStorage,
 	} {
 		if err := storage.Prune(); err != nil {
 			err = fmt.Errorf("failed to prune storage: %w", err)
@@ -910,6 +1105,87 @@ func (m *MissingMessage) ObjectStorageValue() (result []byte) {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region SolidEntryPoint //////////////////////////////////////////////////////////////////////////////////////////////
+
+// SolidEntryPoint represents a Message that is treated like the genesis, so that the Messages in its past cone can be
+// pruned.
+type SolidEntryPoint struct {
+	objectstorage.StorableObjectFlags
+
+	messageID MessageID
+}
+
+// NewSolidEntryPoint creates a new SolidEntryPoint for the given MessageID.
+func NewSolidEntryPoint(messageID MessageID) *SolidEntryPoint {
+	return &SolidEntryPoint{
+		messageID: messageID,
+	}
+}
+
+// SolidEntryPointFromBytes parses the given bytes into a SolidEntryPoint.
+func SolidEntryPointFromBytes(bytes []byte) (result *SolidEntryPoint, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	result, err = SolidEntryPointFromMarshalUtil(marshalUtil)
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// SolidEntryPointFromMarshalUtil parses a SolidEntryPoint from the given MarshalUtil.
+func SolidEntryPointFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (result *SolidEntryPoint, err error) {
+	result = &SolidEntryPoint{}
+
+	if result.messageID, err = MessageIDFromMarshalUtil(marshalUtil); err != nil {
+		err = fmt.Errorf("failed to parse message ID of solid entry point: %w", err)
+		return
+	}
+
+	return
+}
+
+// SolidEntryPointFromObjectStorage restores a SolidEntryPoint from the ObjectStorage.
+func SolidEntryPointFromObjectStorage(key []byte, data []byte) (result objectstorage.StorableObject, err error) {
+	result, _, err = SolidEntryPointFromBytes(byteutils.ConcatBytes(key, data))
+	if err != nil {
+		err = fmt.Errorf("failed to parse solid entry point from object storage: %w", err)
+		return
+	}
+
+	return
+}
+
+// MessageID returns the id of the message.
+func (s *SolidEntryPoint) MessageID() MessageID {
+	return s.messageID
+}
+
+// Bytes returns a marshaled version of this SolidEntryPoint.
+func (s *SolidEntryPoint) Bytes() []byte {
+	return byteutils.ConcatBytes(s.ObjectStorageKey(), s.ObjectStorageValue())
+}
+
+// Update update the solid entry point.
+// It should never happen and will panic if called.
+func (s *SolidEntryPoint) Update(other objectstorage.StorableObject) {
+	panic("solid entry points should never be overwritten and only stored once to optimize IO")
+}
+
+// ObjectStorageKey returns the key of the stored solid entry point.
+// This returns the bytes of the messageID of the solid entry point.
+func (s *SolidEntryPoint) ObjectStorageKey() []byte {
+	return s.messageID[:]
+}
+
+// ObjectStorageValue returns the value of the stored solid entry point.
+func (s *SolidEntryPoint) ObjectStorageValue() (result []byte) {
+	return nil
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &SolidEntryPoint{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region CachedMissingMessage /////////////////////////////////////////////////////////////////////////////////////////
 
 // CachedMissingMessage is a wrapper for the generic CachedObject returned by the object storage that overrides the
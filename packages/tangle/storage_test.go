@@ -51,3 +51,16 @@ func TestStorage_Attachments(t *testing.T) {
 	}
 
 }
+
+func TestStorage_StoreSolidEntryPoint(t *testing.T) {
+	tangle := New()
+	defer tangle.Shutdown()
+
+	// storing the same solid entry point twice (i.e. in two consecutive snapshots) does not overwrite it
+	solidEntryPoint := randomMessageID()
+	tangle.Storage.StoreSolidEntryPoint(solidEntryPoint)
+	tangle.Storage.StoreSolidEntryPoint(solidEntryPoint)
+
+	assert.True(t, tangle.Storage.IsSolidEntryPoint(solidEntryPoint))
+	assert.Equal(t, MessageIDs{solidEntryPoint}, tangle.Storage.SolidEntryPoints())
+}
@@ -0,0 +1,86 @@
+package tangle
+
+import (
+	"sync"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/events"
+)
+
+// region SupplyAuditor ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SupplyAuditor is a Tangle component that audits the token supply of the confirmed ledger state. It is the last line
+// of defense against bugs in the ledger that silently create or destroy tokens.
+type SupplyAuditor struct {
+	// Events is a dictionary for the SupplyAuditor related Events.
+	Events *SupplyAuditorEvents
+
+	tangle      *Tangle
+	lastAudit   *ledgerstate.SupplyAudit
+	auditMutex  sync.Mutex
+	resultMutex sync.RWMutex
+}
+
+// NewSupplyAuditor is the constructor of the SupplyAuditor.
+func NewSupplyAuditor(tangle *Tangle) (supplyAuditor *SupplyAuditor) {
+	supplyAuditor = &SupplyAuditor{
+		Events: &SupplyAuditorEvents{
+			SupplyAudited:  events.NewEvent(supplyAuditEventHandler),
+			SupplyDiverged: events.NewEvent(supplyAuditEventHandler),
+		},
+		tangle: tangle,
+	}
+
+	return
+}
+
+// Audit audits the token supply of the confirmed ledger state and triggers a SupplyDiverged event if it violates the
+// invariants of the ledger. Since Transactions can be confirmed while the ledger is walked, a divergent audit is
+// repeated once and only reported if the divergence persists.
+func (s *SupplyAuditor) Audit() (supplyAudit *ledgerstate.SupplyAudit) {
+	s.auditMutex.Lock()
+	defer s.auditMutex.Unlock()
+
+	if supplyAudit = s.tangle.LedgerState.AuditSupply(); supplyAudit.Divergent() {
+		supplyAudit = s.tangle.LedgerState.AuditSupply()
+	}
+
+	s.resultMutex.Lock()
+	s.lastAudit = supplyAudit
+	s.resultMutex.Unlock()
+
+	s.Events.SupplyAudited.Trigger(supplyAudit)
+	if supplyAudit.Divergent() {
+		s.Events.SupplyDiverged.Trigger(supplyAudit)
+	}
+
+	return
+}
+
+// LastAudit returns the result of the most recent audit (or nil if no audit was performed, yet).
+func (s *SupplyAuditor) LastAudit() *ledgerstate.SupplyAudit {
+	s.resultMutex.RLock()
+	defer s.resultMutex.RUnlock()
+
+	return s.lastAudit
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SupplyAuditorEvents //////////////////////////////////////////////////////////////////////////////////////////
+
+// SupplyAuditorEvents represents events happening in the SupplyAuditor.
+type SupplyAuditorEvents struct {
+	// SupplyAudited is triggered after every audit of the token supply.
+	SupplyAudited *events.Event
+
+	// SupplyDiverged is triggered when the audited token supply violates the invariants of the ledger. It indicates a
+	// critical bug that needs to be investigated immediately.
+	SupplyDiverged *events.Event
+}
+
+func supplyAuditEventHandler(handler interface{}, params ...interface{}) {
+	handler.(func(*ledgerstate.SupplyAudit))(params[0].(*ledgerstate.SupplyAudit))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
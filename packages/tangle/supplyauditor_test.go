@@ -0,0 +1,61 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupplyAuditor(t *testing.T) {
+	tangle := New(WithoutOpinionFormer(true))
+	defer tangle.Shutdown()
+
+	wallets := createWallets(2)
+	genesisOutput := ledgerstate.NewSigLockedSingleOutput(100, wallets[0].address)
+	genesisOutput.SetID(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 1))
+	require.NoError(t, tangle.LedgerState.LoadSnapshotOutput(genesisOutput, snapshotOutputMetadata(genesisOutput)))
+
+	txEssence := ledgerstate.NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, ledgerstate.NewInputs(ledgerstate.NewUTXOInput(genesisOutput.ID())), ledgerstate.NewOutputs(ledgerstate.NewSigLockedSingleOutput(100, wallets[1].address)))
+	tx := ledgerstate.NewTransaction(txEssence, wallets[0].unlockBlocks(txEssence))
+	_, err := tangle.LedgerState.utxoDAG.BookTransaction(tx)
+	require.NoError(t, err)
+	tangle.LedgerState.TransactionMetadata(tx.ID()).Consume(func(transactionMetadata *ledgerstate.TransactionMetadata) {
+		transactionMetadata.SetFinalized(true)
+	})
+
+	divergedAudits := make([]*ledgerstate.SupplyAudit, 0)
+	tangle.SupplyAuditor.Events.SupplyDiverged.Attach(events.NewClosure(func(supplyAudit *ledgerstate.SupplyAudit) {
+		divergedAudits = append(divergedAudits, supplyAudit)
+	}))
+
+	supplyAudit := tangle.SupplyAuditor.Audit()
+	assert.False(t, supplyAudit.Divergent())
+	assert.Equal(t, uint64(100), supplyAudit.TotalSupply())
+	assert.Equal(t, supplyAudit, tangle.SupplyAuditor.LastAudit())
+	assert.Empty(t, divergedAudits)
+
+	// an additional Output of the confirmed Transaction creates tokens out of thin air
+	forgedOutput := ledgerstate.NewSigLockedSingleOutput(10, wallets[1].address)
+	forgedOutput.SetID(ledgerstate.NewOutputID(tx.ID(), 1))
+	require.NoError(t, tangle.LedgerState.LoadSnapshotOutput(forgedOutput, snapshotOutputMetadata(forgedOutput)))
+
+	supplyAudit = tangle.SupplyAuditor.Audit()
+	assert.True(t, supplyAudit.Divergent())
+	assert.Equal(t, uint64(110), supplyAudit.TotalSupply())
+	assert.Equal(t, uint64(100), supplyAudit.ExpectedTotalSupply())
+	assert.Equal(t, []*ledgerstate.SupplyAudit{supplyAudit}, divergedAudits)
+}
+
+func snapshotOutputMetadata(output ledgerstate.Output) (outputMetadata *ledgerstate.OutputMetadata) {
+	outputMetadata = ledgerstate.NewOutputMetadata(output.ID())
+	outputMetadata.SetBranchID(ledgerstate.MasterBranchID)
+	outputMetadata.SetSolid(true)
+	outputMetadata.SetFinalized(true)
+
+	return
+}
@@ -2,7 +2,9 @@ package tangle
 
 import (
 	"sync"
+	"time"
 
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/markers"
 	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/events"
@@ -16,22 +18,31 @@ import (
 
 // Tangle is the central data structure of the IOTA protocol.
 type Tangle struct {
-	Parser         *Parser
-	Storage        *Storage
-	Solidifier     *Solidifier
-	Scheduler      *Scheduler
-	Booker         *Booker
-	TipManager     *TipManager
-	Requester      *Requester
-	MessageFactory *MessageFactory
-	LedgerState    *LedgerState
-	Utils          *Utils
-	Options        *Options
-	Events         *Events
+	Parser                 *Parser
+	Storage                *Storage
+	Solidifier             *Solidifier
+	Scheduler              *Scheduler
+	RateSetter             *RateSetter
+	Booker                 *Booker
+	ApprovalWeightManager  *ApprovalWeightManager
+	TipManager             *TipManager
+	SnapshotManager        *SnapshotManager
+	OrphanageTracker       *OrphanageTracker
+	SupplyAuditor          *SupplyAuditor
+	BranchLifecycleManager *BranchLifecycleManager
+	InclusionStateNotifier *InclusionStateNotifier
+	IssuerBlocklist        *IssuerBlocklist
+	EquivocationFilter     *EquivocationFilter
+	Requester              *Requester
+	MessageFactory         *MessageFactory
+	LedgerState            *LedgerState
+	Utils                  *Utils
+	Options                *Options
+	Events                 *Events
 
 	OpinionFormer            *OpinionFormer
 	PayloadOpinionProvider   OpinionVoterProvider
-	TimestampOpinionProvider OpinionProvider
+	TimestampOpinionProvider TimestampOpinionVoterProvider
 
 	setupParserOnce sync.Once
 }
@@ -41,26 +52,39 @@ func New(options ...Option) (tangle *Tangle) {
 	tangle = &Tangle{
 		Options: buildOptions(options...),
 		Events: &Events{
-			MessageEligible: events.NewEvent(messageIDEventHandler),
-			MessageInvalid:  events.NewEvent(messageIDEventHandler),
-			Error:           events.NewEvent(events.ErrorCaller),
+			MessageEligible:   events.NewEvent(messageIDEventHandler),
+			MessageInvalid:    events.NewEvent(messageIDEventHandler),
+			Error:             events.NewEvent(events.ErrorCaller),
+			IssuerEquivocated: events.NewEvent(equivocationEvidenceEventHandler),
 		},
 	}
 
 	tangle.Parser = NewParser()
 	tangle.Storage = NewStorage(tangle)
+	tangle.IssuerBlocklist = NewIssuerBlocklist(tangle)
+	tangle.Parser.AddMessageFilter(tangle.IssuerBlocklist)
+	tangle.Parser.AddMessageFilter(NewIssuerRateLimitFilter(tangle))
+	tangle.EquivocationFilter = NewEquivocationFilter(tangle)
+	tangle.Parser.AddMessageFilter(tangle.EquivocationFilter)
 	tangle.Solidifier = NewSolidifier(tangle)
 	tangle.Scheduler = NewScheduler(tangle)
+	tangle.RateSetter = NewRateSetter(tangle)
 	tangle.LedgerState = NewLedgerState(tangle)
 	tangle.Booker = NewBooker(tangle)
+	tangle.ApprovalWeightManager = NewApprovalWeightManager(tangle)
 	tangle.Requester = NewRequester(tangle)
 	tangle.TipManager = NewTipManager(tangle)
+	tangle.SnapshotManager = NewSnapshotManager(tangle)
+	tangle.OrphanageTracker = NewOrphanageTracker(tangle)
+	tangle.SupplyAuditor = NewSupplyAuditor(tangle)
+	tangle.BranchLifecycleManager = NewBranchLifecycleManager(tangle)
+	tangle.InclusionStateNotifier = NewInclusionStateNotifier(tangle)
 	tangle.MessageFactory = NewMessageFactory(tangle, tangle.TipManager)
 	tangle.Utils = NewUtils(tangle)
 
 	if !tangle.Options.WithoutOpinionFormer {
 		tangle.PayloadOpinionProvider = NewFCoB(tangle.Options.Store, tangle)
-		tangle.TimestampOpinionProvider = NewTimestampLikedByDefault(tangle)
+		tangle.TimestampOpinionProvider = NewTimestampManager(tangle)
 		tangle.OpinionFormer = NewOpinionFormer(tangle, tangle.PayloadOpinionProvider, tangle.TimestampOpinionProvider)
 	}
 	return
@@ -69,10 +93,16 @@ func New(options ...Option) (tangle *Tangle) {
 // Setup sets up the data flow by connecting the different components (by calling their corresponding Setup method).
 func (t *Tangle) Setup() {
 	t.Storage.Setup()
+	t.EquivocationFilter.Setup()
 	t.Solidifier.Setup()
 	t.Requester.Setup()
 	t.Scheduler.Setup()
+	t.RateSetter.Setup()
 	t.Booker.Setup()
+	t.ApprovalWeightManager.Setup()
+	t.OrphanageTracker.Setup()
+	t.BranchLifecycleManager.Setup()
+	t.InclusionStateNotifier.Setup()
 
 	// Booker and LedgerState setup is left out until the old value tangle is in use.
 	if !t.Options.WithoutOpinionFormer {
@@ -104,6 +134,10 @@ func (t *Tangle) Shutdown() {
 	if !t.Options.WithoutOpinionFormer {
 		t.OpinionFormer.Shutdown()
 	}
+	t.SnapshotManager.Shutdown()
+	t.InclusionStateNotifier.Shutdown()
+	t.TipManager.Shutdown()
+	t.OrphanageTracker.Shutdown()
 	t.Booker.Shutdown()
 	t.LedgerState.Shutdown()
 	t.Scheduler.Shutdown()
@@ -125,6 +159,10 @@ type Events struct {
 
 	// Error is triggered when the Tangle faces an error from which it can not recover.
 	Error *events.Event
+
+	// IssuerEquivocated is triggered when an issuer is caught signing two different Messages with the same sequence
+	// number.
+	IssuerEquivocated *events.Event
 }
 
 func messageIDEventHandler(handler interface{}, params ...interface{}) {
@@ -146,6 +184,16 @@ type Options struct {
 	WithoutOpinionFormer         bool
 	IncreaseMarkersIndexCallback markers.IncreaseIndexCallback
 	TangleWidth                  int
+	SchedulerParams              SchedulerParams
+	RateSetterParams             RateSetterParams
+	WeightProvider               WeightProvider
+	ApprovalWeightThreshold      float64
+	TipSelectionStrategy         TipSelectionStrategy
+	TipMaxAge                    time.Duration
+	OrphanageThreshold           time.Duration
+	BlacklistEquivocatingIssuers bool
+	IssuerRateLimitParams        IssuerRateLimitParams
+	DustProtectionParams         ledgerstate.DustProtectionParams
 }
 
 // buildOptions generates the Options object use by the Tangle.
@@ -154,6 +202,28 @@ func buildOptions(options ...Option) (builtOptions *Options) {
 		Store:                        mapdb.NewMapDB(),
 		Identity:                     identity.GenerateLocalIdentity(),
 		IncreaseMarkersIndexCallback: increaseMarkersIndexCallbackStrategy,
+		SchedulerParams: SchedulerParams{
+			Rate:             DefaultSchedulerRate,
+			MaxBufferSize:    DefaultSchedulerMaxBufferSize,
+			IssuerWeightFunc: ConstantIssuerWeight,
+		},
+		RateSetterParams: RateSetterParams{
+			Enabled:           true,
+			InitialRate:       DefaultRateSetterInitialRate,
+			MinRate:           DefaultRateSetterMinRate,
+			Increase:          DefaultRateSetterIncrease,
+			Decrease:          DefaultRateSetterDecrease,
+			OwnQueueThreshold: DefaultRateSetterOwnQueueThreshold,
+			MaxOwnQueueSize:   DefaultRateSetterMaxOwnQueueSize,
+		},
+		WeightProvider:          NewManaWeightProvider(nil, DefaultWeightProviderActivityWindow),
+		ApprovalWeightThreshold: DefaultApprovalWeightThreshold,
+		TipSelectionStrategy:    NewUniformRandomTipSelection(),
+		TipMaxAge:               DefaultTipMaxAge,
+		OrphanageThreshold:      DefaultOrphanageThreshold,
+		IssuerRateLimitParams: IssuerRateLimitParams{
+			Window: DefaultIssuerRateLimitWindow,
+		},
 	}
 
 	for _, option := range options {
@@ -199,4 +269,104 @@ func TangleWidth(width int) Option {
 	}
 }
 
+// SchedulerConfig is an Option for the Tangle that allows to change the parameters of the Scheduler. Parameters that
+// are not set (or not positive) fall back to their defaults.
+func SchedulerConfig(params SchedulerParams) Option {
+	return func(options *Options) {
+		if params.Rate <= 0 {
+			params.Rate = DefaultSchedulerRate
+		}
+		if params.MaxBufferSize <= 0 {
+			params.MaxBufferSize = DefaultSchedulerMaxBufferSize
+		}
+		if params.IssuerWeightFunc == nil {
+			params.IssuerWeightFunc = ConstantIssuerWeight
+		}
+
+		options.SchedulerParams = params
+	}
+}
+
+// RateSetterConfig is an Option for the Tangle that allows to change the parameters of the RateSetter.
+func RateSetterConfig(params RateSetterParams) Option {
+	return func(options *Options) {
+		options.RateSetterParams = params
+	}
+}
+
+// ApprovalWeightProvider is an Option for the Tangle that allows to change the WeightProvider that is used by the
+// ApprovalWeightManager to determine the weight of the issuers.
+func ApprovalWeightProvider(weightProvider WeightProvider) Option {
+	return func(options *Options) {
+		options.WeightProvider = weightProvider
+	}
+}
+
+// ApprovalWeightThreshold is an Option for the Tangle that allows to change the share of the total weight that needs to
+// approve a Marker or a Branch for it to be confirmed.
+func ApprovalWeightThreshold(threshold float64) Option {
+	return func(options *Options) {
+		options.ApprovalWeightThreshold = threshold
+	}
+}
+
+// TipSelection is an Option for the Tangle that allows to change the TipSelectionStrategy that is used by the
+// TipManager to select strong tips.
+func TipSelection(strategy TipSelectionStrategy) Option {
+	return func(options *Options) {
+		options.TipSelectionStrategy = strategy
+	}
+}
+
+// TipMaxAge is an Option for the Tangle that allows to change the age after which tips are pruned by the TipManager. A
+// value of 0 disables the pruning of expired tips.
+func TipMaxAge(maxAge time.Duration) Option {
+	return func(options *Options) {
+		options.TipMaxAge = maxAge
+	}
+}
+
+// OrphanageThreshold is an Option for the Tangle that allows to change the time after which a Message without
+// approvers is considered to be orphaned.
+func OrphanageThreshold(threshold time.Duration) Option {
+	return func(options *Options) {
+		options.OrphanageThreshold = threshold
+	}
+}
+
+// BlacklistEquivocatingIssuers is an Option for the Tangle that allows to add issuers that were caught signing two
+// different Messages with the same sequence number to the IssuerBlocklist, which makes the Parser reject all of their
+// Messages.
+func BlacklistEquivocatingIssuers(enabled bool) Option {
+	return func(options *Options) {
+		options.BlacklistEquivocatingIssuers = enabled
+	}
+}
+
+// IssuerRateLimit is an Option for the Tangle that allows to limit the amount of Messages that are accepted from a
+// single issuer within a sliding time window.
+func IssuerRateLimit(params IssuerRateLimitParams) Option {
+	return func(options *Options) {
+		options.IssuerRateLimitParams = params
+	}
+}
+
+// DustProtection is an Option for the Tangle that enables the rules of the ledger state that limit the creation of
+// Outputs with tiny balances.
+func DustProtection(params ledgerstate.DustProtectionParams) Option {
+	return func(options *Options) {
+		options.DustProtectionParams = params
+	}
+}
+
+// SchedulerParams defines the parameters of the Scheduler.
+type SchedulerParams struct {
+	// Rate is the time interval between two scheduled messages.
+	Rate time.Duration
+	// MaxBufferSize is the maximum amount of bytes that are buffered before messages get dropped.
+	MaxBufferSize int
+	// IssuerWeightFunc returns the weight that determines the quantum of an issuer.
+	IssuerWeightFunc IssuerWeightFunc
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -154,7 +154,7 @@ func TestTangle_MissingMessages(t *testing.T) {
 	require.NoError(t, err)
 
 	// create the tangle
-	tangle := New(Store(badgerDB), WithoutOpinionFormer(true))
+	tangle := New(Store(badgerDB), WithoutOpinionFormer(true), RateSetterConfig(RateSetterParams{Enabled: false}))
 	defer tangle.Shutdown()
 	require.NoError(t, tangle.Prune())
 
@@ -304,7 +304,7 @@ func TestTangle_Flow(t *testing.T) {
 	tips.Set(EmptyMessageID, EmptyMessageID)
 
 	// create the tangle
-	tangle := New(Store(badgerDB))
+	tangle := New(Store(badgerDB), RateSetterConfig(RateSetterParams{Enabled: false}))
 	defer tangle.Shutdown()
 
 	// create local peer
@@ -0,0 +1,142 @@
+package tangle
+
+import (
+	"github.com/iotaledger/goshimmer/packages/vote"
+	voter "github.com/iotaledger/goshimmer/packages/vote/opinion"
+	"github.com/iotaledger/hive.go/events"
+)
+
+// region TimestampOpinionVoterProvider ////////////////////////////////////////////////////////////////////////////////
+
+// TimestampOpinionVoterProvider is the interface to describe the functionalities of a timestamp opinion provider that
+// hands over the timestamps that it can not decide on its own to an external voter.
+type TimestampOpinionVoterProvider interface {
+	// OpinionProvider is the interface to describe the functionalities of an opinion provider.
+	OpinionProvider
+	// Vote trigger a voting request.
+	Vote() *events.Event
+	// VoteError notify an error coming from the result of voting.
+	VoteError() *events.Event
+	// ProcessVote allows an external voter to hand in the results of the voting process.
+	ProcessVote(*vote.OpinionEvent)
+	// TimestampOpinion returns the TimestampOpinion of the given messageID.
+	TimestampOpinion(MessageID) TimestampOpinion
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region TimestampManager /////////////////////////////////////////////////////////////////////////////////////////////
+
+// TimestampManager is the opinion provider that judges the timestamps of the Messages by comparing their issuing time
+// with their arrival time. Timestamps that are close to the border of the TimestampWindow (level of knowledge One) are
+// handed over to the voter as timestamp vote contexts.
+type TimestampManager struct {
+	Events *TimestampManagerEvents
+
+	tangle *Tangle
+}
+
+// NewTimestampManager returns a new instance of the TimestampManager.
+func NewTimestampManager(tangle *Tangle) (timestampManager *TimestampManager) {
+	timestampManager = &TimestampManager{
+		tangle: tangle,
+		Events: &TimestampManagerEvents{
+			Error: events.NewEvent(events.ErrorCaller),
+			Vote:  events.NewEvent(voteEvent),
+		},
+	}
+
+	return
+}
+
+// Setup sets up the behavior of the component by making it attach to the relevant events of the other components.
+// It is required to satisfy the OpinionProvider interface.
+func (t *TimestampManager) Setup(timestampEvent *events.Event) {
+	t.Events.TimestampOpinionFormed = timestampEvent
+}
+
+// Shutdown shuts down component and persists its state. It is required to satisfy the OpinionProvider interface.
+func (t *TimestampManager) Shutdown() {}
+
+// Vote trigger a voting request.
+func (t *TimestampManager) Vote() *events.Event {
+	return t.Events.Vote
+}
+
+// VoteError notify an error coming from the result of voting.
+func (t *TimestampManager) VoteError() *events.Event {
+	return t.Events.Error
+}
+
+// Opinion returns the liked status of the timestamp of a given messageID.
+func (t *TimestampManager) Opinion(messageID MessageID) (opinion bool) {
+	return t.TimestampOpinion(messageID).Value == voter.Like
+}
+
+// TimestampOpinion returns the TimestampOpinion of the given messageID.
+func (t *TimestampManager) TimestampOpinion(messageID MessageID) (timestampOpinion TimestampOpinion) {
+	t.tangle.Storage.MessageMetadata(messageID).Consume(func(messageMetadata *MessageMetadata) {
+		timestampOpinion = messageMetadata.TimestampOpinion()
+	})
+
+	return
+}
+
+// Evaluate evaluates the opinion of the timestamp of the given messageID.
+func (t *TimestampManager) Evaluate(messageID MessageID) {
+	t.tangle.Storage.Message(messageID).Consume(func(message *Message) {
+		t.tangle.Storage.MessageMetadata(messageID).Consume(func(messageMetadata *MessageMetadata) {
+			timestampOpinion := TimestampQuality(message.IssuingTime(), messageMetadata.ReceivedTime())
+			messageMetadata.SetTimestampOpinion(timestampOpinion)
+
+			if timestampOpinion.LoK == One {
+				t.Events.Vote.Trigger(messageID.String(), timestampOpinion.Value)
+				return
+			}
+
+			t.Events.TimestampOpinionFormed.Trigger(messageID)
+		})
+	})
+}
+
+// ProcessVote allows an external voter to hand in the results of the voting process.
+func (t *TimestampManager) ProcessVote(ev *vote.OpinionEvent) {
+	if ev.Ctx.Type != vote.TimestampType {
+		return
+	}
+
+	messageID, err := NewMessageID(ev.ID)
+	if err != nil {
+		t.Events.Error.Trigger(err)
+		return
+	}
+
+	t.tangle.Storage.MessageMetadata(messageID).Consume(func(messageMetadata *MessageMetadata) {
+		messageMetadata.SetTimestampOpinion(TimestampOpinion{
+			Value: ev.Opinion,
+			LoK:   Two,
+		})
+		t.Events.TimestampOpinionFormed.Trigger(messageID)
+	})
+}
+
+// code contract (make sure the type implements all required methods)
+var _ TimestampOpinionVoterProvider = &TimestampManager{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region TimestampManagerEvents ///////////////////////////////////////////////////////////////////////////////////////
+
+// TimestampManagerEvents defines all the events related to the TimestampManager opinion provider.
+type TimestampManagerEvents struct {
+	// Fired when an opinion of a timestamp is formed.
+	TimestampOpinionFormed *events.Event
+
+	// Error gets called when the TimestampManager faces an error.
+	Error *events.Event
+
+	// Vote gets called when the TimestampManager needs to vote.
+	Vote *events.Event
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,65 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestampManager(t *testing.T) {
+	TimestampWindow = 1 * time.Minute
+	GratuitousNetworkDelay = 15 * time.Second
+
+	tangle := New(WithoutOpinionFormer(true))
+	defer tangle.Shutdown()
+
+	timestampManager := NewTimestampManager(tangle)
+	timestampOpinionFormed := events.NewEvent(messageIDEventHandler)
+	timestampManager.Setup(timestampOpinionFormed)
+
+	formedOpinions := make(map[MessageID]int)
+	timestampOpinionFormed.Attach(events.NewClosure(func(messageID MessageID) {
+		formedOpinions[messageID]++
+	}))
+	votes := make(map[string]opinion.Opinion)
+	timestampManager.Vote().Attach(events.NewClosure(func(id string, initOpn opinion.Opinion) {
+		votes[id] = initOpn
+	}))
+
+	// a recent timestamp is liked without voting
+	recentMessage := newTestParentsDataWithTimestamp("recent", []MessageID{EmptyMessageID}, []MessageID{}, time.Now())
+	tangle.Storage.StoreMessage(recentMessage)
+	timestampManager.Evaluate(recentMessage.ID())
+	assert.Equal(t, TimestampOpinion{Value: opinion.Like, LoK: Three}, timestampManager.TimestampOpinion(recentMessage.ID()))
+	assert.True(t, timestampManager.Opinion(recentMessage.ID()))
+	assert.Equal(t, 1, formedOpinions[recentMessage.ID()])
+
+	// an old timestamp is disliked without voting
+	oldMessage := newTestParentsDataWithTimestamp("old", []MessageID{EmptyMessageID}, []MessageID{}, time.Now().Add(-TimestampWindow-3*GratuitousNetworkDelay))
+	tangle.Storage.StoreMessage(oldMessage)
+	timestampManager.Evaluate(oldMessage.ID())
+	assert.Equal(t, TimestampOpinion{Value: opinion.Dislike, LoK: Three}, timestampManager.TimestampOpinion(oldMessage.ID()))
+	assert.False(t, timestampManager.Opinion(oldMessage.ID()))
+	assert.Equal(t, 1, formedOpinions[oldMessage.ID()])
+
+	// a borderline timestamp is handed over to the voter
+	borderlineMessage := newTestParentsDataWithTimestamp("borderline", []MessageID{EmptyMessageID}, []MessageID{}, time.Now().Add(-TimestampWindow+GratuitousNetworkDelay/2))
+	tangle.Storage.StoreMessage(borderlineMessage)
+	timestampManager.Evaluate(borderlineMessage.ID())
+	assert.Equal(t, TimestampOpinion{Value: opinion.Like, LoK: One}, timestampManager.TimestampOpinion(borderlineMessage.ID()))
+	assert.Equal(t, opinion.Like, votes[borderlineMessage.ID().String()])
+	assert.Equal(t, 0, formedOpinions[borderlineMessage.ID()])
+
+	// the final decision of the voter is written back to the MessageMetadata
+	timestampManager.ProcessVote(&vote.OpinionEvent{
+		ID:      borderlineMessage.ID().String(),
+		Opinion: opinion.Dislike,
+		Ctx:     vote.Context{Type: vote.TimestampType},
+	})
+	assert.Equal(t, TimestampOpinion{Value: opinion.Dislike, LoK: Two}, timestampManager.TimestampOpinion(borderlineMessage.ID()))
+	assert.Equal(t, 1, formedOpinions[borderlineMessage.ID()])
+}
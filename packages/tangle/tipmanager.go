@@ -2,9 +2,12 @@ package tangle
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/iotaledger/goshimmer/packages/clock"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/markers"
 	"github.com/iotaledger/goshimmer/packages/tangle/payload"
 	"github.com/iotaledger/hive.go/datastructure/randommap"
 	"github.com/iotaledger/hive.go/events"
@@ -39,14 +42,57 @@ func (t TipType) String() string {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region TipRemovalReason /////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// UndefinedTipRemovalReason is the TipRemovalReason of TipEvents that are not related to the removal of a tip.
+	UndefinedTipRemovalReason TipRemovalReason = iota
+
+	// TipReferenced is the TipRemovalReason of tips that lost their tip status because they got referenced by a child.
+	TipReferenced
+
+	// TipExpired is the TipRemovalReason of tips that were pruned because they exceeded the maximum tip age.
+	TipExpired
+)
+
+// TipRemovalReason is the reason why a tip was removed from the TipManager.
+type TipRemovalReason uint8
+
+// String returns a human readable version of the TipRemovalReason.
+func (t TipRemovalReason) String() string {
+	switch t {
+	case UndefinedTipRemovalReason:
+		return "TipRemovalReason(UndefinedTipRemovalReason)"
+	case TipReferenced:
+		return "TipRemovalReason(TipReferenced)"
+	case TipExpired:
+		return "TipRemovalReason(TipExpired)"
+	default:
+		return fmt.Sprintf("TipRemovalReason(%X)", uint8(t))
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region TipManager ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-// TipManager manages a map of tips and emits events for their removal and addition.
+const (
+	// DefaultTipMaxAge defines the default age after which tips are pruned, as older tips can not be referenced anymore.
+	DefaultTipMaxAge = maxParentsTimeDifference
+
+	// tipPruningInterval defines the time interval in which the TipManager checks for expired tips.
+	tipPruningInterval = 1 * time.Minute
+)
+
+// TipManager manages a map of tips and emits events for their removal and addition. The tips are stored as a RandomMap
+// of MessageIDs to Tips.
 type TipManager struct {
-	tangle     *Tangle
-	strongTips *randommap.RandomMap
-	weakTips   *randommap.RandomMap
-	Events     *TipManagerEvents
+	tangle       *Tangle
+	strongTips   *randommap.RandomMap
+	weakTips     *randommap.RandomMap
+	Events       *TipManagerEvents
+	shutdown     chan struct{}
+	shutdownOnce sync.Once
 }
 
 // NewTipManager creates a new tip-selector.
@@ -55,6 +101,7 @@ func NewTipManager(tangle *Tangle, tips ...MessageID) *TipManager {
 		tangle:     tangle,
 		strongTips: randommap.New(),
 		weakTips:   randommap.New(),
+		shutdown:   make(chan struct{}),
 		Events: &TipManagerEvents{
 			TipAdded:   events.NewEvent(tipEventHandler),
 			TipRemoved: events.NewEvent(tipEventHandler),
@@ -73,12 +120,31 @@ func (t *TipManager) Setup() {
 	t.tangle.OpinionFormer.Events.MessageOpinionFormed.Attach(events.NewClosure(func(messageID MessageID) {
 		t.tangle.Storage.Message(messageID).Consume(t.AddTip)
 	}))
+
+	if t.tangle.Options.TipMaxAge > 0 {
+		t.startTipPruning()
+	}
+}
+
+// Shutdown stops the background pruning of expired tips.
+func (t *TipManager) Shutdown() {
+	t.shutdownOnce.Do(func() {
+		close(t.shutdown)
+	})
 }
 
 // Set adds the given messageIDs as tips.
 func (t *TipManager) Set(tips ...MessageID) {
 	for _, messageID := range tips {
-		t.strongTips.Set(messageID, messageID)
+		tip := &Tip{MessageID: messageID}
+		t.tangle.Storage.Message(messageID).Consume(func(message *Message) {
+			tip.IssuingTime = message.IssuingTime()
+		})
+		t.tangle.Storage.MessageMetadata(messageID).Consume(func(messageMetadata *MessageMetadata) {
+			tip.Weight = t.weightOfTip(messageMetadata)
+		})
+
+		t.strongTips.Set(messageID, tip)
 	}
 }
 
@@ -107,11 +173,17 @@ func (t *TipManager) AddTip(message *Message) {
 	//  To be sure we probably need to check "It is not directly referenced by any strong message via strong/weak parent"
 	//  before adding a message as a tip. For now we're using only 1 worker after the scheduler and it shouldn't be a problem.
 
+	tip := &Tip{
+		MessageID:   messageID,
+		IssuingTime: message.IssuingTime(),
+		Weight:      t.weightOfTip(messageMetadata),
+	}
+
 	// if branch is monotonically liked: strong message
 	// if branch is not monotonically liked: weak message
 	t.tangle.LedgerState.branchDAG.Branch(messageMetadata.BranchID()).Consume(func(branch ledgerstate.Branch) {
 		if branch.MonotonicallyLiked() {
-			if t.strongTips.Set(messageID, messageID) {
+			if addTip(t.strongTips, tip) {
 				t.Events.TipAdded.Trigger(&TipEvent{
 					MessageID: messageID,
 					TipType:   StrongTip,
@@ -129,6 +201,7 @@ func (t *TipManager) AddTip(message *Message) {
 					t.Events.TipRemoved.Trigger(&TipEvent{
 						MessageID: parent,
 						TipType:   StrongTip,
+						Reason:    TipReferenced,
 					})
 				}
 			})
@@ -138,11 +211,12 @@ func (t *TipManager) AddTip(message *Message) {
 					t.Events.TipRemoved.Trigger(&TipEvent{
 						MessageID: parent,
 						TipType:   WeakTip,
+						Reason:    TipReferenced,
 					})
 				}
 			})
 		} else {
-			if t.weakTips.Set(messageID, messageID) {
+			if addTip(t.weakTips, tip) {
 				t.Events.TipAdded.Trigger(&TipEvent{
 					MessageID: messageID,
 					TipType:   WeakTip,
@@ -162,7 +236,9 @@ func (t *TipManager) Tips(p payload.Payload, countStrongParents, countWeakParent
 	}
 
 	// select strong parents
-	strongParents = t.selectStrongTips(p, countStrongParents)
+	if strongParents, err = t.selectStrongTips(p, countStrongParents); err != nil {
+		return nil, nil, err
+	}
 	// if transaction, make sure that all inputs are in the past cone of the selected tips
 	if p != nil && p.Type() == ledgerstate.TransactionType {
 		transaction := p.(*ledgerstate.Transaction)
@@ -175,7 +251,9 @@ func (t *TipManager) Tips(p payload.Payload, countStrongParents, countWeakParent
 			}
 			tries--
 
-			strongParents = t.selectStrongTips(p, MaxParentsCount)
+			if strongParents, err = t.selectStrongTips(p, MaxParentsCount); err != nil {
+				return nil, nil, err
+			}
 		}
 	}
 
@@ -189,8 +267,9 @@ func (t *TipManager) Tips(p payload.Payload, countStrongParents, countWeakParent
 }
 
 // selectStrongTips returns a list of strong parents. In case of a transaction, it references young enough attachments
-// of consumed transactions directly. Otherwise/additionally count tips are randomly selected.
-func (t *TipManager) selectStrongTips(p payload.Payload, count int) (parents MessageIDs) {
+// of consumed transactions directly. Otherwise/additionally count tips are selected by the configured
+// TipSelectionStrategy. It returns an error if the TipSelectionStrategy fails and no parents were referenced directly.
+func (t *TipManager) selectStrongTips(p payload.Payload, count int) (parents MessageIDs, err error) {
 	parents = make([]MessageID, 0, MaxParentsCount)
 	parentsMap := make(map[MessageID]types.Empty)
 
@@ -239,7 +318,14 @@ func (t *TipManager) selectStrongTips(p payload.Payload, count int) (parents Mes
 		count = MaxParentsCount - len(parents)
 	}
 
-	tips := t.strongTips.RandomUniqueEntries(count)
+	tips, err := t.tangle.Options.TipSelectionStrategy.SelectTips(t.strongTips, count)
+	if err != nil {
+		if len(parents) == 0 {
+			return nil, xerrors.Errorf("failed to select strong tips: %w", err)
+		}
+
+		return parents, nil
+	}
 	// count is invalid or there are no tips
 	if len(tips) == 0 {
 		// only add genesis if no tip was found and not previously referenced (in case of a transaction)
@@ -249,8 +335,7 @@ func (t *TipManager) selectStrongTips(p payload.Payload, count int) (parents Mes
 		return
 	}
 	// at least one tip is returned
-	for _, tip := range tips {
-		messageID := tip.(MessageID)
+	for _, messageID := range tips {
 		if _, ok := parentsMap[messageID]; !ok {
 			parentsMap[messageID] = types.Void
 			parents = append(parents, messageID)
@@ -271,12 +356,78 @@ func (t *TipManager) selectWeakTips(count int) (parents MessageIDs) {
 	}
 	// at least one tip is returned
 	for _, tip := range tips {
-		parents = append(parents, tip.(MessageID))
+		parents = append(parents, tip.(*Tip).MessageID)
 	}
 
 	return
 }
 
+// PruneExpiredTips removes all tips that were issued longer than the configured maximum tip age ago and returns the
+// amount of removed tips.
+func (t *TipManager) PruneExpiredTips() (prunedCount int) {
+	return t.pruneExpiredTips(t.strongTips, StrongTip) + t.pruneExpiredTips(t.weakTips, WeakTip)
+}
+
+// pruneExpiredTips removes the expired tips of the given type.
+func (t *TipManager) pruneExpiredTips(tips *randommap.RandomMap, tipType TipType) (prunedCount int) {
+	if t.tangle.Options.TipMaxAge <= 0 {
+		return
+	}
+
+	now := clock.SyncedTime()
+	for _, tip := range shuffledTips(tips) {
+		if now.Sub(tip.IssuingTime) <= t.tangle.Options.TipMaxAge {
+			continue
+		}
+
+		if _, deleted := tips.Delete(tip.MessageID); deleted {
+			prunedCount++
+			t.Events.TipRemoved.Trigger(&TipEvent{
+				MessageID: tip.MessageID,
+				TipType:   tipType,
+				Reason:    TipExpired,
+			})
+		}
+	}
+
+	return
+}
+
+// startTipPruning starts the background worker that periodically removes the expired tips.
+func (t *TipManager) startTipPruning() {
+	go func() {
+		ticker := time.NewTicker(tipPruningInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				t.PruneExpiredTips()
+			case <-t.shutdown:
+				return
+			}
+		}
+	}()
+}
+
+// weightOfTip returns the highest approval weight of the past Markers of the Message with the given MessageMetadata.
+func (t *TipManager) weightOfTip(messageMetadata *MessageMetadata) (weight float64) {
+	structureDetails := messageMetadata.StructureDetails()
+	if structureDetails == nil {
+		return
+	}
+
+	structureDetails.PastMarkers.ForEach(func(sequenceID markers.SequenceID, index markers.Index) bool {
+		if markerWeight := t.tangle.ApprovalWeightManager.WeightOfMarker(markers.NewMarker(sequenceID, index)); markerWeight > weight {
+			weight = markerWeight
+		}
+
+		return true
+	})
+
+	return
+}
+
 // StrongTipCount the amount of strong tips.
 func (t *TipManager) StrongTipCount() int {
 	return t.strongTips.Size()
@@ -287,6 +438,15 @@ func (t *TipManager) WeakTipCount() int {
 	return t.weakTips.Size()
 }
 
+// addTip adds the given Tip to the given tips and returns true if it was not contained before.
+func addTip(tips *randommap.RandomMap, tip *Tip) (added bool) {
+	if _, exists := tips.Get(tip.MessageID); exists {
+		return false
+	}
+
+	return tips.Set(tip.MessageID, tip)
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region TipManagerEvents /////////////////////////////////////////////////////////////////////////////////////////////
@@ -312,6 +472,9 @@ type TipEvent struct {
 
 	// TipType is the type of the added/removed tip.
 	TipType TipType
+
+	// Reason is the reason why the tip was removed (it is only set for removed tips).
+	Reason TipRemovalReason
 }
 
 func tipEventHandler(handler interface{}, params ...interface{}) {
@@ -6,10 +6,12 @@ import (
 	"time"
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
 	"github.com/iotaledger/goshimmer/packages/vote"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
 )
 
 func TestTipManager_AddTip(t *testing.T) {
@@ -760,6 +762,58 @@ func TestTipManager_TransactionTips(t *testing.T) {
 	}
 }
 
+func TestTipManager_PruneExpiredTips(t *testing.T) {
+	tangle := New(TipMaxAge(time.Minute))
+	defer tangle.Shutdown()
+	tipManager := tangle.TipManager
+
+	removedTips := make(map[MessageID]*TipEvent)
+	tipManager.Events.TipRemoved.Attach(events.NewClosure(func(tipEvent *TipEvent) {
+		removedTips[tipEvent.MessageID] = tipEvent
+	}))
+
+	youngMessage := newTestParentsPayloadWithTimestamp(payload.NewGenericDataPayload([]byte("young")), []MessageID{EmptyMessageID}, []MessageID{}, time.Now())
+	expiredMessage := newTestParentsPayloadWithTimestamp(payload.NewGenericDataPayload([]byte("expired")), []MessageID{EmptyMessageID}, []MessageID{}, time.Now().Add(-5*time.Minute))
+	tangle.Storage.StoreMessage(youngMessage)
+	tangle.Storage.StoreMessage(expiredMessage)
+	tipManager.Set(youngMessage.ID(), expiredMessage.ID())
+	tipManager.weakTips.Set(expiredMessage.ID(), &Tip{MessageID: expiredMessage.ID(), IssuingTime: expiredMessage.IssuingTime()})
+
+	assert.Equal(t, 2, tipManager.PruneExpiredTips())
+	assert.Equal(t, 1, tipManager.StrongTipCount())
+	assert.Equal(t, 0, tipManager.WeakTipCount())
+	assert.Contains(t, tipManager.strongTips.Keys(), youngMessage.ID())
+
+	require.Contains(t, removedTips, expiredMessage.ID())
+	assert.Equal(t, TipExpired, removedTips[expiredMessage.ID()].Reason)
+	assert.Equal(t, 0, tipManager.PruneExpiredTips())
+}
+
+func TestTipManager_AgeRestrictedTips(t *testing.T) {
+	tangle := New(TipSelection(NewAgeRestrictedTipSelection(time.Minute)))
+	defer tangle.Shutdown()
+	tipManager := tangle.TipManager
+
+	// without any tips the genesis is selected
+	strongParents, _, err := tipManager.Tips(nil, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, MessageIDs{EmptyMessageID}, strongParents)
+
+	// if all tips are too old, the genesis is not selected as a fallback
+	oldMessage := newTestParentsPayloadWithTimestamp(payload.NewGenericDataPayload([]byte("old")), []MessageID{EmptyMessageID}, []MessageID{}, time.Now().Add(-5*time.Minute))
+	tangle.Storage.StoreMessage(oldMessage)
+	tipManager.Set(oldMessage.ID())
+	_, _, err = tipManager.Tips(nil, 2, 2)
+	assert.True(t, xerrors.Is(err, ErrNoEligibleTips))
+
+	youngMessage := newTestParentsPayloadWithTimestamp(payload.NewGenericDataPayload([]byte("young")), []MessageID{EmptyMessageID}, []MessageID{}, time.Now())
+	tangle.Storage.StoreMessage(youngMessage)
+	tipManager.Set(youngMessage.ID())
+	strongParents, _, err = tipManager.Tips(nil, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, MessageIDs{youngMessage.ID()}, strongParents)
+}
+
 func storeBookLikeMessage(t *testing.T, tangle *Tangle, message *Message) {
 	// we need to store and book transactions so that we also have attachments of transactions available
 	tangle.Storage.StoreMessage(message)
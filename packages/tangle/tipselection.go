@@ -0,0 +1,183 @@
+package tangle
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/hive.go/datastructure/randommap"
+	"golang.org/x/xerrors"
+)
+
+// region TipSelectionStrategy /////////////////////////////////////////////////////////////////////////////////////////
+
+// TipSelectionStrategy is the interface for the strategies that are used by the TipManager to select strong tips.
+type TipSelectionStrategy interface {
+	// SelectTips returns up to count unique MessageIDs from the given tips (a RandomMap of MessageIDs to Tips).
+	SelectTips(tips *randommap.RandomMap, count int) (selectedTips MessageIDs, err error)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Tip //////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Tip holds the information about a tip that is cached by the TipManager when the tip is added, so that the
+// TipSelectionStrategies do not need to load it from the Storage on every selection.
+type Tip struct {
+	// MessageID is the identifier of the Message that is the tip.
+	MessageID MessageID
+
+	// IssuingTime is the issuing time of the Message.
+	IssuingTime time.Time
+
+	// Weight is the highest approval weight of the past Markers of the Message at the time it became a tip.
+	Weight float64
+}
+
+// String returns a human readable version of the Tip.
+func (t *Tip) String() string {
+	return fmt.Sprintf("Tip{MessageID: %s, IssuingTime: %s, Weight: %f}", t.MessageID, t.IssuingTime, t.Weight)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region UniformRandomTipSelection ////////////////////////////////////////////////////////////////////////////////////
+
+// UniformRandomTipSelection is the TipSelectionStrategy that selects tips uniformly at random.
+type UniformRandomTipSelection struct{}
+
+// NewUniformRandomTipSelection returns a new UniformRandomTipSelection.
+func NewUniformRandomTipSelection() *UniformRandomTipSelection {
+	return &UniformRandomTipSelection{}
+}
+
+// SelectTips returns up to count unique MessageIDs that are chosen uniformly at random from the given tips.
+func (u *UniformRandomTipSelection) SelectTips(tips *randommap.RandomMap, count int) (selectedTips MessageIDs, err error) {
+	selectedTips = make(MessageIDs, 0, count)
+	for _, tip := range tips.RandomUniqueEntries(count) {
+		selectedTips = append(selectedTips, tip.(*Tip).MessageID)
+	}
+
+	return
+}
+
+// code contract (make sure the type implements all required methods)
+var _ TipSelectionStrategy = &UniformRandomTipSelection{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AgeRestrictedTipSelection ////////////////////////////////////////////////////////////////////////////////////
+
+// AgeRestrictedTipSelection is the TipSelectionStrategy that selects tips uniformly at random but rejects all tips that
+// were issued longer than MaxAge ago.
+type AgeRestrictedTipSelection struct {
+	maxAge time.Duration
+}
+
+// NewAgeRestrictedTipSelection returns a new AgeRestrictedTipSelection that rejects tips older than maxAge.
+func NewAgeRestrictedTipSelection(maxAge time.Duration) *AgeRestrictedTipSelection {
+	return &AgeRestrictedTipSelection{
+		maxAge: maxAge,
+	}
+}
+
+// SelectTips returns up to count unique MessageIDs that are chosen uniformly at random from the given tips whose
+// issuing time is not older than the configured maximum age. It returns an ErrNoEligibleTips if there are tips but all
+// of them are too old.
+func (a *AgeRestrictedTipSelection) SelectTips(tips *randommap.RandomMap, count int) (selectedTips MessageIDs, err error) {
+	selectedTips = make(MessageIDs, 0, count)
+	if count <= 0 || tips.Size() == 0 {
+		return
+	}
+
+	now := clock.SyncedTime()
+	for _, tip := range shuffledTips(tips) {
+		if now.Sub(tip.IssuingTime) > a.maxAge {
+			continue
+		}
+
+		if selectedTips = append(selectedTips, tip.MessageID); len(selectedTips) == count {
+			return
+		}
+	}
+
+	if len(selectedTips) == 0 {
+		err = xerrors.Errorf("all %d tips are older than %s: %w", tips.Size(), a.maxAge, ErrNoEligibleTips)
+	}
+
+	return
+}
+
+// MaxAge returns the maximum age of the tips that are selected by the strategy.
+func (a *AgeRestrictedTipSelection) MaxAge() time.Duration {
+	return a.maxAge
+}
+
+// code contract (make sure the type implements all required methods)
+var _ TipSelectionStrategy = &AgeRestrictedTipSelection{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region HeaviestApprovalWeightTipSelection ///////////////////////////////////////////////////////////////////////////
+
+// HeaviestApprovalWeightTipSelection is the TipSelectionStrategy that prefers the tips whose past cone carries the
+// highest approval weight. Tips with the same weight are chosen in random order.
+type HeaviestApprovalWeightTipSelection struct{}
+
+// NewHeaviestApprovalWeightTipSelection returns a new HeaviestApprovalWeightTipSelection.
+func NewHeaviestApprovalWeightTipSelection() *HeaviestApprovalWeightTipSelection {
+	return &HeaviestApprovalWeightTipSelection{}
+}
+
+// SelectTips returns up to count unique MessageIDs with the highest approval weight from the given tips.
+func (h *HeaviestApprovalWeightTipSelection) SelectTips(tips *randommap.RandomMap, count int) (selectedTips MessageIDs, err error) {
+	selectedTips = make(MessageIDs, 0, count)
+	if count <= 0 {
+		return
+	}
+
+	candidates := shuffledTips(tips)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Weight > candidates[j].Weight
+	})
+
+	for _, tip := range candidates {
+		if len(selectedTips) == count {
+			break
+		}
+		selectedTips = append(selectedTips, tip.MessageID)
+	}
+
+	return
+}
+
+// code contract (make sure the type implements all required methods)
+var _ TipSelectionStrategy = &HeaviestApprovalWeightTipSelection{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility functions ////////////////////////////////////////////////////////////////////////////////////////////
+
+// shuffledTips returns all Tips of the given RandomMap in random order.
+func shuffledTips(tips *randommap.RandomMap) (shuffled []*Tip) {
+	shuffled = make([]*Tip, 0, tips.Size())
+	tips.ForEach(func(_ interface{}, value interface{}) {
+		shuffled = append(shuffled, value.(*Tip))
+	})
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Errors ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ErrNoEligibleTips is returned by a TipSelectionStrategy if none of the existing tips is eligible to be selected.
+var ErrNoEligibleTips = fmt.Errorf("no eligible tips")
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,109 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/datastructure/randommap"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+)
+
+func TestUniformRandomTipSelection(t *testing.T) {
+	tips := randommap.New()
+	for i := 0; i < 10; i++ {
+		messageID := randomMessageID()
+		tips.Set(messageID, &Tip{MessageID: messageID})
+	}
+
+	strategy := NewUniformRandomTipSelection()
+	assertSelectedTips(t, strategy, tips, 4, 4)
+	assertSelectedTips(t, strategy, tips, 20, 10)
+	assertSelectedTips(t, strategy, randommap.New(), 4, 0)
+}
+
+func TestAgeRestrictedTipSelection(t *testing.T) {
+	tips := randommap.New()
+	youngTips := make(map[MessageID]bool)
+	for i := 0; i < 6; i++ {
+		issuingTime := time.Now()
+		if i%2 == 0 {
+			issuingTime = issuingTime.Add(-10 * time.Minute)
+		}
+
+		messageID := randomMessageID()
+		tips.Set(messageID, &Tip{MessageID: messageID, IssuingTime: issuingTime})
+		youngTips[messageID] = i%2 != 0
+	}
+
+	strategy := NewAgeRestrictedTipSelection(time.Minute)
+	selectedTips, err := strategy.SelectTips(tips, 8)
+	require.NoError(t, err)
+	assert.Len(t, selectedTips, 3)
+	for _, tip := range selectedTips {
+		assert.True(t, youngTips[tip])
+	}
+
+	assertSelectedTips(t, strategy, tips, 2, 2)
+	assertSelectedTips(t, strategy, randommap.New(), 2, 0)
+
+	// if all tips are too old, no tip is selected and an error is returned
+	oldTips := randommap.New()
+	for messageID, young := range youngTips {
+		if !young {
+			oldTips.Set(messageID, &Tip{MessageID: messageID, IssuingTime: time.Now().Add(-10 * time.Minute)})
+		}
+	}
+	selectedTips, err = strategy.SelectTips(oldTips, 2)
+	assert.True(t, xerrors.Is(err, ErrNoEligibleTips))
+	assert.Empty(t, selectedTips)
+}
+
+func TestHeaviestApprovalWeightTipSelection(t *testing.T) {
+	issuers := make([]*identity.LocalIdentity, 3)
+	weightProvider := NewManaWeightProvider(nil, DefaultWeightProviderActivityWindow)
+	for i := range issuers {
+		issuers[i] = identity.GenerateLocalIdentity()
+		weightProvider.Update(identity.NewID(issuers[i].PublicKey()))
+	}
+
+	tangle := New(WithoutOpinionFormer(true), ApprovalWeightProvider(weightProvider))
+	defer tangle.Shutdown()
+	tangle.Booker.Setup()
+	tangle.ApprovalWeightManager.Setup()
+
+	// chain of messages whose approval weight decreases towards the end of the chain
+	messages := make([]*Message, 0)
+	parent := EmptyMessageID
+	for _, issuer := range issuers {
+		message := newTestIssuerDataMessage(issuer.PublicKey(), []MessageID{parent})
+		messages = append(messages, message)
+		parent = message.ID()
+	}
+
+	for _, message := range messages {
+		tangle.Storage.StoreMessage(message)
+		require.NoError(t, tangle.Booker.Book(message.ID()))
+	}
+
+	// the weights are cached when the tips are added
+	messageIDs := make(MessageIDs, 0, len(messages))
+	for _, message := range messages {
+		messageIDs = append(messageIDs, message.ID())
+	}
+	tangle.TipManager.Set(messageIDs...)
+
+	strategy := NewHeaviestApprovalWeightTipSelection()
+	selectedTips, err := strategy.SelectTips(tangle.TipManager.strongTips, 2)
+	require.NoError(t, err)
+	assert.Equal(t, MessageIDs{messages[0].ID(), messages[1].ID()}, selectedTips)
+	assertSelectedTips(t, strategy, tangle.TipManager.strongTips, 8, len(messages))
+}
+
+func assertSelectedTips(t *testing.T, strategy TipSelectionStrategy, tips *randommap.RandomMap, count int, expectedCount int) {
+	selectedTips, err := strategy.SelectTips(tips, count)
+	require.NoError(t, err)
+	assert.Len(t, selectedTips, expectedCount)
+}
@@ -12,8 +12,20 @@ import (
 func OpinionRetriever(id string, objectType vote.ObjectType) opinion.Opinion {
 	switch objectType {
 	case vote.TimestampType:
-		// TODO: implement
-		return opinion.Like
+		messageID, err := tangle.NewMessageID(id)
+		if err != nil {
+			log.Errorf("received invalid vote request for timestamp '%s'", id)
+
+			return opinion.Unknown
+		}
+
+		timestampOpinion := messagelayer.Tangle().TimestampOpinionProvider.TimestampOpinion(messageID)
+
+		if timestampOpinion.LoK == tangle.Pending {
+			return opinion.Unknown
+		}
+
+		return timestampOpinion.Value
 	default: // conflict type
 		transactionID, err := ledgerstate.TransactionIDFromBase58(id)
 		if err != nil {
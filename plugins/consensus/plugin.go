@@ -118,6 +118,16 @@ func configure(_ *node.Plugin) {
 		log.Errorf("FCOB error: %s", err)
 	}))
 
+	// subscribe to timestamp opinion events
+	messagelayer.Tangle().TimestampOpinionProvider.Vote().Attach(events.NewClosure(func(id string, initOpn opinion.Opinion) {
+		if err := Voter().Vote(id, vote.TimestampType, initOpn); err != nil {
+			log.Warnf("FPC vote: %s", err)
+		}
+	}))
+	messagelayer.Tangle().TimestampOpinionProvider.VoteError().Attach(events.NewClosure(func(err error) {
+		log.Errorf("timestamp opinion error: %s", err)
+	}))
+
 	// subscribe to message-layer
 	messagelayer.Tangle().Scheduler.Events.MessageScheduled.Attach(events.NewClosure(readStatement))
 }
@@ -170,15 +180,22 @@ func configureFPC() {
 	}))
 
 	Voter().Events().Finalized.Attach(events.NewClosure(messagelayer.Tangle().PayloadOpinionProvider.ProcessVote))
+	Voter().Events().Finalized.Attach(events.NewClosure(messagelayer.Tangle().TimestampOpinionProvider.ProcessVote))
 	Voter().Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
-		if ev.Ctx.Type == vote.ConflictType {
+		switch ev.Ctx.Type {
+		case vote.ConflictType:
 			log.Infof("FPC finalized for transaction with id '%s' - final opinion: '%s'", ev.ID, ev.Opinion)
+		case vote.TimestampType:
+			log.Infof("FPC finalized for timestamp of message with id '%s' - final opinion: '%s'", ev.ID, ev.Opinion)
 		}
 	}))
 
 	Voter().Events().Failed.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
-		if ev.Ctx.Type == vote.ConflictType {
+		switch ev.Ctx.Type {
+		case vote.ConflictType:
 			log.Warnf("FPC failed for transaction with id '%s' - last opinion: '%s'", ev.ID, ev.Opinion)
+		case vote.TimestampType:
+			log.Warnf("FPC failed for timestamp of message with id '%s' - last opinion: '%s'", ev.ID, ev.Opinion)
 		}
 	}))
 
@@ -62,8 +62,9 @@ func createExplorerMessage(msg *tangle.Message) (*ExplorerMessage, error) {
 
 // ExplorerAddress defines the struct of the ExplorerAddress.
 type ExplorerAddress struct {
-	Address   string           `json:"address"`
-	OutputIDs []ExplorerOutput `json:"output_ids"`
+	Address     string                  `json:"address"`
+	OutputIDs   []ExplorerOutput        `json:"output_ids"`
+	AliasOutput *valueutils.AliasOutput `json:"alias_output,omitempty"`
 }
 
 // ExplorerOutput defines the struct of the ExplorerOutput.
@@ -207,8 +208,31 @@ func findAddress(strAddress string) (*ExplorerAddress, error) {
 	}
 
 	return &ExplorerAddress{
-		Address:   strAddress,
-		OutputIDs: outputids,
+		Address:     strAddress,
+		OutputIDs:   outputids,
+		AliasOutput: findAliasOutput(address),
 	}, nil
 
 }
+
+// findAliasOutput resolves the current output of the alias if the given address is an AliasAddress.
+func findAliasOutput(address ledgerstate.Address) *valueutils.AliasOutput {
+	aliasAddress, isAliasAddress := address.(*ledgerstate.AliasAddress)
+	if !isAliasAddress {
+		return nil
+	}
+
+	cachedOutput, err := messagelayer.Tangle().LedgerState.AliasOutput(aliasAddress)
+	if err != nil {
+		return nil
+	}
+	defer cachedOutput.Release()
+
+	aliasOutput, isAliasOutput := cachedOutput.Unwrap().(*ledgerstate.AliasOutput)
+	if !isAliasOutput {
+		return nil
+	}
+	parsedAliasOutput := valueutils.ParseAliasOutput(aliasOutput)
+
+	return &parsedAliasOutput
+}
@@ -1,6 +1,7 @@
 package faucet
 
 import (
+	"bytes"
 	"sync"
 	"time"
 
@@ -131,16 +132,22 @@ func (c *Component) SendFunds(msg *tangle.Message) (m *tangle.Message, txID stri
 
 // collectUTXOsForFunding iterates over the faucet's UTXOs until the token threshold is reached.
 // this function also returns the remainder balance for the given outputs.
+// outputs that can currently not be unlocked by the faucet (e.g. because of a timelock) are skipped.
 func (c *Component) collectUTXOsForFunding() (inputs ledgerstate.Inputs, addrsIndices map[uint64]ledgerstate.Inputs, remainder int64) {
 	var total = c.tokensPerRequest
 	var i uint64
 	addrsIndices = map[uint64]ledgerstate.Inputs{}
+	now := clock.SyncedTime()
 
 	// get a list of address for inputs
 	for i = 0; total > 0; i++ {
 		addr := c.seed.Address(i).Address()
 		cachedOutputs := messagelayer.Tangle().LedgerState.OutputsOnAddress(addr)
 		cachedOutputs.Consume(func(output ledgerstate.Output) {
+			if locked(output, addr, now) {
+				return
+			}
+
 			messagelayer.Tangle().LedgerState.OutputMetadata(output.ID()).Consume(func(outputMetadata *ledgerstate.OutputMetadata) {
 				if outputMetadata.ConsumerCount() > 0 || total == 0 {
 					return
@@ -180,6 +187,16 @@ func (c *Component) nextUnusedAddress() ledgerstate.Address {
 	}
 }
 
+// locked checks if the given output can not be unlocked by the given address at the given time.
+func locked(output ledgerstate.Output, addr ledgerstate.Address, now time.Time) bool {
+	extendedLockedOutput, isExtendedLockedOutput := output.(*ledgerstate.ExtendedLockedOutput)
+	if !isExtendedLockedOutput {
+		return false
+	}
+
+	return extendedLockedOutput.TimeLockedNow(now) || !bytes.Equal(extendedLockedOutput.UnlockAddressNow(now).Bytes(), addr.Bytes())
+}
+
 type wallet struct {
 	keyPair ed25519.KeyPair
 	address *ledgerstate.ED25519Address
@@ -0,0 +1,24 @@
+package mana
+
+import (
+	"time"
+
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	// CfgDecay defines the decay rate (per second) of the base access mana.
+	CfgDecay = "mana.decay"
+
+	// CfgEMACoefficient defines the coefficient (per second) of the exponential moving average of the effective mana.
+	CfgEMACoefficient = "mana.emaCoefficient"
+
+	// CfgPersistInterval defines the time interval between two snapshots of the mana vectors that are persisted.
+	CfgPersistInterval = "mana.persistInterval"
+)
+
+func init() {
+	flag.Float64(CfgDecay, 0.00003209, "the decay rate (per second) of the base access mana")
+	flag.Float64(CfgEMACoefficient, 0.00003209, "the coefficient (per second) of the exponential moving average of the effective mana")
+	flag.Duration(CfgPersistInterval, time.Minute, "the time interval between two snapshots of the mana vectors that are persisted")
+}
@@ -0,0 +1,229 @@
+package mana
+
+import (
+	"sync"
+	"time"
+
+	dbpkg "github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/plugins/config"
+	"github.com/iotaledger/goshimmer/plugins/database"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"golang.org/x/xerrors"
+)
+
+// PluginName is the name of the mana plugin.
+const PluginName = "Mana"
+
+var (
+	// plugin is the plugin instance of the mana plugin.
+	plugin          *node.Plugin
+	once            sync.Once
+	log             *logger.Logger
+	baseManaVectors map[mana.Type]*mana.BaseManaVector
+	storage         *objectstorage.ObjectStorage
+)
+
+// Plugin gets the plugin instance.
+func Plugin() *node.Plugin {
+	once.Do(func() {
+		plugin = node.NewPlugin(PluginName, node.Enabled, configure, run)
+	})
+	return plugin
+}
+
+func configure(*node.Plugin) {
+	log = logger.NewLogger(PluginName)
+
+	mana.Decay = config.Node().Float64(CfgDecay)
+	mana.EMACoefficient = config.Node().Float64(CfgEMACoefficient)
+
+	baseManaVectors = make(map[mana.Type]*mana.BaseManaVector)
+	for _, manaType := range []mana.Type{mana.AccessMana, mana.ConsensusMana} {
+		baseManaVector, err := mana.NewBaseManaVector(manaType)
+		if err != nil {
+			log.Panic(err)
+		}
+		baseManaVectors[manaType] = baseManaVector
+	}
+
+	storage = objectstorage.NewFactory(database.Store(), dbpkg.PrefixMana).New(0, mana.PersistableBaseManaFromObjectStorage, objectstorage.CacheTime(0), objectstorage.LeakDetectionEnabled(false))
+	loadPersistedMana()
+
+	// the approval of the issuers is weighted by their consensus mana
+	messagelayer.SetConsensusManaRetriever(func(nodeID identity.ID) float64 {
+		consensusMana, err := GetConsensusMana(nodeID)
+		if err != nil {
+			return 0
+		}
+
+		return consensusMana
+	})
+
+	messagelayer.Tangle().LedgerState.Events.TransactionBooked.Attach(events.NewClosure(func(transactionID ledgerstate.TransactionID) {
+		bookTransaction(mana.AccessMana, transactionID)
+	}))
+	messagelayer.Tangle().LedgerState.Events.TransactionConfirmed.Attach(events.NewClosure(func(transactionID ledgerstate.TransactionID) {
+		bookTransaction(mana.ConsensusMana, transactionID)
+	}))
+}
+
+func run(*node.Plugin) {
+	persistInterval := config.Node().Duration(CfgPersistInterval)
+
+	if err := daemon.BackgroundWorker(PluginName, func(shutdownSignal <-chan struct{}) {
+		// persist the mana periodically, so it survives a crash of the node
+		ticker := time.NewTicker(persistInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				storeMana()
+			case <-shutdownSignal:
+				storeMana()
+				storage.Shutdown()
+				return
+			}
+		}
+	}, shutdown.PriorityTangle); err != nil {
+		log.Panicf("Failed to start as daemon: %s", err)
+	}
+}
+
+// GetManaMap returns the mana of all nodes for the given mana Type at the given time (or now if no time is provided).
+func GetManaMap(manaType mana.Type, optionalUpdateTime ...time.Time) (mana.NodeMap, error) {
+	baseManaVector, exists := baseManaVectors[manaType]
+	if !exists {
+		return nil, xerrors.Errorf("failed to retrieve mana map of %s: %w", manaType, mana.ErrUnknownManaType)
+	}
+
+	return baseManaVector.GetManaMap(optionalUpdateTime...)
+}
+
+// GetAccessMana returns the access mana of the given node.
+func GetAccessMana(nodeID identity.ID) (float64, error) {
+	return baseManaVectors[mana.AccessMana].GetMana(nodeID)
+}
+
+// GetConsensusMana returns the consensus mana of the given node.
+func GetConsensusMana(nodeID identity.ID) (float64, error) {
+	return baseManaVectors[mana.ConsensusMana].GetMana(nodeID)
+}
+
+// GetHighestManaNodes returns the n nodes with the highest mana of the given mana Type.
+func GetHighestManaNodes(manaType mana.Type, n uint) ([]mana.Node, error) {
+	baseManaVector, exists := baseManaVectors[manaType]
+	if !exists {
+		return nil, xerrors.Errorf("failed to retrieve highest mana nodes of %s: %w", manaType, mana.ErrUnknownManaType)
+	}
+
+	return baseManaVector.GetHighestManaNodes(n)
+}
+
+// bookTransaction books the mana changes of the given Transaction into the BaseManaVector of the given mana Type.
+func bookTransaction(manaType mana.Type, transactionID ledgerstate.TransactionID) {
+	txInfo, err := txInfo(transactionID)
+	if err != nil {
+		log.Errorf("failed to book %s mana of Transaction with %s: %s", manaType, transactionID, err)
+		return
+	}
+
+	if err = baseManaVectors[manaType].Book(txInfo); err != nil {
+		log.Errorf("failed to book %s mana of Transaction with %s: %s", manaType, transactionID, err)
+	}
+}
+
+// txInfo collects the mana relevant information of the given Transaction.
+func txInfo(transactionID ledgerstate.TransactionID) (txInfo *mana.TxInfo, err error) {
+	ledgerState := messagelayer.Tangle().LedgerState
+
+	if !ledgerState.Transaction(transactionID).Consume(func(transaction *ledgerstate.Transaction) {
+		essence := transaction.Essence()
+		txInfo = &mana.TxInfo{
+			TimeStamp:     essence.Timestamp(),
+			TransactionID: transactionID,
+			TotalBalance:  sumOutputBalances(essence.Outputs()),
+			PledgeID: map[mana.Type]identity.ID{
+				mana.AccessMana:    essence.AccessPledgeID(),
+				mana.ConsensusMana: essence.ConsensusPledgeID(),
+			},
+			InputInfos: make([]mana.InputInfo, 0, len(essence.Inputs())),
+		}
+
+		for _, input := range essence.Inputs() {
+			referencedOutputID := input.(*ledgerstate.UTXOInput).ReferencedOutputID()
+
+			inputInfo := mana.InputInfo{}
+			if !ledgerState.Output(referencedOutputID).Consume(func(output ledgerstate.Output) {
+				inputInfo.Amount = sumOutputBalances(ledgerstate.Outputs{output})
+			}) {
+				err = xerrors.Errorf("failed to load Output with %s", referencedOutputID)
+				return
+			}
+
+			// outputs of the genesis have no Transaction that pledged them
+			ledgerState.Transaction(referencedOutputID.TransactionID()).Consume(func(inputTransaction *ledgerstate.Transaction) {
+				inputInfo.TimeStamp = inputTransaction.Essence().Timestamp()
+				inputInfo.PledgeID = map[mana.Type]identity.ID{
+					mana.AccessMana:    inputTransaction.Essence().AccessPledgeID(),
+					mana.ConsensusMana: inputTransaction.Essence().ConsensusPledgeID(),
+				}
+			})
+
+			txInfo.InputInfos = append(txInfo.InputInfos, inputInfo)
+		}
+	}) {
+		err = xerrors.Errorf("failed to load Transaction with %s", transactionID)
+	}
+
+	return
+}
+
+// sumOutputBalances returns the sum of the balances of all colors of the given Outputs.
+func sumOutputBalances(outputs ledgerstate.Outputs) (sum float64) {
+	for _, output := range outputs {
+		output.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+			sum += float64(balance)
+			return true
+		})
+	}
+
+	return
+}
+
+// loadPersistedMana restores the BaseManaVectors from the object storage.
+func loadPersistedMana() {
+	storage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		cachedObject.Consume(func(object objectstorage.StorableObject) {
+			persistableBaseMana := object.(*mana.PersistableBaseMana)
+			baseManaVector, exists := baseManaVectors[persistableBaseMana.ManaType()]
+			if !exists {
+				log.Errorf("failed to load persisted mana: %s", mana.ErrUnknownManaType)
+				return
+			}
+
+			if err := baseManaVector.FromPersistable(persistableBaseMana); err != nil {
+				log.Errorf("failed to load persisted mana: %s", err)
+			}
+		})
+		return true
+	})
+}
+
+// storeMana persists the BaseManaVectors in the object storage.
+func storeMana() {
+	for _, baseManaVector := range baseManaVectors {
+		for _, persistableBaseMana := range baseManaVector.ToPersistables() {
+			storage.Store(persistableBaseMana).Release()
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package messagelayer
 
 import (
+	"bufio"
 	"errors"
+	"fmt"
+	"io"
 	"os"
 	"sync"
 	"time"
@@ -9,11 +12,13 @@ import (
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/plugins/autopeering"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
 	"github.com/iotaledger/goshimmer/plugins/config"
 	"github.com/iotaledger/goshimmer/plugins/database"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 	flag "github.com/spf13/pflag"
@@ -32,8 +37,113 @@ const (
 	// CfgMessageLayerFCOBAverageNetworkDelay is the avg. network delay to use for FCoB rules
 	CfgMessageLayerFCOBAverageNetworkDelay = "messageLayer.fcob.averageNetworkDelay"
 
+	// CfgLocalSnapshotEnable defines whether the node periodically creates local snapshots and prunes old messages.
+	CfgLocalSnapshotEnable = "messageLayer.localSnapshot.enable"
+
+	// CfgLocalSnapshotInterval is the time interval between two local snapshots.
+	CfgLocalSnapshotInterval = "messageLayer.localSnapshot.interval"
+
+	// CfgLocalSnapshotDepth is the age of the messages that are cut off by a local snapshot.
+	CfgLocalSnapshotDepth = "messageLayer.localSnapshot.depth"
+
+	// CfgLocalSnapshotFile is the path to the file that the latest local snapshot is written to.
+	CfgLocalSnapshotFile = "messageLayer.localSnapshot.file"
+
+	// CfgSupplyAuditEnable defines whether the node periodically audits the token supply of the confirmed ledger state.
+	CfgSupplyAuditEnable = "messageLayer.supplyAudit.enable"
+
+	// CfgSupplyAuditInterval is the time interval between two audits of the token supply.
+	CfgSupplyAuditInterval = "messageLayer.supplyAudit.interval"
+
+	// CfgBranchGCInterval is the time interval between two clean ups of the BranchDAG after conflicts were resolved.
+	CfgBranchGCInterval = "messageLayer.branchGC.interval"
+
+	// CfgTimestampWindow is the time window for assessing the quality of the timestamps of the messages.
+	CfgTimestampWindow = "messageLayer.timestamp.window"
+
+	// CfgTimestampGratuitousNetworkDelay is the time after which all messages are assumed to be delivered.
+	CfgTimestampGratuitousNetworkDelay = "messageLayer.timestamp.gratuitousNetworkDelay"
+
 	// CfgTangleWidth is the width of the Tangle.
 	CfgTangleWidth = "messageLayer.tangleWidth"
+
+	// CfgSchedulerRate is the time interval between two messages scheduled by the node.
+	CfgSchedulerRate = "messageLayer.scheduler.rate"
+
+	// CfgSchedulerMaxBufferSize is the maximum amount of bytes buffered by the scheduler before messages get dropped.
+	CfgSchedulerMaxBufferSize = "messageLayer.scheduler.maxBufferSize"
+
+	// CfgRateSetterEnable defines whether the rate setter limits the issuance of the node's own messages.
+	CfgRateSetterEnable = "messageLayer.rateSetter.enable"
+
+	// CfgRateSetterInitialRate is the issuance rate (in messages per second) that is used at startup.
+	CfgRateSetterInitialRate = "messageLayer.rateSetter.initialRate"
+
+	// CfgRateSetterMinRate is the lower bound of the issuance rate (in messages per second).
+	CfgRateSetterMinRate = "messageLayer.rateSetter.minRate"
+
+	// CfgRateSetterIncrease is the additive increase of the issuance rate per second.
+	CfgRateSetterIncrease = "messageLayer.rateSetter.increase"
+
+	// CfgRateSetterDecrease is the multiplicative decrease factor of the issuance rate.
+	CfgRateSetterDecrease = "messageLayer.rateSetter.decrease"
+
+	// CfgRateSetterOwnQueueThreshold is the size (in bytes) of the own scheduler queue above which the rate is decreased.
+	CfgRateSetterOwnQueueThreshold = "messageLayer.rateSetter.ownQueueThreshold"
+
+	// CfgRateSetterMaxOwnQueueSize is the size (in bytes) of the own scheduler queue above which issuance is rejected.
+	CfgRateSetterMaxOwnQueueSize = "messageLayer.rateSetter.maxOwnQueueSize"
+
+	// CfgTipSelectionStrategy is the name of the strategy that is used to select strong tips.
+	CfgTipSelectionStrategy = "messageLayer.tipSelection.strategy"
+
+	// CfgTipSelectionMaxTipAge is the maximum age of the tips selected by the age restricted tip selection strategy.
+	CfgTipSelectionMaxTipAge = "messageLayer.tipSelection.maxTipAge"
+
+	// CfgTipPruningMaxAge is the age after which tips are pruned by the tip manager.
+	CfgTipPruningMaxAge = "messageLayer.tipPruning.maxAge"
+
+	// CfgOrphanageThreshold is the time after which a message without approvers is considered to be orphaned.
+	CfgOrphanageThreshold = "messageLayer.orphanage.threshold"
+
+	// CfgApprovalWeightThreshold is the share of the total weight of the active issuers that needs to approve a message
+	// or a branch for it to be confirmed.
+	CfgApprovalWeightThreshold = "messageLayer.approvalWeight.threshold"
+
+	// CfgApprovalWeightActivityWindow is the time after which an issuer that did not issue any message loses its weight.
+	CfgApprovalWeightActivityWindow = "messageLayer.approvalWeight.activityWindow"
+
+	// CfgBlacklistEquivocatingIssuers defines whether all messages of issuers that were caught equivocating are rejected.
+	CfgBlacklistEquivocatingIssuers = "messageLayer.equivocation.blacklist"
+
+	// CfgIssuerRateLimitWindow is the length of the sliding window of the per-issuer rate limit.
+	CfgIssuerRateLimitWindow = "messageLayer.issuerRateLimit.window"
+
+	// CfgIssuerRateLimitMaxMessages is the maximum amount of messages per issuer within the sliding window.
+	CfgIssuerRateLimitMaxMessages = "messageLayer.issuerRateLimit.maxMessages"
+
+	// CfgDustMinimumOutputAmount is the minimum amount of tokens that every created output has to hold.
+	CfgDustMinimumOutputAmount = "messageLayer.dust.minimumOutputAmount"
+
+	// CfgDustThreshold is the amount of tokens below which an output is considered to be a dust output.
+	CfgDustThreshold = "messageLayer.dust.threshold"
+
+	// CfgDustMaxOutputsPerAddress is the maximum amount of dust outputs on an address without a dust allowance.
+	CfgDustMaxOutputsPerAddress = "messageLayer.dust.maxOutputsPerAddress"
+
+	// CfgDustAllowance is the amount of tokens an address has to hold to be allowed to hold any amount of dust outputs.
+	CfgDustAllowance = "messageLayer.dust.allowance"
+)
+
+const (
+	// UniformRandomTipSelection is the name of the strategy that selects tips uniformly at random.
+	UniformRandomTipSelection = "uniform"
+
+	// AgeRestrictedTipSelection is the name of the strategy that selects tips uniformly at random but rejects old tips.
+	AgeRestrictedTipSelection = "ageRestricted"
+
+	// HeaviestApprovalWeightTipSelection is the name of the strategy that prefers tips with the highest approval weight.
+	HeaviestApprovalWeightTipSelection = "heaviestApprovalWeight"
 )
 
 var (
@@ -45,7 +155,38 @@ var (
 func init() {
 	flag.String(CfgMessageLayerSnapshotFile, "./snapshot.bin", "the path to the snapshot file")
 	flag.Int(CfgMessageLayerFCOBAverageNetworkDelay, 5, "the avg. network delay to use for FCoB rules")
+	flag.Bool(CfgLocalSnapshotEnable, false, "whether the node periodically creates local snapshots and prunes old messages")
+	flag.Duration(CfgLocalSnapshotInterval, time.Hour, "the time interval between two local snapshots")
+	flag.Duration(CfgLocalSnapshotDepth, 30*time.Minute, "the age of the messages that are cut off by a local snapshot")
+	flag.String(CfgLocalSnapshotFile, "./localsnapshot.bin", "the path to the file that the latest local snapshot is written to")
+	flag.Bool(CfgSupplyAuditEnable, false, "whether the node periodically audits the token supply of the confirmed ledger state")
+	flag.Duration(CfgSupplyAuditInterval, 10*time.Minute, "the time interval between two audits of the token supply")
+	flag.Duration(CfgBranchGCInterval, time.Minute, "the time interval between two clean ups of the branch DAG after conflicts were resolved")
+	flag.Duration(CfgTimestampWindow, tangle.TimestampWindow, "the time window for assessing the quality of the timestamps of the messages")
+	flag.Duration(CfgTimestampGratuitousNetworkDelay, tangle.GratuitousNetworkDelay, "the time after which all messages are assumed to be delivered")
 	flag.Int(CfgTangleWidth, 0, "the width of the Tangle")
+	flag.Duration(CfgSchedulerRate, tangle.DefaultSchedulerRate, "the time interval between two messages scheduled by the node")
+	flag.Int(CfgSchedulerMaxBufferSize, tangle.DefaultSchedulerMaxBufferSize, "the maximum amount of bytes buffered by the scheduler")
+	flag.Bool(CfgRateSetterEnable, true, "whether the rate setter limits the issuance of the node's own messages")
+	flag.Float64(CfgRateSetterInitialRate, tangle.DefaultRateSetterInitialRate, "the issuance rate (in messages per second) that is used at startup")
+	flag.Float64(CfgRateSetterMinRate, tangle.DefaultRateSetterMinRate, "the lower bound of the issuance rate (in messages per second)")
+	flag.Float64(CfgRateSetterIncrease, tangle.DefaultRateSetterIncrease, "the additive increase of the issuance rate per second")
+	flag.Float64(CfgRateSetterDecrease, tangle.DefaultRateSetterDecrease, "the multiplicative decrease factor of the issuance rate")
+	flag.Int(CfgRateSetterOwnQueueThreshold, tangle.DefaultRateSetterOwnQueueThreshold, "the size (in bytes) of the own scheduler queue above which the rate is decreased")
+	flag.Int(CfgRateSetterMaxOwnQueueSize, tangle.DefaultRateSetterMaxOwnQueueSize, "the size (in bytes) of the own scheduler queue above which issuance is rejected")
+	flag.String(CfgTipSelectionStrategy, UniformRandomTipSelection, "the strategy that is used to select strong tips (uniform, ageRestricted, heaviestApprovalWeight)")
+	flag.Duration(CfgTipSelectionMaxTipAge, time.Minute, "the maximum age of the tips selected by the ageRestricted tip selection strategy")
+	flag.Duration(CfgTipPruningMaxAge, tangle.DefaultTipMaxAge, "the age after which tips are pruned by the tip manager (0 disables the pruning)")
+	flag.Duration(CfgOrphanageThreshold, tangle.DefaultOrphanageThreshold, "the time after which a message without approvers is considered to be orphaned")
+	flag.Float64(CfgApprovalWeightThreshold, tangle.DefaultApprovalWeightThreshold, "the share of the total weight of the active issuers that needs to approve a message or a branch for it to be confirmed")
+	flag.Duration(CfgApprovalWeightActivityWindow, tangle.DefaultWeightProviderActivityWindow, "the time after which an issuer that did not issue any message loses its weight")
+	flag.Bool(CfgBlacklistEquivocatingIssuers, false, "whether all messages of issuers that were caught equivocating are rejected")
+	flag.Duration(CfgIssuerRateLimitWindow, tangle.DefaultIssuerRateLimitWindow, "the length of the sliding window of the per-issuer rate limit")
+	flag.Int(CfgIssuerRateLimitMaxMessages, 0, "the maximum amount of messages per issuer within the sliding window (0 disables the rate limit)")
+	flag.Int64(CfgDustMinimumOutputAmount, int64(ledgerstate.DefaultDustProtectionParams.MinimumOutputAmount), "the minimum amount of tokens that every created output has to hold")
+	flag.Int64(CfgDustThreshold, int64(ledgerstate.DefaultDustProtectionParams.DustThreshold), "the amount of tokens below which an output is considered to be a dust output (0 disables the limit of dust outputs that is applied to the transactions issued by the node)")
+	flag.Int(CfgDustMaxOutputsPerAddress, ledgerstate.DefaultDustProtectionParams.MaxDustOutputsPerAddress, "the maximum amount of dust outputs on an address without a dust allowance")
+	flag.Int64(CfgDustAllowance, int64(ledgerstate.DefaultDustProtectionParams.DustAllowance), "the amount of tokens an address has to hold in non-dust outputs to be allowed to hold any amount of dust outputs")
 }
 
 var (
@@ -55,6 +196,9 @@ var (
 	tangleInstance *tangle.Tangle
 	tangleOnce     sync.Once
 	log            *logger.Logger
+
+	consensusManaRetriever      tangle.ManaRetrieverFunc
+	consensusManaRetrieverMutex sync.RWMutex
 )
 
 // Plugin gets the plugin instance.
@@ -72,12 +216,77 @@ func Tangle() *tangle.Tangle {
 			tangle.Store(database.Store()),
 			tangle.Identity(local.GetInstance().LocalIdentity()),
 			tangle.TangleWidth(config.Node().Int(CfgTangleWidth)),
+			tangle.SchedulerConfig(tangle.SchedulerParams{
+				Rate:             config.Node().Duration(CfgSchedulerRate),
+				MaxBufferSize:    config.Node().Int(CfgSchedulerMaxBufferSize),
+				IssuerWeightFunc: tangle.ConstantIssuerWeight,
+			}),
+			tangle.RateSetterConfig(tangle.RateSetterParams{
+				Enabled:           config.Node().Bool(CfgRateSetterEnable),
+				InitialRate:       config.Node().Float64(CfgRateSetterInitialRate),
+				MinRate:           config.Node().Float64(CfgRateSetterMinRate),
+				Increase:          config.Node().Float64(CfgRateSetterIncrease),
+				Decrease:          config.Node().Float64(CfgRateSetterDecrease),
+				OwnQueueThreshold: config.Node().Int(CfgRateSetterOwnQueueThreshold),
+				MaxOwnQueueSize:   config.Node().Int(CfgRateSetterMaxOwnQueueSize),
+			}),
+			tangle.TipSelection(tipSelectionStrategy()),
+			tangle.TipMaxAge(config.Node().Duration(CfgTipPruningMaxAge)),
+			tangle.OrphanageThreshold(config.Node().Duration(CfgOrphanageThreshold)),
+			tangle.ApprovalWeightProvider(tangle.NewManaWeightProvider(consensusMana, config.Node().Duration(CfgApprovalWeightActivityWindow))),
+			tangle.ApprovalWeightThreshold(config.Node().Float64(CfgApprovalWeightThreshold)),
+			tangle.BlacklistEquivocatingIssuers(config.Node().Bool(CfgBlacklistEquivocatingIssuers)),
+			tangle.IssuerRateLimit(tangle.IssuerRateLimitParams{
+				Window:      config.Node().Duration(CfgIssuerRateLimitWindow),
+				MaxMessages: config.Node().Int(CfgIssuerRateLimitMaxMessages),
+			}),
+			tangle.DustProtection(ledgerstate.DustProtectionParams{
+				MinimumOutputAmount:      uint64(config.Node().Int64(CfgDustMinimumOutputAmount)),
+				DustThreshold:            uint64(config.Node().Int64(CfgDustThreshold)),
+				MaxDustOutputsPerAddress: config.Node().Int(CfgDustMaxOutputsPerAddress),
+				DustAllowance:            uint64(config.Node().Int64(CfgDustAllowance)),
+			}),
 		)
 	})
 
 	return tangleInstance
 }
 
+// SetConsensusManaRetriever sets the function that retrieves the consensus mana that the approval of the issuers is
+// weighted with. It is set by the mana plugin (which depends on the message layer itself).
+func SetConsensusManaRetriever(retriever tangle.ManaRetrieverFunc) {
+	consensusManaRetrieverMutex.Lock()
+	defer consensusManaRetrieverMutex.Unlock()
+
+	consensusManaRetriever = retriever
+}
+
+// consensusMana returns the consensus mana of the given issuer (or 0 if the mana plugin is disabled).
+func consensusMana(issuerID identity.ID) float64 {
+	consensusManaRetrieverMutex.RLock()
+	defer consensusManaRetrieverMutex.RUnlock()
+
+	if consensusManaRetriever == nil {
+		return 0
+	}
+
+	return consensusManaRetriever(issuerID)
+}
+
+// tipSelectionStrategy returns the TipSelectionStrategy that is configured for the node.
+func tipSelectionStrategy() tangle.TipSelectionStrategy {
+	switch strategy := config.Node().String(CfgTipSelectionStrategy); strategy {
+	case UniformRandomTipSelection:
+		return tangle.NewUniformRandomTipSelection()
+	case AgeRestrictedTipSelection:
+		return tangle.NewAgeRestrictedTipSelection(config.Node().Duration(CfgTipSelectionMaxTipAge))
+	case HeaviestApprovalWeightTipSelection:
+		return tangle.NewHeaviestApprovalWeightTipSelection()
+	default:
+		panic(fmt.Sprintf("unknown tip selection strategy: %s", strategy))
+	}
+}
+
 func configure(*node.Plugin) {
 	log = logger.NewLogger(PluginName)
 	Tangle().Setup()
@@ -86,24 +295,67 @@ func configure(*node.Plugin) {
 		log.Error(err)
 	}))
 
+	Tangle().Events.IssuerEquivocated.Attach(events.NewClosure(func(evidence *tangle.EquivocationEvidence) {
+		firstMessage, secondMessage := evidence.Messages()
+		log.Warnf("issuer %s equivocated with sequence number %d: %s and %s", identity.NewID(evidence.IssuerPublicKey()), evidence.SequenceNumber(), firstMessage.ID(), secondMessage.ID())
+	}))
+
 	// read snapshot file
-	snapshotFilePath := config.Node().String(CfgMessageLayerSnapshotFile)
-	if len(snapshotFilePath) != 0 {
-		snapshot := ledgerstate.Snapshot{}
-		f, err := os.Open(snapshotFilePath)
-		if err != nil {
-			log.Panic("can not open snapshot file:", err)
-		}
-		if _, err := snapshot.ReadFrom(f); err != nil {
-			log.Panic("could not read snapshot file:", err)
+	if snapshotFilePath := config.Node().String(CfgMessageLayerSnapshotFile); len(snapshotFilePath) != 0 {
+		description, err := loadSnapshotFile(Tangle(), snapshotFilePath, uint32(config.Node().Int(autopeering.CfgNetworkVersion)))
+		switch {
+		case err != nil:
+			log.Panicf("could not load snapshot file %s: %s", snapshotFilePath, err)
+		case len(description) == 0:
+			log.Infof("skipped snapshot %s as the database already contains a ledger", snapshotFilePath)
+		default:
+			log.Infof("read snapshot from %s (%s)", snapshotFilePath, description)
 		}
-		Tangle().LedgerState.LoadSnapshot(snapshot)
-		log.Infof("read snapshot from %s", snapshotFilePath)
 	}
 
 	avgNetworkDelay := config.Node().Int(CfgMessageLayerFCOBAverageNetworkDelay)
 	tangle.LikedThreshold = (time.Duration(avgNetworkDelay) * time.Second)
 	tangle.LocallyFinalizedThreshold = (time.Duration(avgNetworkDelay*2) * time.Second)
+
+	tangle.TimestampWindow = config.Node().Duration(CfgTimestampWindow)
+	tangle.GratuitousNetworkDelay = config.Node().Duration(CfgTimestampGratuitousNetworkDelay)
+}
+
+// loadSnapshotFile loads the (versioned or legacy) snapshot file at the given path into the given Tangle and returns a
+// description of the loaded snapshot. Snapshots are only loaded into an empty database (an empty description is
+// returned otherwise), as the ledger of a node that already ran has moved past its snapshot. Versioned snapshots that
+// belong to a different network are rejected.
+func loadSnapshotFile(tangleInstance *tangle.Tangle, snapshotFilePath string, networkID uint32) (description string, err error) {
+	if tangleInstance.LedgerState.StateRoot() != ledgerstate.EmptyMerkleRoot {
+		return
+	}
+
+	f, err := os.Open(snapshotFilePath)
+	if err != nil {
+		err = xerrors.Errorf("failed to open snapshot file: %w", err)
+		return
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	if tangle.IsVersionedSnapshot(reader) {
+		header, importErr := tangleInstance.SnapshotManager.ImportSnapshot(f, networkID)
+		if importErr != nil {
+			err = importErr
+			return
+		}
+
+		return fmt.Sprintf("taken at %s with ledger root %s", header.Timestamp, header.LedgerRoot.Base58()), nil
+	}
+
+	snapshot := tangle.NewSnapshot()
+	if _, err = snapshot.ReadFrom(reader); err != nil {
+		err = xerrors.Errorf("failed to read snapshot file: %w", err)
+		return
+	}
+	tangleInstance.SnapshotManager.LoadSnapshot(snapshot)
+
+	return fmt.Sprintf("%d solid entry points", len(snapshot.SolidEntryPoints)), nil
 }
 
 func run(*node.Plugin) {
@@ -113,6 +365,152 @@ func run(*node.Plugin) {
 	}, shutdown.PriorityTangle); err != nil {
 		log.Panicf("Failed to start as daemon: %s", err)
 	}
+
+	if config.Node().Bool(CfgLocalSnapshotEnable) {
+		runLocalSnapshots()
+	}
+
+	if config.Node().Bool(CfgSupplyAuditEnable) {
+		runSupplyAudits()
+	}
+
+	runBranchGarbageCollection()
+}
+
+func runSupplyAudits() {
+	interval := config.Node().Duration(CfgSupplyAuditInterval)
+
+	Tangle().SupplyAuditor.Events.SupplyDiverged.Attach(events.NewClosure(func(supplyAudit *ledgerstate.SupplyAudit) {
+		log.Errorf("CRITICAL: the token supply of the ledger diverged (total supply: %d, expected: %d, divergent colors: %v)", supplyAudit.TotalSupply(), supplyAudit.ExpectedTotalSupply(), supplyAudit.DivergentColors())
+	}))
+
+	if err := daemon.BackgroundWorker("SupplyAudit", func(shutdownSignal <-chan struct{}) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if supplyAudit := Tangle().SupplyAuditor.Audit(); !supplyAudit.Divergent() {
+					log.Debugf("audited token supply of %d", supplyAudit.TotalSupply())
+				}
+			case <-shutdownSignal:
+				return
+			}
+		}
+	}, shutdown.PrioritySupplyAudit); err != nil {
+		log.Panicf("Failed to start as daemon: %s", err)
+	}
+}
+
+func runBranchGarbageCollection() {
+	interval := config.Node().Duration(CfgBranchGCInterval)
+
+	if err := daemon.BackgroundWorker("BranchGC", func(shutdownSignal <-chan struct{}) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := Tangle().BranchLifecycleManager.CollectGarbage(); err != nil {
+					log.Errorf("failed to clean up the branch DAG: %s", err)
+				}
+			case <-shutdownSignal:
+				return
+			}
+		}
+	}, shutdown.PriorityBranchGC); err != nil {
+		log.Panicf("Failed to start as daemon: %s", err)
+	}
+}
+
+func runLocalSnapshots() {
+	interval := config.Node().Duration(CfgLocalSnapshotInterval)
+	depth := config.Node().Duration(CfgLocalSnapshotDepth)
+	filePath := config.Node().String(CfgLocalSnapshotFile)
+
+	Tangle().SnapshotManager.Events.MessagesPruned.Attach(events.NewClosure(func(prunedMessagesCount int) {
+		log.Infof("pruned %d messages below the local snapshot", prunedMessagesCount)
+	}))
+
+	if err := daemon.BackgroundWorker("LocalSnapshot", func(shutdownSignal <-chan struct{}) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := createLocalSnapshot(time.Now().Add(-depth), filePath); err != nil {
+					log.Warnf("failed to create local snapshot: %s", err)
+				}
+			case <-shutdownSignal:
+				return
+			}
+		}
+	}, shutdown.PriorityLocalSnapshot); err != nil {
+		log.Panicf("Failed to start as daemon: %s", err)
+	}
+}
+
+// createLocalSnapshot creates a versioned local snapshot at the given cut and writes it to the given file.
+func createLocalSnapshot(cutTime time.Time, filePath string) (err error) {
+	var summary *tangle.SnapshotSummary
+	if err = writeSnapshotFile(filePath, func(writer io.Writer) (err error) {
+		summary, err = Tangle().SnapshotManager.CreateSnapshot(writer, uint32(config.Node().Int(autopeering.CfgNetworkVersion)), cutTime)
+		return
+	}); err != nil {
+		return err
+	}
+
+	log.Infof("created local snapshot at %s with %d outputs and %d solid entry points", cutTime, summary.Outputs, summary.SolidEntryPoints)
+
+	return nil
+}
+
+// ExportSnapshot exports a versioned snapshot of the confirmed ledger state of the node to the given file, so that new
+// nodes can be bootstrapped from it. Contrary to local snapshots, no messages are pruned.
+func ExportSnapshot(filePath string) (summary *tangle.SnapshotSummary, err error) {
+	if err = writeSnapshotFile(filePath, func(writer io.Writer) (err error) {
+		summary, err = Tangle().SnapshotManager.ExportSnapshot(writer, uint32(config.Node().Int(autopeering.CfgNetworkVersion)))
+		return
+	}); err != nil {
+		return
+	}
+
+	log.Infof("exported snapshot to %s (%d outputs, %d solid entry points, ledger root %s)", filePath, summary.Outputs, summary.SolidEntryPoints, summary.Header.LedgerRoot.Base58())
+	for color, supply := range summary.Supply {
+		log.Infof("-> total supply of %s: %d", color, supply)
+	}
+
+	return
+}
+
+// writeSnapshotFile writes a snapshot file by passing a buffered writer to the given write function. It writes to a
+// temporary file first, so that the previous snapshot stays intact if writing fails.
+func writeSnapshotFile(filePath string, write func(writer io.Writer) error) (err error) {
+	tempFilePath := filePath + ".tmp"
+	f, err := os.Create(tempFilePath)
+	if err != nil {
+		return xerrors.Errorf("failed to create snapshot file: %w", err)
+	}
+	bufferedWriter := bufio.NewWriter(f)
+	if err = write(bufferedWriter); err != nil {
+		_ = f.Close()
+		return xerrors.Errorf("failed to write snapshot file: %w", err)
+	}
+	if err = bufferedWriter.Flush(); err != nil {
+		_ = f.Close()
+		return xerrors.Errorf("failed to write snapshot file: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return xerrors.Errorf("failed to close snapshot file: %w", err)
+	}
+	if err = os.Rename(tempFilePath, filePath); err != nil {
+		return xerrors.Errorf("failed to rename snapshot file: %w", err)
+	}
+
+	return
 }
 
 // AwaitMessageToBeBooked awaits maxAwait for the given message to get booked.
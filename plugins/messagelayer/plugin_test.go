@@ -0,0 +1,81 @@
+package messagelayer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSnapshotFile(t *testing.T) {
+	keyPair := ed25519.GenerateKeyPair()
+	address := ledgerstate.NewED25519Address(keyPair.PublicKey)
+
+	// export a versioned snapshot from a running node
+	sourceTangle := tangle.New(tangle.WithoutOpinionFormer(true))
+	defer sourceTangle.Shutdown()
+
+	output := ledgerstate.NewSigLockedSingleOutput(100, address)
+	output.SetID(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 1))
+	outputMetadata := ledgerstate.NewOutputMetadata(output.ID())
+	outputMetadata.SetBranchID(ledgerstate.MasterBranchID)
+	outputMetadata.SetSolid(true)
+	outputMetadata.SetFinalized(true)
+	require.NoError(t, sourceTangle.LedgerState.LoadSnapshotOutput(output, outputMetadata))
+
+	snapshotFilePath := filepath.Join(t.TempDir(), "snapshot.bin")
+	snapshotFile, err := os.Create(snapshotFilePath)
+	require.NoError(t, err)
+	summary, err := sourceTangle.SnapshotManager.ExportSnapshot(snapshotFile, 1)
+	require.NoError(t, err)
+	require.NoError(t, snapshotFile.Close())
+
+	// the versioned snapshot is loaded into an empty database
+	targetTangle := tangle.New(tangle.WithoutOpinionFormer(true))
+	defer targetTangle.Shutdown()
+
+	description, err := loadSnapshotFile(targetTangle, snapshotFilePath, 1)
+	require.NoError(t, err)
+	assert.NotEmpty(t, description)
+	assert.Equal(t, summary.Header.LedgerRoot, targetTangle.LedgerState.StateRoot())
+	assert.True(t, targetTangle.LedgerState.Output(output.ID()).Consume(func(ledgerstate.Output) {}))
+
+	// it is skipped once the database contains a ledger (e.g. after a restart)
+	description, err = loadSnapshotFile(targetTangle, snapshotFilePath, 1)
+	require.NoError(t, err)
+	assert.Empty(t, description)
+
+	// snapshots of a different network are rejected
+	otherTangle := tangle.New(tangle.WithoutOpinionFormer(true))
+	defer otherTangle.Shutdown()
+
+	_, err = loadSnapshotFile(otherTangle, snapshotFilePath, 2)
+	assert.Error(t, err)
+
+	// legacy snapshots are still supported
+	legacySnapshotFilePath := filepath.Join(t.TempDir(), "legacy.bin")
+	legacySnapshotFile, err := os.Create(legacySnapshotFilePath)
+	require.NoError(t, err)
+	legacySnapshot := tangle.NewSnapshot()
+	legacySnapshot.LedgerSnapshot = ledgerstate.Snapshot{
+		ledgerstate.GenesisTransactionID: {
+			address: ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 100}),
+		},
+	}
+	_, err = legacySnapshot.WriteTo(legacySnapshotFile)
+	require.NoError(t, err)
+	require.NoError(t, legacySnapshotFile.Close())
+
+	legacyTangle := tangle.New(tangle.WithoutOpinionFormer(true))
+	defer legacyTangle.Shutdown()
+
+	description, err = loadSnapshotFile(legacyTangle, legacySnapshotFilePath, 1)
+	require.NoError(t, err)
+	assert.NotEmpty(t, description)
+	assert.NotEqual(t, ledgerstate.EmptyMerkleRoot, legacyTangle.LedgerState.StateRoot())
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/hive.go/syncutils"
+	"go.uber.org/atomic"
+)
+
+var (
+	// number of supply audits that found a divergence since the start of the node.
+	supplyDivergenceCount atomic.Uint64
+
+	// whether the most recent supply audit found a divergence.
+	supplyDiverged atomic.Bool
+
+	// total supply per color of the confirmed ledger state at the time of the most recent supply audit.
+	ledgerSupply = make(map[ledgerstate.Color]uint64)
+
+	// protect map from concurrent read/write.
+	ledgerSupplyMutex syncutils.RWMutex
+)
+
+// SupplyDivergenceCount returns the number of supply audits that found a divergence since the start of the node.
+func SupplyDivergenceCount() uint64 {
+	return supplyDivergenceCount.Load()
+}
+
+// SupplyDiverged returns true if the most recent supply audit found a divergence.
+func SupplyDiverged() bool {
+	return supplyDiverged.Load()
+}
+
+// LedgerSupply returns the total supply per color of the confirmed ledger state at the time of the most recent supply
+// audit.
+func LedgerSupply() map[ledgerstate.Color]uint64 {
+	ledgerSupplyMutex.RLock()
+	defer ledgerSupplyMutex.RUnlock()
+
+	// copy the original map
+	clone := make(map[ledgerstate.Color]uint64)
+	for key, element := range ledgerSupply {
+		clone[key] = element
+	}
+
+	return clone
+}
+
+////// Handling data updates and measuring //////
+
+func processSupplyAudit(supplyAudit *ledgerstate.SupplyAudit) {
+	ledgerSupplyMutex.Lock()
+	defer ledgerSupplyMutex.Unlock()
+
+	ledgerSupply = make(map[ledgerstate.Color]uint64, len(supplyAudit.Supply))
+	for color, supply := range supplyAudit.Supply {
+		ledgerSupply[color] = supply
+	}
+
+	diverged := supplyAudit.Divergent()
+	supplyDiverged.Store(diverged)
+	if diverged {
+		supplyDivergenceCount.Inc()
+	}
+}
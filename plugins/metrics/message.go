@@ -4,8 +4,10 @@ import (
 	"time"
 
 	"github.com/iotaledger/goshimmer/packages/metrics"
+	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/packages/tangle/payload"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/syncutils"
 	"go.uber.org/atomic"
 )
@@ -56,6 +58,36 @@ var (
 
 	// number of messages being requested by the message layer.
 	requestQueueSize atomic.Int64
+
+	// number of bytes buffered by the scheduler.
+	schedulerBufferSize atomic.Int64
+
+	// number of messages buffered by the scheduler.
+	schedulerBufferLength atomic.Int64
+
+	// number of bytes buffered by the scheduler per issuer.
+	schedulerIssuerQueueSizes map[identity.ID]int
+
+	// protect map from concurrent read/write.
+	schedulerIssuerQueueSizesMutex syncutils.RWMutex
+
+	// number of messages dropped by the scheduler since start of the node.
+	schedulerDiscardedCount atomic.Uint64
+
+	// number of tips pruned by the tip manager because they expired since start of the node.
+	expiredTipsCount atomic.Uint64
+
+	// number of messages that did not get any approver in time since start of the node.
+	orphanedMessageCount atomic.Uint64
+
+	// number of orphaned messages per issuer since start of the node.
+	orphanedMessageCountPerIssuer = make(map[identity.ID]uint64)
+
+	// number of orphaned messages per payload type since start of the node.
+	orphanedMessageCountPerPayload = make(map[payload.Type]uint64)
+
+	// protect orphanage maps from concurrent read/write.
+	orphanedMessageCountMutex syncutils.RWMutex
 )
 
 ////// Exported functions to obtain metrics from outside //////
@@ -89,6 +121,73 @@ func MessageRequestQueueSize() int64 {
 	return requestQueueSize.Load()
 }
 
+// SchedulerBufferSize returns the number of bytes that are currently buffered by the scheduler.
+func SchedulerBufferSize() int64 {
+	return schedulerBufferSize.Load()
+}
+
+// SchedulerBufferLength returns the number of messages that are currently buffered by the scheduler.
+func SchedulerBufferLength() int64 {
+	return schedulerBufferLength.Load()
+}
+
+// SchedulerIssuerQueueSizes returns the number of bytes that are currently buffered by the scheduler per issuer.
+func SchedulerIssuerQueueSizes() map[identity.ID]int {
+	schedulerIssuerQueueSizesMutex.RLock()
+	defer schedulerIssuerQueueSizesMutex.RUnlock()
+
+	// copy the original map
+	clone := make(map[identity.ID]int)
+	for key, element := range schedulerIssuerQueueSizes {
+		clone[key] = element
+	}
+
+	return clone
+}
+
+// SchedulerDiscardedCount returns the number of messages that were dropped by the scheduler since the start of the node.
+func SchedulerDiscardedCount() uint64 {
+	return schedulerDiscardedCount.Load()
+}
+
+// ExpiredTipsCount returns the number of tips that were pruned because they expired since the start of the node.
+func ExpiredTipsCount() uint64 {
+	return expiredTipsCount.Load()
+}
+
+// OrphanedMessageCount returns the number of messages that did not get any approver in time since the start of the node.
+func OrphanedMessageCount() uint64 {
+	return orphanedMessageCount.Load()
+}
+
+// OrphanedMessageCountPerIssuer returns the number of orphaned messages per issuer since the start of the node.
+func OrphanedMessageCountPerIssuer() map[identity.ID]uint64 {
+	orphanedMessageCountMutex.RLock()
+	defer orphanedMessageCountMutex.RUnlock()
+
+	// copy the original map
+	clone := make(map[identity.ID]uint64)
+	for key, element := range orphanedMessageCountPerIssuer {
+		clone[key] = element
+	}
+
+	return clone
+}
+
+// OrphanedMessageCountPerPayload returns the number of orphaned messages per payload type since the start of the node.
+func OrphanedMessageCountPerPayload() map[payload.Type]uint64 {
+	orphanedMessageCountMutex.RLock()
+	defer orphanedMessageCountMutex.RUnlock()
+
+	// copy the original map
+	clone := make(map[payload.Type]uint64)
+	for key, element := range orphanedMessageCountPerPayload {
+		clone[key] = element
+	}
+
+	return clone
+}
+
 // MessageSolidCountDB returns the number of messages that are solid in the DB.
 func MessageSolidCountDB() uint64 {
 	return initialMessageSolidCountDB + messageSolidCountDBInc.Load()
@@ -131,6 +230,15 @@ func increasePerPayloadCounter(p payload.Type) {
 	messageTotalCount.Inc()
 }
 
+func increaseOrphanedMessageCounter(orphanedMessage *tangle.OrphanedMessage) {
+	orphanedMessageCountMutex.Lock()
+	defer orphanedMessageCountMutex.Unlock()
+
+	orphanedMessageCountPerIssuer[orphanedMessage.IssuerID]++
+	orphanedMessageCountPerPayload[orphanedMessage.PayloadType]++
+	orphanedMessageCount.Inc()
+}
+
 func measureMessageTips() {
 	metrics.Events().MessageTips.Trigger((uint64)(messagelayer.Tangle().TipManager.StrongTipCount()))
 }
@@ -160,6 +268,17 @@ func measureRequestQueueSize() {
 	requestQueueSize.Store(size)
 }
 
+func measureSchedulerBuffer() {
+	scheduler := messagelayer.Tangle().Scheduler
+	schedulerBufferSize.Store(int64(scheduler.BufferSize()))
+	schedulerBufferLength.Store(int64(scheduler.BufferLength()))
+
+	issuerQueueSizes := scheduler.IssuerQueueSizes()
+	schedulerIssuerQueueSizesMutex.Lock()
+	schedulerIssuerQueueSizes = issuerQueueSizes
+	schedulerIssuerQueueSizesMutex.Unlock()
+}
+
 func measureInitialDBStats() {
 	solid, total, avgSolidTime, missing := messagelayer.Tangle().Storage.DBStats()
 	initialMessageSolidCountDB = uint64(solid)
@@ -68,6 +68,7 @@ func run(_ *node.Plugin) {
 				measureMessageTips()
 				measureReceivedMPS()
 				measureRequestQueueSize()
+				measureSchedulerBuffer()
 				measureGossipTraffic()
 			}, 1*time.Second, shutdownSignal)
 		}
@@ -98,6 +99,20 @@ func registerLocalMetrics() {
 		})
 	}))
 
+	messagelayer.Tangle().Scheduler.Events.MessageDiscarded.Attach(events.NewClosure(func(tangle.MessageID) {
+		schedulerDiscardedCount.Inc()
+	}))
+
+	messagelayer.Tangle().TipManager.Events.TipRemoved.Attach(events.NewClosure(func(tipEvent *tangle.TipEvent) {
+		if tipEvent.Reason == tangle.TipExpired {
+			expiredTipsCount.Inc()
+		}
+	}))
+
+	messagelayer.Tangle().OrphanageTracker.Events.MessageOrphaned.Attach(events.NewClosure(increaseOrphanedMessageCounter))
+
+	messagelayer.Tangle().SupplyAuditor.Events.SupplyAudited.Attach(events.NewClosure(processSupplyAudit))
+
 	messagelayer.Tangle().Storage.Events.MessageRemoved.Attach(events.NewClosure(func(messageId tangle.MessageID) {
 		// MessageRemoved triggered when the message gets removed from database.
 		messageTotalCountDB.Dec()
@@ -15,6 +15,20 @@ var (
 	messageMissingCountDB prometheus.Gauge
 	messageRequestCount   prometheus.Gauge
 
+	schedulerBufferSize        prometheus.Gauge
+	schedulerBufferLength      prometheus.Gauge
+	schedulerIssuerQueueSizes  *prometheus.GaugeVec
+	schedulerDiscardedMessages prometheus.Gauge
+
+	expiredTips               prometheus.Gauge
+	orphanedMessages          prometheus.Gauge
+	orphanedMessagesPerIssuer *prometheus.GaugeVec
+	orphanedMessagesPerType   *prometheus.GaugeVec
+
+	supplyDivergences prometheus.Gauge
+	supplyDiverged    prometheus.Gauge
+	ledgerSupply      *prometheus.GaugeVec
+
 	transactionCounter prometheus.Gauge
 	valueTips          prometheus.Gauge
 )
@@ -68,6 +82,73 @@ func registerTangleMetrics() {
 		Help: "current number requested messages by the message tangle",
 	})
 
+	schedulerBufferSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tangle_scheduler_buffer_size",
+		Help: "current number of bytes buffered by the scheduler",
+	})
+
+	schedulerBufferLength = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tangle_scheduler_buffer_length",
+		Help: "current number of messages buffered by the scheduler",
+	})
+
+	schedulerIssuerQueueSizes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tangle_scheduler_issuer_queue_size",
+			Help: "current number of bytes buffered by the scheduler per issuer",
+		}, []string{
+			"issuer",
+		})
+
+	schedulerDiscardedMessages = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tangle_scheduler_discarded_messages",
+		Help: "number of messages dropped by the scheduler since the start of the node",
+	})
+
+	expiredTips = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tangle_expired_tips_count",
+		Help: "number of tips pruned because they expired since the start of the node",
+	})
+
+	orphanedMessages = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tangle_orphaned_messages_count",
+		Help: "number of messages that did not get any approver in time since the start of the node",
+	})
+
+	orphanedMessagesPerIssuer = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tangle_orphaned_messages_per_issuer_count",
+			Help: "number of orphaned messages per issuer since the start of the node",
+		}, []string{
+			"issuer",
+		})
+
+	orphanedMessagesPerType = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tangle_orphaned_messages_per_type_count",
+			Help: "number of orphaned messages per payload type since the start of the node",
+		}, []string{
+			"message_type",
+		})
+
+	supplyDivergences = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tangle_supply_divergences_count",
+		Help: "number of supply audits that found a divergence of the token supply since the start of the node",
+	})
+
+	supplyDiverged = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tangle_supply_diverged",
+		Help: "1 if the most recent supply audit found a divergence of the token supply, 0 otherwise",
+	})
+
+	ledgerSupply = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tangle_ledger_supply",
+			Help: "total supply per color of the confirmed ledger state at the time of the most recent supply audit",
+		}, []string{
+			"color",
+		})
+
 	registry.MustRegister(messageTips)
 	registry.MustRegister(messagePerTypeCount)
 	registry.MustRegister(messageTotalCount)
@@ -76,7 +157,18 @@ func registerTangleMetrics() {
 	registry.MustRegister(avgSolidificationTime)
 	registry.MustRegister(messageMissingCountDB)
 	registry.MustRegister(messageRequestCount)
+	registry.MustRegister(schedulerBufferSize)
+	registry.MustRegister(schedulerBufferLength)
+	registry.MustRegister(schedulerIssuerQueueSizes)
+	registry.MustRegister(schedulerDiscardedMessages)
 	registry.MustRegister(transactionCounter)
+	registry.MustRegister(expiredTips)
+	registry.MustRegister(orphanedMessages)
+	registry.MustRegister(orphanedMessagesPerIssuer)
+	registry.MustRegister(orphanedMessagesPerType)
+	registry.MustRegister(supplyDivergences)
+	registry.MustRegister(supplyDiverged)
+	registry.MustRegister(ledgerSupply)
 
 	addCollect(collectTangleMetrics)
 }
@@ -93,5 +185,30 @@ func collectTangleMetrics() {
 	avgSolidificationTime.Set(metrics.AvgSolidificationTime())
 	messageMissingCountDB.Set(float64(metrics.MessageMissingCountDB()))
 	messageRequestCount.Set(float64(metrics.MessageRequestQueueSize()))
+	schedulerBufferSize.Set(float64(metrics.SchedulerBufferSize()))
+	schedulerBufferLength.Set(float64(metrics.SchedulerBufferLength()))
+	schedulerIssuerQueueSizes.Reset()
+	for issuerID, size := range metrics.SchedulerIssuerQueueSizes() {
+		schedulerIssuerQueueSizes.WithLabelValues(issuerID.String()).Set(float64(size))
+	}
+	schedulerDiscardedMessages.Set(float64(metrics.SchedulerDiscardedCount()))
+	expiredTips.Set(float64(metrics.ExpiredTipsCount()))
+	orphanedMessages.Set(float64(metrics.OrphanedMessageCount()))
+	for issuerID, count := range metrics.OrphanedMessageCountPerIssuer() {
+		orphanedMessagesPerIssuer.WithLabelValues(issuerID.String()).Set(float64(count))
+	}
+	for payloadType, count := range metrics.OrphanedMessageCountPerPayload() {
+		orphanedMessagesPerType.WithLabelValues(payloadType.String()).Set(float64(count))
+	}
+	supplyDivergences.Set(float64(metrics.SupplyDivergenceCount()))
+	if metrics.SupplyDiverged() {
+		supplyDiverged.Set(1)
+	} else {
+		supplyDiverged.Set(0)
+	}
+	ledgerSupply.Reset()
+	for color, supply := range metrics.LedgerSupply() {
+		ledgerSupply.WithLabelValues(color.String()).Set(float64(supply))
+	}
 	// transactionCounter.Set(float64(metrics.ValueTransactionCounter()))
 }
@@ -200,10 +200,16 @@ func (u *UtxoDB) checkUnlockBlocks(tx *ledgerstate.Transaction) error {
 				panic("wrong referenced unlock bkock index")
 			}
 			signatureBlock = unlockBlocks[ub.ReferencedIndex()]
+		case *ledgerstate.AliasUnlockBlock:
+			signatureBlock = ub
+		case *ledgerstate.MultiSignatureUnlockBlock:
+			signatureBlock = ub
+		case *ledgerstate.AggregatedBLSUnlockBlock:
+			signatureBlock = ub
 		default:
 			return xerrors.New("wrong unlock block type")
 		}
-		valid, err := out.UnlockValid(tx, signatureBlock)
+		valid, err := out.UnlockValid(tx, signatureBlock, outputs)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,123 @@
+package blocklist
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+	"golang.org/x/xerrors"
+)
+
+// PluginName is the name of the web API blocklist endpoint plugin.
+const PluginName = "WebAPI Blocklist Endpoint"
+
+var (
+	// plugin is the plugin instance of the web API blocklist endpoint plugin.
+	plugin *node.Plugin
+	once   sync.Once
+)
+
+// Plugin gets the plugin instance.
+func Plugin() *node.Plugin {
+	once.Do(func() {
+		plugin = node.NewPlugin(PluginName, node.Enabled, configure)
+	})
+	return plugin
+}
+
+func configure(_ *node.Plugin) {
+	webapi.Server().GET("blocklist", getBlockedIssuersHandler)
+	webapi.Server().POST("blocklist/add", addBlockedIssuerHandler)
+	webapi.Server().POST("blocklist/remove", removeBlockedIssuerHandler)
+}
+
+// getBlockedIssuersHandler returns all the issuers that are currently blocked by the node.
+func getBlockedIssuersHandler(c echo.Context) error {
+	response := BlockedIssuersResponse{
+		BlockedIssuers: make([]BlockedIssuer, 0),
+	}
+	for issuerPublicKey, blockedSince := range messagelayer.Tangle().IssuerBlocklist.BlockedIssuers() {
+		response.BlockedIssuers = append(response.BlockedIssuers, BlockedIssuer{
+			IssuerPublicKey: base58.Encode(issuerPublicKey.Bytes()),
+			IssuerID:        identity.NewID(issuerPublicKey).String(),
+			BlockedSince:    blockedSince.Unix(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// addBlockedIssuerHandler adds the issuer of the request to the blocklist of the node.
+func addBlockedIssuerHandler(c echo.Context) error {
+	issuerPublicKey, err := issuerPublicKeyFromRequest(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, Response{
+		Changed: messagelayer.Tangle().IssuerBlocklist.Block(issuerPublicKey),
+	})
+}
+
+// removeBlockedIssuerHandler removes the issuer of the request from the blocklist of the node.
+func removeBlockedIssuerHandler(c echo.Context) error {
+	issuerPublicKey, err := issuerPublicKeyFromRequest(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, Response{
+		Changed: messagelayer.Tangle().IssuerBlocklist.Unblock(issuerPublicKey),
+	})
+}
+
+// issuerPublicKeyFromRequest parses the base58 encoded issuer public key of the Request.
+func issuerPublicKeyFromRequest(c echo.Context) (issuerPublicKey ed25519.PublicKey, err error) {
+	var request Request
+	if err = c.Bind(&request); err != nil {
+		err = xerrors.Errorf("failed to parse request: %w", err)
+		return
+	}
+
+	publicKeyBytes, err := base58.Decode(request.IssuerPublicKey)
+	if err != nil {
+		err = xerrors.Errorf("failed to decode issuer public key %s: %w", request.IssuerPublicKey, err)
+		return
+	}
+	if issuerPublicKey, _, err = ed25519.PublicKeyFromBytes(publicKeyBytes); err != nil {
+		err = xerrors.Errorf("failed to parse issuer public key %s: %w", request.IssuerPublicKey, err)
+		return
+	}
+
+	return
+}
+
+// Request is the request of the blocklist add and remove endpoints.
+type Request struct {
+	IssuerPublicKey string `json:"issuerPublicKey"`
+}
+
+// Response is the response of the blocklist add and remove endpoints.
+type Response struct {
+	Changed bool   `json:"changed"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BlockedIssuersResponse is the response of the blocklist endpoint.
+type BlockedIssuersResponse struct {
+	BlockedIssuers []BlockedIssuer `json:"blockedIssuers"`
+	Error          string          `json:"error,omitempty"`
+}
+
+// BlockedIssuer contains the information about a blocked issuer.
+type BlockedIssuer struct {
+	IssuerPublicKey string `json:"issuerPublicKey"`
+	IssuerID        string `json:"issuerID"`
+	BlockedSince    int64  `json:"blockedSince"`
+}
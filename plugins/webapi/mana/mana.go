@@ -0,0 +1,102 @@
+package mana
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	manaPlugin "github.com/iotaledger/goshimmer/plugins/mana"
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+	"golang.org/x/xerrors"
+)
+
+// getManaHandler returns the access and consensus mana of the node with the given nodeID (or of the local node).
+func getManaHandler(c echo.Context) error {
+	nodeID, err := nodeIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, GetManaResponse{Error: err.Error()})
+	}
+
+	accessMana, err := manaPlugin.GetAccessMana(nodeID)
+	if err != nil && !xerrors.Is(err, mana.ErrNodeNotFoundInBaseManaVector) {
+		return c.JSON(http.StatusInternalServerError, GetManaResponse{Error: err.Error()})
+	}
+	consensusMana, err := manaPlugin.GetConsensusMana(nodeID)
+	if err != nil && !xerrors.Is(err, mana.ErrNodeNotFoundInBaseManaVector) {
+		return c.JSON(http.StatusInternalServerError, GetManaResponse{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, GetManaResponse{
+		ShortNodeID: nodeID.String(),
+		NodeID:      base58.Encode(nodeID.Bytes()),
+		Access:      accessMana,
+		Consensus:   consensusMana,
+	})
+}
+
+// getAllManaHandler returns the access and consensus mana of all known nodes.
+func getAllManaHandler(c echo.Context) error {
+	accessManaMap, err := manaPlugin.GetManaMap(mana.AccessMana)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, GetAllManaResponse{Error: err.Error()})
+	}
+	consensusManaMap, err := manaPlugin.GetManaMap(mana.ConsensusMana)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, GetAllManaResponse{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, GetAllManaResponse{
+		Access:    accessManaMap.ToNodeStrList(),
+		Consensus: consensusManaMap.ToNodeStrList(),
+	})
+}
+
+// getPercentileHandler returns the percentiles of the access and consensus mana of the node with the given nodeID (or of
+// the local node).
+func getPercentileHandler(c echo.Context) error {
+	nodeID, err := nodeIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, GetPercentileResponse{Error: err.Error()})
+	}
+
+	response := GetPercentileResponse{
+		ShortNodeID: nodeID.String(),
+		NodeID:      base58.Encode(nodeID.Bytes()),
+	}
+	for manaType, percentile := range map[mana.Type]*float64{mana.AccessMana: &response.Access, mana.ConsensusMana: &response.Consensus} {
+		manaMap, err := manaPlugin.GetManaMap(manaType)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, GetPercentileResponse{Error: err.Error()})
+		}
+		if *percentile, err = manaMap.Percentile(nodeID); err != nil && !xerrors.Is(err, mana.ErrNodeNotFoundInBaseManaVector) {
+			return c.JSON(http.StatusInternalServerError, GetPercentileResponse{Error: err.Error()})
+		}
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetManaResponse defines the response of the mana endpoint.
+type GetManaResponse struct {
+	ShortNodeID string  `json:"shortNodeID,omitempty"`
+	NodeID      string  `json:"nodeID,omitempty"`
+	Access      float64 `json:"access"`
+	Consensus   float64 `json:"consensus"`
+	Error       string  `json:"error,omitempty"`
+}
+
+// GetAllManaResponse defines the response of the mana/all endpoint.
+type GetAllManaResponse struct {
+	Access    []mana.NodeStr `json:"access"`
+	Consensus []mana.NodeStr `json:"consensus"`
+	Error     string         `json:"error,omitempty"`
+}
+
+// GetPercentileResponse defines the response of the mana/percentile endpoint.
+type GetPercentileResponse struct {
+	ShortNodeID string  `json:"shortNodeID,omitempty"`
+	NodeID      string  `json:"nodeID,omitempty"`
+	Access      float64 `json:"access"`
+	Consensus   float64 `json:"consensus"`
+	Error       string  `json:"error,omitempty"`
+}
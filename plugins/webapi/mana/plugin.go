@@ -0,0 +1,58 @@
+package mana
+
+import (
+	"sync"
+
+	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+	"golang.org/x/xerrors"
+)
+
+// PluginName is the name of the web API mana endpoint plugin.
+const PluginName = "WebAPI Mana Endpoint"
+
+var (
+	// plugin is the plugin instance of the web API mana endpoint plugin.
+	plugin *node.Plugin
+	once   sync.Once
+)
+
+// Plugin gets the plugin instance.
+func Plugin() *node.Plugin {
+	once.Do(func() {
+		plugin = node.NewPlugin(PluginName, node.Enabled, configure)
+	})
+	return plugin
+}
+
+func configure(_ *node.Plugin) {
+	webapi.Server().GET("mana", getManaHandler)
+	webapi.Server().GET("mana/all", getAllManaHandler)
+	webapi.Server().GET("mana/percentile", getPercentileHandler)
+}
+
+// nodeIDFromContext parses the base58 encoded nodeID query parameter and falls back to the ID of the local node if it
+// was omitted.
+func nodeIDFromContext(c echo.Context) (nodeID identity.ID, err error) {
+	nodeIDString := c.QueryParam("nodeID")
+	if nodeIDString == "" {
+		return local.GetInstance().ID(), nil
+	}
+
+	nodeIDBytes, err := base58.Decode(nodeIDString)
+	if err != nil {
+		err = xerrors.Errorf("failed to decode nodeID %s: %w", nodeIDString, err)
+		return
+	}
+	if len(nodeIDBytes) != len(identity.ID{}) {
+		err = xerrors.Errorf("failed to decode nodeID %s: invalid length", nodeIDString)
+		return
+	}
+	copy(nodeID[:], nodeIDBytes)
+
+	return
+}
@@ -0,0 +1,14 @@
+package snapshot
+
+import (
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	// CfgExportFile defines the file that the snapshots exported via the web API are written to.
+	CfgExportFile = "webapi.snapshot.exportFile"
+)
+
+func init() {
+	flag.String(CfgExportFile, "./exported-snapshot.bin", "the file that the snapshots exported via the web API are written to")
+}
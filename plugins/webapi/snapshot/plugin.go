@@ -0,0 +1,70 @@
+package snapshot
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/iotaledger/goshimmer/plugins/config"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+)
+
+// PluginName is the name of the web API snapshot endpoint plugin.
+const PluginName = "WebAPI Snapshot Endpoint"
+
+var (
+	// plugin is the plugin instance of the web API snapshot endpoint plugin.
+	plugin *node.Plugin
+	once   sync.Once
+)
+
+// Plugin gets the plugin instance.
+func Plugin() *node.Plugin {
+	once.Do(func() {
+		plugin = node.NewPlugin(PluginName, node.Enabled, configure)
+	})
+	return plugin
+}
+
+func configure(_ *node.Plugin) {
+	webapi.Server().POST("snapshot/export", exportSnapshotHandler)
+}
+
+// exportSnapshotHandler exports a snapshot of the confirmed ledger state of the node to the configured export file.
+func exportSnapshotHandler(c echo.Context) error {
+	filePath := config.Node().String(CfgExportFile)
+	summary, err := messagelayer.ExportSnapshot(filePath)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ExportResponse{Error: err.Error()})
+	}
+
+	supply := make(map[string]uint64, len(summary.Supply))
+	for color, amount := range summary.Supply {
+		supply[color.String()] = amount
+	}
+
+	return c.JSON(http.StatusOK, ExportResponse{
+		FilePath:         filePath,
+		NetworkID:        summary.Header.NetworkID,
+		Timestamp:        summary.Header.Timestamp.Unix(),
+		LedgerRoot:       summary.Header.LedgerRoot.Base58(),
+		Outputs:          summary.Outputs,
+		SolidEntryPoints: summary.SolidEntryPoints,
+		Supply:           supply,
+	})
+}
+
+// ExportResponse is the response of the snapshot export endpoint. It contains the total supply per color which allows
+// to sanity check the snapshot before it is used to bootstrap other nodes.
+type ExportResponse struct {
+	FilePath         string            `json:"filePath,omitempty"`
+	NetworkID        uint32            `json:"networkID,omitempty"`
+	Timestamp        int64             `json:"timestamp,omitempty"`
+	LedgerRoot       string            `json:"ledgerRoot,omitempty"`
+	Outputs          int               `json:"outputs,omitempty"`
+	SolidEntryPoints int               `json:"solidEntryPoints,omitempty"`
+	Supply           map[string]uint64 `json:"supply,omitempty"`
+	Error            string            `json:"error,omitempty"`
+}
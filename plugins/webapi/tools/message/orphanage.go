@@ -0,0 +1,57 @@
+package message
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/labstack/echo"
+)
+
+// OrphanageHandler returns the statistics about the messages that did not get any approver in time.
+func OrphanageHandler(c echo.Context) error {
+	stats := messagelayer.Tangle().OrphanageTracker.Stats()
+	ownIssuerID := local.GetInstance().Identity.ID()
+
+	res := &OrphanageResponse{
+		TotalCount:          stats.TotalCount,
+		OwnIssuerID:         ownIssuerID.String(),
+		OwnCount:            stats.CountPerIssuer[ownIssuerID],
+		CountPerIssuer:      make(map[string]uint64),
+		CountPerPayloadType: make(map[string]uint64),
+	}
+	for issuerID, count := range stats.CountPerIssuer {
+		res.CountPerIssuer[issuerID.String()] = count
+	}
+	for payloadType, count := range stats.CountPerPayloadType {
+		res.CountPerPayloadType[payloadType.String()] = count
+	}
+	for _, orphanedMessage := range messagelayer.Tangle().OrphanageTracker.RecentOrphanedMessages() {
+		res.RecentMessages = append(res.RecentMessages, OrphanedMessage{
+			ID:          orphanedMessage.MessageID.String(),
+			IssuerID:    orphanedMessage.IssuerID.String(),
+			PayloadType: orphanedMessage.PayloadType.String(),
+			IssuingTime: orphanedMessage.IssuingTime.Unix(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// OrphanageResponse is the HTTP response containing the statistics about the orphaned messages.
+type OrphanageResponse struct {
+	TotalCount          uint64            `json:"totalCount"`
+	OwnIssuerID         string            `json:"ownIssuerID"`
+	OwnCount            uint64            `json:"ownCount"`
+	CountPerIssuer      map[string]uint64 `json:"countPerIssuer"`
+	CountPerPayloadType map[string]uint64 `json:"countPerPayloadType"`
+	RecentMessages      []OrphanedMessage `json:"recentMessages,omitempty"`
+}
+
+// OrphanedMessage contains the information about a message that did not get any approver in time.
+type OrphanedMessage struct {
+	ID          string `json:"id"`
+	IssuerID    string `json:"issuerID"`
+	PayloadType string `json:"payloadType"`
+	IssuingTime int64  `json:"issuingTime"`
+}
@@ -33,5 +33,6 @@ func configure(_ *node.Plugin) {
 	webapi.Server().GET("tools/message/pastcone", message.PastconeHandler)
 	webapi.Server().GET("tools/message/missing", message.MissingHandler)
 	webapi.Server().GET("tools/message/approval", message.ApprovalHandler)
+	webapi.Server().GET("tools/message/orphanage", message.OrphanageHandler)
 	webapi.Server().GET("tools/value/objects", value.ObjectsHandler)
 }
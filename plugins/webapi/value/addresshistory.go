@@ -0,0 +1,104 @@
+package value
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/labstack/echo"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// defaultAddressHistoryLimit defines the amount of entries that are returned if no limit is provided.
+	defaultAddressHistoryLimit = 100
+
+	// maxAddressHistoryLimit defines the maximum amount of entries that are returned by a single request.
+	maxAddressHistoryLimit = 1000
+)
+
+// getAddressHistoryHandler gets the chronologically ordered history of the transactions that moved tokens from or to
+// the given address. The result is paginated via the offset and limit query parameters.
+func getAddressHistoryHandler(c echo.Context) error {
+	address, err := ledgerstate.AddressFromBase58EncodedString(c.QueryParam("address"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, GetAddressHistoryResponse{Error: err.Error()})
+	}
+	offset, err := intQueryParam(c, "offset", 0)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, GetAddressHistoryResponse{Error: err.Error()})
+	}
+	limit, err := intQueryParam(c, "limit", defaultAddressHistoryLimit)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, GetAddressHistoryResponse{Error: err.Error()})
+	}
+	if limit == 0 || limit > maxAddressHistoryLimit {
+		return c.JSON(http.StatusBadRequest, GetAddressHistoryResponse{Error: xerrors.Errorf("limit must be between 1 and %d", maxAddressHistoryLimit).Error()})
+	}
+
+	addressHistory := messagelayer.Tangle().LedgerState.AddressHistory(address)
+	response := GetAddressHistoryResponse{
+		Address: address.Base58(),
+		Total:   len(addressHistory),
+		Entries: make([]AddressHistoryEntry, 0),
+	}
+	for i := offset; i < len(addressHistory) && i < offset+limit; i++ {
+		response.Entries = append(response.Entries, ParseAddressHistoryEntry(addressHistory[i]))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// ParseAddressHistoryEntry handle address history entry json object.
+func ParseAddressHistoryEntry(addressHistoryEntry *ledgerstate.AddressHistoryEntry) (entry AddressHistoryEntry) {
+	entry = AddressHistoryEntry{
+		TransactionID: addressHistoryEntry.TransactionID().Base58(),
+		Timestamp:     addressHistoryEntry.Timestamp().Unix(),
+		Direction:     "incoming",
+		Rejected:      addressHistoryEntry.Rejected(),
+	}
+	if addressHistoryEntry.Direction() == ledgerstate.OutgoingDirection {
+		entry.Direction = "outgoing"
+	}
+	addressHistoryEntry.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+		entry.Balances = append(entry.Balances, Balance{
+			Value: int64(balance),
+			Color: color.String(),
+		})
+		return true
+	})
+
+	return
+}
+
+// intQueryParam parses the non-negative integer query parameter with the given name and returns the default value if
+// it is not set.
+func intQueryParam(c echo.Context, name string, defaultValue int) (value int, err error) {
+	if c.QueryParam(name) == "" {
+		return defaultValue, nil
+	}
+
+	if value, err = strconv.Atoi(c.QueryParam(name)); err != nil || value < 0 {
+		err = xerrors.Errorf("invalid %s parameter: %s", name, c.QueryParam(name))
+	}
+
+	return
+}
+
+// GetAddressHistoryResponse is the HTTP response from retrieving the history of an address.
+type GetAddressHistoryResponse struct {
+	Address string                `json:"address,omitempty"`
+	Total   int                   `json:"total"`
+	Entries []AddressHistoryEntry `json:"entries,omitempty"`
+	Error   string                `json:"error,omitempty"`
+}
+
+// AddressHistoryEntry holds the effect of a single transaction on the balances of an address.
+type AddressHistoryEntry struct {
+	TransactionID string    `json:"transaction_id"`
+	Timestamp     int64     `json:"timestamp"`
+	Direction     string    `json:"direction"`
+	Balances      []Balance `json:"balances"`
+	Rejected      bool      `json:"rejected"`
+}
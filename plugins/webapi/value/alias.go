@@ -0,0 +1,40 @@
+package value
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/labstack/echo"
+	"golang.org/x/xerrors"
+)
+
+// getAliasHandler gets the current output of the alias with the given alias address.
+func getAliasHandler(c echo.Context) error {
+	aliasAddress, err := ledgerstate.AliasAddressFromBase58EncodedString(c.QueryParam("address"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, GetAliasResponse{Error: err.Error()})
+	}
+
+	cachedOutput, err := messagelayer.Tangle().LedgerState.AliasOutput(aliasAddress)
+	if err != nil {
+		if xerrors.Is(err, ledgerstate.ErrAliasOutputNotFound) {
+			return c.JSON(http.StatusNotFound, GetAliasResponse{Error: err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, GetAliasResponse{Error: err.Error()})
+	}
+	defer cachedOutput.Release()
+
+	aliasOutput, isAliasOutput := cachedOutput.Unwrap().(*ledgerstate.AliasOutput)
+	if !isAliasOutput {
+		return c.JSON(http.StatusNotFound, GetAliasResponse{Error: "Alias not found"})
+	}
+
+	return c.JSON(http.StatusOK, GetAliasResponse{AliasOutput: ParseAliasOutput(aliasOutput)})
+}
+
+// GetAliasResponse is the HTTP response from retrieving the current output of an alias.
+type GetAliasResponse struct {
+	AliasOutput AliasOutput `json:"alias_output,omitempty"`
+	Error       string      `json:"error,omitempty"`
+}
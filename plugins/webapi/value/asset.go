@@ -0,0 +1,41 @@
+package value
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/labstack/echo"
+	"golang.org/x/xerrors"
+)
+
+// getAssetHandler gets the on-tangle definition (name, symbol, precision, supply policy and controller) of the asset
+// with the given color.
+func getAssetHandler(c echo.Context) error {
+	color, err := ledgerstate.ColorFromBase58EncodedString(c.QueryParam("color"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, GetAssetResponse{Error: err.Error()})
+	}
+
+	cachedOutput, err := messagelayer.Tangle().LedgerState.AssetOutput(color)
+	if err != nil {
+		if xerrors.Is(err, ledgerstate.ErrAssetOutputNotFound) {
+			return c.JSON(http.StatusNotFound, GetAssetResponse{Error: err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, GetAssetResponse{Error: err.Error()})
+	}
+	defer cachedOutput.Release()
+
+	assetOutput, isAssetOutput := cachedOutput.Unwrap().(*ledgerstate.AssetOutput)
+	if !isAssetOutput {
+		return c.JSON(http.StatusNotFound, GetAssetResponse{Error: "Asset not found"})
+	}
+
+	return c.JSON(http.StatusOK, GetAssetResponse{AssetOutput: ParseAssetOutput(assetOutput)})
+}
+
+// GetAssetResponse is the HTTP response from retrieving the on-tangle definition of an asset.
+type GetAssetResponse struct {
+	AssetOutput AssetOutput `json:"asset_output,omitempty"`
+	Error       string      `json:"error,omitempty"`
+}
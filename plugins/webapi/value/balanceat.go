@@ -0,0 +1,99 @@
+package value
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/labstack/echo"
+	"golang.org/x/xerrors"
+)
+
+// getBalanceAtHandler reconstructs the outputs and balances of the given address in the confirmed ledger state at a
+// past point in time. The point in time is either given as a unix timestamp or as the ID of a confirmed transaction
+// (checkpoint).
+func getBalanceAtHandler(c echo.Context) error {
+	address, err := ledgerstate.AddressFromBase58EncodedString(c.QueryParam("address"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, GetBalanceAtResponse{Error: err.Error()})
+	}
+	timestamp, err := balanceAtTimestamp(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, GetBalanceAtResponse{Error: err.Error()})
+	}
+
+	balances := make(map[ledgerstate.Color]uint64)
+	response := GetBalanceAtResponse{
+		Address:   address.Base58(),
+		Timestamp: timestamp.Unix(),
+		Outputs:   make([]OutputID, 0),
+		Balances:  make([]Balance, 0),
+	}
+	for _, output := range messagelayer.Tangle().LedgerState.OutputsOnAddressAt(address, timestamp) {
+		outputBalances := make([]Balance, 0)
+		output.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+			balances[color] += balance
+			outputBalances = append(outputBalances, Balance{
+				Value: int64(balance),
+				Color: color.String(),
+			})
+			return true
+		})
+
+		spendableFrom, spendableUntil := SpendablePeriod(output, address)
+		response.Outputs = append(response.Outputs, OutputID{
+			ID:             output.ID().Base58(),
+			Balances:       outputBalances,
+			InclusionState: InclusionState{Confirmed: true, Finalized: true},
+			SpendableFrom:  spendableFrom,
+			SpendableUntil: spendableUntil,
+		})
+	}
+	ledgerstate.NewColoredBalances(balances).ForEach(func(color ledgerstate.Color, balance uint64) bool {
+		response.Balances = append(response.Balances, Balance{
+			Value: int64(balance),
+			Color: color.String(),
+		})
+		return true
+	})
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// balanceAtTimestamp is an internal utility function that determines the point in time of a balanceAt request from
+// either the timestamp or the checkpoint query parameter.
+func balanceAtTimestamp(c echo.Context) (timestamp time.Time, err error) {
+	switch timestampParam, checkpointParam := c.QueryParam("timestamp"), c.QueryParam("checkpoint"); {
+	case timestampParam != "" && checkpointParam != "":
+		err = xerrors.New("only one of timestamp and checkpoint can be provided")
+	case timestampParam != "":
+		unixTimestamp, parseErr := strconv.ParseInt(timestampParam, 10, 64)
+		if parseErr != nil || unixTimestamp < 0 {
+			err = xerrors.Errorf("invalid timestamp parameter: %s", timestampParam)
+			return
+		}
+		timestamp = time.Unix(unixTimestamp, 0)
+	case checkpointParam != "":
+		transactionID, parseErr := ledgerstate.TransactionIDFromBase58(checkpointParam)
+		if parseErr != nil {
+			err = xerrors.Errorf("invalid checkpoint parameter: %w", parseErr)
+			return
+		}
+		timestamp, err = messagelayer.Tangle().LedgerState.CheckpointTime(transactionID)
+	default:
+		err = xerrors.New("either timestamp or checkpoint must be provided")
+	}
+
+	return
+}
+
+// GetBalanceAtResponse is the HTTP response from reconstructing the balances of an address at a past point in time.
+type GetBalanceAtResponse struct {
+	Address   string     `json:"address,omitempty"`
+	Timestamp int64      `json:"timestamp,omitempty"`
+	Outputs   []OutputID `json:"outputs,omitempty"`
+	Balances  []Balance  `json:"balances,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
@@ -0,0 +1,53 @@
+package value
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/labstack/echo"
+)
+
+// checkTransactionHandler performs the validation checks of the ledger (and the issuance policies of the node) on a
+// transaction without issuing it.
+func checkTransactionHandler(c echo.Context) error {
+	var request CheckTransactionRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, CheckTransactionResponse{Error: err.Error()})
+	}
+
+	// parse tx
+	tx, _, err := ledgerstate.TransactionFromBytes(request.TransactionBytes)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, CheckTransactionResponse{Error: err.Error()})
+	}
+
+	if _, err = messagelayer.Tangle().LedgerState.CheckTransaction(tx); err == nil {
+		err = messagelayer.Tangle().LedgerState.CheckTransactionPastCone(tx)
+	}
+	if err == nil {
+		err = messagelayer.Tangle().LedgerState.CheckDustAllowance(tx)
+	}
+	if err != nil {
+		return c.JSON(http.StatusOK, CheckTransactionResponse{
+			TransactionID:   tx.ID().Base58(),
+			Error:           err.Error(),
+			ValidationError: ParseValidationError(err),
+		})
+	}
+
+	return c.JSON(http.StatusOK, CheckTransactionResponse{TransactionID: tx.ID().Base58(), Valid: true})
+}
+
+// CheckTransactionRequest holds the transaction object(bytes) to check.
+type CheckTransactionRequest struct {
+	TransactionBytes []byte `json:"txn_bytes"`
+}
+
+// CheckTransactionResponse is the HTTP response from checking a transaction.
+type CheckTransactionResponse struct {
+	TransactionID   string           `json:"transaction_id,omitempty"`
+	Valid           bool             `json:"valid"`
+	Error           string           `json:"error,omitempty"`
+	ValidationError *ValidationError `json:"validation_error,omitempty"`
+}
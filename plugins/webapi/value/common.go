@@ -1,9 +1,11 @@
 package value
 
 import (
+	"bytes"
 	"time"
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"golang.org/x/xerrors"
 )
 
 var maxBookedAwaitTime = 5 * time.Second
@@ -32,20 +34,167 @@ func ParseTransaction(t *ledgerstate.Transaction) (txn Transaction) {
 			Balances: balances,
 		})
 	}
+	// process unlock blocks
+	var unlockBlocks []UnlockBlock
+	for _, unlockBlock := range t.UnlockBlocks() {
+		unlockBlocks = append(unlockBlocks, ParseUnlockBlock(unlockBlock))
+	}
+
 	return Transaction{
-		Inputs:      inputs,
-		Outputs:     outputs,
-		Signature:   t.UnlockBlocks().Bytes(),
-		DataPayload: t.Essence().Bytes(),
+		Inputs:       inputs,
+		Outputs:      outputs,
+		UnlockBlocks: unlockBlocks,
+		Signature:    t.UnlockBlocks().Bytes(),
+		DataPayload:  t.Essence().Bytes(),
+	}
+}
+
+// ParseUnlockBlock handle unlock block json object.
+func ParseUnlockBlock(u ledgerstate.UnlockBlock) (unlockBlock UnlockBlock) {
+	unlockBlock.Type = u.Type().String()
+	switch typedUnlockBlock := u.(type) {
+	case *ledgerstate.ReferenceUnlockBlock:
+		unlockBlock.ReferencedIndex = typedUnlockBlock.ReferencedIndex()
+	case *ledgerstate.AliasUnlockBlock:
+		unlockBlock.ReferencedIndex = typedUnlockBlock.AliasInputIndex()
+	case *ledgerstate.MultiSignatureUnlockBlock:
+		unlockBlock.Threshold = typedUnlockBlock.Threshold()
+		for _, publicKey := range typedUnlockBlock.PublicKeys() {
+			unlockBlock.PublicKeys = append(unlockBlock.PublicKeys, publicKey.String())
+		}
+		if address, err := typedUnlockBlock.Address(); err == nil {
+			unlockBlock.Address = address.Base58()
+		}
+	case *ledgerstate.AggregatedBLSUnlockBlock:
+		for _, publicKey := range typedUnlockBlock.PublicKeys() {
+			unlockBlock.PublicKeys = append(unlockBlock.PublicKeys, publicKey.Base58())
+		}
+	}
+	unlockBlock.Bytes = u.Bytes()
+
+	return
+}
+
+// ParseAliasOutput handle alias output json object.
+func ParseAliasOutput(o *ledgerstate.AliasOutput) (aliasOutput AliasOutput) {
+	var balances []Balance
+	o.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+		balances = append(balances, Balance{
+			Value: int64(balance),
+			Color: color.String(),
+		})
+		return true
+	})
+
+	return AliasOutput{
+		ID:               o.ID().Base58(),
+		AliasAddress:     o.AliasAddress().Base58(),
+		Balances:         balances,
+		StateAddress:     o.StateAddress().Base58(),
+		GoverningAddress: o.GoverningAddress().Base58(),
+		StateIndex:       o.StateIndex(),
+		StateData:        o.StateData(),
+		ImmutableData:    o.ImmutableData(),
+		GovernanceUpdate: o.IsGovernanceUpdate(),
+	}
+}
+
+// ParseAssetOutput handle asset output json object.
+func ParseAssetOutput(o *ledgerstate.AssetOutput) (assetOutput AssetOutput) {
+	var balances []Balance
+	o.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+		balances = append(balances, Balance{
+			Value: int64(balance),
+			Color: color.String(),
+		})
+		return true
+	})
+
+	return AssetOutput{
+		ID:                 o.ID().Base58(),
+		Color:              o.AssetColor().String(),
+		Balances:           balances,
+		ControllingAddress: o.Address().Base58(),
+		Name:               o.Name(),
+		Symbol:             o.Symbol(),
+		Precision:          o.Precision(),
+		Mintable:           o.SupplyPolicy().Mintable(),
+		Burnable:           o.SupplyPolicy().Burnable(),
+	}
+}
+
+// SpendablePeriod returns the period (in unix nanoseconds) in which the given Output can be unlocked by the given
+// Address. A value of 0 means that the period is not bounded in that direction.
+func SpendablePeriod(output ledgerstate.Output, address ledgerstate.Address) (spendableFrom int64, spendableUntil int64) {
+	extendedLockedOutput, isExtendedLockedOutput := output.(*ledgerstate.ExtendedLockedOutput)
+	if !isExtendedLockedOutput {
+		return
+	}
+
+	from := extendedLockedOutput.TimeLock()
+	if fallbackAddress := extendedLockedOutput.FallbackAddress(); fallbackAddress != nil && !bytes.Equal(fallbackAddress.Bytes(), extendedLockedOutput.Address().Bytes()) {
+		if bytes.Equal(fallbackAddress.Bytes(), address.Bytes()) {
+			if extendedLockedOutput.FallbackDeadline().After(from) {
+				from = extendedLockedOutput.FallbackDeadline()
+			}
+		} else {
+			spendableUntil = extendedLockedOutput.FallbackDeadline().UnixNano()
+		}
+	}
+	if !from.IsZero() {
+		spendableFrom = from.UnixNano()
+	}
+
+	return
+}
+
+// ParseValidationError returns the details of the TransactionValidationError that is contained in the given error (or
+// nil if the error does not contain any).
+func ParseValidationError(err error) *ValidationError {
+	var validationErr *ledgerstate.TransactionValidationError
+	if !xerrors.As(err, &validationErr) {
+		return nil
+	}
+
+	validationError := &ValidationError{
+		Reason:      validationErr.Reason.Error(),
+		InputIndex:  validationErr.InputIndex,
+		OutputIndex: validationErr.OutputIndex,
+	}
+	if validationErr.Reason == ledgerstate.ErrBalanceMismatch || validationErr.Reason == ledgerstate.ErrAssetBurnNotAllowed {
+		validationError.Color = validationErr.Color.String()
+		validationError.Consumed = validationErr.Consumed
+		validationError.Spent = validationErr.Spent
+	}
+	if validationErr.Reason == ledgerstate.ErrUncoloredBalanceMismatch {
+		validationError.Consumed = validationErr.Consumed
+		validationError.Spent = validationErr.Spent
 	}
+	if validationErr.Reason == ledgerstate.ErrDustAllowanceExceeded {
+		validationError.Address = validationErr.Address.Base58()
+		validationError.DustOutputs = validationErr.DustOutputs
+	}
+
+	return validationError
 }
 
 // Transaction holds the information of a transaction.
 type Transaction struct {
-	Inputs      []string `json:"inputs"`
-	Outputs     []Output `json:"outputs"`
-	Signature   []byte   `json:"signature"`
-	DataPayload []byte   `json:"data_payload"`
+	Inputs       []string      `json:"inputs"`
+	Outputs      []Output      `json:"outputs"`
+	UnlockBlocks []UnlockBlock `json:"unlock_blocks"`
+	Signature    []byte        `json:"signature"`
+	DataPayload  []byte        `json:"data_payload"`
+}
+
+// UnlockBlock holds the information of an unlock block
+type UnlockBlock struct {
+	Type            string   `json:"type"`
+	ReferencedIndex uint16   `json:"referenced_index,omitempty"`
+	Address         string   `json:"address,omitempty"`
+	Threshold       uint8    `json:"threshold,omitempty"`
+	PublicKeys      []string `json:"public_keys,omitempty"`
+	Bytes           []byte   `json:"bytes"`
 }
 
 // OutputID holds the output id and its inclusion state
@@ -53,6 +202,8 @@ type OutputID struct {
 	ID             string         `json:"id"`
 	Balances       []Balance      `json:"balances"`
 	InclusionState InclusionState `json:"inclusion_state"`
+	SpendableFrom  int64          `json:"spendable_from,omitempty"`
+	SpendableUntil int64          `json:"spendable_until,omitempty"`
 }
 
 // UnspentOutput holds the address and the corresponding unspent output ids
@@ -67,6 +218,32 @@ type Output struct {
 	Balances []Balance `json:"balances"`
 }
 
+// AliasOutput holds the current state of an alias
+type AliasOutput struct {
+	ID               string    `json:"id"`
+	AliasAddress     string    `json:"alias_address"`
+	Balances         []Balance `json:"balances"`
+	StateAddress     string    `json:"state_address"`
+	GoverningAddress string    `json:"governing_address"`
+	StateIndex       uint32    `json:"state_index"`
+	StateData        []byte    `json:"state_data"`
+	ImmutableData    []byte    `json:"immutable_data"`
+	GovernanceUpdate bool      `json:"governance_update"`
+}
+
+// AssetOutput holds the on-tangle definition of an asset
+type AssetOutput struct {
+	ID                 string    `json:"id"`
+	Color              string    `json:"color"`
+	Balances           []Balance `json:"balances"`
+	ControllingAddress string    `json:"controlling_address"`
+	Name               string    `json:"name"`
+	Symbol             string    `json:"symbol"`
+	Precision          uint8     `json:"precision"`
+	Mintable           bool      `json:"mintable"`
+	Burnable           bool      `json:"burnable"`
+}
+
 // Balance holds the value and the color of token
 type Balance struct {
 	Value int64  `json:"value"`
@@ -84,6 +261,19 @@ type InclusionState struct {
 	Preferred   bool `json:"preferred,omitempty"`
 }
 
+// ValidationError holds the details of a failed validation of a transaction. The indexes are -1 if the failure is not
+// related to an input or output.
+type ValidationError struct {
+	Reason      string `json:"reason"`
+	InputIndex  int    `json:"input_index"`
+	OutputIndex int    `json:"output_index"`
+	Color       string `json:"color,omitempty"`
+	Consumed    uint64 `json:"consumed,omitempty"`
+	Spent       uint64 `json:"spent,omitempty"`
+	Address     string `json:"address,omitempty"`
+	DustOutputs int    `json:"dust_outputs,omitempty"`
+}
+
 // Signature defines the struct of a signature.
 type Signature struct {
 	Version   byte   `json:"version"`
@@ -0,0 +1,19 @@
+package value
+
+import (
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	// CfgSubscribeAllowedOrigins defines the config flag of the origins that are allowed to open a subscription websocket
+	// (in addition to the origin of the web API itself).
+	CfgSubscribeAllowedOrigins = "webapi.subscribe.allowedOrigins"
+	// CfgSubscribeMaxInterests defines the config flag of the maximum amount of transactions, outputs and addresses that
+	// a single subscription websocket can subscribe to.
+	CfgSubscribeMaxInterests = "webapi.subscribe.maxInterests"
+)
+
+func init() {
+	flag.StringSlice(CfgSubscribeAllowedOrigins, nil, "the origins that are allowed to subscribe to inclusion state changes (\"*\" allows all origins)")
+	flag.Int(CfgSubscribeMaxInterests, 1000, "the maximum amount of transactions, outputs and addresses a single websocket can subscribe to")
+}
@@ -28,6 +28,14 @@ func configure(_ *node.Plugin) {
 	webapi.Server().GET("value/attachments", attachmentsHandler)
 	webapi.Server().POST("value/unspentOutputs", unspentOutputsHandler)
 	webapi.Server().POST("value/sendTransaction", sendTransactionHandler)
+	webapi.Server().POST("value/checkTransaction", checkTransactionHandler)
 	//webapi.Server().POST("value/sendTransactionByJson", sendTransactionByJSONHandler)
 	webapi.Server().GET("value/transactionByID", getTransactionByIDHandler)
+	webapi.Server().GET("value/alias", getAliasHandler)
+	webapi.Server().GET("value/asset", getAssetHandler)
+	webapi.Server().GET("value/stateRoot", getStateRootHandler)
+	webapi.Server().GET("value/outputProof", getOutputProofHandler)
+	webapi.Server().GET("value/addressHistory", getAddressHistoryHandler)
+	webapi.Server().GET("value/balanceAt", getBalanceAtHandler)
+	webapi.Server().GET("value/subscribe", subscribeHandler)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/issuer"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
 	"github.com/labstack/echo"
+	"golang.org/x/xerrors"
 )
 
 var sendTxMu sync.Mutex
@@ -29,6 +30,19 @@ func sendTransactionHandler(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, SendTransactionResponse{Error: err.Error()})
 	}
 
+	// reject invalid transactions before they are issued
+	if _, err = messagelayer.Tangle().LedgerState.CheckTransaction(tx); err != nil && xerrors.Is(err, ledgerstate.ErrTransactionInvalid) {
+		return c.JSON(http.StatusBadRequest, SendTransactionResponse{Error: err.Error(), ValidationError: ParseValidationError(err)})
+	}
+	if err = messagelayer.Tangle().LedgerState.CheckTransactionPastCone(tx); err != nil && xerrors.Is(err, ledgerstate.ErrTransactionInvalid) {
+		return c.JSON(http.StatusBadRequest, SendTransactionResponse{Error: err.Error(), ValidationError: ParseValidationError(err)})
+	}
+
+	// the node does not issue transactions that exceed the dust allowance of an address (local issuance policy)
+	if err = messagelayer.Tangle().LedgerState.CheckDustAllowance(tx); err != nil && xerrors.Is(err, ledgerstate.ErrTransactionInvalid) {
+		return c.JSON(http.StatusBadRequest, SendTransactionResponse{Error: err.Error(), ValidationError: ParseValidationError(err)})
+	}
+
 	issueTransaction := func() (*tangle.Message, error) {
 		msg, e := issuer.IssuePayload(tx, messagelayer.Tangle())
 		if e != nil {
@@ -51,6 +65,7 @@ type SendTransactionRequest struct {
 
 // SendTransactionResponse is the HTTP response from sending transaction.
 type SendTransactionResponse struct {
-	TransactionID string `json:"transaction_id,omitempty"`
-	Error         string `json:"error,omitempty"`
+	TransactionID   string           `json:"transaction_id,omitempty"`
+	Error           string           `json:"error,omitempty"`
+	ValidationError *ValidationError `json:"validation_error,omitempty"`
 }
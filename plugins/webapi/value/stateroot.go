@@ -0,0 +1,52 @@
+package value
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+)
+
+// getStateRootHandler gets the merkle root that commits to the confirmed unspent outputs of the ledger.
+func getStateRootHandler(c echo.Context) error {
+	return c.JSON(http.StatusOK, GetStateRootResponse{Root: messagelayer.Tangle().LedgerState.StateRoot().Base58()})
+}
+
+// getOutputProofHandler gets the proof of the inclusion (or the non-inclusion) of the output with the given ID in the
+// confirmed unspent outputs of the ledger.
+func getOutputProofHandler(c echo.Context) error {
+	outputID, err := ledgerstate.OutputIDFromBase58(c.QueryParam("outputID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, GetOutputProofResponse{Error: err.Error()})
+	}
+
+	proof := messagelayer.Tangle().LedgerState.OutputProof(outputID)
+	outputHash := proof.OutputHash()
+
+	return c.JSON(http.StatusOK, GetOutputProofResponse{
+		OutputID:   outputID.Base58(),
+		Root:       proof.Root().Base58(),
+		Included:   proof.Included(),
+		OutputHash: base58.Encode(outputHash[:]),
+		Proof:      proof.Bytes(),
+	})
+}
+
+// GetStateRootResponse is the HTTP response from retrieving the state root of the ledger.
+type GetStateRootResponse struct {
+	Root  string `json:"root,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// GetOutputProofResponse is the HTTP response from retrieving the proof of an output. The proof can be parsed with
+// ledgerstate.OutputProofFromBytes and verified against the root.
+type GetOutputProofResponse struct {
+	OutputID   string `json:"output_id,omitempty"`
+	Root       string `json:"root,omitempty"`
+	Included   bool   `json:"included"`
+	OutputHash string `json:"output_hash,omitempty"`
+	Proof      []byte `json:"proof,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
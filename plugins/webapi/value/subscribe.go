@@ -0,0 +1,186 @@
+package value
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/plugins/config"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/labstack/echo"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// subscriptionWriteTimeout defines the time after which a stalled write to a subscriber is aborted.
+	subscriptionWriteTimeout = 3 * time.Second
+
+	// subscriptionBufferSize defines the amount of notifications that are buffered for a single subscriber. Subscribers
+	// that fall behind by more than this are disconnected (rather than silently missing a notification).
+	subscriptionBufferSize = 1000
+)
+
+var upgrader = websocket.Upgrader{
+	HandshakeTimeout:  subscriptionWriteTimeout,
+	CheckOrigin:       checkOrigin,
+	EnableCompression: true,
+}
+
+// subscribeHandler upgrades the connection to a websocket and pushes the changes of the inclusion state of the
+// transactions that the client is interested in. The interest is registered by sending SubscriptionRequests over the
+// websocket, which can happen at any time while the connection is open.
+func subscribeHandler(c echo.Context) error {
+	ws, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		return err
+	}
+	defer ws.Close()
+
+	notifications := make(chan InclusionStateNotification, subscriptionBufferSize)
+	overflow := make(chan struct{})
+	var overflowOnce sync.Once
+	closed := make(chan struct{})
+	push := func(notification InclusionStateNotification) {
+		select {
+		case notifications <- notification:
+		case <-closed:
+		default:
+			overflowOnce.Do(func() { close(overflow) })
+		}
+	}
+
+	subscription := messagelayer.Tangle().InclusionStateNotifier.Subscribe(func(notification *tangle.InclusionStateNotification) {
+		push(ParseInclusionStateNotification(notification))
+	})
+	defer subscription.Unsubscribe()
+
+	go func() {
+		defer close(closed)
+
+		for {
+			var request SubscriptionRequest
+			if err := ws.ReadJSON(&request); err != nil {
+				return
+			}
+			if err := applySubscriptionRequest(subscription, request, config.Node().Int(CfgSubscribeMaxInterests)); err != nil {
+				push(InclusionStateNotification{Error: err.Error()})
+			}
+		}
+	}()
+
+	for {
+		select {
+		case notification := <-notifications:
+			if err := ws.SetWriteDeadline(time.Now().Add(subscriptionWriteTimeout)); err != nil {
+				return nil
+			}
+			if err := ws.WriteJSON(notification); err != nil {
+				return nil
+			}
+		case <-overflow:
+			_ = ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "subscriber too slow"), time.Now().Add(subscriptionWriteTimeout))
+			return nil
+		case <-closed:
+			return nil
+		}
+	}
+}
+
+// checkOrigin allows websocket connections from the origin of the web API itself, from the configured origins and from
+// clients that do not send an origin (i.e. clients that are not browsers).
+func checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	for _, allowedOrigin := range config.Node().Strings(CfgSubscribeAllowedOrigins) {
+		if allowedOrigin == "*" || strings.EqualFold(allowedOrigin, origin) {
+			return true
+		}
+	}
+
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(originURL.Host, r.Host)
+}
+
+// applySubscriptionRequest registers the interests of the given SubscriptionRequest. All identifiers are parsed before
+// any of them is registered, so an invalid request does not change the subscription. Requests that would exceed the
+// given maximum amount of interests of the subscription are rejected as a whole.
+func applySubscriptionRequest(subscription *tangle.InclusionStateSubscription, request SubscriptionRequest, maxInterests int) error {
+	if requestedInterests := len(request.TransactionIDs) + len(request.OutputIDs) + len(request.Addresses); subscription.InterestCount()+requestedInterests > maxInterests {
+		return xerrors.Errorf("subscribing to %d more transactions, outputs or addresses exceeds the limit of %d", requestedInterests, maxInterests)
+	}
+
+	transactionIDs := make([]ledgerstate.TransactionID, len(request.TransactionIDs))
+	for i, base58TransactionID := range request.TransactionIDs {
+		transactionID, err := ledgerstate.TransactionIDFromBase58(base58TransactionID)
+		if err != nil {
+			return xerrors.Errorf("failed to parse TransactionID %s: %w", base58TransactionID, err)
+		}
+		transactionIDs[i] = transactionID
+	}
+	outputIDs := make([]ledgerstate.OutputID, len(request.OutputIDs))
+	for i, base58OutputID := range request.OutputIDs {
+		outputID, err := ledgerstate.OutputIDFromBase58(base58OutputID)
+		if err != nil {
+			return xerrors.Errorf("failed to parse OutputID %s: %w", base58OutputID, err)
+		}
+		outputIDs[i] = outputID
+	}
+	addresses := make([]ledgerstate.Address, len(request.Addresses))
+	for i, base58Address := range request.Addresses {
+		address, err := ledgerstate.AddressFromBase58EncodedString(base58Address)
+		if err != nil {
+			return xerrors.Errorf("failed to parse Address %s: %w", base58Address, err)
+		}
+		addresses[i] = address
+	}
+
+	for _, transactionID := range transactionIDs {
+		subscription.SubscribeTransaction(transactionID)
+	}
+	for _, outputID := range outputIDs {
+		subscription.SubscribeOutput(outputID)
+	}
+	for _, address := range addresses {
+		subscription.SubscribeAddress(address)
+	}
+
+	return nil
+}
+
+// ParseInclusionStateNotification handle inclusion state notification json object.
+func ParseInclusionStateNotification(notification *tangle.InclusionStateNotification) InclusionStateNotification {
+	return InclusionStateNotification{
+		Type:          notification.Type.String(),
+		TransactionID: notification.TransactionID.Base58(),
+		BranchID:      notification.BranchID.Base58(),
+	}
+}
+
+// SubscriptionRequest is the message that a client sends over the websocket to register its interest in transactions,
+// outputs and addresses.
+type SubscriptionRequest struct {
+	TransactionIDs []string `json:"transaction_ids,omitempty"`
+	OutputIDs      []string `json:"output_ids,omitempty"`
+	Addresses      []string `json:"addresses,omitempty"`
+}
+
+// InclusionStateNotification is the message that is pushed over the websocket when the inclusion state of a
+// subscribed transaction changes. Its type is one of booked, liked, confirmed, rejected or branch-changed.
+type InclusionStateNotification struct {
+	Type          string `json:"type,omitempty"`
+	TransactionID string `json:"transaction_id,omitempty"`
+	BranchID      string `json:"branch_id,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
@@ -29,6 +29,11 @@ func unspentOutputsHandler(c echo.Context) error {
 		// get outputids by address
 		cachedOutputs := messagelayer.Tangle().LedgerState.OutputsOnAddress(address)
 		cachedOutputs.Consume(func(output ledgerstate.Output) {
+			// AssetOutputs define assets and can not be spent like funds
+			if output.Type() == ledgerstate.AssetOutputType {
+				return
+			}
+
 			cachedOutputMetadata := messagelayer.Tangle().LedgerState.OutputMetadata(output.ID())
 			cachedOutputMetadata.Consume(func(outputMetadata *ledgerstate.OutputMetadata) {
 				if outputMetadata.ConsumerCount() == 0 {
@@ -56,10 +61,13 @@ func unspentOutputsHandler(c echo.Context) error {
 					inclusionState.Rejected = txInclusionState == ledgerstate.Rejected
 					inclusionState.Conflicting = len(messagelayer.Tangle().LedgerState.ConflictSet(txID)) == 0
 
+					spendableFrom, spendableUntil := SpendablePeriod(output, address)
 					outputids = append(outputids, OutputID{
 						ID:             output.ID().Base58(),
 						Balances:       b,
 						InclusionState: inclusionState,
+						SpendableFrom:  spendableFrom,
+						SpendableUntil: spendableUntil,
 					})
 				}
 			})
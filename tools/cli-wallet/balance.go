@@ -7,6 +7,7 @@ import (
 	"text/tabwriter"
 
 	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 )
 
 func execBalanceCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
@@ -37,6 +38,15 @@ func execBalanceCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		return
 	}
 
+	// look up the on-tangle definitions of the assets (colors without a definition keep their local name)
+	for _, balances := range []map[ledgerstate.Color]uint64{confirmedBalance, pendingBalance} {
+		for color := range balances {
+			if color != ledgerstate.ColorIOTA {
+				_, _ = cliWallet.LookupAsset(color)
+			}
+		}
+	}
+
 	// print balances
 	for color, amount := range confirmedBalance {
 		_, _ = fmt.Fprintf(w, "%s\t%d %s\t%s\t%s\n", "[ OK ]", amount, cliWallet.AssetRegistry().Symbol(color), color.String(), cliWallet.AssetRegistry().Name(color))
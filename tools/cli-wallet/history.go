@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func execHistoryCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+
+	history, err := cliWallet.History()
+	if err != nil {
+		printUsage(nil, err.Error())
+	}
+
+	// initialize tab writer
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	defer w.Flush()
+
+	// print header
+	fmt.Println()
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "STATUS", "TIME", "ADDRESS INDEX", "TRANSACTION ID", "AMOUNT", "COLOR")
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "------", "-------------------", "-------------", "--------------------------------------------", "---------------", "--------------------------------------------")
+
+	// print empty if no history entries were found
+	if len(history) == 0 {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "<EMPTY>", "<EMPTY>", "<EMPTY>", "<EMPTY>", "<EMPTY>", "<EMPTY>")
+
+		return
+	}
+
+	// print history entries
+	for _, entry := range history {
+		status := "[ OK ]"
+		if entry.Rejected {
+			status = "[FAIL]"
+		}
+		sign := "+"
+		if entry.Direction == ledgerstate.OutgoingDirection {
+			sign = "-"
+		}
+
+		entry.Balances.ForEach(func(color ledgerstate.Color, balance uint64) bool {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s%d %s\t%s\n", status, entry.Timestamp.Format("2006-01-02 15:04:05"), entry.Address.Index, entry.TransactionID.Base58(), sign, balance, cliWallet.AssetRegistry().Symbol(color), color.String())
+			return true
+		})
+	}
+}
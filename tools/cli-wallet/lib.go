@@ -134,6 +134,8 @@ func printUsage(command *flag.FlagSet, optionalErrorMessage ...string) {
 		fmt.Println("COMMANDS:")
 		fmt.Println("  balance")
 		fmt.Println("        show the balances held by this wallet")
+		fmt.Println("  history")
+		fmt.Println("        show the transactions that moved funds from or to this wallet")
 		fmt.Println("  send-funds")
 		fmt.Println("        initiate a value transfer")
 		fmt.Println("  create-asset")
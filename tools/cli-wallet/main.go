@@ -35,6 +35,7 @@ func main() {
 
 	// define sub commands
 	balanceCommand := flag.NewFlagSet("balance", flag.ExitOnError)
+	historyCommand := flag.NewFlagSet("history", flag.ExitOnError)
 	sendFundsCommand := flag.NewFlagSet("send-funds", flag.ExitOnError)
 	createAssetCommand := flag.NewFlagSet("create-asset", flag.ExitOnError)
 	addressCommand := flag.NewFlagSet("address", flag.ExitOnError)
@@ -45,6 +46,8 @@ func main() {
 	switch os.Args[1] {
 	case "balance":
 		execBalanceCommand(balanceCommand, wallet)
+	case "history":
+		execHistoryCommand(historyCommand, wallet)
 	case "address":
 		execAddressCommand(addressCommand, wallet)
 	case "send-funds":
@@ -9,6 +9,7 @@ import (
 	"github.com/iotaledger/goshimmer/client"
 	walletseed "github.com/iotaledger/goshimmer/client/wallet/packages/seed"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	webapi_value "github.com/iotaledger/goshimmer/plugins/webapi/value"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/identity"
 )
@@ -30,30 +31,50 @@ func main() {
 	mySeed := walletseed.NewSeed()
 	myAddr := mySeed.Address(0)
 
-	if _, err := clients[0].SendFaucetRequest(myAddr.Address().Base58()); err != nil {
+	// subscribe before requesting the funds, so we do not miss the confirmation
+	subscription, err := clients[0].Subscribe(webapi_value.SubscriptionRequest{Addresses: []string{myAddr.Address().Base58()}})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer subscription.Close()
+
+	if _, err = clients[0].SendFaucetRequest(myAddr.Address().Base58()); err != nil {
 		fmt.Println(err)
 		return
 	}
 
-	var myOutputID string
 	var confirmed bool
 	// wait for the funds
-	for i := 0; i < 10; i++ {
-		time.Sleep(5 * time.Second)
-		resp, err := clients[0].GetUnspentOutputs([]string{myAddr.Address().Base58()})
-		if err != nil {
-			fmt.Println(err)
-			return
-		}
-		fmt.Println("Waiting for funds to be confirmed...")
-		for _, v := range resp.UnspentOutputs {
-			if len(v.OutputIDs) > 0 {
-				myOutputID = v.OutputIDs[0].ID
-				confirmed = v.OutputIDs[0].InclusionState.Confirmed
-				break
+	fmt.Println("Waiting for funds to be confirmed...")
+	timeout := time.After(50 * time.Second)
+	for !confirmed {
+		select {
+		case notification, ok := <-subscription.Notifications():
+			if !ok {
+				fmt.Println(subscription.Err())
+				return
+			}
+			if notification.Error != "" {
+				fmt.Println(notification.Error)
+				return
 			}
+			confirmed = notification.Type == "confirmed"
+		case <-timeout:
+			fmt.Println("OutputID not confirmed")
+			return
 		}
-		if myOutputID != "" && confirmed {
+	}
+
+	var myOutputID string
+	resp, err := clients[0].GetUnspentOutputs([]string{myAddr.Address().Base58()})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, v := range resp.UnspentOutputs {
+		if len(v.OutputIDs) > 0 {
+			myOutputID = v.OutputIDs[0].ID
 			break
 		}
 	}
@@ -63,11 +84,6 @@ func main() {
 		return
 	}
 
-	if !confirmed {
-		fmt.Println("OutputID not confirmed")
-		return
-	}
-
 	out, err := ledgerstate.OutputIDFromBase58(myOutputID)
 	if err != nil {
 		fmt.Println("malformed OutputID")
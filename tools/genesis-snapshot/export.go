@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bufio"
+	"log"
+	"os"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	flag "github.com/spf13/pflag"
+	"golang.org/x/xerrors"
+)
+
+const (
+	exportCommand        = "export"
+	cfgExportDatabaseDir = "db-dir"
+	cfgExportNetworkID   = "network-id"
+	defaultDatabaseDir   = "mainnetdb"
+	// defaultNetworkID matches the default network version of the autopeering plugin of the node.
+	defaultNetworkID = 16
+)
+
+// runExport exports a snapshot of the confirmed ledger state from the database of a node. The node needs to be
+// stopped, since the database can only be opened by a single process (use the snapshot/export endpoint of the web API
+// to export a snapshot from a running node instead).
+func runExport(args []string) {
+	flagSet := flag.NewFlagSet(exportCommand, flag.ExitOnError)
+	databaseDir := flagSet.String(cfgExportDatabaseDir, defaultDatabaseDir, "the database directory of the node")
+	snapshotFileName := flagSet.String(cfgSnapshotFileName, defaultSnapshotFileName, "the name of the exported snapshot file")
+	networkID := flagSet.Uint32(cfgExportNetworkID, defaultNetworkID, "the network version of the node")
+	if err := flagSet.Parse(args); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("exporting snapshot from %s to %s...", *databaseDir, *snapshotFileName)
+	summary, err := exportSnapshot(*databaseDir, *snapshotFileName, *networkID)
+	if err != nil {
+		log.Fatal("unable to export snapshot: ", err)
+	}
+
+	log.Println("snapshot:")
+	log.Printf("-> network id: %d", summary.Header.NetworkID)
+	log.Printf("-> ledger root (base58): %s", summary.Header.LedgerRoot.Base58())
+	log.Printf("-> outputs: %d", summary.Outputs)
+	log.Printf("-> solid entry points: %d", summary.SolidEntryPoints)
+	for color, supply := range summary.Supply {
+		log.Printf("-> total supply of %s: %d", color, supply)
+	}
+
+	log.Printf("exported %s, bye", *snapshotFileName)
+}
+
+// exportSnapshot opens the database in the given directory and writes a snapshot of its confirmed ledger state to the
+// given file.
+func exportSnapshot(databaseDir string, snapshotFileName string, networkID uint32) (summary *tangle.SnapshotSummary, err error) {
+	// do not create an empty database if the directory does not exist
+	if _, err = os.Stat(databaseDir); err != nil {
+		return nil, xerrors.Errorf("failed to access database directory: %w", err)
+	}
+	db, err := database.NewDB(databaseDir)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to open database (is the node still running?): %w", err)
+	}
+	defer db.Close()
+
+	ledgerTangle := tangle.New(tangle.Store(db.NewStore()), tangle.WithoutOpinionFormer(true))
+	defer ledgerTangle.Shutdown()
+
+	f, err := os.Create(snapshotFileName)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create snapshot file: %w", err)
+	}
+	defer f.Close()
+
+	bufferedWriter := bufio.NewWriter(f)
+	if summary, err = ledgerTangle.SnapshotManager.ExportSnapshot(bufferedWriter, networkID); err != nil {
+		return
+	}
+	if err = bufferedWriter.Flush(); err != nil {
+		return nil, xerrors.Errorf("failed to write snapshot file: %w", err)
+	}
+
+	return
+}
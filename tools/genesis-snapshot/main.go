@@ -29,6 +29,11 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == exportCommand {
+		runExport(os.Args[2:])
+		return
+	}
+
 	flag.Parse()
 	if err := viper.BindPFlags(flag.CommandLine); err != nil {
 		panic(err)
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"golang.org/x/xerrors"
+)
+
+const (
+	cfgDatabaseDir     = "db-dir"
+	defaultDatabaseDir = "mainnetdb"
+)
+
+func init() {
+	flag.String(cfgDatabaseDir, defaultDatabaseDir, "the database directory of the node")
+}
+
+// main audits the token supply of the confirmed ledger state in the database of a (stopped) node. It runs the same
+// check as the supply auditor of the node and exits with a non-zero exit code if the supply diverged.
+func main() {
+	flag.Parse()
+	if err := viper.BindPFlags(flag.CommandLine); err != nil {
+		panic(err)
+	}
+	databaseDir := viper.GetString(cfgDatabaseDir)
+	log.Printf("auditing token supply of %s...", databaseDir)
+
+	supplyAudit, err := auditSupply(databaseDir)
+	if err != nil {
+		log.Fatal("unable to audit token supply: ", err)
+	}
+
+	log.Printf("-> total supply: %d", supplyAudit.TotalSupply())
+	log.Printf("-> expected total supply: %d", supplyAudit.ExpectedTotalSupply())
+	for color, supply := range supplyAudit.Supply {
+		log.Printf("-> supply of %s: %d (genesis: %d, minted: %d)", color, supply, supplyAudit.GenesisSupply[color], supplyAudit.MintedSupply[color])
+	}
+
+	if supplyAudit.Divergent() {
+		log.Fatalf("CRITICAL: the token supply of the ledger diverged (divergent colors: %v)", supplyAudit.DivergentColors())
+	}
+
+	log.Println("the token supply of the ledger is consistent, bye")
+}
+
+// auditSupply opens the database in the given directory and audits the token supply of its confirmed ledger state.
+func auditSupply(databaseDir string) (supplyAudit *ledgerstate.SupplyAudit, err error) {
+	// do not create an empty database if the directory does not exist
+	if _, err = os.Stat(databaseDir); err != nil {
+		return nil, xerrors.Errorf("failed to access database directory: %w", err)
+	}
+	db, err := database.NewDB(databaseDir)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to open database (is the node still running?): %w", err)
+	}
+	defer db.Close()
+
+	ledgerTangle := tangle.New(tangle.Store(db.NewStore()), tangle.WithoutOpinionFormer(true))
+	defer ledgerTangle.Shutdown()
+
+	return ledgerTangle.SupplyAuditor.Audit(), nil
+}